@@ -2,6 +2,7 @@ package activity
 
 import (
 	"context"
+	"time"
 
 	"github.com/goliatone/go-featuregate/gate"
 )
@@ -10,18 +11,43 @@ import (
 type Action string
 
 const (
-	ActionSet   Action = "set"
-	ActionUnset Action = "unset"
+	ActionSet    Action = "set"
+	ActionUnset  Action = "unset"
+	ActionReload Action = "reload"
+	// ActionRollback is an automatic revert raised by a rollback trigger; treat it as
+	// incident-grade.
+	ActionRollback Action = "rollback"
+	// ActionSetLimit and ActionUnsetLimit change numeric limit overrides; the new
+	// value is in UpdateEvent.Limit.
+	ActionSetLimit   Action = "set_limit"
+	ActionUnsetLimit Action = "unset_limit"
 )
 
+// DiffSummary lists keys affected by a configuration reload.
+type DiffSummary struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+// Empty reports whether the summary has no changes.
+func (d DiffSummary) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
 // UpdateEvent captures a runtime override mutation.
 type UpdateEvent struct {
+	ID            string
 	Key           string
 	NormalizedKey string
 	Scope         gate.ScopeRef
 	Actor         gate.ActorRef
 	Action        Action
 	Value         *bool
+	Limit         *int64
+	ExpiresAt     time.Time
+	Metadata      gate.OverrideMetadata
+	Diff          *DiffSummary
 }
 
 // Hook receives update events.
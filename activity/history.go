@@ -0,0 +1,178 @@
+package activity
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// DefaultHistoryCapacity is the number of entries a Log keeps per key.
+const DefaultHistoryCapacity = 1000
+
+// Entry is an update event with the time it was recorded.
+type Entry struct {
+	At    time.Time
+	Event UpdateEvent
+}
+
+// HistoryReader returns the recorded entries for a key in one scope, oldest first.
+type HistoryReader interface {
+	History(ctx context.Context, key string, scope gate.ScopeRef) ([]Entry, error)
+}
+
+// Log is an in-memory audit log of override updates. Register it with
+// resolver.WithActivityHook; durable audit stores implement HistoryReader instead.
+type Log struct {
+	mu       sync.RWMutex
+	now      func() time.Time
+	capacity int
+	entries  map[string][]Entry
+}
+
+// LogOption customizes a Log.
+type LogOption func(*Log)
+
+// WithLogClock sets the clock used to stamp entries.
+func WithLogClock(c clock.Clock) LogOption {
+	return func(l *Log) {
+		if l == nil {
+			return
+		}
+		l.now = clock.NowFunc(c)
+	}
+}
+
+// WithHistoryCapacity caps the entries kept per key; the oldest are dropped first.
+// Values below one use the default.
+func WithHistoryCapacity(capacity int) LogOption {
+	return func(l *Log) {
+		if l == nil {
+			return
+		}
+		l.capacity = capacity
+	}
+}
+
+// NewLog constructs an empty Log.
+func NewLog(opts ...LogOption) *Log {
+	l := &Log{
+		now:      time.Now,
+		capacity: DefaultHistoryCapacity,
+		entries:  map[string][]Entry{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(l)
+		}
+	}
+	if l.capacity < 1 {
+		l.capacity = DefaultHistoryCapacity
+	}
+	return l
+}
+
+// OnUpdate implements Hook. Reload events carry no key and are not recorded.
+func (l *Log) OnUpdate(_ context.Context, event UpdateEvent) {
+	if l == nil {
+		return
+	}
+	key := historyKey(event)
+	if key == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := append(l.entries[key], Entry{At: l.now(), Event: event})
+	if over := len(entries) - l.capacity; over > 0 {
+		entries = append([]Entry(nil), entries[over:]...)
+	}
+	l.entries[key] = entries
+}
+
+// History implements HistoryReader.
+func (l *Log) History(_ context.Context, key string, scope gate.ScopeRef) ([]Entry, error) {
+	if l == nil {
+		return nil, nil
+	}
+	key = gate.NormalizeKey(strings.TrimSpace(key))
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var out []Entry
+	for _, entry := range l.entries[key] {
+		if entry.Event.Scope == scope {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+func historyKey(event UpdateEvent) string {
+	if event.NormalizedKey != "" {
+		return event.NormalizedKey
+	}
+	return gate.NormalizeKey(strings.TrimSpace(event.Key))
+}
+
+// Segment is a span of time during which a key held one override state in a scope.
+// A nil Value means no override was set, so the configured default applied.
+type Segment struct {
+	Value    *bool
+	Action   Action
+	Actor    gate.ActorRef
+	Reason   string
+	Start    time.Time
+	End      time.Time // zero while the segment is current
+	Duration time.Duration
+}
+
+// Current reports whether the segment is still in effect.
+func (s Segment) Current() bool {
+	return s.End.IsZero()
+}
+
+// Timeline orders entries into contiguous value segments ending at now. Overrides that
+// expire before the next change close at their expiry, followed by a default segment.
+func Timeline(entries []Entry, now time.Time) []Segment {
+	ordered := append([]Entry(nil), entries...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].At.Before(ordered[j].At)
+	})
+	segments := make([]Segment, 0, len(ordered))
+	for i, entry := range ordered {
+		next := now
+		if i+1 < len(ordered) {
+			next = ordered[i+1].At
+		}
+		event := entry.Event
+		segment := Segment{
+			Value:  event.Value,
+			Action: event.Action,
+			Actor:  event.Actor,
+			Reason: event.Metadata.Reason,
+			Start:  entry.At,
+		}
+		expires := event.ExpiresAt
+		if event.Value != nil && !expires.IsZero() && expires.Before(next) {
+			segments = append(segments, closeSegment(segment, expires))
+			segment = Segment{Action: ActionUnset, Reason: "expired", Start: expires}
+		}
+		if i+1 < len(ordered) {
+			segment = closeSegment(segment, next)
+		} else {
+			segment.Duration = now.Sub(segment.Start)
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func closeSegment(segment Segment, end time.Time) Segment {
+	segment.End = end
+	segment.Duration = end.Sub(segment.Start)
+	return segment
+}
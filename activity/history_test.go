@@ -0,0 +1,74 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func TestLogTimelineSegments(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewManual(start)
+	log := NewLog(WithLogClock(clk))
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme"}
+	on, off := true, false
+
+	log.OnUpdate(ctx, UpdateEvent{
+		NormalizedKey: "billing.v2",
+		Scope:         tenant,
+		Actor:         gate.ActorRef{ID: "alice"},
+		Action:        ActionSet,
+		Value:         &on,
+		Metadata:      gate.OverrideMetadata{Reason: "launch"},
+	})
+	clk.Advance(time.Hour)
+	log.OnUpdate(ctx, UpdateEvent{
+		NormalizedKey: "billing.v2",
+		Scope:         tenant,
+		Actor:         gate.ActorRef{ID: "bob"},
+		Action:        ActionSet,
+		Value:         &off,
+		ExpiresAt:     clk.Now().Add(30 * time.Minute),
+		Metadata:      gate.OverrideMetadata{Reason: "incident"},
+	})
+	log.OnUpdate(ctx, UpdateEvent{NormalizedKey: "billing.v2", Scope: gate.ScopeRef{Kind: gate.ScopeSystem}, Action: ActionSet, Value: &on})
+	log.OnUpdate(ctx, UpdateEvent{Action: ActionReload})
+
+	entries, err := log.History(ctx, " billing.v2 ", tenant)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 tenant entries, got %d", len(entries))
+	}
+
+	segments := Timeline(entries, start.Add(2*time.Hour))
+	if len(segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", segments)
+	}
+	if first := segments[0]; *first.Value != true || first.Actor.ID != "alice" || first.Reason != "launch" || first.Duration != time.Hour {
+		t.Fatalf("unexpected first segment %+v", first)
+	}
+	if second := segments[1]; *second.Value != false || second.Duration != 30*time.Minute || second.Current() {
+		t.Fatalf("unexpected second segment %+v", second)
+	}
+	if last := segments[2]; last.Value != nil || !last.Current() || last.Reason != "expired" || last.Duration != 30*time.Minute {
+		t.Fatalf("unexpected expiry segment %+v", last)
+	}
+}
+
+func TestLogCapacityDropsOldest(t *testing.T) {
+	log := NewLog(WithHistoryCapacity(2))
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	for _, actor := range []string{"a", "b", "c"} {
+		log.OnUpdate(context.Background(), UpdateEvent{Key: "k", Scope: system, Action: ActionUnset, Actor: gate.ActorRef{ID: actor}})
+	}
+	entries, _ := log.History(context.Background(), "k", system)
+	if len(entries) != 2 || entries[0].Event.Actor.ID != "b" {
+		t.Fatalf("expected oldest entry dropped, got %+v", entries)
+	}
+}
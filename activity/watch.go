@@ -0,0 +1,155 @@
+package activity
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWatchBufferSize is the per-subscriber event capacity.
+const DefaultWatchBufferSize = 256
+
+// Broadcaster fans update events out to watchers. Each subscription has a fixed-size
+// ring buffer; when a slow watcher fills it, the oldest events are dropped and the
+// subscription is flagged for resync instead of growing without bound.
+type Broadcaster struct {
+	mu         sync.Mutex
+	bufferSize int
+	subs       map[*Subscription]struct{}
+}
+
+// BroadcastOption customizes a Broadcaster.
+type BroadcastOption func(*Broadcaster)
+
+// WithBufferSize sets the per-subscriber capacity. Values below one use the default.
+func WithBufferSize(size int) BroadcastOption {
+	return func(b *Broadcaster) {
+		if b == nil {
+			return
+		}
+		b.bufferSize = size
+	}
+}
+
+// NewBroadcaster constructs a Broadcaster. Register it with resolver.WithActivityHook.
+func NewBroadcaster(opts ...BroadcastOption) *Broadcaster {
+	b := &Broadcaster{
+		bufferSize: DefaultWatchBufferSize,
+		subs:       map[*Subscription]struct{}{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	if b.bufferSize < 1 {
+		b.bufferSize = DefaultWatchBufferSize
+	}
+	return b
+}
+
+// OnUpdate implements Hook. It never blocks on slow subscribers.
+func (b *Broadcaster) OnUpdate(_ context.Context, event UpdateEvent) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		sub.push(event)
+	}
+}
+
+// Subscribe registers a watcher. Call Close when done.
+func (b *Broadcaster) Subscribe() *Subscription {
+	sub := &Subscription{
+		broadcaster: b,
+		buf:         make([]UpdateEvent, b.bufferSize),
+		ready:       make(chan struct{}, 1),
+	}
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+	return sub
+}
+
+// Subscribers reports the number of open subscriptions.
+func (b *Broadcaster) Subscribers() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Subscription is a single watcher's bounded view of update events.
+type Subscription struct {
+	broadcaster *Broadcaster
+	mu          sync.Mutex
+	buf         []UpdateEvent
+	head        int
+	count       int
+	dropped     uint64
+	sinceDrain  uint64
+	ready       chan struct{}
+}
+
+// Ready is signalled when events (or a resync) are waiting to be drained.
+func (s *Subscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Drain returns the buffered events, oldest first, and empties the buffer.
+// dropped counts the events discarded since the previous drain; when it is
+// non-zero the watcher should reload full state because the returned events
+// are incomplete.
+func (s *Subscription) Drain() (events []UpdateEvent, dropped uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events = make([]UpdateEvent, 0, s.count)
+	for i := 0; i < s.count; i++ {
+		idx := (s.head + i) % len(s.buf)
+		events = append(events, s.buf[idx])
+		s.buf[idx] = UpdateEvent{}
+	}
+	s.head, s.count = 0, 0
+	dropped, s.sinceDrain = s.sinceDrain, 0
+	return events, dropped
+}
+
+// Dropped reports how many events were discarded over the subscription's
+// lifetime.
+func (s *Subscription) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close unregisters the subscription.
+func (s *Subscription) Close() {
+	if s == nil || s.broadcaster == nil {
+		return
+	}
+	s.broadcaster.mu.Lock()
+	delete(s.broadcaster.subs, s)
+	s.broadcaster.mu.Unlock()
+}
+
+func (s *Subscription) push(event UpdateEvent) {
+	s.mu.Lock()
+	if s.count == len(s.buf) {
+		s.head = (s.head + 1) % len(s.buf)
+		s.count--
+		s.dropped++
+		s.sinceDrain++
+	}
+	s.buf[(s.head+s.count)%len(s.buf)] = event
+	s.count++
+	s.mu.Unlock()
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+var _ Hook = (*Broadcaster)(nil)
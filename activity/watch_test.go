@@ -0,0 +1,45 @@
+package activity
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSubscriptionDropsOldestAndFlagsResync(t *testing.T) {
+	b := NewBroadcaster(WithBufferSize(2))
+	sub := b.Subscribe()
+	defer sub.Close()
+
+	for _, key := range []string{"a", "b", "c"} {
+		b.OnUpdate(context.Background(), UpdateEvent{Key: key, Action: ActionSet})
+	}
+	select {
+	case <-sub.Ready():
+	default:
+		t.Fatalf("expected subscription to be ready")
+	}
+	events, dropped := sub.Drain()
+	if dropped != 1 || sub.Dropped() != 1 {
+		t.Fatalf("expected one drop, got dropped=%d lifetime=%d", dropped, sub.Dropped())
+	}
+	if len(events) != 2 || events[0].Key != "b" || events[1].Key != "c" {
+		t.Fatalf("expected newest events in order, got %+v", events)
+	}
+
+	b.OnUpdate(context.Background(), UpdateEvent{Key: "d"})
+	if events, dropped := sub.Drain(); dropped != 0 || len(events) != 1 || events[0].Key != "d" {
+		t.Fatalf("expected the drop count to clear after drain, got %+v (dropped=%d)", events, dropped)
+	}
+
+	for _, key := range []string{"e", "f", "g", "h"} {
+		b.OnUpdate(context.Background(), UpdateEvent{Key: key})
+	}
+	if _, dropped := sub.Drain(); dropped != 2 || sub.Dropped() != 3 {
+		t.Fatalf("expected two drops since the last drain, got dropped=%d lifetime=%d", dropped, sub.Dropped())
+	}
+
+	sub.Close()
+	if b.Subscribers() != 0 {
+		t.Fatalf("expected closed subscription to be removed")
+	}
+}
@@ -0,0 +1,227 @@
+// Package adminadapter backs a feature-flags admin resource: list catalog entries with
+// their effective values per scope and change overrides on behalf of the signed-in actor.
+//
+// go-admin registers Resource from its side (like the PreferencesStore adapter), which
+// keeps go-featuregate free of a go-admin dependency and avoids an import cycle.
+package adminadapter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+const (
+	// ResourceName is the admin resource identifier.
+	ResourceName = "feature_flags"
+
+	TextCodeActorRequired = "ADMIN_ACTOR_REQUIRED"
+	TextCodeReadOnly      = "ADMIN_GATE_READ_ONLY"
+)
+
+// ActorExtractor returns the admin user performing a change.
+type ActorExtractor func(ctx context.Context) gate.ActorRef
+
+// Option customizes a Resource.
+type Option func(*Resource)
+
+// WithActorExtractor sets how the acting user is read from the request context.
+// Defaults to the scope user ID.
+func WithActorExtractor(extractor ActorExtractor) Option {
+	return func(r *Resource) {
+		if r == nil || extractor == nil {
+			return
+		}
+		r.actor = extractor
+	}
+}
+
+// WithMessageResolver sets the resolver for catalog descriptions. Defaults to catalog.PlainResolver.
+func WithMessageResolver(resolver catalog.MessageResolver) Option {
+	return func(r *Resource) {
+		if r == nil || resolver == nil {
+			return
+		}
+		r.messages = resolver
+	}
+}
+
+// Resource implements the feature-flags admin resource.
+type Resource struct {
+	gate     gate.FeatureGate
+	catalog  catalog.Catalog
+	actor    ActorExtractor
+	messages catalog.MessageResolver
+}
+
+// NewResource builds a Resource over a gate and catalog. Mutations require the gate to
+// implement gate.MutableFeatureGate.
+func NewResource(fg gate.FeatureGate, c catalog.Catalog, opts ...Option) *Resource {
+	r := &Resource{
+		gate:     fg,
+		catalog:  c,
+		actor:    defaultActor,
+		messages: catalog.PlainResolver{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Filter selects the scopes whose effective values are shown. System is always included.
+type Filter struct {
+	TenantID string
+	OrgID    string
+	UserID   string
+	Locale   string
+}
+
+// ScopeValue is the effective value of a feature at one scope.
+type ScopeValue struct {
+	Scope   gate.ScopeRef
+	Enabled bool
+	Source  gate.ResolveSource
+}
+
+// Row is one catalog entry with its effective values, most specific scope first.
+type Row struct {
+	Key         string
+	Description string
+	Status      catalog.Status
+	Owner       string
+	Values      []ScopeValue
+}
+
+// List returns every catalog entry with effective values for the filter's scopes.
+func (r *Resource) List(ctx context.Context, filter Filter) ([]Row, error) {
+	if r == nil || r.catalog == nil {
+		return []Row{}, nil
+	}
+	defs := r.catalog.List()
+	rows := make([]Row, 0, len(defs))
+	for _, def := range defs {
+		row, err := r.row(ctx, def, filter)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Get returns a single feature. Keys missing from the catalog are still resolved.
+func (r *Resource) Get(ctx context.Context, key string, filter Filter) (Row, error) {
+	def := catalog.FeatureDefinition{Key: gate.NormalizeKey(key)}
+	if r != nil && r.catalog != nil {
+		if found, ok := r.catalog.Get(key); ok {
+			def = found
+		}
+	}
+	return r.row(ctx, def, filter)
+}
+
+// Set stores an override at scopeRef as the extracted actor.
+func (r *Resource) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, meta gate.OverrideMetadata) error {
+	mutable, actor, err := r.mutation(ctx, key)
+	if err != nil {
+		return err
+	}
+	return mutable.Set(ctx, key, scopeRef, enabled, actor, gate.WithMetadata(meta))
+}
+
+// Unset removes the override at scopeRef as the extracted actor.
+func (r *Resource) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef) error {
+	mutable, actor, err := r.mutation(ctx, key)
+	if err != nil {
+		return err
+	}
+	return mutable.Unset(ctx, key, scopeRef, actor)
+}
+
+func (r *Resource) row(ctx context.Context, def catalog.FeatureDefinition, filter Filter) (Row, error) {
+	if r == nil || r.gate == nil {
+		return Row{}, ferrors.WrapSentinel(ferrors.ErrGateRequired, "adminadapter: feature gate is required", nil)
+	}
+	row := Row{Key: def.Key, Status: def.Lifecycle(), Owner: def.Owner}
+	if text, err := r.messages.Resolve(ctx, filter.Locale, def.Description); err == nil {
+		row.Description = text
+	}
+	for _, chain := range scopeChains(filter) {
+		value, source, err := r.resolve(ctx, def.Key, chain)
+		if err != nil {
+			return Row{}, err
+		}
+		row.Values = append(row.Values, ScopeValue{Scope: chain[0], Enabled: value, Source: source})
+	}
+	return row, nil
+}
+
+func (r *Resource) resolve(ctx context.Context, key string, chain gate.ScopeChain) (bool, gate.ResolveSource, error) {
+	opt := gate.WithScopeChain(chain)
+	if traceable, ok := r.gate.(gate.TraceableFeatureGate); ok {
+		value, trace, err := traceable.ResolveWithTrace(ctx, key, opt)
+		return value, trace.Source, err
+	}
+	value, err := r.gate.Enabled(ctx, key, opt)
+	return value, "", err
+}
+
+func (r *Resource) mutation(ctx context.Context, key string) (gate.MutableFeatureGate, gate.ActorRef, error) {
+	if r == nil || r.gate == nil {
+		return nil, gate.ActorRef{}, ferrors.WrapSentinel(ferrors.ErrGateRequired, "adminadapter: feature gate is required", nil)
+	}
+	mutable, ok := r.gate.(gate.MutableFeatureGate)
+	if !ok {
+		return nil, gate.ActorRef{}, ferrors.NewOperation(TextCodeReadOnly, "adminadapter: feature gate is read-only", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(key),
+		})
+	}
+	actor := r.actor(ctx)
+	if strings.TrimSpace(actor.ID) == "" {
+		return nil, gate.ActorRef{}, ferrors.NewBadInput(TextCodeActorRequired, "adminadapter: acting user is required", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(key),
+		})
+	}
+	return mutable, actor, nil
+}
+
+// scopeChains returns one chain per shown scope, most specific first; each chain
+// starts at its scope and falls back through the broader ones.
+func scopeChains(filter Filter) []gate.ScopeChain {
+	tenantID := strings.TrimSpace(filter.TenantID)
+	orgID := strings.TrimSpace(filter.OrgID)
+	userID := strings.TrimSpace(filter.UserID)
+
+	base := gate.ScopeChain{{Kind: gate.ScopeSystem}}
+	chains := []gate.ScopeChain{base}
+	if tenantID != "" {
+		base = append(gate.ScopeChain{{Kind: gate.ScopeTenant, ID: tenantID, TenantID: tenantID}}, base...)
+		chains = append(chains, base)
+	}
+	if orgID != "" {
+		base = append(gate.ScopeChain{{Kind: gate.ScopeOrg, ID: orgID, TenantID: tenantID, OrgID: orgID}}, base...)
+		chains = append(chains, base)
+	}
+	if userID != "" {
+		base = append(gate.ScopeChain{{Kind: gate.ScopeUser, ID: userID, TenantID: tenantID, OrgID: orgID}}, base...)
+		chains = append(chains, base)
+	}
+	for i, j := 0, len(chains)-1; i < j; i, j = i+1, j-1 {
+		chains[i], chains[j] = chains[j], chains[i]
+	}
+	return chains
+}
+
+func defaultActor(ctx context.Context) gate.ActorRef {
+	if id := scope.UserID(ctx); id != "" {
+		return gate.ActorRef{ID: id, Type: "user"}
+	}
+	return gate.ActorRef{}
+}
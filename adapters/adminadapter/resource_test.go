@@ -0,0 +1,46 @@
+package adminadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestResourceShowsScopedValuesAndRequiresActor(t *testing.T) {
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"users.signup": true})),
+		resolver.WithOverrideStore(store.NewMemoryStore()),
+	)
+	resource := NewResource(fg, configadapter.NewCatalog(map[string]any{
+		"users.signup": map[string]any{"description": "Allow signups"},
+	}))
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+
+	if err := resource.Set(context.Background(), "users.signup", tenant, false, gate.OverrideMetadata{}); err == nil {
+		t.Fatalf("expected error without an acting user")
+	}
+	ctx := scope.WithUserID(context.Background(), "admin-1")
+	if err := resource.Set(ctx, "users.signup", tenant, false, gate.OverrideMetadata{Reason: "abuse"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	rows, err := resource.List(ctx, Filter{TenantID: "acme"})
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("list: %v %+v", err, rows)
+	}
+	row := rows[0]
+	if row.Description != "Allow signups" || len(row.Values) != 2 {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+	if row.Values[0].Scope.Kind != gate.ScopeTenant || row.Values[0].Enabled || row.Values[0].Source != gate.ResolveSourceOverride {
+		t.Fatalf("expected tenant override first, got %+v", row.Values[0])
+	}
+	if row.Values[1].Scope.Kind != gate.ScopeSystem || !row.Values[1].Enabled {
+		t.Fatalf("expected system default second, got %+v", row.Values[1])
+	}
+}
@@ -0,0 +1,377 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/migrate"
+
+	"github.com/goliatone/go-featuregate/adapters/bunadapter"
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+// Option customizes schema management.
+type Option func(*config)
+
+type config struct {
+	table string
+}
+
+// WithTable sets the table name managed by the migrations.
+func WithTable(table string) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.table = strings.TrimSpace(table)
+	}
+}
+
+func newConfig(opts ...Option) config {
+	cfg := config{table: bunadapter.DefaultTable}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.table == "" {
+		cfg.table = bunadapter.DefaultTable
+	}
+	return cfg
+}
+
+type column struct {
+	name  string
+	types map[dialect.Name]string
+	def   string
+}
+
+func (c column) sqlType(name dialect.Name) string {
+	if typ, ok := c.types[name]; ok {
+		return typ
+	}
+	return c.def
+}
+
+var (
+	colKey       = column{name: "key", def: "TEXT NOT NULL"}
+	colScopeType = column{name: "scope_type", def: "TEXT NOT NULL"}
+	colScopeID   = column{name: "scope_id", def: "TEXT NOT NULL DEFAULT ''"}
+	colTenantID  = column{name: "tenant_id", def: "TEXT NOT NULL DEFAULT ''"}
+	colOrgID     = column{name: "org_id", def: "TEXT NOT NULL DEFAULT ''"}
+	colEnabled   = column{name: "enabled", def: "BOOLEAN NULL"}
+	colUpdatedBy = column{name: "updated_by", def: "TEXT NULL"}
+	colUpdatedAt = column{name: "updated_at", def: "TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP", types: map[dialect.Name]string{
+		dialect.PG: "TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()",
+	}}
+	colExpiresAt = column{name: "expires_at", def: "TIMESTAMP NULL", types: map[dialect.Name]string{dialect.PG: "TIMESTAMP WITH TIME ZONE NULL"}}
+	colReason    = column{name: "reason", def: "TEXT NULL"}
+	colTicketURL = column{name: "ticket_url", def: "TEXT NULL"}
+	colOwner     = column{name: "owner", def: "TEXT NULL"}
+	colLabels    = column{name: "labels", def: "TEXT NULL", types: map[dialect.Name]string{dialect.PG: "JSONB NULL"}}
+	colPriority  = column{name: "priority", def: "INTEGER NOT NULL DEFAULT 0"}
+)
+
+// primaryKey is the current primary key; legacyPrimaryKey predates tenant/org qualification.
+var (
+	primaryKey       = []string{"key", "scope_type", "scope_id", "tenant_id", "org_id"}
+	legacyPrimaryKey = []string{"key", "scope_type", "scope_id"}
+)
+
+// step is a single schema migration. The first step creates the table with the
+// current schema; later steps add columns to tables created by earlier versions.
+type step struct {
+	name    string
+	comment string
+	create  bool
+	rekey   bool
+	columns []column
+}
+
+var steps = []step{
+	{
+		name:    "20250101000001",
+		comment: "feature_flags_create",
+		create:  true,
+		columns: []column{colKey, colScopeType, colScopeID, colEnabled, colUpdatedBy, colUpdatedAt},
+	},
+	{
+		name:    "20250101000002",
+		comment: "feature_flags_expiry",
+		columns: []column{colExpiresAt},
+	},
+	{
+		name:    "20250101000003",
+		comment: "feature_flags_metadata",
+		columns: []column{colReason, colTicketURL, colOwner, colLabels},
+	},
+	{
+		name:    "20250101000004",
+		comment: "feature_flags_scope_qualifiers",
+		rekey:   true,
+		columns: []column{colTenantID, colOrgID},
+	},
+	{
+		name:    "20250101000005",
+		comment: "feature_flags_priority",
+		columns: []column{colPriority},
+	},
+}
+
+// Migrations returns the feature_flags schema as bun migrations.
+// Column types target Postgres and SQLite; other dialects use the SQLite types.
+// The scope qualifier step re-keys tables to include tenant_id and org_id (SQLite
+// tables are rebuilt, since SQLite cannot alter a primary key) and splits scope_id
+// values packed as "tenant|org|id" by earlier versions into the new columns.
+func Migrations(opts ...Option) *migrate.Migrations {
+	migrations := migrate.NewMigrations()
+	Register(migrations, opts...)
+	return migrations
+}
+
+// Register adds the feature_flags migrations to an existing migration set.
+// Every step is idempotent, so tables created by hand are upgraded in place.
+func Register(migrations *migrate.Migrations, opts ...Option) {
+	if migrations == nil {
+		return
+	}
+	cfg := newConfig(opts...)
+	for _, s := range steps {
+		s := s
+		migrations.Add(migrate.Migration{
+			Name:    s.name,
+			Comment: s.comment,
+			Up: func(ctx context.Context, m *migrate.Migrator, _ *migrate.Migration) error {
+				return s.up(ctx, m.DB(), cfg.table)
+			},
+			Down: func(ctx context.Context, m *migrate.Migrator, _ *migrate.Migration) error {
+				return s.down(ctx, m.DB(), cfg.table)
+			},
+		})
+	}
+}
+
+// EnsureSchema creates the feature_flags table and adds any missing columns.
+func EnsureSchema(ctx context.Context, db bun.IDB, opts ...Option) error {
+	if db == nil {
+		return ferrors.WrapSentinel(ferrors.ErrStoreRequired, "migrations: db is required", map[string]any{
+			ferrors.MetaAdapter:   "bun",
+			ferrors.MetaOperation: "ensure_schema",
+		})
+	}
+	cfg := newConfig(opts...)
+	for _, s := range steps {
+		if err := s.up(ctx, db, cfg.table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTableSQL renders the full CREATE TABLE statement for a dialect.
+func CreateTableSQL(name dialect.Name, table string) string {
+	return createTableSQL(name, table, allColumns(), primaryKey)
+}
+
+func createTableSQL(name dialect.Name, table string, columns []column, key []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n", table)
+	for _, col := range columns {
+		fmt.Fprintf(&b, "    %s %s,\n", col.name, col.sqlType(name))
+	}
+	fmt.Fprintf(&b, "    PRIMARY KEY (%s)\n)", strings.Join(key, ", "))
+	return b.String()
+}
+
+func allColumns() []column {
+	var out []column
+	for _, s := range steps {
+		out = append(out, s.columns...)
+	}
+	return out
+}
+
+func (s step) up(ctx context.Context, db bun.IDB, table string) error {
+	name := db.Dialect().Name()
+	if s.create {
+		if _, err := db.ExecContext(ctx, CreateTableSQL(name, table)); err != nil {
+			return migrationError(err, table, s, "create_table")
+		}
+		return nil
+	}
+	existing, err := existingColumns(ctx, db, table)
+	if err != nil {
+		return migrationError(err, table, s, "inspect_columns")
+	}
+	added := false
+	for _, col := range s.columns {
+		if existing[col.name] {
+			continue
+		}
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, col.name, col.sqlType(name))
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return migrationError(err, table, s, "add_column")
+		}
+		added = true
+	}
+	if !s.rekey || !added {
+		return nil
+	}
+	if err := rekey(ctx, db, table, primaryKey, nil); err != nil {
+		return migrationError(err, table, s, "primary_key")
+	}
+	if err := splitScopeIDs(ctx, db, table); err != nil {
+		return migrationError(err, table, s, "split_scope_ids")
+	}
+	return nil
+}
+
+// rekey replaces the primary key. Postgres alters the constraint in place; SQLite
+// cannot, so the table is rebuilt with the new key, without the drop columns, and
+// its rows copied over.
+func rekey(ctx context.Context, db bun.IDB, table string, key []string, drop []column) error {
+	if db.Dialect().Name() == dialect.PG {
+		constraint := table[strings.LastIndex(table, ".")+1:] + "_pkey"
+		query := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s, ADD PRIMARY KEY (%s)", table, constraint, strings.Join(key, ", "))
+		_, err := db.ExecContext(ctx, query)
+		return err
+	}
+	existing, err := existingColumns(ctx, db, table)
+	if err != nil {
+		return err
+	}
+	var columns []column
+	var names []string
+	for _, col := range allColumns() {
+		if existing[col.name] && !hasColumn(drop, col.name) {
+			columns = append(columns, col)
+			names = append(names, col.name)
+		}
+	}
+	rebuilt := table + "_rekey"
+	list := strings.Join(names, ", ")
+	for _, query := range []string{
+		fmt.Sprintf("DROP TABLE IF EXISTS %s", rebuilt),
+		createTableSQL(db.Dialect().Name(), rebuilt, columns, key),
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", rebuilt, list, list, table),
+		fmt.Sprintf("DROP TABLE %s", table),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", rebuilt, table),
+	} {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packedScope is a row whose scope_id packs tenant and org as "tenant|org|id", the
+// format used before the tenant_id and org_id columns existed.
+type packedScope struct {
+	Key       string `bun:"key"`
+	ScopeType string `bun:"scope_type"`
+	ScopeID   string `bun:"scope_id"`
+}
+
+// splitScopeIDs moves packed tenant and org qualifiers into their columns.
+func splitScopeIDs(ctx context.Context, db bun.IDB, table string) error {
+	var rows []packedScope
+	query := fmt.Sprintf("SELECT key, scope_type, scope_id FROM %s WHERE scope_id LIKE '%%|%%|%%' AND tenant_id = '' AND org_id = ''", table)
+	if err := db.NewRaw(query).Scan(ctx, &rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		parts := strings.SplitN(row.ScopeID, "|", 3)
+		if _, err := db.ExecContext(ctx,
+			fmt.Sprintf("UPDATE %s SET tenant_id = ?, org_id = ?, scope_id = ? WHERE key = ? AND scope_type = ? AND scope_id = ? AND tenant_id = '' AND org_id = ''", table),
+			parts[0], parts[1], parts[2], row.Key, row.ScopeType, row.ScopeID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packScopeIDs reverses splitScopeIDs before the qualifier columns are dropped.
+func packScopeIDs(ctx context.Context, db bun.IDB, table string) error {
+	query := fmt.Sprintf("UPDATE %s SET scope_id = tenant_id || '|' || org_id || '|' || scope_id, tenant_id = '', org_id = '' WHERE tenant_id <> '' OR org_id <> ''", table)
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
+
+func (s step) down(ctx context.Context, db bun.IDB, table string) error {
+	if s.create {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", table)); err != nil {
+			return migrationError(err, table, s, "drop_table")
+		}
+		return nil
+	}
+	existing, err := existingColumns(ctx, db, table)
+	if err != nil {
+		return migrationError(err, table, s, "inspect_columns")
+	}
+	present := false
+	for _, col := range s.columns {
+		present = present || existing[col.name]
+	}
+	if !present {
+		return nil
+	}
+	if s.rekey {
+		if err := packScopeIDs(ctx, db, table); err != nil {
+			return migrationError(err, table, s, "pack_scope_ids")
+		}
+		if err := rekey(ctx, db, table, legacyPrimaryKey, s.columns); err != nil {
+			return migrationError(err, table, s, "primary_key")
+		}
+		if db.Dialect().Name() != dialect.PG {
+			return nil
+		}
+	}
+	for _, col := range s.columns {
+		if !existing[col.name] {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, col.name)); err != nil {
+			return migrationError(err, table, s, "drop_column")
+		}
+	}
+	return nil
+}
+
+func hasColumn(columns []column, name string) bool {
+	for _, col := range columns {
+		if col.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// existingColumns reads column names portably from an empty result set.
+func existingColumns(ctx context.Context, db bun.IDB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE 1 = 0", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool, len(names))
+	for _, name := range names {
+		out[strings.ToLower(name)] = true
+	}
+	return out, rows.Err()
+}
+
+func migrationError(err error, table string, s step, operation string) error {
+	return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "migrations: "+s.comment+" failed", map[string]any{
+		ferrors.MetaAdapter:   "bun",
+		ferrors.MetaTable:     table,
+		ferrors.MetaOperation: operation,
+	})
+}
@@ -0,0 +1,124 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/sqliteshim"
+	"github.com/uptrace/bun/migrate"
+
+	"github.com/goliatone/go-featuregate/adapters/bunadapter"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func TestCreateTableSQLIncludesAllColumns(t *testing.T) {
+	pg := CreateTableSQL(dialect.PG, "feature_flags")
+	for _, want := range []string{
+		"CREATE TABLE IF NOT EXISTS feature_flags",
+		"expires_at TIMESTAMP WITH TIME ZONE NULL",
+		"labels JSONB NULL",
+		"tenant_id TEXT NOT NULL DEFAULT ''",
+		"PRIMARY KEY (key, scope_type, scope_id, tenant_id, org_id)",
+	} {
+		if !strings.Contains(pg, want) {
+			t.Fatalf("expected %q in:\n%s", want, pg)
+		}
+	}
+	if sqlite := CreateTableSQL(dialect.SQLite, "flags"); !strings.Contains(sqlite, "labels TEXT NULL") {
+		t.Fatalf("expected sqlite labels column in:\n%s", sqlite)
+	}
+}
+
+func TestRegisterAddsOrderedMigrations(t *testing.T) {
+	migrations := migrate.NewMigrations()
+	Register(migrations, WithTable("flags"))
+	sorted := migrations.Sorted()
+	if len(sorted) != len(steps) {
+		t.Fatalf("expected %d migrations, got %d", len(steps), len(sorted))
+	}
+	if sorted[0].Comment != "feature_flags_create" {
+		t.Fatalf("expected create migration first, got %s", sorted[0])
+	}
+}
+
+func openSQLite(t *testing.T) *bun.DB {
+	t.Helper()
+	sqlDB, err := sql.Open(sqliteshim.ShimName, "file::memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	db := bun.NewDB(sqlDB, sqlitedialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestEnsureSchemaUpgradesBaselineTables(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	for _, query := range []string{
+		`CREATE TABLE feature_flags (
+			key TEXT NOT NULL,
+			scope_type TEXT NOT NULL,
+			scope_id TEXT NOT NULL DEFAULT '',
+			enabled BOOLEAN NULL,
+			updated_by TEXT NULL,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (key, scope_type, scope_id)
+		)`,
+		`INSERT INTO feature_flags (key, scope_type, scope_id, enabled) VALUES
+			('reports', 'user', 'acme|o1|u1', TRUE),
+			('reports', 'user', 'globex||u1', FALSE),
+			('reports', 'tenant', 'acme||acme', TRUE),
+			('reports', 'system', '', TRUE)`,
+	} {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			t.Fatalf("baseline: %v", err)
+		}
+	}
+
+	if err := EnsureSchema(ctx, db); err != nil {
+		t.Fatalf("ensure schema: %v", err)
+	}
+	if err := EnsureSchema(ctx, db); err != nil {
+		t.Fatalf("ensure schema is not idempotent: %v", err)
+	}
+
+	overrides := bunadapter.NewStore(db)
+	acmeUser := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1", TenantID: "acme", OrgID: "o1"}
+	globexUser := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1", TenantID: "globex"}
+	for ref, want := range map[gate.ScopeRef]bool{acmeUser: true, globexUser: false, {Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}: true} {
+		matches, err := overrides.GetAll(ctx, "reports", gate.ScopeChain{ref})
+		if err != nil || len(matches) != 1 || matches[0].Override.Value != want {
+			t.Fatalf("expected the packed %+v row to be split and readable, got %+v (%v)", ref, matches, err)
+		}
+	}
+	if err := overrides.Set(ctx, "reports", acmeUser, false, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("expected upserts to match the rebuilt primary key: %v", err)
+	}
+	if err := overrides.Set(ctx, "reports", gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1"}, true, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("expected an unqualified row beside the qualified ones: %v", err)
+	}
+	var count int
+	if err := db.NewRaw("SELECT COUNT(*) FROM feature_flags WHERE scope_type = 'user'").Scan(ctx, &count); err != nil || count != 3 {
+		t.Fatalf("expected three user rows, got %d (%v)", count, err)
+	}
+
+	qualifiers := steps[3]
+	if err := qualifiers.down(ctx, db, bunadapter.DefaultTable); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	var packed []string
+	if err := db.NewRaw("SELECT scope_id FROM feature_flags WHERE scope_type = 'user' ORDER BY scope_id").Scan(ctx, &packed); err != nil {
+		t.Fatalf("read packed: %v", err)
+	}
+	if !reflect.DeepEqual(packed, []string{"acme|o1|u1", "globex||u1", "u1"}) {
+		t.Fatalf("expected down to pack the qualifiers back, got %v", packed)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 
+	"github.com/goliatone/go-featuregate/clock"
 	"github.com/goliatone/go-featuregate/ferrors"
 	"github.com/goliatone/go-featuregate/gate"
 	"github.com/goliatone/go-featuregate/store"
@@ -17,6 +19,25 @@ import (
 // DefaultTable is the default table name for feature flag overrides.
 const DefaultTable = "feature_flags"
 
+// recordAlias is the alias Bun uses for FeatureFlagRecord columns in model queries.
+const recordAlias = "feature_flag_record"
+
+// conflictColumns is the primary key targeted by upserts.
+const conflictColumns = "key, scope_type, scope_id, tenant_id, org_id"
+
+// upsertColumns are rewritten when an override row already exists.
+var upsertColumns = []string{
+	"enabled",
+	"updated_by",
+	"updated_at",
+	"expires_at",
+	"reason",
+	"ticket_url",
+	"owner",
+	"labels",
+	"priority",
+}
+
 // ErrDBRequired indicates the underlying Bun DB is missing.
 var ErrDBRequired = ferrors.ErrStoreRequired
 
@@ -69,6 +90,16 @@ func WithTable(table string) Option {
 	}
 }
 
+// WithClock sets the clock used for updated_at and TTL expiry.
+func WithClock(c clock.Clock) Option {
+	return func(adapter *Store) {
+		if adapter == nil {
+			return
+		}
+		adapter.now = clock.NowFunc(c)
+	}
+}
+
 // WithNowFunc overrides the timestamp function used for updates.
 func WithNowFunc(now func() time.Time) Option {
 	return func(adapter *Store) {
@@ -89,51 +120,117 @@ func WithUpdatedByBuilder(builder func(gate.ActorRef) string) Option {
 	}
 }
 
+type txKey struct{}
+
+// ContextWithTx routes store reads and writes made with ctx through tx, so a Gate
+// resolving inside the transaction sees its pending Set/Unset calls while other
+// requests keep reading committed rows. The context is also marked with
+// store.WithUncommitted so caches never retain transaction-local values.
+func ContextWithTx(ctx context.Context, tx bun.Tx) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return store.WithUncommitted(context.WithValue(ctx, txKey{}, tx))
+}
+
+// TxFromContext returns the transaction attached by ContextWithTx.
+func TxFromContext(ctx context.Context) (bun.Tx, bool) {
+	if ctx == nil {
+		return bun.Tx{}, false
+	}
+	tx, ok := ctx.Value(txKey{}).(bun.Tx)
+	return tx, ok
+}
+
+// WithTx returns a copy of the store bound to tx. Prefer ContextWithTx when the
+// store sits behind a Gate or cache.
+func (s *Store) WithTx(tx bun.Tx) *Store {
+	if s == nil {
+		return nil
+	}
+	bound := *s
+	bound.db = tx
+	return &bound
+}
+
+// conn returns the transaction carried by ctx, or the configured DB.
+func (s *Store) conn(ctx context.Context) bun.IDB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return s.db
+}
+
 // FeatureFlagRecord maps to the feature_flags table.
 type FeatureFlagRecord struct {
 	bun.BaseModel `bun:"table:feature_flags"`
-	Key           string    `bun:"key,pk"`
-	ScopeType     string    `bun:"scope_type,pk"`
-	ScopeID       string    `bun:"scope_id,pk"`
-	Enabled       *bool     `bun:"enabled,nullzero"`
-	UpdatedBy     string    `bun:"updated_by,nullzero"`
-	UpdatedAt     time.Time `bun:"updated_at,nullzero"`
+	Key           string            `bun:"key,pk"`
+	ScopeType     string            `bun:"scope_type,pk"`
+	ScopeID       string            `bun:"scope_id,pk"`
+	TenantID      string            `bun:"tenant_id,pk"`
+	OrgID         string            `bun:"org_id,pk"`
+	Enabled       *bool             `bun:"enabled,nullzero"`
+	UpdatedBy     string            `bun:"updated_by,nullzero"`
+	UpdatedAt     time.Time         `bun:"updated_at,nullzero"`
+	ExpiresAt     time.Time         `bun:"expires_at,nullzero"`
+	Reason        string            `bun:"reason,nullzero"`
+	TicketURL     string            `bun:"ticket_url,nullzero"`
+	Owner         string            `bun:"owner,nullzero"`
+	Labels        map[string]string `bun:"labels,nullzero"`
+	Priority      int               `bun:"priority,notnull"`
 }
 
 // GetAll implements store.Reader.
+// All scopes in the chain (including role and perm scopes) are read with a single
+// WHERE key = ? AND (scope_type, scope_id, tenant_id, org_id) IN (...) query; matches follow chain order.
 func (s *Store) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]store.OverrideMatch, error) {
 	if s == nil || s.db == nil {
 		return nil, storeRequiredError(key, gate.ScopeRef{}, "get_all")
 	}
-	normalized, err := normalizeKey(key)
+	normalized, err := storedKey(key)
 	if err != nil {
 		return nil, err
 	}
-	matches := make([]store.OverrideMatch, 0)
+	if len(chain) == 0 {
+		return nil, nil
+	}
+	pairs := make([][]string, 0, len(chain))
+	seen := make(map[scopeKey]struct{}, len(chain))
 	for _, ref := range chain {
 		scope := scopeKeyFromRef(ref)
-		record := FeatureFlagRecord{}
-		query := s.db.NewSelect().Model(&record).
-			Where("key = ?", normalized).
-			Where("scope_type = ?", scope.kind).
-			Where("scope_id = ?", scope.id).
-			Limit(1)
-		if s.table != "" {
-			query = query.TableExpr(s.table)
+		if _, ok := seen[scope]; ok {
+			continue
 		}
-		if err := query.Scan(ctx); err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				continue
-			}
-			return nil, ferrors.WrapExternal(err, ferrors.TextCodeStoreReadFailed, "bunadapter: read failed", map[string]any{
-				ferrors.MetaAdapter:              "bun",
-				ferrors.MetaStore:                "bun",
-				ferrors.MetaTable:                s.table,
-				ferrors.MetaFeatureKey:           strings.TrimSpace(key),
-				ferrors.MetaFeatureKeyNormalized: normalized,
-				ferrors.MetaScope:                ref,
-				ferrors.MetaOperation:            "get_all",
-			})
+		seen[scope] = struct{}{}
+		pairs = append(pairs, []string{string(scope.kind), scope.id, scope.tenantID, scope.orgID})
+	}
+
+	records := make([]FeatureFlagRecord, 0, len(pairs))
+	query := s.conn(ctx).NewSelect().Model(&records).
+		ModelTableExpr(s.aliasedTable()).
+		Where("? = ?", bun.Ident("key"), normalized).
+		Where("(scope_type, scope_id, tenant_id, org_id) IN (?)", bun.In(pairs))
+	if err := query.Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, ferrors.WrapExternal(err, ferrors.TextCodeStoreReadFailed, "bunadapter: read failed", map[string]any{
+			ferrors.MetaAdapter:              "bun",
+			ferrors.MetaStore:                "bun",
+			ferrors.MetaTable:                s.table,
+			ferrors.MetaFeatureKey:           strings.TrimSpace(key),
+			ferrors.MetaFeatureKeyNormalized: normalized,
+			ferrors.MetaChain:                chain,
+			ferrors.MetaOperation:            "get_all",
+		})
+	}
+
+	byScope := make(map[scopeKey]FeatureFlagRecord, len(records))
+	for _, record := range records {
+		byScope[scopeKeyFromRecord(record)] = record
+	}
+	matches := make([]store.OverrideMatch, 0, len(records))
+	for _, ref := range chain {
+		record, ok := byScope[scopeKeyFromRef(ref)]
+		if !ok {
+			continue
 		}
 		matches = append(matches, store.OverrideMatch{
 			Scope:    ref,
@@ -143,8 +240,52 @@ func (s *Store) GetAll(ctx context.Context, key string, chain gate.ScopeChain) (
 	return matches, nil
 }
 
+// List implements store.Lister. The key filter runs in SQL; label filters are
+// applied after decoding so the labels column stays portable across dialects.
+func (s *Store) List(ctx context.Context, filter store.ListFilter) ([]store.OverrideRecord, error) {
+	if s == nil || s.db == nil {
+		return nil, storeRequiredError(filter.Key, gate.ScopeRef{}, "list")
+	}
+	records := make([]FeatureFlagRecord, 0)
+	query := s.conn(ctx).NewSelect().Model(&records).
+		ModelTableExpr(s.aliasedTable()).
+		Order("key ASC", "scope_type ASC", "tenant_id ASC", "org_id ASC", "scope_id ASC")
+	normalized := ""
+	if strings.TrimSpace(filter.Key) != "" {
+		key, err := normalizeKey(filter.Key)
+		if err != nil {
+			return nil, err
+		}
+		normalized = key
+		query = query.Where("? = ?", bun.Ident("key"), normalized)
+	}
+	if err := query.Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, ferrors.WrapExternal(err, ferrors.TextCodeStoreReadFailed, "bunadapter: list failed", map[string]any{
+			ferrors.MetaAdapter:              "bun",
+			ferrors.MetaStore:                "bun",
+			ferrors.MetaTable:                s.table,
+			ferrors.MetaFeatureKey:           strings.TrimSpace(filter.Key),
+			ferrors.MetaFeatureKeyNormalized: normalized,
+			ferrors.MetaOperation:            "list",
+		})
+	}
+	out := make([]store.OverrideRecord, 0, len(records))
+	for _, record := range records {
+		override := overrideFromRecord(record)
+		if !override.Metadata.MatchesLabels(filter.Labels) {
+			continue
+		}
+		out = append(out, store.OverrideRecord{
+			Key:      record.Key,
+			Scope:    scopeRefFromRecord(record),
+			Override: override,
+		})
+	}
+	return out, nil
+}
+
 // Set implements store.Writer.
-func (s *Store) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef) error {
+func (s *Store) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
 	if s == nil || s.db == nil {
 		return storeRequiredError(key, scopeRef, "set")
 	}
@@ -153,7 +294,8 @@ func (s *Store) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, ena
 		return err
 	}
 	scope := scopeKeyFromRef(scopeRef)
-	return s.upsert(ctx, normalized, scope, boolPtr(enabled), actor)
+	req := gate.ApplyMutationOptions(opts...)
+	return s.upsert(ctx, normalized, scope, boolPtr(enabled), req.Expiry(s.now()), req.Metadata, req.Priority, actor)
 }
 
 // Unset implements store.Writer.
@@ -161,12 +303,12 @@ func (s *Store) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, a
 	if s == nil || s.db == nil {
 		return storeRequiredError(key, scopeRef, "unset")
 	}
-	normalized, err := normalizeKey(key)
+	normalized, err := storedKey(key)
 	if err != nil {
 		return err
 	}
 	scope := scopeKeyFromRef(scopeRef)
-	return s.upsert(ctx, normalized, scope, nil, actor)
+	return s.upsert(ctx, normalized, scope, nil, time.Time{}, gate.OverrideMetadata{}, 0, actor)
 }
 
 // Delete removes a stored override row.
@@ -179,14 +321,14 @@ func (s *Store) Delete(ctx context.Context, key string, scopeRef gate.ScopeRef)
 		return err
 	}
 	scope := scopeKeyFromRef(scopeRef)
-	query := s.db.NewDelete().
-		Where("key = ?", normalized).
+	_, err = s.conn(ctx).NewDelete().
+		TableExpr(s.table).
+		Where("? = ?", bun.Ident("key"), normalized).
 		Where("scope_type = ?", scope.kind).
-		Where("scope_id = ?", scope.id)
-	if s.table != "" {
-		query = query.TableExpr(s.table)
-	}
-	_, err = query.Exec(ctx)
+		Where("scope_id = ?", scope.id).
+		Where("tenant_id = ?", scope.tenantID).
+		Where("org_id = ?", scope.orgID).
+		Exec(ctx)
 	if err != nil {
 		return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "bunadapter: delete failed", map[string]any{
 			ferrors.MetaAdapter:              "bun",
@@ -201,24 +343,24 @@ func (s *Store) Delete(ctx context.Context, key string, scopeRef gate.ScopeRef)
 	return nil
 }
 
-func (s *Store) upsert(ctx context.Context, key string, scope scopeKey, enabled *bool, actor gate.ActorRef) error {
+func (s *Store) upsert(ctx context.Context, key string, scope scopeKey, enabled *bool, expiresAt time.Time, meta gate.OverrideMetadata, priority int, actor gate.ActorRef) error {
 	record := FeatureFlagRecord{
 		Key:       key,
 		ScopeType: string(scope.kind),
 		ScopeID:   scope.id,
+		TenantID:  scope.tenantID,
+		OrgID:     scope.orgID,
 		Enabled:   enabled,
 		UpdatedBy: s.updatedBy(actor),
 		UpdatedAt: s.now(),
-	}
-	query := s.db.NewInsert().Model(&record).
-		On("CONFLICT (key, scope_type, scope_id) DO UPDATE").
-		Set("enabled = EXCLUDED.enabled").
-		Set("updated_by = EXCLUDED.updated_by").
-		Set("updated_at = EXCLUDED.updated_at")
-	if s.table != "" {
-		query = query.TableExpr(s.table)
-	}
-	_, err := query.Exec(ctx)
+		ExpiresAt: expiresAt,
+		Reason:    meta.Reason,
+		TicketURL: meta.TicketURL,
+		Owner:     meta.Owner,
+		Labels:    meta.Labels,
+		Priority:  priority,
+	}
+	err := s.write(ctx, []FeatureFlagRecord{record})
 	if err != nil {
 		return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "bunadapter: upsert failed", map[string]any{
 			ferrors.MetaAdapter:              "bun",
@@ -233,6 +375,133 @@ func (s *Store) upsert(ctx context.Context, key string, scope scopeKey, enabled
 	return nil
 }
 
+// WriteBatch implements store.BatchWriter with one multi-row upsert.
+func (s *Store) WriteBatch(ctx context.Context, mutations []store.Mutation) error {
+	if s == nil || s.db == nil {
+		return storeRequiredError("", gate.ScopeRef{}, "write_batch")
+	}
+	records := make([]FeatureFlagRecord, 0, len(mutations))
+	type rowKey struct {
+		key   string
+		scope scopeKey
+	}
+	positions := make(map[rowKey]int, len(mutations))
+	now := s.now()
+	for _, m := range mutations {
+		normalized, err := normalizeKey(m.Key)
+		if err != nil {
+			return err
+		}
+		scope := scopeKeyFromRef(m.Scope)
+		record := FeatureFlagRecord{
+			Key:       normalized,
+			ScopeType: string(scope.kind),
+			ScopeID:   scope.id,
+			TenantID:  scope.tenantID,
+			OrgID:     scope.orgID,
+			Enabled:   m.Enabled,
+			UpdatedBy: s.updatedBy(m.Actor),
+			UpdatedAt: now,
+		}
+		if m.Enabled != nil {
+			record.ExpiresAt = m.ExpiresAt
+			record.Reason = m.Metadata.Reason
+			record.TicketURL = m.Metadata.TicketURL
+			record.Owner = m.Metadata.Owner
+			record.Labels = m.Metadata.Labels
+			record.Priority = m.Priority
+		}
+		// A multi-row upsert may not touch the same row twice; keep the last write.
+		id := rowKey{key: normalized, scope: scope}
+		if i, ok := positions[id]; ok {
+			records[i] = record
+			continue
+		}
+		positions[id] = len(records)
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := s.write(ctx, records); err != nil {
+		return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "bunadapter: batch upsert failed", map[string]any{
+			ferrors.MetaAdapter:   "bun",
+			ferrors.MetaStore:     "bun",
+			ferrors.MetaTable:     s.table,
+			ferrors.MetaOperation: "write_batch",
+		})
+	}
+	return nil
+}
+
+// write upserts records with the statement the DB dialect supports.
+func (s *Store) write(ctx context.Context, records []FeatureFlagRecord) error {
+	switch s.conn(ctx).Dialect().Name() {
+	case dialect.PG, dialect.SQLite:
+		return s.upsertOnConflict(ctx, &records)
+	case dialect.MySQL:
+		return s.upsertOnDuplicateKey(ctx, &records)
+	default:
+		for i := range records {
+			if err := s.updateOrInsert(ctx, &records[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// upsertOnConflict uses INSERT ... ON CONFLICT, shared by Postgres and SQLite 3.24+.
+func (s *Store) upsertOnConflict(ctx context.Context, records *[]FeatureFlagRecord) error {
+	query := s.conn(ctx).NewInsert().Model(records).
+		ModelTableExpr(s.table).
+		On("CONFLICT (" + conflictColumns + ") DO UPDATE")
+	for _, column := range upsertColumns {
+		query = query.Set("? = EXCLUDED.?", bun.Ident(column), bun.Ident(column))
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
+
+// upsertOnDuplicateKey uses MySQL's INSERT ... ON DUPLICATE KEY UPDATE.
+func (s *Store) upsertOnDuplicateKey(ctx context.Context, records *[]FeatureFlagRecord) error {
+	query := s.conn(ctx).NewInsert().Model(records).
+		ModelTableExpr(s.table).
+		On("DUPLICATE KEY UPDATE")
+	for _, column := range upsertColumns {
+		query = query.Set("? = VALUES(?)", bun.Ident(column), bun.Ident(column))
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
+
+// updateOrInsert is the portable fallback for dialects without a native upsert:
+// update the row by primary key and insert it when nothing matched. Concurrent
+// writers may race on the insert; the primary key rejects the duplicate.
+func (s *Store) updateOrInsert(ctx context.Context, record *FeatureFlagRecord) error {
+	res, err := s.conn(ctx).NewUpdate().Model(record).
+		ModelTableExpr(s.aliasedTable()).
+		Column(upsertColumns...).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected > 0 {
+		return nil
+	}
+	_, err = s.conn(ctx).NewInsert().Model(record).
+		ModelTableExpr(s.table).
+		Exec(ctx)
+	return err
+}
+
+// aliasedTable renders the configured table with the alias Bun expects for
+// FeatureFlagRecord columns, so custom table names do not add a second FROM entry.
+func (s *Store) aliasedTable() string {
+	return s.table + " AS " + recordAlias
+}
+
 func defaultUpdatedBy(actor gate.ActorRef) string {
 	if actor.ID != "" {
 		return actor.ID
@@ -247,8 +516,17 @@ func defaultUpdatedBy(actor gate.ActorRef) string {
 }
 
 func normalizeKey(key string) (string, error) {
+	return checkKey(strings.TrimSpace(key), gate.NormalizeKey(key))
+}
+
+// storedKey trims key without resolving aliases, so rows written under a legacy
+// key before gate.RegisterAliases stay readable and can be unset.
+func storedKey(key string) (string, error) {
 	trimmed := strings.TrimSpace(key)
-	normalized := gate.NormalizeKey(trimmed)
+	return checkKey(trimmed, trimmed)
+}
+
+func checkKey(trimmed, normalized string) (string, error) {
 	if normalized == "" {
 		return "", ferrors.WrapSentinel(ferrors.ErrInvalidKey, "bunadapter: feature key required", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
@@ -265,8 +543,10 @@ func boolPtr(value bool) *bool {
 }
 
 type scopeKey struct {
-	kind scopeKind
-	id   string
+	kind     scopeKind
+	id       string
+	tenantID string
+	orgID    string
 }
 
 type scopeKind string
@@ -278,12 +558,27 @@ const (
 	scopeUser   scopeKind = "user"
 	scopeRole   scopeKind = "role"
 	scopePerm   scopeKind = "perm"
+	scopeEnv    scopeKind = "env"
 )
 
 func scopeKeyFromRef(ref gate.ScopeRef) scopeKey {
+	if ref.Kind == gate.ScopeSystem {
+		return scopeKey{kind: scopeSystem}
+	}
 	return scopeKey{
-		kind: scopeKindFromRef(ref.Kind),
-		id:   scopeIDFromRef(ref),
+		kind:     scopeKindFromRef(ref.Kind),
+		id:       scopeIDFromRef(ref),
+		tenantID: ref.TenantID,
+		orgID:    ref.OrgID,
+	}
+}
+
+func scopeKeyFromRecord(record FeatureFlagRecord) scopeKey {
+	return scopeKey{
+		kind:     scopeKind(record.ScopeType),
+		id:       record.ScopeID,
+		tenantID: record.TenantID,
+		orgID:    record.OrgID,
 	}
 }
 
@@ -301,41 +596,64 @@ func scopeKindFromRef(kind gate.ScopeKind) scopeKind {
 		return scopeRole
 	case gate.ScopePerm:
 		return scopePerm
+	case gate.ScopeEnv:
+		return scopeEnv
 	default:
 		return scopeSystem
 	}
 }
 
 func scopeIDFromRef(ref gate.ScopeRef) string {
-	if ref.Kind == gate.ScopeSystem {
-		return ""
-	}
-	id := ref.ID
-	if id == "" {
-		switch ref.Kind {
-		case gate.ScopeTenant:
-			id = ref.TenantID
-		case gate.ScopeOrg:
-			id = ref.OrgID
-		}
+	if ref.ID != "" {
+		return ref.ID
 	}
-	if ref.TenantID == "" && ref.OrgID == "" {
-		return id
+	switch ref.Kind {
+	case gate.ScopeTenant:
+		return ref.TenantID
+	case gate.ScopeOrg:
+		return ref.OrgID
 	}
-	return strings.Join([]string{ref.TenantID, ref.OrgID, id}, "|")
+	return ""
 }
 
 func overrideFromRecord(record FeatureFlagRecord) store.Override {
 	if record.Enabled == nil {
 		return store.UnsetOverride()
 	}
+	override := store.DisabledOverride()
 	if *record.Enabled {
-		return store.EnabledOverride()
+		override = store.EnabledOverride()
+	}
+	override.ExpiresAt = record.ExpiresAt
+	override.Metadata = gate.OverrideMetadata{
+		Reason:    record.Reason,
+		TicketURL: record.TicketURL,
+		Owner:     record.Owner,
+		Labels:    record.Labels,
+	}
+	override.Priority = record.Priority
+	return override
+}
+
+// scopeRefFromRecord reverses scopeKeyFromRef for listed rows.
+func scopeRefFromRecord(record FeatureFlagRecord) gate.ScopeRef {
+	kind, ok := gate.ParseScopeKind(record.ScopeType)
+	if !ok || kind == gate.ScopeSystem {
+		return gate.ScopeRef{Kind: gate.ScopeSystem}
+	}
+	return gate.ScopeRef{
+		Kind:     kind,
+		ID:       record.ScopeID,
+		TenantID: record.TenantID,
+		OrgID:    record.OrgID,
 	}
-	return store.DisabledOverride()
 }
 
-var _ store.ReadWriter = (*Store)(nil)
+var (
+	_ store.ReadWriter  = (*Store)(nil)
+	_ store.Lister      = (*Store)(nil)
+	_ store.BatchWriter = (*Store)(nil)
+)
 
 func storeRequiredError(key string, scopeRef gate.ScopeRef, operation string) error {
 	trimmed := strings.TrimSpace(key)
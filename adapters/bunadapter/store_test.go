@@ -0,0 +1,49 @@
+package bunadapter_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/sqliteshim"
+
+	"github.com/goliatone/go-featuregate/adapters/bunadapter"
+	"github.com/goliatone/go-featuregate/adapters/bunadapter/migrations"
+	"github.com/goliatone/go-featuregate/store"
+	"github.com/goliatone/go-featuregate/store/storetest"
+)
+
+// openSQLite returns a fresh file-backed database with the feature_flags schema, so
+// transactions and plain reads use separate connections.
+func openSQLite(t *testing.T) *bun.DB {
+	t.Helper()
+	sqlDB, err := sql.Open(sqliteshim.ShimName, "file:"+filepath.Join(t.TempDir(), "flags.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	db := bun.NewDB(sqlDB, sqlitedialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+	if err := migrations.EnsureSchema(context.Background(), db); err != nil {
+		t.Fatalf("ensure schema: %v", err)
+	}
+	return db
+}
+
+func TestStoreConformanceSQLite(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.ReadWriter {
+		return bunadapter.NewStore(openSQLite(t))
+	})
+}
+
+func TestStoreTransactionsSQLite(t *testing.T) {
+	storetest.RunTransactions(t, func(t *testing.T) (store.ReadWriter, storetest.Begin) {
+		db := openSQLite(t)
+		return bunadapter.NewStore(db), func(ctx context.Context) (context.Context, storetest.Tx, error) {
+			tx, err := db.BeginTx(ctx, nil)
+			return bunadapter.ContextWithTx(ctx, tx), tx, err
+		}
+	})
+}
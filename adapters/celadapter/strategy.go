@@ -0,0 +1,225 @@
+// Package celadapter provides a resolver strategy that targets features with CEL
+// expressions evaluated over the scope chain and caller-supplied attributes.
+package celadapter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// StrategyName is reported in ResolveTrace.Strategy and Gate.Config.
+const StrategyName = "cel"
+
+const (
+	TextCodeRuleInvalid = "CEL_RULE_INVALID"
+	TextCodeRuleFailed  = "CEL_RULE_FAILED"
+)
+
+// Variables available to every expression.
+const (
+	VarKey      = "key"
+	VarUserID   = "user_id"
+	VarTenantID = "tenant_id"
+	VarOrgID    = "org_id"
+	VarEnv      = "env"
+	VarRoles    = "roles"
+	VarPerms    = "perms"
+	VarAttrs    = "attrs"
+)
+
+// AttributesFunc supplies request attributes exposed to expressions as attrs.
+type AttributesFunc func(ctx context.Context) map[string]any
+
+// Option customizes a Strategy.
+type Option func(*Strategy)
+
+// WithAttributes sets the attribute source for the attrs variable.
+func WithAttributes(fn AttributesFunc) Option {
+	return func(s *Strategy) {
+		if s == nil {
+			return
+		}
+		s.attributes = fn
+	}
+}
+
+// WithFallback sets the strategy consulted before rules, so explicit overrides keep
+// precedence. Defaults to resolver.DefaultResolveStrategy.
+func WithFallback(strategy resolver.ResolveStrategy) Option {
+	return func(s *Strategy) {
+		if s == nil {
+			return
+		}
+		s.fallback = strategy
+	}
+}
+
+type rule struct {
+	source  string
+	program cel.Program
+}
+
+// Strategy evaluates per-key CEL rules after explicit overrides.
+type Strategy struct {
+	rules      map[string]rule
+	attributes AttributesFunc
+	fallback   resolver.ResolveStrategy
+}
+
+// NewStrategy compiles rules, keyed by feature key. Every expression must return a bool;
+// the first invalid rule is reported with its key.
+func NewStrategy(rules map[string]string, opts ...Option) (*Strategy, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(VarKey, cel.StringType),
+		cel.Variable(VarUserID, cel.StringType),
+		cel.Variable(VarTenantID, cel.StringType),
+		cel.Variable(VarOrgID, cel.StringType),
+		cel.Variable(VarEnv, cel.StringType),
+		cel.Variable(VarRoles, cel.ListType(cel.StringType)),
+		cel.Variable(VarPerms, cel.ListType(cel.StringType)),
+		cel.Variable(VarAttrs, cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, ferrors.WrapInternal(err, TextCodeRuleInvalid, "celadapter: environment failed", nil)
+	}
+	s := &Strategy{
+		rules:    make(map[string]rule, len(rules)),
+		fallback: resolver.DefaultResolveStrategy,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.fallback == nil {
+		s.fallback = resolver.DefaultResolveStrategy
+	}
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		normalized := gate.NormalizeKey(key)
+		if normalized == "" {
+			return nil, ferrors.WrapSentinel(ferrors.ErrInvalidKey, "celadapter: rule key required", nil)
+		}
+		source := strings.TrimSpace(rules[key])
+		meta := map[string]any{
+			ferrors.MetaAdapter:              "cel",
+			ferrors.MetaFeatureKey:           key,
+			ferrors.MetaFeatureKeyNormalized: normalized,
+		}
+		ast, issues := env.Compile(source)
+		if issues != nil && issues.Err() != nil {
+			return nil, ferrors.WrapBadInput(issues.Err(), TextCodeRuleInvalid, "celadapter: rule does not compile", meta)
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, ferrors.NewBadInput(TextCodeRuleInvalid, "celadapter: rule must return bool, got "+ast.OutputType().String(), meta)
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, ferrors.WrapBadInput(err, TextCodeRuleInvalid, "celadapter: rule program failed", meta)
+		}
+		s.rules[normalized] = rule{source: source, program: program}
+	}
+	return s, nil
+}
+
+// Option registers the strategy on a resolver.Gate.
+func (s *Strategy) Option() resolver.Option {
+	return resolver.WithNamedResolveStrategy(StrategyName, s.Resolve)
+}
+
+// Resolve implements resolver.ResolveStrategy. Explicit overrides win; otherwise a key
+// with a rule resolves to the expression result with source gate.ResolveSourceRule.
+func (s *Strategy) Resolve(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch, opts resolver.ResolveOptions) (resolver.OverrideDecision, gate.ResolveTrace, error) {
+	decision, trace, err := s.fallback(ctx, key, chain, matches, opts)
+	if err != nil || decision.Matched {
+		return decision, trace, err
+	}
+	r, ok := s.rules[gate.NormalizeKey(key)]
+	if !ok {
+		return decision, trace, nil
+	}
+	trace.Strategy = StrategyName
+	trace.Rule.Expression = r.source
+	out, _, err := r.program.ContextEval(ctx, s.activation(ctx, key, chain))
+	if err != nil {
+		err = ferrors.WrapOperation(err, TextCodeRuleFailed, "celadapter: rule evaluation failed", map[string]any{
+			ferrors.MetaAdapter:    "cel",
+			ferrors.MetaFeatureKey: key,
+			ferrors.MetaChain:      chain,
+		})
+		trace.Rule.Error = err
+		return resolver.OverrideDecision{Strategy: StrategyName}, trace, err
+	}
+	value, ok := out.Value().(bool)
+	if !ok {
+		err = ferrors.NewOperation(TextCodeRuleFailed, fmt.Sprintf("celadapter: rule returned %T", out.Value()), map[string]any{
+			ferrors.MetaAdapter:    "cel",
+			ferrors.MetaFeatureKey: key,
+		})
+		trace.Rule.Error = err
+		return resolver.OverrideDecision{Strategy: StrategyName}, trace, err
+	}
+	trace.Rule.Result = fmt.Sprint(value)
+	return resolver.OverrideDecision{
+		Matched:  true,
+		Value:    value,
+		Strategy: StrategyName,
+		Source:   gate.ResolveSourceRule,
+	}, trace, nil
+}
+
+func (s *Strategy) activation(ctx context.Context, key string, chain gate.ScopeChain) map[string]any {
+	vars := map[string]any{
+		VarKey:      gate.NormalizeKey(key),
+		VarUserID:   "",
+		VarTenantID: "",
+		VarOrgID:    "",
+		VarEnv:      "",
+		VarRoles:    []string{},
+		VarPerms:    []string{},
+		VarAttrs:    map[string]any{},
+	}
+	roles, perms := []string{}, []string{}
+	for _, ref := range chain {
+		switch ref.Kind {
+		case gate.ScopeUser:
+			vars[VarUserID] = ref.ID
+		case gate.ScopeTenant:
+			vars[VarTenantID] = ref.ID
+		case gate.ScopeOrg:
+			vars[VarOrgID] = ref.ID
+		case gate.ScopeRole:
+			roles = append(roles, ref.ID)
+		case gate.ScopePerm:
+			perms = append(perms, ref.ID)
+		case gate.ScopeEnv:
+			vars[VarEnv] = ref.ID
+		}
+		if vars[VarTenantID] == "" && ref.TenantID != "" {
+			vars[VarTenantID] = ref.TenantID
+		}
+		if vars[VarOrgID] == "" && ref.OrgID != "" {
+			vars[VarOrgID] = ref.OrgID
+		}
+	}
+	vars[VarRoles], vars[VarPerms] = roles, perms
+	if s.attributes != nil {
+		if attrs := s.attributes(ctx); attrs != nil {
+			vars[VarAttrs] = attrs
+		}
+	}
+	return vars
+}
@@ -0,0 +1,71 @@
+package celadapter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+type attrsKey struct{}
+
+func TestStrategyEvaluatesRulesAfterOverrides(t *testing.T) {
+	ctx := context.Background()
+	strategy, err := NewStrategy(map[string]string{
+		"beta.checkout": `tenant_id == "acme" && "admin" in roles && attrs.plan == "pro"`,
+	}, WithAttributes(func(ctx context.Context) map[string]any {
+		attrs, _ := ctx.Value(attrsKey{}).(map[string]any)
+		return attrs
+	}))
+	if err != nil {
+		t.Fatalf("new strategy: %v", err)
+	}
+	overrides := store.NewMemoryStore()
+	g := resolver.New(resolver.WithOverrideStore(overrides), strategy.Option())
+	chain := gate.WithScopeChain(gate.ScopeChain{
+		{Kind: gate.ScopeUser, ID: "u-1", TenantID: "acme"},
+		{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"},
+		{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"},
+		{Kind: gate.ScopeSystem},
+	})
+
+	proCtx := context.WithValue(ctx, attrsKey{}, map[string]any{"plan": "pro"})
+	value, trace, err := g.ResolveWithTrace(proCtx, "beta.checkout", chain)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if !value || trace.Source != gate.ResolveSourceRule || trace.Strategy != StrategyName || trace.Rule.Result != "true" {
+		t.Fatalf("expected rule to enable the feature, got %v (%+v)", value, trace)
+	}
+	if !strings.Contains(gate.Explain(trace), "rule: ") {
+		t.Fatalf("expected explain output to include the rule, got %q", gate.Explain(trace))
+	}
+
+	freeCtx := context.WithValue(ctx, attrsKey{}, map[string]any{"plan": "free"})
+	if value, _ := g.Enabled(freeCtx, "beta.checkout", chain); value {
+		t.Fatalf("expected rule to disable the feature for the free plan")
+	}
+
+	if err := overrides.Set(ctx, "beta.checkout", gate.ScopeRef{Kind: gate.ScopeSystem}, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	value, trace, _ = g.ResolveWithTrace(proCtx, "beta.checkout", chain)
+	if value || trace.Source != gate.ResolveSourceOverride {
+		t.Fatalf("expected explicit override to win over the rule, got %v (%s)", value, trace.Source)
+	}
+}
+
+func TestNewStrategyRejectsInvalidRules(t *testing.T) {
+	if _, err := NewStrategy(map[string]string{"bad": `tenant_id ==`}); err == nil {
+		t.Fatalf("expected compile error")
+	}
+	if _, err := NewStrategy(map[string]string{"bad": `tenant_id`}); err == nil {
+		t.Fatalf("expected non-bool rule to be rejected")
+	}
+	if _, err := NewStrategy(map[string]string{"ok": `user_id != ""`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
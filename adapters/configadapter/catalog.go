@@ -2,6 +2,7 @@ package configadapter
 
 import (
 	"strings"
+	"time"
 
 	"github.com/goliatone/go-featuregate/catalog"
 	"github.com/goliatone/go-featuregate/gate"
@@ -75,7 +76,47 @@ func defsAdd(out map[string]catalog.FeatureDefinition, def catalog.FeatureDefini
 	out[normalized] = def
 }
 
+// definitionFromMap reads a definition from a map with a description. Lifecycle keys
+// (status, owner, created_at, sunset_at) and groups are read alongside it.
 func definitionFromMap(data map[string]any) (catalog.FeatureDefinition, bool) {
+	def, ok := describedDefinition(data)
+	if !ok {
+		return def, false
+	}
+	if status, ok := data["status"].(string); ok {
+		if parsed, ok := catalog.ParseStatus(status); ok {
+			def.Status = parsed
+		}
+	}
+	if owner, ok := data["owner"].(string); ok {
+		def.Owner = strings.TrimSpace(owner)
+	}
+	def.CreatedAt = timeFromValue(data["created_at"])
+	def.SunsetAt = timeFromValue(data["sunset_at"])
+	def.Groups = groupsFromValue(data["groups"])
+	return def, true
+}
+
+// groupsFromValue reads a list of group names, or a comma-separated string.
+func groupsFromValue(value any) []string {
+	switch typed := value.(type) {
+	case string:
+		return strings.Split(typed, ",")
+	case []string:
+		return typed
+	case []any:
+		out := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if group, ok := item.(string); ok {
+				out = append(out, group)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func describedDefinition(data map[string]any) (catalog.FeatureDefinition, bool) {
 	if msg, ok := messageFromValue(data["description"]); ok {
 		return catalog.FeatureDefinition{Description: msg}, true
 	}
@@ -97,6 +138,22 @@ func definitionFromMap(data map[string]any) (catalog.FeatureDefinition, bool) {
 	return catalog.FeatureDefinition{}, false
 }
 
+// timeFromValue accepts a time.Time or a date ("2006-01-02") or RFC 3339 string.
+func timeFromValue(value any) time.Time {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed
+	case string:
+		typed = strings.TrimSpace(typed)
+		for _, layout := range []string{time.DateOnly, time.RFC3339} {
+			if parsed, err := time.Parse(layout, typed); err == nil {
+				return parsed
+			}
+		}
+	}
+	return time.Time{}
+}
+
 func messageFromValue(value any) (catalog.Message, bool) {
 	switch typed := value.(type) {
 	case string:
@@ -1,6 +1,11 @@
 package configadapter
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/catalog"
+)
 
 func TestCatalogFromNestedMap(t *testing.T) {
 	cat := NewCatalog(map[string]any{
@@ -69,3 +74,26 @@ func TestCatalogDescriptionKeyFields(t *testing.T) {
 		t.Fatalf("unexpected description text: %q", def.Description.Text)
 	}
 }
+
+func TestCatalogReadsLifecycleFields(t *testing.T) {
+	cat := NewCatalog(map[string]any{
+		"legacy": map[string]any{
+			"search": map[string]any{
+				"description": "Old search",
+				"status":      "deprecated",
+				"owner":       "search-team",
+				"created_at":  "2024-01-15",
+				"sunset_at":   "2026-07-01T00:00:00Z",
+			},
+		},
+	})
+	def, ok := cat.Get("legacy.search")
+	if !ok {
+		t.Fatalf("expected legacy.search to exist")
+	}
+	if def.Status != catalog.StatusDeprecated || def.Owner != "search-team" ||
+		!def.CreatedAt.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) ||
+		!def.SunsetAt.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected lifecycle: %+v", def)
+	}
+}
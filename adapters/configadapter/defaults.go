@@ -2,6 +2,8 @@ package configadapter
 
 import (
 	"context"
+	"encoding/json"
+	"math"
 	"strings"
 
 	"github.com/goliatone/go-config/config"
@@ -26,12 +28,14 @@ func WithDelimiter(delimiter string) Option {
 	}
 }
 
-// Defaults provides resolver.Defaults backed by config maps.
+// Defaults provides resolver.Defaults and resolver.LimitDefaults backed by config maps.
 type Defaults struct {
 	values map[string]resolver.DefaultResult
+	limits map[string]int64
 }
 
-// NewDefaults builds Defaults from a nested map containing OptionalBool or bool values.
+// NewDefaults builds Defaults from a nested map. OptionalBool and bool values become
+// flag defaults; integer values (including whole JSON numbers) become limit defaults.
 func NewDefaults(data map[string]any, opts ...Option) *Defaults {
 	cfg := configOptions{delimiter: "."}
 	for _, opt := range opts {
@@ -43,9 +47,9 @@ func NewDefaults(data map[string]any, opts ...Option) *Defaults {
 		cfg.delimiter = "."
 	}
 
-	values := map[string]resolver.DefaultResult{}
-	flattenDefaults("", data, cfg.delimiter, values)
-	return &Defaults{values: values}
+	d := &Defaults{values: map[string]resolver.DefaultResult{}, limits: map[string]int64{}}
+	flattenDefaults("", data, cfg.delimiter, d)
+	return d
 }
 
 // NewDefaultsFromBools builds Defaults from a simple map of booleans.
@@ -75,12 +79,47 @@ func (d *Defaults) Default(_ context.Context, key string) (resolver.DefaultResul
 	return resolver.DefaultResult{}, nil
 }
 
+// LimitDefault implements resolver.LimitDefaults.
+func (d *Defaults) LimitDefault(_ context.Context, key string) (resolver.LimitDefaultResult, error) {
+	if d == nil || len(d.limits) == 0 {
+		return resolver.LimitDefaultResult{}, nil
+	}
+	if value, ok := d.limits[gate.NormalizeKey(strings.TrimSpace(key))]; ok {
+		return resolver.LimitDefaultResult{Set: true, Value: value}, nil
+	}
+	return resolver.LimitDefaultResult{}, nil
+}
+
+// LimitSnapshot returns a copy of the limit defaults keyed by normalized feature key.
+func (d *Defaults) LimitSnapshot() map[string]int64 {
+	if d == nil || len(d.limits) == 0 {
+		return map[string]int64{}
+	}
+	out := make(map[string]int64, len(d.limits))
+	for key, value := range d.limits {
+		out[key] = value
+	}
+	return out
+}
+
+// Snapshot returns a copy of the flattened defaults keyed by normalized feature key.
+func (d *Defaults) Snapshot() map[string]resolver.DefaultResult {
+	if d == nil || len(d.values) == 0 {
+		return map[string]resolver.DefaultResult{}
+	}
+	out := make(map[string]resolver.DefaultResult, len(d.values))
+	for key, value := range d.values {
+		out[key] = value
+	}
+	return out
+}
+
 type optionalBool interface {
 	IsSet() bool
 	Value() bool
 }
 
-func flattenDefaults(prefix string, data map[string]any, delim string, out map[string]resolver.DefaultResult) {
+func flattenDefaults(prefix string, data map[string]any, delim string, out *Defaults) {
 	if len(data) == 0 {
 		return
 	}
@@ -100,12 +139,14 @@ func flattenDefaults(prefix string, data map[string]any, delim string, out map[s
 		case map[string]bool:
 			flattenDefaults(path, boolMapToAny(typed), delim, out)
 		default:
+			normalized := gate.NormalizeKey(path)
+			if normalized == "" {
+				continue
+			}
 			if def, ok := defaultFromValue(value); ok {
-				normalized := gate.NormalizeKey(path)
-				if normalized == "" {
-					continue
-				}
-				out[normalized] = def
+				out.values[normalized] = def
+			} else if limit, ok := limitFromValue(value); ok {
+				out.limits[normalized] = limit
 			}
 		}
 	}
@@ -134,6 +175,29 @@ func defaultFromValue(value any) (resolver.DefaultResult, bool) {
 	}
 }
 
+func limitFromValue(value any) (int64, bool) {
+	switch typed := value.(type) {
+	case int:
+		return int64(typed), true
+	case int32:
+		return int64(typed), true
+	case int64:
+		return typed, true
+	case uint32:
+		return int64(typed), true
+	case float64:
+		if typed != math.Trunc(typed) || math.Abs(typed) > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(typed), true
+	case json.Number:
+		limit, err := typed.Int64()
+		return limit, err == nil
+	default:
+		return 0, false
+	}
+}
+
 func boolMapToAny(data map[string]bool) map[string]any {
 	if len(data) == 0 {
 		return nil
@@ -52,3 +52,31 @@ func TestDefaultsFromBools(t *testing.T) {
 		t.Fatalf("expected bool default to be set true, got %+v", result)
 	}
 }
+
+func TestDefaultsIntegerValuesBecomeLimits(t *testing.T) {
+	defaults := NewDefaults(map[string]any{
+		"projects": map[string]any{
+			"max":    10,
+			"ratio":  0.5,
+			"export": true,
+		},
+		"seats": float64(25),
+	})
+
+	result, err := defaults.LimitDefault(context.Background(), "projects.max")
+	if err != nil || !result.Set || result.Value != 10 {
+		t.Fatalf("expected projects.max limit 10, got %+v (%v)", result, err)
+	}
+	if result, _ := defaults.LimitDefault(context.Background(), "seats"); !result.Set || result.Value != 25 {
+		t.Fatalf("expected whole float to become a limit, got %+v", result)
+	}
+	if result, _ := defaults.LimitDefault(context.Background(), "projects.ratio"); result.Set {
+		t.Fatalf("expected fractional values to be ignored, got %+v", result)
+	}
+	if result, _ := defaults.Default(context.Background(), "projects.max"); result.Set {
+		t.Fatalf("expected limits to stay out of flag defaults, got %+v", result)
+	}
+	if snapshot := defaults.LimitSnapshot(); len(snapshot) != 2 {
+		t.Fatalf("expected two limits in snapshot, got %v", snapshot)
+	}
+}
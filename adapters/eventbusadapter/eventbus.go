@@ -0,0 +1,289 @@
+// Package eventbusadapter publishes override updates to a message bus such as NATS or
+// Kafka, for downstream cache invalidation and analytics pipelines.
+//
+// Hook implements activity.Hook and hands each update to a Publisher as a JSON
+// Envelope. The package does not import a bus client: NATS wraps anything with the
+// Publish method of *nats.Conn, and other buses plug in through PublisherFunc:
+//
+//	hook := eventbusadapter.NewHook(eventbusadapter.NATS(nc))
+//
+//	hook := eventbusadapter.NewHook(eventbusadapter.PublisherFunc(func(ctx context.Context, msg eventbusadapter.Message) error {
+//		return writer.WriteMessages(ctx, kafka.Message{Topic: msg.Subject, Key: []byte(msg.Key), Value: msg.Data})
+//	}))
+//
+// Publishing runs on the caller's goroutine; combine with resolver.WithAsyncHooks to
+// keep it off the Set path.
+package eventbusadapter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const adapterName = "eventbus"
+
+const (
+	// DefaultSubjectPrefix prefixes the default subject; the normalized key follows,
+	// so NATS subscribers can use wildcards such as "featuregate.updates.billing.>".
+	DefaultSubjectPrefix = "featuregate.updates"
+
+	// Header names set on every Message.
+	HeaderEventID = "featuregate-event-id"
+	HeaderAction  = "featuregate-action"
+
+	TextCodePublishFailed = "EVENTBUS_PUBLISH_FAILED"
+
+	MetaSubject = "subject"
+)
+
+// Message is one published update.
+type Message struct {
+	// Subject is the NATS subject or Kafka topic.
+	Subject string
+	// Key is the normalized feature key, usable as a partition key.
+	Key     string
+	Data    []byte
+	Headers map[string]string
+}
+
+// Publisher delivers messages to a bus.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// PublisherFunc wraps a function as a Publisher.
+type PublisherFunc func(ctx context.Context, msg Message) error
+
+// Publish implements Publisher.
+func (fn PublisherFunc) Publish(ctx context.Context, msg Message) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, msg)
+}
+
+// NATSConn is the part of *nats.Conn the NATS publisher uses.
+type NATSConn interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATS returns a Publisher for a NATS connection. Headers are not sent; the event ID
+// and action are also in the envelope.
+func NATS(conn NATSConn) Publisher {
+	return PublisherFunc(func(_ context.Context, msg Message) error {
+		if conn == nil {
+			return nil
+		}
+		return conn.Publish(msg.Subject, msg.Data)
+	})
+}
+
+// Scope is the JSON form of gate.ScopeRef.
+type Scope struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+	OrgID    string `json:"org_id,omitempty"`
+}
+
+// Actor is the JSON form of gate.ActorRef.
+type Actor struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Diff is the JSON form of activity.DiffSummary.
+type Diff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+// Envelope is the JSON body of a Message.
+type Envelope struct {
+	ID            string                 `json:"id,omitempty"`
+	Time          time.Time              `json:"time"`
+	Key           string                 `json:"key,omitempty"`
+	NormalizedKey string                 `json:"normalized_key,omitempty"`
+	Action        string                 `json:"action"`
+	Scope         *Scope                 `json:"scope,omitempty"`
+	Actor         *Actor                 `json:"actor,omitempty"`
+	Value         *bool                  `json:"value,omitempty"`
+	Limit         *int64                 `json:"limit,omitempty"`
+	ExpiresAt     *time.Time             `json:"expires_at,omitempty"`
+	Metadata      *gate.OverrideMetadata `json:"metadata,omitempty"`
+	Diff          *Diff                  `json:"diff,omitempty"`
+}
+
+// Option customizes Hook.
+type Option func(*Hook)
+
+// WithSubject sets how the subject is derived from an envelope. The default is
+// DefaultSubjectPrefix plus the normalized key, or plus "reload" for reloads.
+func WithSubject(fn func(Envelope) string) Option {
+	return func(h *Hook) {
+		if h == nil || fn == nil {
+			return
+		}
+		h.subject = fn
+	}
+}
+
+// WithSubjectPrefix keeps the default subject layout under another prefix.
+func WithSubjectPrefix(prefix string) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.subject = prefixedSubject(prefix)
+	}
+}
+
+// WithOnError registers a callback for publish failures.
+func WithOnError(fn func(error)) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.onError = fn
+	}
+}
+
+// WithClock sets the clock used for envelope timestamps.
+func WithClock(c clock.Clock) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.now = clock.NowFunc(c)
+	}
+}
+
+// Hook publishes activity updates.
+type Hook struct {
+	publisher Publisher
+	subject   func(Envelope) string
+	onError   func(error)
+	now       func() time.Time
+}
+
+// NewHook constructs a Hook publishing through publisher.
+func NewHook(publisher Publisher, opts ...Option) *Hook {
+	h := &Hook{
+		publisher: publisher,
+		subject:   prefixedSubject(DefaultSubjectPrefix),
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	if h.now == nil {
+		h.now = time.Now
+	}
+	return h
+}
+
+// OnUpdate implements activity.Hook. Failures go to the WithOnError callback.
+func (h *Hook) OnUpdate(ctx context.Context, event activity.UpdateEvent) {
+	if err := h.Publish(ctx, event); err != nil && h.onError != nil {
+		h.onError(err)
+	}
+}
+
+// Publish sends event and returns the publisher's error.
+func (h *Hook) Publish(ctx context.Context, event activity.UpdateEvent) error {
+	if h == nil || h.publisher == nil {
+		return nil
+	}
+	envelope := h.Envelope(event)
+	subject := h.subject(envelope)
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return ferrors.WrapBadInput(err, TextCodePublishFailed, "eventbusadapter: encode event failed", map[string]any{
+			ferrors.MetaAdapter:    adapterName,
+			ferrors.MetaFeatureKey: envelope.NormalizedKey,
+		})
+	}
+	msg := Message{
+		Subject: subject,
+		Key:     envelope.NormalizedKey,
+		Data:    data,
+		Headers: map[string]string{HeaderAction: envelope.Action},
+	}
+	if envelope.ID != "" {
+		msg.Headers[HeaderEventID] = envelope.ID
+	}
+	if err := h.publisher.Publish(ctx, msg); err != nil {
+		return ferrors.WrapExternal(err, TextCodePublishFailed, "eventbusadapter: publish failed", map[string]any{
+			ferrors.MetaAdapter:    adapterName,
+			ferrors.MetaFeatureKey: envelope.NormalizedKey,
+			MetaSubject:            subject,
+		})
+	}
+	return nil
+}
+
+// Envelope converts event to its published form.
+func (h *Hook) Envelope(event activity.UpdateEvent) Envelope {
+	out := Envelope{
+		ID:            event.ID,
+		Time:          h.now(),
+		Key:           event.Key,
+		NormalizedKey: event.NormalizedKey,
+		Action:        string(event.Action),
+		Value:         event.Value,
+		Limit:         event.Limit,
+	}
+	if out.NormalizedKey == "" && event.Key != "" {
+		out.NormalizedKey = gate.NormalizeKey(event.Key)
+	}
+	if event.Action != activity.ActionReload {
+		out.Scope = &Scope{
+			Kind:     event.Scope.Kind.String(),
+			ID:       event.Scope.ID,
+			TenantID: event.Scope.TenantID,
+			OrgID:    event.Scope.OrgID,
+		}
+	}
+	if event.Actor != (gate.ActorRef{}) {
+		out.Actor = &Actor{ID: event.Actor.ID, Type: event.Actor.Type, Name: event.Actor.Name}
+	}
+	if !event.ExpiresAt.IsZero() {
+		expiresAt := event.ExpiresAt
+		out.ExpiresAt = &expiresAt
+	}
+	if !event.Metadata.IsZero() {
+		meta := event.Metadata
+		out.Metadata = &meta
+	}
+	if event.Diff != nil {
+		out.Diff = &Diff{Added: event.Diff.Added, Removed: event.Diff.Removed, Changed: event.Diff.Changed}
+	}
+	return out
+}
+
+func prefixedSubject(prefix string) func(Envelope) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), ".")
+	return func(envelope Envelope) string {
+		suffix := envelope.NormalizedKey
+		if suffix == "" {
+			suffix = envelope.Action
+		}
+		if prefix == "" {
+			return suffix
+		}
+		return prefix + "." + suffix
+	}
+}
+
+var _ activity.Hook = (*Hook)(nil)
@@ -0,0 +1,85 @@
+package eventbusadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+type fakeNATS struct {
+	subjects []string
+	payloads [][]byte
+	err      error
+}
+
+func (f *fakeNATS) Publish(subject string, data []byte) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.subjects = append(f.subjects, subject)
+	f.payloads = append(f.payloads, data)
+	return nil
+}
+
+func TestHookPublishesUpdatesToNATS(t *testing.T) {
+	conn := &fakeNATS{}
+	fg := resolver.New(
+		resolver.WithOverrideStore(store.NewMemoryStore()),
+		resolver.WithActivityHook(NewHook(NATS(conn))),
+	)
+	ctx := context.Background()
+	org := gate.ScopeRef{Kind: gate.ScopeOrg, ID: "eng", TenantID: "acme", OrgID: "eng"}
+	if err := fg.Set(ctx, "billing.v2", org, true, gate.ActorRef{ID: "u1", Type: "user"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := fg.Unset(ctx, "billing.v2", org, gate.ActorRef{ID: "u1"}); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+	if len(conn.subjects) != 2 || conn.subjects[0] != "featuregate.updates.billing.v2" {
+		t.Fatalf("unexpected subjects: %v", conn.subjects)
+	}
+	var set, unset Envelope
+	if err := json.Unmarshal(conn.payloads[0], &set); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if err := json.Unmarshal(conn.payloads[1], &unset); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if set.ID == "" || set.NormalizedKey != "billing.v2" || set.Action != "set" || set.Value == nil || !*set.Value ||
+		set.Scope == nil || set.Scope.Kind != "org" || set.Scope.TenantID != "acme" || set.Actor == nil || set.Actor.Type != "user" {
+		t.Fatalf("unexpected set envelope: %+v", set)
+	}
+	if unset.Action != "unset" || unset.Value != nil {
+		t.Fatalf("unexpected unset envelope: %+v", unset)
+	}
+}
+
+func TestHookReportsPublishFailures(t *testing.T) {
+	var msgs []Message
+	var reported error
+	hook := NewHook(PublisherFunc(func(_ context.Context, msg Message) error {
+		msgs = append(msgs, msg)
+		if len(msgs) > 1 {
+			return errors.New("broker down")
+		}
+		return nil
+	}), WithSubjectPrefix("flags."), WithOnError(func(err error) { reported = err }))
+
+	hook.OnUpdate(context.Background(), activity.UpdateEvent{ID: "e1", Action: activity.ActionReload, Diff: &activity.DiffSummary{Added: []string{"a"}}})
+	if len(msgs) != 1 || msgs[0].Subject != "flags.reload" || msgs[0].Headers[HeaderEventID] != "e1" || msgs[0].Headers[HeaderAction] != "reload" {
+		t.Fatalf("unexpected reload message: %+v", msgs)
+	}
+
+	hook.OnUpdate(context.Background(), activity.UpdateEvent{Key: "Search", Action: activity.ActionSet})
+	rich, ok := ferrors.As(reported)
+	if !ok || rich.TextCode != TextCodePublishFailed || rich.Metadata[MetaSubject] != "flags.Search" || msgs[1].Key != "Search" {
+		t.Fatalf("expected publish failure to be reported, got %v", reported)
+	}
+}
@@ -0,0 +1,124 @@
+// Package gokitadapter exposes a feature gate to go-kit services: endpoint middleware,
+// a feature lookup endpoint, and transport request funcs that move scope values from
+// HTTP headers or gRPC metadata into the context.
+//
+// The package does not import go-kit. Endpoint and Middleware are aliases for the
+// unnamed function shapes, so results assign to endpoint.Endpoint directly and
+// middleware is adapted in one line:
+//
+//	mw := gokitadapter.Guard(gate, "billing.v2")
+//	var guarded endpoint.Middleware = func(next endpoint.Endpoint) endpoint.Endpoint { return mw(next) }
+package gokitadapter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/gate/guard"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+// TextCodeRequestInvalid reports an unsupported request type for the enabled endpoint.
+const TextCodeRequestInvalid = "GOKIT_REQUEST_INVALID"
+
+// Endpoint has the shape of go-kit's endpoint.Endpoint.
+type Endpoint = func(ctx context.Context, request any) (any, error)
+
+// Middleware has the shape of go-kit's endpoint.Middleware over Endpoint.
+type Middleware = func(Endpoint) Endpoint
+
+// Guard returns endpoint middleware that fails with guard's disabled error when key is
+// disabled. The context gains a gate.EvaluationMemo so the endpoint sees the value the
+// guard checked. A nil gate lets every request through.
+func Guard(fg gate.FeatureGate, key string, opts ...guard.Option) Middleware {
+	return func(next Endpoint) Endpoint {
+		return func(ctx context.Context, request any) (any, error) {
+			ctx = gate.WithEvaluationMemo(ctx)
+			if err := guard.Require(ctx, fg, key, opts...); err != nil {
+				return nil, err
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// HTTPToContext returns a go-kit http.RequestFunc (use with kithttp.ServerBefore) that
+// stores scope values from the named headers. Pass scope.DefaultHeaderNames for the
+// X-Tenant-ID, X-Org-ID, and X-User-ID headers.
+func HTTPToContext(names scope.HeaderNames) func(context.Context, *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		if r == nil {
+			return ctx
+		}
+		return scope.FromHeaders(ctx, r.Header.Get, names)
+	}
+}
+
+// MetadataToContext returns a request func for go-kit's gRPC transport that stores
+// scope values from incoming metadata. Metadata keys are matched case-insensitively;
+// adapt it with func(ctx context.Context, md metadata.MD) context.Context { return fn(ctx, md) }.
+func MetadataToContext(names scope.HeaderNames) func(context.Context, map[string][]string) context.Context {
+	return func(ctx context.Context, md map[string][]string) context.Context {
+		return scope.FromHeaders(ctx, func(name string) string {
+			if values := md[strings.ToLower(name)]; len(values) > 0 {
+				return values[0]
+			}
+			return ""
+		}, names)
+	}
+}
+
+// EnabledRequest asks the enabled endpoint to resolve a key.
+type EnabledRequest struct {
+	Key string `json:"key"`
+}
+
+// EnabledResponse is the enabled endpoint result.
+type EnabledResponse struct {
+	Key     string             `json:"key"`
+	Enabled bool               `json:"enabled"`
+	Source  gate.ResolveSource `json:"source,omitempty"`
+}
+
+// MakeEnabledEndpoint returns an endpoint resolving EnabledRequest (or a plain key
+// string) with the scope carried by the context.
+func MakeEnabledEndpoint(fg gate.FeatureGate) Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		if fg == nil {
+			return nil, ferrors.WrapSentinel(ferrors.ErrGateRequired, "gokitadapter: feature gate is required", nil)
+		}
+		var key string
+		switch typed := request.(type) {
+		case EnabledRequest:
+			key = typed.Key
+		case *EnabledRequest:
+			if typed != nil {
+				key = typed.Key
+			}
+		case string:
+			key = typed
+		default:
+			return nil, ferrors.NewBadInput(TextCodeRequestInvalid, "gokitadapter: unsupported request type", map[string]any{
+				ferrors.MetaAdapter: "gokit",
+			})
+		}
+		resp := EnabledResponse{Key: gate.NormalizeKey(strings.TrimSpace(key))}
+		if traceable, ok := fg.(gate.TraceableFeatureGate); ok {
+			value, trace, err := traceable.ResolveWithTrace(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			resp.Enabled, resp.Source = value, trace.Source
+			return resp, nil
+		}
+		value, err := fg.Enabled(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		resp.Enabled = value
+		return resp, nil
+	}
+}
@@ -0,0 +1,57 @@
+package gokitadapter
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/gate/guard"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestGuardAndTransportScope(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"billing.v2": false})),
+		resolver.WithOverrideStore(overrides),
+	)
+	if err := fg.Set(ctx, "billing.v2", gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}, true, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	var endpoint Endpoint = func(context.Context, any) (any, error) { return "ok", nil }
+	guarded := Guard(fg, "billing.v2")(endpoint)
+
+	if _, err := guarded(ctx, nil); !errors.Is(err, guard.ErrFeatureDisabled) {
+		t.Fatalf("expected disabled error without scope, got %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Tenant-ID", "acme")
+	httpCtx := HTTPToContext(scope.DefaultHeaderNames)(ctx, req)
+	if resp, err := guarded(httpCtx, nil); err != nil || resp != "ok" {
+		t.Fatalf("expected tenant header to enable feature, got %v (%v)", resp, err)
+	}
+
+	grpcCtx := MetadataToContext(scope.DefaultHeaderNames)(ctx, map[string][]string{"x-tenant-id": {"acme"}})
+	if scope.TenantID(grpcCtx) != "acme" {
+		t.Fatalf("expected tenant from metadata, got %q", scope.TenantID(grpcCtx))
+	}
+
+	resp, err := MakeEnabledEndpoint(fg)(grpcCtx, EnabledRequest{Key: "billing.v2"})
+	if err != nil {
+		t.Fatalf("enabled endpoint: %v", err)
+	}
+	if out := resp.(EnabledResponse); !out.Enabled || out.Source != gate.ResolveSourceOverride {
+		t.Fatalf("unexpected response %+v", out)
+	}
+	if _, err := MakeEnabledEndpoint(fg)(ctx, 42); err == nil {
+		t.Fatalf("expected error for unsupported request")
+	}
+}
@@ -0,0 +1,177 @@
+// Package grpcadapter provides a gate.ClaimsProvider that reads tenant, org, user,
+// roles, and permissions from incoming gRPC metadata, so gRPC services derive the same
+// scope chain as HTTP services using scope.FromHeaders.
+//
+// The package does not import grpc-go. Metadata is read through MetadataFunc, usually
+// wrapping metadata.FromIncomingContext:
+//
+//	claims := grpcadapter.NewClaimsProvider(grpcadapter.WithMetadataFunc(func(ctx context.Context) (grpcadapter.MD, bool) {
+//		md, ok := metadata.FromIncomingContext(ctx)
+//		return grpcadapter.MD(md), ok
+//	}))
+//	gate := resolver.New(resolver.WithClaimsProvider(claims))
+package grpcadapter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+// MD has the shape of grpc-go's metadata.MD.
+type MD = map[string][]string
+
+// MetadataFunc returns the incoming metadata of the call in ctx.
+type MetadataFunc func(ctx context.Context) (MD, bool)
+
+// MetadataNames lists the metadata keys carrying claim values. Keys are matched
+// case-insensitively; an empty name is not read.
+type MetadataNames struct {
+	TenantID string
+	OrgID    string
+	UserID   string
+	Roles    string
+	Perms    string
+	Env      string
+}
+
+// DefaultMetadataNames mirror scope.DefaultHeaderNames in gRPC's lowercase form.
+var DefaultMetadataNames = MetadataNames{
+	TenantID: "x-tenant-id",
+	OrgID:    "x-org-id",
+	UserID:   "x-user-id",
+	Roles:    "x-roles",
+	Perms:    "x-permissions",
+}
+
+// Option customizes ClaimsProvider.
+type Option func(*ClaimsProvider)
+
+// WithMetadataFunc sets how incoming metadata is found. The default reads metadata
+// stored with ContextWithMetadata.
+func WithMetadataFunc(fn MetadataFunc) Option {
+	return func(p *ClaimsProvider) {
+		if p == nil || fn == nil {
+			return
+		}
+		p.metadata = fn
+	}
+}
+
+// WithMetadataNames overrides the metadata keys read for each claim.
+func WithMetadataNames(names MetadataNames) Option {
+	return func(p *ClaimsProvider) {
+		if p == nil {
+			return
+		}
+		p.names = names
+	}
+}
+
+// ClaimsProvider derives gate.ActorClaims from gRPC metadata.
+type ClaimsProvider struct {
+	metadata MetadataFunc
+	names    MetadataNames
+}
+
+// NewClaimsProvider constructs a provider reading DefaultMetadataNames.
+func NewClaimsProvider(opts ...Option) *ClaimsProvider {
+	provider := &ClaimsProvider{metadata: MetadataFromContext, names: DefaultMetadataNames}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(provider)
+		}
+	}
+	return provider
+}
+
+// ClaimsFromContext starts from the scope values already in ctx and replaces them with
+// any non-empty metadata values, matching how scope.FromHeaders layers over earlier
+// middleware. Roles and permissions accept repeated keys and comma-separated values.
+func (p *ClaimsProvider) ClaimsFromContext(ctx context.Context) (gate.ActorClaims, error) {
+	claims := scope.ClaimsFromContext(ctx)
+	if p == nil || p.metadata == nil || ctx == nil {
+		return claims, nil
+	}
+	md, ok := p.metadata(ctx)
+	if !ok || len(md) == 0 {
+		return claims, nil
+	}
+	assign := func(target *string, name string) {
+		if value := first(md, name); value != "" {
+			*target = value
+		}
+	}
+	assign(&claims.TenantID, p.names.TenantID)
+	assign(&claims.OrgID, p.names.OrgID)
+	assign(&claims.SubjectID, p.names.UserID)
+	assign(&claims.Env, p.names.Env)
+	if roles := list(md, p.names.Roles); len(roles) > 0 {
+		claims.Roles = roles
+	}
+	if perms := list(md, p.names.Perms); len(perms) > 0 {
+		claims.Perms = perms
+	}
+	return claims, nil
+}
+
+type metadataKey struct{}
+
+// ContextWithMetadata stores md in ctx for the default MetadataFunc. Use it from an
+// interceptor when wrapping metadata.FromIncomingContext is not convenient.
+func ContextWithMetadata(ctx context.Context, md MD) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// MetadataFromContext returns metadata stored with ContextWithMetadata.
+func MetadataFromContext(ctx context.Context) (MD, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	md, ok := ctx.Value(metadataKey{}).(MD)
+	return md, ok
+}
+
+func values(md MD, name string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil
+	}
+	if found, ok := md[name]; ok {
+		return found
+	}
+	for key, found := range md {
+		if strings.EqualFold(key, name) {
+			return found
+		}
+	}
+	return nil
+}
+
+func first(md MD, name string) string {
+	for _, value := range values(md, name) {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func list(md MD, name string) []string {
+	var out []string
+	for _, value := range values(md, name) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+var _ gate.ClaimsProvider = (*ClaimsProvider)(nil)
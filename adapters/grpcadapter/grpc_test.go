@@ -0,0 +1,62 @@
+package grpcadapter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestClaimsProviderReadsMetadata(t *testing.T) {
+	ctx := scope.WithOrgID(scope.WithTenantID(context.Background(), "from-ctx"), "org-ctx")
+	ctx = ContextWithMetadata(ctx, MD{
+		"x-tenant-id":   {"acme"},
+		"x-user-id":     {" u1 "},
+		"x-roles":       {"admin, editor", "viewer"},
+		"x-permissions": {"reports.read"},
+	})
+	claims, err := NewClaimsProvider().ClaimsFromContext(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := gate.ActorClaims{
+		SubjectID: "u1",
+		TenantID:  "acme",
+		OrgID:     "org-ctx",
+		Roles:     []string{"admin", "editor", "viewer"},
+		Perms:     []string{"reports.read"},
+	}
+	if !reflect.DeepEqual(claims, want) {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestClaimsProviderFeedsResolverChain(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	org := gate.ScopeRef{Kind: gate.ScopeOrg, ID: "eng", TenantID: "acme", OrgID: "eng"}
+	if err := overrides.Set(ctx, "search.v3", org, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	type mdKey struct{}
+	provider := NewClaimsProvider(
+		WithMetadataFunc(func(ctx context.Context) (MD, bool) {
+			md, ok := ctx.Value(mdKey{}).(MD)
+			return md, ok
+		}),
+		WithMetadataNames(MetadataNames{TenantID: "tenant", OrgID: "Org"}),
+	)
+	fg := resolver.New(resolver.WithOverrideStore(overrides), resolver.WithClaimsProvider(provider))
+
+	if enabled, _ := fg.Enabled(ctx, "search.v3"); enabled {
+		t.Fatalf("expected disabled without metadata")
+	}
+	callCtx := context.WithValue(ctx, mdKey{}, MD{"tenant": {"acme"}, "org": {"eng"}})
+	if enabled, err := fg.Enabled(callCtx, "search.v3"); err != nil || !enabled {
+		t.Fatalf("expected org override from metadata, got %v (%v)", enabled, err)
+	}
+}
@@ -0,0 +1,528 @@
+// Package jwtadapter provides a gate.ClaimsProvider that reads a bearer token from the
+// context, verifies it with a configurable Keyfunc, and maps its claims to
+// gate.ActorClaims. It depends only on the standard library and supports the HS, RS,
+// PS, ES, and EdDSA signature families; plug another JWT library in with WithVerifier.
+//
+// The alg header is untrusted: a token is only checked with an algorithm of the
+// key's family (and, for ES, the hash that matches the key's curve). Pin the exact
+// algorithms you issue with WithAlgorithms.
+package jwtadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// Error text codes reported by the provider.
+const (
+	TextCodeTokenMissing = "JWT_TOKEN_MISSING"
+	TextCodeTokenInvalid = "JWT_TOKEN_INVALID"
+	TextCodeTokenExpired = "JWT_TOKEN_EXPIRED"
+)
+
+// Header is the decoded JOSE header of a token.
+type Header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Claims are the decoded token claims.
+type Claims map[string]any
+
+// Keyfunc returns the key that verifies a token with header: []byte for HS*,
+// *rsa.PublicKey for RS* and PS*, *ecdsa.PublicKey for ES*, ed25519.PublicKey for EdDSA.
+type Keyfunc func(ctx context.Context, header Header) (any, error)
+
+// StaticKey returns a Keyfunc that always uses key.
+func StaticKey(key any) Keyfunc {
+	return func(context.Context, Header) (any, error) {
+		return key, nil
+	}
+}
+
+// VerifyFunc verifies a raw token and returns its claims. Use it to delegate
+// verification to another JWT library.
+type VerifyFunc func(ctx context.Context, token string) (Claims, error)
+
+// ClaimNames maps token claims to actor claims. Names may be dotted paths into nested
+// objects, such as "realm_access.roles". An empty name skips the field.
+type ClaimNames struct {
+	Subject string
+	Tenant  string
+	Org     string
+	Roles   string
+	Perms   string
+	Env     string
+}
+
+// DefaultClaimNames reads sub, tenant_id, org_id, roles, and permissions.
+var DefaultClaimNames = ClaimNames{
+	Subject: "sub",
+	Tenant:  "tenant_id",
+	Org:     "org_id",
+	Roles:   "roles",
+	Perms:   "permissions",
+}
+
+// Provider implements gate.ClaimsProvider for bearer tokens.
+type Provider struct {
+	keyfunc  Keyfunc
+	verify   VerifyFunc
+	names    ClaimNames
+	now      func() time.Time
+	leeway   time.Duration
+	issuer   string
+	audience string
+	required bool
+	algs     map[string]bool
+}
+
+// Option customizes a Provider.
+type Option func(*Provider)
+
+// WithClaimNames overrides the claim names mapped to actor claims.
+func WithClaimNames(names ClaimNames) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.names = names
+	}
+}
+
+// WithVerifier replaces the built-in signature and time checks with fn.
+func WithVerifier(fn VerifyFunc) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.verify = fn
+	}
+}
+
+// WithClock sets the clock used for exp and nbf checks.
+func WithClock(c clock.Clock) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.now = clock.NowFunc(c)
+	}
+}
+
+// WithLeeway tolerates clock skew when checking exp and nbf.
+func WithLeeway(leeway time.Duration) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.leeway = leeway
+	}
+}
+
+// WithIssuer requires the iss claim to equal issuer.
+func WithIssuer(issuer string) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.issuer = strings.TrimSpace(issuer)
+	}
+}
+
+// WithAudience requires the aud claim to contain audience.
+func WithAudience(audience string) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.audience = strings.TrimSpace(audience)
+	}
+}
+
+// WithAlgorithms accepts only tokens whose alg header is one of algs, for example
+// WithAlgorithms("RS256"). Other tokens are rejected before the Keyfunc runs.
+// Without it, any algorithm of the key's family is accepted.
+func WithAlgorithms(algs ...string) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.algs = map[string]bool{}
+		for _, alg := range algs {
+			if alg = strings.TrimSpace(alg); alg != "" {
+				p.algs[alg] = true
+			}
+		}
+	}
+}
+
+// WithRequireToken makes a missing token an error instead of anonymous claims.
+func WithRequireToken(required bool) Option {
+	return func(p *Provider) {
+		if p == nil {
+			return
+		}
+		p.required = required
+	}
+}
+
+// NewProvider constructs a provider that verifies tokens with keyfunc.
+func NewProvider(keyfunc Keyfunc, opts ...Option) *Provider {
+	p := &Provider{
+		keyfunc: keyfunc,
+		names:   DefaultClaimNames,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+	if p.now == nil {
+		p.now = time.Now
+	}
+	return p
+}
+
+// ClaimsFromContext implements gate.ClaimsProvider. Without a token in ctx it returns
+// empty claims unless WithRequireToken is set.
+func (p *Provider) ClaimsFromContext(ctx context.Context) (gate.ActorClaims, error) {
+	token, ok := TokenFromContext(ctx)
+	if !ok {
+		if p.required {
+			return gate.ActorClaims{}, ferrors.NewBadInput(TextCodeTokenMissing, "jwtadapter: bearer token is required", map[string]any{
+				ferrors.MetaAdapter: "jwt",
+			})
+		}
+		return gate.ActorClaims{}, nil
+	}
+	return p.Parse(ctx, token)
+}
+
+// Parse verifies token and maps its claims.
+func (p *Provider) Parse(ctx context.Context, token string) (gate.ActorClaims, error) {
+	claims, err := p.Verify(ctx, token)
+	if err != nil {
+		return gate.ActorClaims{}, err
+	}
+	return p.Map(claims), nil
+}
+
+// Map converts token claims to actor claims using the configured claim names.
+func (p *Provider) Map(claims Claims) gate.ActorClaims {
+	return gate.ActorClaims{
+		SubjectID: claimString(claims, p.names.Subject),
+		TenantID:  claimString(claims, p.names.Tenant),
+		OrgID:     claimString(claims, p.names.Org),
+		Roles:     claimList(claims, p.names.Roles),
+		Perms:     claimList(claims, p.names.Perms),
+		Env:       claimString(claims, p.names.Env),
+	}
+}
+
+// Verify checks the token signature, exp, nbf, and the configured issuer and audience.
+func (p *Provider) Verify(ctx context.Context, token string) (Claims, error) {
+	if p.verify != nil {
+		claims, err := p.verify(ctx, token)
+		if err != nil {
+			return nil, ferrors.WrapBadInput(err, TextCodeTokenInvalid, "jwtadapter: token verification failed", map[string]any{
+				ferrors.MetaAdapter: "jwt",
+			})
+		}
+		return claims, nil
+	}
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token", nil)
+	}
+	var header Header
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalid("malformed header", err)
+	}
+	if len(p.algs) > 0 && !p.algs[header.Alg] {
+		return nil, invalid(fmt.Sprintf("algorithm %q is not allowed", header.Alg), nil)
+	}
+	if p.keyfunc == nil {
+		return nil, invalid("no keyfunc configured", nil)
+	}
+	key, err := p.keyfunc(ctx, header)
+	if err != nil {
+		return nil, invalid("key lookup failed", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature", err)
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, invalid("signature verification failed", err)
+	}
+	claims := Claims{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalid("malformed claims", err)
+	}
+	if err := p.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (p *Provider) validate(claims Claims) error {
+	now := p.now()
+	if exp, ok := claimTime(claims, "exp"); ok && !now.Before(exp.Add(p.leeway)) {
+		return ferrors.NewBadInput(TextCodeTokenExpired, "jwtadapter: token expired", map[string]any{
+			ferrors.MetaAdapter: "jwt",
+		})
+	}
+	if nbf, ok := claimTime(claims, "nbf"); ok && now.Add(p.leeway).Before(nbf) {
+		return invalid("token not valid yet", nil)
+	}
+	if p.issuer != "" && claimString(claims, "iss") != p.issuer {
+		return invalid("unexpected issuer", nil)
+	}
+	if p.audience != "" {
+		for _, aud := range claimList(claims, "aud") {
+			if aud == p.audience {
+				return nil
+			}
+		}
+		return invalid("unexpected audience", nil)
+	}
+	return nil
+}
+
+type tokenKey struct{}
+
+// ContextWithToken stores a raw bearer token in ctx.
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, tokenKey{}, strings.TrimSpace(token))
+}
+
+// TokenFromContext returns the token stored by ContextWithToken.
+func TokenFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	token, ok := ctx.Value(tokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+// BearerToken extracts the token from an Authorization header value.
+func BearerToken(authorization string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// HeaderToContext stores the bearer token from the Authorization header read through
+// get, so the same code serves HTTP headers and gRPC metadata.
+func HeaderToContext(ctx context.Context, get func(name string) string) context.Context {
+	if get == nil {
+		return ctx
+	}
+	if token, ok := BearerToken(get("Authorization")); ok {
+		return ContextWithToken(ctx, token)
+	}
+	return ctx
+}
+
+// Middleware stores the request's bearer token in its context.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(HeaderToContext(r.Context(), r.Header.Get)))
+	})
+}
+
+func invalid(reason string, err error) error {
+	meta := map[string]any{ferrors.MetaAdapter: "jwt"}
+	if err != nil {
+		return ferrors.WrapBadInput(err, TextCodeTokenInvalid, "jwtadapter: "+reason, meta)
+	}
+	return ferrors.NewBadInput(TextCodeTokenInvalid, "jwtadapter: "+reason, meta)
+}
+
+func decodeSegment(segment string, out any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+
+var hashes = map[string]crypto.Hash{
+	"256": crypto.SHA256,
+	"384": crypto.SHA384,
+	"512": crypto.SHA512,
+}
+
+// curveHashes pairs each ES curve with the hash RFC 7518 assigns it, so a P-384 key
+// only verifies ES384 tokens.
+var curveHashes = map[elliptic.Curve]crypto.Hash{
+	elliptic.P256(): crypto.SHA256,
+	elliptic.P384(): crypto.SHA384,
+	elliptic.P521(): crypto.SHA512,
+}
+
+func verifySignature(alg string, key any, signed string, signature []byte) error {
+	if alg == "EdDSA" {
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("EdDSA requires an ed25519.PublicKey, got %T", key)
+		}
+		if !ed25519.Verify(pub, []byte(signed), signature) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	}
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	hash, ok := hashes[alg[2:]]
+	if !ok {
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	digest := hash.New()
+	digest.Write([]byte(signed))
+	sum := digest.Sum(nil)
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("%s requires a []byte secret, got %T", alg, key)
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an *rsa.PublicKey, got %T", alg, key)
+		}
+		if alg[0] == 'R' {
+			return rsa.VerifyPKCS1v15(pub, hash, sum, signature)
+		}
+		return rsa.VerifyPSS(pub, hash, sum, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an *ecdsa.PublicKey, got %T", alg, key)
+		}
+		if curveHashes[pub.Curve] != hash {
+			return fmt.Errorf("%s does not match the key's %s curve", alg, pub.Curve.Params().Name)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, sum, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported alg %q", alg)
+}
+
+// claimValue follows a dotted path through nested claim objects.
+func claimValue(claims Claims, name string) (any, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, false
+	}
+	if value, ok := claims[name]; ok {
+		return value, true
+	}
+	var current any = map[string]any(claims)
+	for _, part := range strings.Split(name, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func claimString(claims Claims, name string) string {
+	value, ok := claimValue(claims, name)
+	if !ok || value == nil {
+		return ""
+	}
+	switch typed := value.(type) {
+	case string:
+		return strings.TrimSpace(typed)
+	case json.Number:
+		return typed.String()
+	default:
+		return strings.TrimSpace(fmt.Sprint(typed))
+	}
+}
+
+// claimList reads an array claim, or a space- or comma-separated string such as an
+// OAuth scope claim.
+func claimList(claims Claims, name string) []string {
+	value, ok := claimValue(claims, name)
+	if !ok || value == nil {
+		return nil
+	}
+	var out []string
+	switch typed := value.(type) {
+	case []any:
+		for _, item := range typed {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				out = append(out, strings.TrimSpace(s))
+			}
+		}
+	case string:
+		out = strings.FieldsFunc(typed, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+	return out
+}
+
+func claimTime(claims Claims, name string) (time.Time, bool) {
+	value, ok := claims[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := value.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
+
+var _ gate.ClaimsProvider = (*Provider)(nil)
@@ -0,0 +1,183 @@
+package jwtadapter
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+var secret = []byte("test-secret")
+
+func signHS256(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	signing := segment(t, map[string]any{"alg": "HS256", "typ": "JWT"}) + "." + segment(t, claims)
+	mac := hmac.New(crypto.SHA256.New, secret)
+	mac.Write([]byte(signing))
+	return signing + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func segment(t *testing.T, value any) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestProviderMapsVerifiedClaims(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	provider := NewProvider(StaticKey(secret),
+		WithClock(clock.NewManual(now)),
+		WithAudience("app"),
+		WithClaimNames(ClaimNames{Subject: "sub", Tenant: "tid", Org: "org.id", Roles: "realm_access.roles", Perms: "scope"}),
+	)
+	token := signHS256(t, map[string]any{
+		"sub":          "u1",
+		"tid":          "acme",
+		"org":          map[string]any{"id": "eng"},
+		"realm_access": map[string]any{"roles": []string{"admin", "editor"}},
+		"scope":        "reports.read reports.write",
+		"aud":          []string{"app", "other"},
+		"exp":          now.Add(time.Hour).Unix(),
+	})
+	claims, err := provider.ClaimsFromContext(ContextWithToken(context.Background(), token))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := gate.ActorClaims{
+		SubjectID: "u1",
+		TenantID:  "acme",
+		OrgID:     "eng",
+		Roles:     []string{"admin", "editor"},
+		Perms:     []string{"reports.read", "reports.write"},
+	}
+	if !reflect.DeepEqual(claims, want) {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestProviderRejectsInvalidTokens(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	provider := NewProvider(StaticKey(secret), WithClock(clock.NewManual(now)), WithRequireToken(true))
+	cases := map[string]struct {
+		token string
+		code  string
+	}{
+		"expired":   {signHS256(t, map[string]any{"sub": "u1", "exp": now.Add(-time.Minute).Unix()}), TextCodeTokenExpired},
+		"tampered":  {signHS256(t, map[string]any{"sub": "u1"}) + "x", TextCodeTokenInvalid},
+		"malformed": {"not-a-token", TextCodeTokenInvalid},
+	}
+	for name, tc := range cases {
+		_, err := provider.ClaimsFromContext(ContextWithToken(context.Background(), tc.token))
+		if rich, ok := ferrors.As(err); !ok || rich.TextCode != tc.code {
+			t.Fatalf("%s: expected %s, got %v", name, tc.code, err)
+		}
+	}
+	if _, err := provider.ClaimsFromContext(context.Background()); err == nil {
+		t.Fatalf("expected missing token to fail when required")
+	}
+	if claims, err := NewProvider(StaticKey(secret)).ClaimsFromContext(context.Background()); err != nil || claims.SubjectID != "" {
+		t.Fatalf("expected anonymous claims without a token, got %+v %v", claims, err)
+	}
+}
+
+func signES(t *testing.T, key *ecdsa.PrivateKey, alg string, hash crypto.Hash, claims map[string]any) string {
+	t.Helper()
+	signing := segment(t, map[string]any{"alg": alg, "kid": "k1"}) + "." + segment(t, claims)
+	digest := hash.New()
+	digest.Write([]byte(signing))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest.Sum(nil))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	size := (key.Curve.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	s.FillBytes(signature[size:])
+	return signing + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestProviderVerifiesECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	token := signES(t, key, "ES256", crypto.SHA256, map[string]any{"sub": "u2"})
+
+	provider := NewProvider(func(_ context.Context, header Header) (any, error) {
+		if header.Kid != "k1" {
+			t.Fatalf("expected kid k1, got %q", header.Kid)
+		}
+		return &key.PublicKey, nil
+	})
+	claims, err := provider.Parse(context.Background(), token)
+	if err != nil || claims.SubjectID != "u2" {
+		t.Fatalf("expected verified ES256 token, got %+v %v", claims, err)
+	}
+}
+
+func TestProviderRestrictsAlgorithms(t *testing.T) {
+	token := signHS256(t, map[string]any{"sub": "u1"})
+	if _, err := NewProvider(StaticKey(secret), WithAlgorithms("HS256")).Parse(context.Background(), token); err != nil {
+		t.Fatalf("expected an allowed algorithm to verify, got %v", err)
+	}
+	looked := false
+	pinned := NewProvider(func(context.Context, Header) (any, error) {
+		looked = true
+		return secret, nil
+	}, WithAlgorithms("RS256"))
+	if _, err := pinned.Parse(context.Background(), token); err == nil || looked {
+		t.Fatalf("expected HS256 to be rejected before key lookup, got %v (looked up %v)", err, looked)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	provider := NewProvider(StaticKey(&key.PublicKey))
+	if _, err := provider.Parse(context.Background(), signES(t, key, "ES384", crypto.SHA384, map[string]any{"sub": "u2"})); err != nil {
+		t.Fatalf("expected ES384 on P-384 to verify, got %v", err)
+	}
+	if _, err := provider.Parse(context.Background(), signES(t, key, "ES256", crypto.SHA256, map[string]any{"sub": "u2"})); err == nil {
+		t.Fatal("expected ES256 on a P-384 key to be rejected")
+	}
+}
+
+func TestMiddlewareFeedsResolverChain(t *testing.T) {
+	overrides := store.NewMemoryStore()
+	admin := gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"}
+	if err := overrides.Set(context.Background(), "reports.export", admin, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	fg := resolver.New(
+		resolver.WithOverrideStore(overrides),
+		resolver.WithClaimsProvider(NewProvider(StaticKey(secret))),
+	)
+	var enabled bool
+	handler := Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		enabled, _ = fg.Enabled(r.Context(), "reports.export")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+signHS256(t, map[string]any{"sub": "u1", "tenant_id": "acme", "roles": []string{"Admin"}}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !enabled {
+		t.Fatalf("expected role override from token claims to enable the feature")
+	}
+}
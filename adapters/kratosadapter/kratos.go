@@ -0,0 +1,129 @@
+// Package kratosadapter exposes a feature gate to Kratos services: server middleware
+// that moves scope values from transport headers into the context, guard middleware,
+// and a Config type for wiring the gate from the Kratos config tree.
+//
+// The package does not import Kratos. Handler and Middleware are aliases for the
+// unnamed function shapes of middleware.Handler and middleware.Middleware, and the
+// transport header is read through HeaderFunc:
+//
+//	headers := kratosadapter.WithHeaderFunc(func(ctx context.Context) (kratosadapter.Header, bool) {
+//		tr, ok := transport.FromServerContext(ctx)
+//		if !ok {
+//			return nil, false
+//		}
+//		return tr.RequestHeader(), true
+//	})
+//	server := kratosadapter.Server(headers)
+//	http.Middleware(func(next middleware.Handler) middleware.Handler { return server(next) })
+package kratosadapter
+
+import (
+	"context"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/gate/guard"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// Handler has the shape of Kratos' middleware.Handler.
+type Handler = func(ctx context.Context, req any) (any, error)
+
+// Middleware has the shape of Kratos' middleware.Middleware over Handler.
+type Middleware = func(Handler) Handler
+
+// Header is the part of Kratos' transport.Header the adapter reads.
+type Header interface {
+	Get(key string) string
+}
+
+// HeaderFunc returns the request header of the server transport in ctx, usually via
+// transport.FromServerContext.
+type HeaderFunc func(ctx context.Context) (Header, bool)
+
+// Option customizes Server.
+type Option func(*config)
+
+type config struct {
+	headers HeaderFunc
+	names   scope.HeaderNames
+}
+
+// WithHeaderFunc sets how the request header is found. Without it Server is a no-op.
+func WithHeaderFunc(fn HeaderFunc) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.headers = fn
+	}
+}
+
+// WithHeaderNames overrides the header names read for tenant, org, and user.
+func WithHeaderNames(names scope.HeaderNames) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.names = names
+	}
+}
+
+// Server returns middleware that stores scope values from the request header in the
+// context (scope.DefaultHeaderNames unless overridden) and attaches a
+// gate.EvaluationMemo so every check during the request sees one value per key.
+func Server(opts ...Option) Middleware {
+	cfg := &config{names: scope.DefaultHeaderNames}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			if cfg.headers != nil {
+				if header, ok := cfg.headers(ctx); ok && header != nil {
+					ctx = scope.FromHeaders(ctx, header.Get, cfg.names)
+				}
+			}
+			return next(gate.WithEvaluationMemo(ctx), req)
+		}
+	}
+}
+
+// Guard returns middleware that fails with guard's disabled error when key is
+// disabled. Map it to a Kratos error with guard.WithDisabledError or
+// guard.WithErrorMapper. A nil gate lets every request through.
+func Guard(fg gate.FeatureGate, key string, opts ...guard.Option) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			ctx = gate.WithEvaluationMemo(ctx)
+			if err := guard.Require(ctx, fg, key, opts...); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// Config is the featuregate section of a Kratos config file; scan it with
+// c.Value("featuregate").Scan(&cfg).
+type Config struct {
+	Defaults    map[string]any `json:"defaults" yaml:"defaults"`
+	StrictStore bool           `json:"strict_store" yaml:"strict_store"`
+}
+
+// NewGate builds a resolver gate from cfg. Defaults accept nested maps or flat dotted
+// keys; overrides is optional. Extra options are applied last.
+func NewGate(cfg Config, overrides store.ReadWriter, opts ...resolver.Option) *resolver.Gate {
+	base := []resolver.Option{
+		resolver.WithDefaults(configadapter.NewDefaults(cfg.Defaults)),
+		resolver.WithStrictStore(cfg.StrictStore),
+	}
+	if overrides != nil {
+		base = append(base, resolver.WithOverrideStore(overrides))
+	}
+	return resolver.New(append(base, opts...)...)
+}
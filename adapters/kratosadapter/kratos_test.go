@@ -0,0 +1,44 @@
+package kratosadapter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/gate/guard"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+type headerKey struct{}
+
+func TestServerAndGuardMiddleware(t *testing.T) {
+	ctx := context.Background()
+	fg := NewGate(Config{Defaults: map[string]any{"search": map[string]any{"v3": false}}}, store.NewMemoryStore())
+	if err := fg.Set(ctx, "search.v3", gate.ScopeRef{Kind: gate.ScopeOrg, ID: "org-1", OrgID: "org-1"}, true, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	headers := WithHeaderFunc(func(ctx context.Context) (Header, bool) {
+		header, ok := ctx.Value(headerKey{}).(http.Header)
+		return header, ok
+	})
+	var seenOrg string
+	var handler Handler = func(ctx context.Context, _ any) (any, error) {
+		seenOrg = scope.OrgID(ctx)
+		return "ok", nil
+	}
+	chain := Server(headers, WithHeaderNames(scope.HeaderNames{OrgID: "X-Organization"}))(Guard(fg, "search.v3")(handler))
+
+	if _, err := chain(ctx, nil); !errors.Is(err, guard.ErrFeatureDisabled) {
+		t.Fatalf("expected disabled without header, got %v", err)
+	}
+
+	reqCtx := context.WithValue(ctx, headerKey{}, http.Header{"X-Organization": {"org-1"}})
+	resp, err := chain(reqCtx, nil)
+	if err != nil || resp != "ok" || seenOrg != "org-1" {
+		t.Fatalf("expected org header to enable feature, got %v (%v) org=%q", resp, err, seenOrg)
+	}
+}
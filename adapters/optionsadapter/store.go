@@ -16,6 +16,7 @@ import (
 
 const (
 	prioritySystem = 10
+	priorityEnv    = 15
 	priorityTenant = 20
 	priorityOrg    = 30
 	priorityUser   = 40
@@ -145,8 +146,8 @@ func (s *Store) GetAll(ctx context.Context, key string, chain gate.ScopeChain) (
 	return matches, nil
 }
 
-// Set implements store.Writer.
-func (s *Store) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef) error {
+// Set implements store.Writer. Expiry and metadata options are not persisted by go-options snapshots.
+func (s *Store) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, _ ...gate.MutationOption) error {
 	if s == nil || s.stateStore == nil {
 		domain := ""
 		if s != nil {
@@ -246,6 +247,8 @@ func defaultScopeFromRef(ref gate.ScopeRef) opts.Scope {
 		return scoped(scopeName("role", ref.ID), "Role", priorityRole, scopeMetadata(ref, metadataRoleID))
 	case gate.ScopePerm:
 		return scoped(scopeName("perm", ref.ID), "Perm", priorityPerm, scopeMetadata(ref, metadataPermID))
+	case gate.ScopeEnv:
+		return scoped(scopeName("env", ref.ID), "Env", priorityEnv, scopeMetadata(ref, scope.MetadataEnv))
 	default:
 		return scoped("system", "System", prioritySystem, map[string]any{})
 	}
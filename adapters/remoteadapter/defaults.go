@@ -0,0 +1,379 @@
+// Package remoteadapter provides resolver.Defaults fetched from an HTTP endpoint, so
+// defaults can change fleet-wide without a redeploy.
+//
+// The endpoint serves a JSON document in the shape accepted by configadapter.NewDefaults.
+// Responses are revalidated with ETag/If-None-Match, the last good document is mirrored
+// to a local file for cold starts while the endpoint is unreachable, and a background
+// loop refreshes on an interval.
+package remoteadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/resolver"
+)
+
+const adapterName = "remote"
+
+const (
+	TextCodeFetchFailed     = "REMOTE_DEFAULTS_FETCH_FAILED"
+	TextCodeDocumentInvalid = "REMOTE_DEFAULTS_INVALID"
+	TextCodeUnavailable     = "REMOTE_DEFAULTS_UNAVAILABLE"
+
+	MetaURL    = "url"
+	MetaStatus = "status"
+)
+
+// Option customizes Defaults.
+type Option func(*Defaults)
+
+// WithHTTPClient sets the client used for fetches. Defaults to a client with a 10s timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Defaults) {
+		if d == nil || client == nil {
+			return
+		}
+		d.client = client
+	}
+}
+
+// WithHeader adds a request header, for example an authorization token.
+func WithHeader(name, value string) Option {
+	return func(d *Defaults) {
+		if d == nil || name == "" {
+			return
+		}
+		d.header.Add(name, value)
+	}
+}
+
+// WithCacheFile mirrors the last good document to path and loads it when the
+// endpoint is unreachable at Start.
+func WithCacheFile(path string) Option {
+	return func(d *Defaults) {
+		if d == nil {
+			return
+		}
+		d.cacheFile = path
+	}
+}
+
+// WithRefreshInterval enables background refresh after Start. Zero disables it.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(d *Defaults) {
+		if d == nil || interval < 0 {
+			return
+		}
+		d.interval = interval
+	}
+}
+
+// WithOnChange registers a callback run after a refresh installs a new document, for
+// example to clear the resolver cache.
+func WithOnChange(fn func()) Option {
+	return func(d *Defaults) {
+		if d == nil {
+			return
+		}
+		d.onChange = fn
+	}
+}
+
+// WithOnError registers a callback for background refresh failures.
+func WithOnError(fn func(error)) Option {
+	return func(d *Defaults) {
+		if d == nil {
+			return
+		}
+		d.onError = fn
+	}
+}
+
+// WithConfigOptions sets the configadapter options used to parse documents.
+func WithConfigOptions(opts ...configadapter.Option) Option {
+	return func(d *Defaults) {
+		if d == nil {
+			return
+		}
+		d.configOpts = opts
+	}
+}
+
+// WithClock sets the clock used for fetch timestamps.
+func WithClock(c clock.Clock) Option {
+	return func(d *Defaults) {
+		if d == nil {
+			return
+		}
+		d.now = clock.NowFunc(c)
+	}
+}
+
+// Defaults implements resolver.Defaults from a remote document.
+type Defaults struct {
+	url        string
+	client     *http.Client
+	header     http.Header
+	cacheFile  string
+	interval   time.Duration
+	onChange   func()
+	onError    func(error)
+	configOpts []configadapter.Option
+	now        func() time.Time
+
+	current atomic.Pointer[snapshot]
+	mu      sync.Mutex
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+type snapshot struct {
+	defaults  *configadapter.Defaults
+	etag      string
+	fetchedAt time.Time
+}
+
+// cacheDocument is the on-disk mirror of the last good response.
+type cacheDocument struct {
+	ETag      string          `json:"etag,omitempty"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Defaults  json.RawMessage `json:"defaults"`
+}
+
+// NewDefaults constructs Defaults for url. Call Start (or Refresh) before resolving;
+// until a document loads every key is unset.
+func NewDefaults(url string, opts ...Option) *Defaults {
+	d := &Defaults{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		header: http.Header{},
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	return d
+}
+
+// Default implements resolver.Defaults.
+func (d *Defaults) Default(ctx context.Context, key string) (resolver.DefaultResult, error) {
+	if d == nil {
+		return resolver.DefaultResult{}, nil
+	}
+	snap := d.current.Load()
+	if snap == nil {
+		return resolver.DefaultResult{}, nil
+	}
+	return snap.defaults.Default(ctx, key)
+}
+
+// ETag returns the entity tag of the installed document.
+func (d *Defaults) ETag() string {
+	if snap := d.current.Load(); snap != nil {
+		return snap.etag
+	}
+	return ""
+}
+
+// FetchedAt returns when the installed document was fetched.
+func (d *Defaults) FetchedAt() time.Time {
+	if snap := d.current.Load(); snap != nil {
+		return snap.fetchedAt
+	}
+	return time.Time{}
+}
+
+// Start loads the document, falling back to the cache file when the endpoint fails,
+// and starts background refresh when an interval is set. It fails only when neither
+// source yields a document.
+func (d *Defaults) Start(ctx context.Context) error {
+	if _, err := d.Refresh(ctx); err != nil {
+		if loadErr := d.loadCacheFile(); loadErr != nil {
+			return ferrors.WrapExternal(err, TextCodeUnavailable, "remoteadapter: defaults unavailable from endpoint and cache file", map[string]any{
+				ferrors.MetaAdapter: adapterName,
+				MetaURL:             d.url,
+				ferrors.MetaPath:    d.cacheFile,
+			})
+		}
+		d.report(err)
+	}
+	if d.interval <= 0 {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stop != nil {
+		return nil
+	}
+	d.stop = make(chan struct{})
+	d.done = make(chan struct{})
+	go d.loop(d.stop, d.done)
+	return nil
+}
+
+// Stop ends background refresh and waits for it to exit.
+func (d *Defaults) Stop() {
+	d.mu.Lock()
+	stop, done := d.stop, d.done
+	d.stop, d.done = nil, nil
+	d.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// Refresh fetches the document, sending the current ETag. It reports whether a new
+// document was installed; a 304 response keeps the current one.
+func (d *Defaults) Refresh(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.url, nil)
+	if err != nil {
+		return false, ferrors.WrapBadInput(err, TextCodeFetchFailed, "remoteadapter: invalid request", map[string]any{
+			ferrors.MetaAdapter: adapterName,
+			MetaURL:             d.url,
+		})
+	}
+	for name, values := range d.header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	current := d.current.Load()
+	if current != nil && current.etag != "" {
+		req.Header.Set("If-None-Match", current.etag)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return false, d.fetchError(err, 0)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && current != nil:
+		return false, nil
+	case resp.StatusCode != http.StatusOK:
+		return false, d.fetchError(fmt.Errorf("unexpected status %s", resp.Status), resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, d.fetchError(err, resp.StatusCode)
+	}
+	etag := resp.Header.Get("ETag")
+	if err := d.install(body, etag, d.now()); err != nil {
+		return false, err
+	}
+	d.writeCacheFile(body, etag)
+	if d.onChange != nil {
+		d.onChange()
+	}
+	return true, nil
+}
+
+func (d *Defaults) loop(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), d.interval)
+			_, err := d.Refresh(ctx)
+			cancel()
+			if err != nil {
+				d.report(err)
+			}
+		}
+	}
+}
+
+func (d *Defaults) install(body []byte, etag string, fetchedAt time.Time) error {
+	data := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(&data); err != nil {
+		return ferrors.WrapBadInput(err, TextCodeDocumentInvalid, "remoteadapter: invalid defaults document", map[string]any{
+			ferrors.MetaAdapter: adapterName,
+			MetaURL:             d.url,
+		})
+	}
+	d.current.Store(&snapshot{
+		defaults:  configadapter.NewDefaults(data, d.configOpts...),
+		etag:      etag,
+		fetchedAt: fetchedAt,
+	})
+	return nil
+}
+
+func (d *Defaults) loadCacheFile() error {
+	if d.cacheFile == "" {
+		return fmt.Errorf("no cache file configured")
+	}
+	raw, err := os.ReadFile(d.cacheFile)
+	if err != nil {
+		return err
+	}
+	doc := cacheDocument{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	return d.install(doc.Defaults, doc.ETag, doc.FetchedAt)
+}
+
+// writeCacheFile replaces the cache file atomically; failures only cost the cold-start fallback.
+func (d *Defaults) writeCacheFile(body []byte, etag string) {
+	if d.cacheFile == "" {
+		return
+	}
+	raw, err := json.Marshal(cacheDocument{ETag: etag, FetchedAt: d.FetchedAt(), Defaults: body})
+	if err == nil {
+		tmp := d.cacheFile + ".tmp"
+		if err = os.MkdirAll(filepath.Dir(d.cacheFile), 0o755); err == nil {
+			if err = os.WriteFile(tmp, raw, 0o644); err == nil {
+				err = os.Rename(tmp, d.cacheFile)
+			}
+		}
+	}
+	if err != nil {
+		d.report(ferrors.WrapOperation(err, TextCodeFetchFailed, "remoteadapter: failed to write cache file", map[string]any{
+			ferrors.MetaAdapter: adapterName,
+			ferrors.MetaPath:    d.cacheFile,
+		}))
+	}
+}
+
+func (d *Defaults) fetchError(err error, status int) error {
+	meta := map[string]any{
+		ferrors.MetaAdapter: adapterName,
+		MetaURL:             d.url,
+	}
+	if status != 0 {
+		meta[MetaStatus] = status
+	}
+	return ferrors.WrapExternal(err, TextCodeFetchFailed, "remoteadapter: failed to fetch defaults", meta)
+}
+
+func (d *Defaults) report(err error) {
+	if d.onError != nil && err != nil {
+		d.onError(err)
+	}
+}
+
+var _ resolver.Defaults = (*Defaults)(nil)
@@ -0,0 +1,57 @@
+package remoteadapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDefaultsRevalidatesWithETagAndFallsBackToCacheFile(t *testing.T) {
+	ctx := context.Background()
+	var fetches, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"users": {"signup": true}}`))
+	}))
+	cacheFile := filepath.Join(t.TempDir(), "defaults.json")
+
+	defaults := NewDefaults(server.URL, WithHeader("Authorization", "Bearer token"), WithCacheFile(cacheFile))
+	if err := defaults.Start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	changed, err := defaults.Refresh(ctx)
+	if err != nil || changed || notModified.Load() != 1 || defaults.ETag() != `"v1"` {
+		t.Fatalf("expected 304 revalidation, got changed=%v err=%v etag=%q", changed, err, defaults.ETag())
+	}
+	result, err := defaults.Default(ctx, "users.signup")
+	if err != nil || !result.Set || !result.Value {
+		t.Fatalf("expected remote default, got %+v (%v)", result, err)
+	}
+	server.Close()
+
+	cold := NewDefaults(server.URL, WithCacheFile(cacheFile))
+	if err := cold.Start(ctx); err != nil {
+		t.Fatalf("expected cache file fallback, got %v", err)
+	}
+	result, _ = cold.Default(ctx, "users.signup")
+	if !result.Set || !result.Value || cold.ETag() != `"v1"` {
+		t.Fatalf("expected cached default, got %+v etag=%q", result, cold.ETag())
+	}
+
+	if err := NewDefaults(server.URL).Start(ctx); err == nil {
+		t.Fatalf("expected error without endpoint or cache file")
+	}
+}
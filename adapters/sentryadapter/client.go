@@ -0,0 +1,282 @@
+package sentryadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// SentryClientName identifies the reporter in the X-Sentry-Auth header.
+const SentryClientName = "go-featuregate/sentryadapter"
+
+// SentryOption customizes Sentry.
+type SentryOption func(*Sentry)
+
+// WithHTTPClient sets the client used for posts. Defaults to a client with a 5s timeout.
+func WithHTTPClient(client *http.Client) SentryOption {
+	return func(s *Sentry) {
+		if s == nil || client == nil {
+			return
+		}
+		s.client = client
+	}
+}
+
+// WithEnvironment sets the environment of every event.
+func WithEnvironment(environment string) SentryOption {
+	return func(s *Sentry) {
+		if s == nil {
+			return
+		}
+		s.environment = environment
+	}
+}
+
+// WithRelease sets the release of every event.
+func WithRelease(release string) SentryOption {
+	return func(s *Sentry) {
+		if s == nil {
+			return
+		}
+		s.release = release
+	}
+}
+
+// WithServerName sets the server name of every event.
+func WithServerName(name string) SentryOption {
+	return func(s *Sentry) {
+		if s == nil {
+			return
+		}
+		s.serverName = name
+	}
+}
+
+// Sentry posts reports to Sentry's envelope endpoint.
+type Sentry struct {
+	endpoint    string
+	dsn         string
+	publicKey   string
+	client      *http.Client
+	environment string
+	release     string
+	serverName  string
+	now         func() time.Time
+}
+
+// NewSentry returns a Reporter for the project identified by dsn, in the form
+// https://<public key>@<host>/<project id>.
+func NewSentry(dsn string, opts ...SentryOption) (*Sentry, error) {
+	endpoint, publicKey, err := parseDSN(dsn)
+	if err != nil {
+		return nil, ferrors.WrapBadInput(err, TextCodeReportFailed, "sentryadapter: invalid DSN", map[string]any{
+			ferrors.MetaAdapter: adapterName,
+		})
+	}
+	s := &Sentry{
+		endpoint:  endpoint,
+		dsn:       dsn,
+		publicKey: publicKey,
+		client:    &http.Client{Timeout: 5 * time.Second},
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s, nil
+}
+
+// Report implements Reporter.
+func (s *Sentry) Report(ctx context.Context, report Report) error {
+	if s == nil || report.Err == nil {
+		return nil
+	}
+	event := s.Event(report)
+	body, err := envelope(s.dsn, event)
+	if err != nil {
+		return ferrors.WrapBadInput(err, TextCodeReportFailed, "sentryadapter: encode event failed", map[string]any{
+			ferrors.MetaAdapter:    adapterName,
+			ferrors.MetaFeatureKey: report.Key,
+		})
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return ferrors.WrapInternal(err, TextCodeReportFailed, "sentryadapter: build request failed", map[string]any{
+			ferrors.MetaAdapter: adapterName,
+		})
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", SentryClientName, s.publicKey))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ferrors.WrapExternal(err, TextCodeReportFailed, "sentryadapter: post failed", map[string]any{
+			ferrors.MetaAdapter:    adapterName,
+			ferrors.MetaFeatureKey: report.Key,
+		})
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return ferrors.NewExternal(TextCodeReportFailed, "sentryadapter: unexpected status "+resp.Status, map[string]any{
+			ferrors.MetaAdapter:    adapterName,
+			ferrors.MetaFeatureKey: report.Key,
+			MetaStatus:             resp.StatusCode,
+		})
+	}
+	return nil
+}
+
+// Event is the Sentry event posted for one report.
+type Event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       Level             `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger"`
+	Message     string            `json:"message"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	User        *EventUser        `json:"user,omitempty"`
+	Fingerprint []string          `json:"fingerprint,omitempty"`
+	Exception   *EventExceptions  `json:"exception,omitempty"`
+}
+
+// EventUser is the user interface of a Sentry event.
+type EventUser struct {
+	ID string `json:"id"`
+}
+
+// EventExceptions is the exception interface of a Sentry event.
+type EventExceptions struct {
+	Values []EventException `json:"values"`
+}
+
+// EventException is one exception of a Sentry event.
+type EventException struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Event converts report to the event Report posts.
+func (s *Sentry) Event(report Report) Event {
+	event := Event{
+		EventID:     newEventID(),
+		Timestamp:   s.now().UTC(),
+		Level:       report.Level,
+		Platform:    "go",
+		Logger:      "featuregate",
+		Message:     fmt.Sprintf("feature %s: %s stage failed", report.Key, report.Stage),
+		Environment: s.environment,
+		Release:     s.release,
+		ServerName:  s.serverName,
+		Tags:        report.Tags,
+		Fingerprint: report.Fingerprint,
+	}
+	if event.Level == "" {
+		event.Level = LevelError
+	}
+	if report.UserID != "" {
+		event.User = &EventUser{ID: report.UserID}
+	}
+	extra := map[string]any{}
+	if len(report.Chain) > 0 {
+		extra["scope_chain"] = chainLabels(report.Chain)
+	}
+	for name, value := range report.Metadata {
+		extra[name] = value
+	}
+	if len(extra) > 0 {
+		event.Extra = extra
+	}
+	if report.Err != nil {
+		errType := fmt.Sprintf("%T", report.Err)
+		if rich, ok := ferrors.As(report.Err); ok && rich.TextCode != "" {
+			errType = rich.TextCode
+		}
+		event.Exception = &EventExceptions{Values: []EventException{{Type: errType, Value: report.Err.Error()}}}
+	}
+	return event
+}
+
+func envelope(dsn string, event Event) ([]byte, error) {
+	header, err := json.Marshal(map[string]any{"event_id": event.EventID, "dsn": dsn, "sent_at": event.Timestamp})
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	item, err := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.WriteByte('\n')
+	buf.Write(item)
+	buf.WriteByte('\n')
+	buf.Write(payload)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func parseDSN(dsn string) (endpoint, publicKey string, err error) {
+	parsed, err := url.Parse(strings.TrimSpace(dsn))
+	if err != nil {
+		return "", "", err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", "", fmt.Errorf("unsupported scheme %q", parsed.Scheme)
+	}
+	if parsed.User == nil || parsed.User.Username() == "" {
+		return "", "", fmt.Errorf("missing public key")
+	}
+	path := strings.Trim(parsed.Path, "/")
+	project := path
+	prefix := ""
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		prefix, project = path[:i+1], path[i+1:]
+	}
+	if project == "" {
+		return "", "", fmt.Errorf("missing project id")
+	}
+	endpoint = fmt.Sprintf("%s://%s/%sapi/%s/envelope/", parsed.Scheme, parsed.Host, prefix, project)
+	return endpoint, parsed.User.Username(), nil
+}
+
+func newEventID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+func chainLabels(chain gate.ScopeChain) []string {
+	labels := make([]string, 0, len(chain))
+	for _, ref := range chain {
+		label := ref.Kind.String()
+		if ref.ID != "" {
+			label += ":" + ref.ID
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+var _ Reporter = (*Sentry)(nil)
@@ -0,0 +1,321 @@
+// Package sentryadapter forwards flag-layer failures to an error reporter, so store,
+// claims, default, and schedule failures reach the on-call dashboard instead of a
+// debug log.
+//
+// Hook implements gate.ResolveHook and turns every failed stage of a resolution into
+// a Report: errors the gate returned (claims failures under resolver.FailClosed,
+// strict-store failures, and default lookups) are reported at LevelError, and
+// failures the gate absorbed by serving a fallback are reported at LevelWarning.
+// Errors downgraded by a ferrors.SeverityPolicy are reported at their severity, and
+// dropped below WithMinSeverity. Reports carry the feature key, stage, and request
+// scope as tags.
+//
+// Sentry implements Reporter by posting events to a Sentry DSN; other services plug
+// in through ReporterFunc:
+//
+//	sentry, err := sentryadapter.NewSentry(os.Getenv("SENTRY_DSN"), sentryadapter.WithEnvironment("production"))
+//	hook := sentryadapter.NewHook(sentry)
+//	fg := resolver.New(resolver.WithResolveHook(hook), resolver.WithAsyncHooks(256, 1))
+//
+// Reporting runs on the hook's goroutine; combine with resolver.WithAsyncHooks to
+// keep it off the resolve path.
+package sentryadapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const adapterName = "sentry"
+
+// Level is the severity of a report.
+type Level string
+
+const (
+	// LevelError marks failures the gate returned to the caller.
+	LevelError Level = "error"
+	// LevelWarning marks failures the gate absorbed by serving a fallback.
+	LevelWarning Level = "warning"
+	// LevelInfo and LevelDebug mark failures a severity policy downgraded.
+	LevelInfo  Level = "info"
+	LevelDebug Level = "debug"
+)
+
+const (
+	// StageResolve marks returned errors that do not belong to a lookup stage, such
+	// as invalid keys.
+	StageResolve = "resolve"
+
+	// Tag names set on every report.
+	TagFeatureKey    = "feature_key"
+	TagStage         = "stage"
+	TagSource        = "source"
+	TagStrict        = "strict"
+	TagTenantID      = "tenant_id"
+	TagOrgID         = "org_id"
+	TagErrorCode     = "error_code"
+	TagErrorCategory = "error_category"
+
+	TextCodeReportFailed = "SENTRY_REPORT_FAILED"
+
+	MetaStatus = "status"
+)
+
+// Report is one flag-layer failure.
+type Report struct {
+	Err   error
+	Level Level
+	// Stage is the resolve stage that failed: one of the gate.Stage* names or
+	// StageResolve.
+	Stage string
+	Key   string
+	// UserID is the user scope of the request, if any.
+	UserID string
+	Chain  gate.ScopeChain
+	Tags   map[string]string
+	// Metadata holds the request identifiers the gate's extractors collected.
+	Metadata map[string]string
+	// Fingerprint groups reports of the same failure.
+	Fingerprint []string
+}
+
+// Reporter receives reports.
+type Reporter interface {
+	Report(ctx context.Context, report Report) error
+}
+
+// ReporterFunc wraps a function as a Reporter.
+type ReporterFunc func(ctx context.Context, report Report) error
+
+// Report implements Reporter.
+func (fn ReporterFunc) Report(ctx context.Context, report Report) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, report)
+}
+
+// Option customizes Hook.
+type Option func(*Hook)
+
+// WithTags adds static tags to every report, for example the service name.
+func WithTags(tags map[string]string) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		for name, value := range tags {
+			h.tags[name] = value
+		}
+	}
+}
+
+// WithAbsorbed toggles reporting failures the gate absorbed by serving a fallback.
+// Defaults to true.
+func WithAbsorbed(enabled bool) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.absorbed = enabled
+	}
+}
+
+// WithMinSeverity drops failures below min, after a ferrors.SeverityPolicy has
+// downgraded them. Defaults to ferrors.SeverityWarning.
+func WithMinSeverity(min ferrors.Severity) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.minSeverity = min
+	}
+}
+
+// WithFilter reports only the failures fn accepts.
+func WithFilter(fn func(Report) bool) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.filter = fn
+	}
+}
+
+// WithOnError registers a callback for reports the reporter failed to deliver.
+func WithOnError(fn func(error)) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.onError = fn
+	}
+}
+
+// Hook reports failed resolve stages.
+type Hook struct {
+	reporter    Reporter
+	tags        map[string]string
+	absorbed    bool
+	minSeverity ferrors.Severity
+	filter      func(Report) bool
+	onError     func(error)
+}
+
+// NewHook returns a Hook reporting to reporter.
+func NewHook(reporter Reporter, opts ...Option) *Hook {
+	h := &Hook{
+		reporter:    reporter,
+		tags:        map[string]string{},
+		absorbed:    true,
+		minSeverity: ferrors.SeverityWarning,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	return h
+}
+
+// OnResolve implements gate.ResolveHook. Delivery failures go to the WithOnError
+// callback.
+func (h *Hook) OnResolve(ctx context.Context, event gate.ResolveEvent) {
+	if h == nil || h.reporter == nil {
+		return
+	}
+	for _, report := range h.Reports(event) {
+		if h.filter != nil && !h.filter(report) {
+			continue
+		}
+		if err := h.reporter.Report(ctx, report); err != nil && h.onError != nil {
+			h.onError(err)
+		}
+	}
+}
+
+// Reports returns the failures recorded in event: the returned error first, then
+// every stage error the gate absorbed, unless WithAbsorbed(false) is set. Each
+// report takes the severity of its error, capped at warning for absorbed failures;
+// reports below WithMinSeverity are dropped.
+func (h *Hook) Reports(event gate.ResolveEvent) []Report {
+	trace := event.Trace
+	stages := []struct {
+		name string
+		err  error
+	}{
+		{gate.StageOverride, trace.Override.Error},
+		{gate.StageDefault, trace.Default.Error},
+		{gate.StageSchedule, trace.Schedule.Error},
+	}
+	var reports []Report
+	if event.Error != nil {
+		stage := StageResolve
+		if rich, ok := ferrors.As(event.Error); ok && rich.TextCode == ferrors.TextCodeScopeResolveFailed {
+			stage = gate.StageClaims
+		}
+		for _, candidate := range stages {
+			if candidate.err == event.Error {
+				stage = candidate.name
+			}
+		}
+		reports = h.appendReport(reports, event, stage, event.Error, ferrors.SeverityOf(event.Error))
+	}
+	if !h.absorbed {
+		return reports
+	}
+	for _, candidate := range stages {
+		if candidate.err == nil || candidate.err == event.Error {
+			continue
+		}
+		severity := min(ferrors.SeverityOf(candidate.err), ferrors.SeverityWarning)
+		reports = h.appendReport(reports, event, candidate.name, candidate.err, severity)
+	}
+	return reports
+}
+
+func (h *Hook) appendReport(reports []Report, event gate.ResolveEvent, stage string, err error, severity ferrors.Severity) []Report {
+	if severity < h.minSeverity {
+		return reports
+	}
+	return append(reports, h.report(event, stage, err, severity))
+}
+
+func (h *Hook) report(event gate.ResolveEvent, stage string, err error, severity ferrors.Severity) Report {
+	key := event.NormalizedKey
+	if key == "" {
+		key = event.Key
+	}
+	tags := make(map[string]string, len(h.tags)+8)
+	for name, value := range h.tags {
+		tags[name] = value
+	}
+	tags[TagFeatureKey] = key
+	tags[TagStage] = stage
+	if event.Source != "" {
+		tags[TagSource] = string(event.Source)
+	}
+	report := Report{
+		Err:      err,
+		Level:    levelFor(severity),
+		Stage:    stage,
+		Key:      key,
+		Chain:    event.Chain,
+		Tags:     tags,
+		Metadata: event.Metadata,
+	}
+	for _, ref := range event.Chain {
+		switch ref.Kind {
+		case gate.ScopeTenant:
+			setOnce(tags, TagTenantID, ref.ID)
+		case gate.ScopeOrg:
+			setOnce(tags, TagOrgID, ref.ID)
+		case gate.ScopeUser:
+			if report.UserID == "" {
+				report.UserID = ref.ID
+			}
+		}
+	}
+	code := ""
+	if rich, ok := ferrors.As(err); ok {
+		code = rich.TextCode
+		if code != "" {
+			tags[TagErrorCode] = code
+		}
+		tags[TagErrorCategory] = rich.Category.String()
+		if strict, ok := rich.Metadata[ferrors.MetaStrict].(bool); ok {
+			tags[TagStrict] = strconv.FormatBool(strict)
+		}
+	}
+	if code == "" {
+		code = stage
+	}
+	report.Fingerprint = []string{"featuregate", stage, code, key}
+	return report
+}
+
+func levelFor(severity ferrors.Severity) Level {
+	switch {
+	case severity >= ferrors.SeverityError:
+		return LevelError
+	case severity == ferrors.SeverityWarning:
+		return LevelWarning
+	case severity == ferrors.SeverityInfo:
+		return LevelInfo
+	}
+	return LevelDebug
+}
+
+func setOnce(tags map[string]string, name, value string) {
+	if value == "" {
+		return
+	}
+	if _, ok := tags[name]; !ok {
+		tags[name] = value
+	}
+}
+
+var _ gate.ResolveHook = (*Hook)(nil)
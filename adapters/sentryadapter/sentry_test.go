@@ -0,0 +1,174 @@
+package sentryadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+type failingStore struct{}
+
+func (failingStore) GetAll(context.Context, string, gate.ScopeChain) ([]store.OverrideMatch, error) {
+	return nil, errors.New("connection refused")
+}
+
+type failingClaims struct{}
+
+func (failingClaims) ClaimsFromContext(context.Context) (gate.ActorClaims, error) {
+	return gate.ActorClaims{}, errors.New("token expired")
+}
+
+func TestHookReportsStoreFailuresWithScopeTags(t *testing.T) {
+	ctx := context.Background()
+	claims := gate.WithClaims(gate.ActorClaims{TenantID: "acme", OrgID: "eng", SubjectID: "u1"})
+	for _, tc := range []struct {
+		name   string
+		strict bool
+		level  Level
+	}{
+		{name: "absorbed", strict: false, level: LevelWarning},
+		{name: "strict", strict: true, level: LevelError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var reports []Report
+			hook := NewHook(ReporterFunc(func(_ context.Context, report Report) error {
+				reports = append(reports, report)
+				return nil
+			}), WithTags(map[string]string{"service": "billing"}))
+			fg := resolver.New(
+				resolver.WithOverrideStore(failingStore{}),
+				resolver.WithStrictStore(tc.strict),
+				resolver.WithResolveHook(hook),
+			)
+			_, _, err := fg.ResolveWithTrace(ctx, "checkout", claims)
+			if (err != nil) != tc.strict {
+				t.Fatalf("unexpected resolve error: %v", err)
+			}
+			if len(reports) != 1 {
+				t.Fatalf("expected one report, got %+v", reports)
+			}
+			report := reports[0]
+			if report.Level != tc.level || report.Stage != gate.StageOverride || report.Key != "checkout" || report.UserID != "u1" {
+				t.Fatalf("unexpected report: %+v", report)
+			}
+			tags := report.Tags
+			if tags[TagTenantID] != "acme" || tags[TagOrgID] != "eng" || tags[TagErrorCode] != ferrors.TextCodeStoreReadFailed ||
+				tags[TagStrict] != map[bool]string{true: "true", false: "false"}[tc.strict] || tags["service"] != "billing" {
+				t.Fatalf("unexpected tags: %v", tags)
+			}
+		})
+	}
+}
+
+func TestHookReportsClaimsFailures(t *testing.T) {
+	var reports []Report
+	hook := NewHook(ReporterFunc(func(_ context.Context, report Report) error {
+		reports = append(reports, report)
+		return nil
+	}))
+	fg := resolver.New(
+		resolver.WithClaimsProvider(failingClaims{}),
+		resolver.WithClaimsFailureMode(resolver.FailClosed),
+		resolver.WithResolveHook(hook),
+	)
+	if _, _, err := fg.ResolveWithTrace(context.Background(), "checkout"); err == nil {
+		t.Fatalf("expected claims error")
+	}
+	if len(reports) != 1 || reports[0].Stage != gate.StageClaims || reports[0].Level != LevelError {
+		t.Fatalf("unexpected reports: %+v", reports)
+	}
+}
+
+func TestSentryPostsEnvelopes(t *testing.T) {
+	var path, auth string
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		auth = r.Header.Get("X-Sentry-Auth")
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSpace(string(body)), "\n")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://public@", 1) + "/42"
+	sentry, err := NewSentry(dsn, WithEnvironment("production"))
+	if err != nil {
+		t.Fatalf("new sentry: %v", err)
+	}
+	err = sentry.Report(context.Background(), Report{
+		Err:    ferrors.WrapExternal(errors.New("down"), ferrors.TextCodeStoreReadFailed, "override store read failed", nil),
+		Level:  LevelWarning,
+		Stage:  gate.StageOverride,
+		Key:    "checkout",
+		UserID: "u1",
+		Tags:   map[string]string{TagFeatureKey: "checkout"},
+	})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if path != "/api/42/envelope/" || !strings.Contains(auth, "sentry_key=public") {
+		t.Fatalf("unexpected request: %s %s", path, auth)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("expected a three-line envelope, got %q", lines)
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(lines[2]), &event); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if event.Level != LevelWarning || event.Environment != "production" || event.User == nil || event.User.ID != "u1" ||
+		event.Tags[TagFeatureKey] != "checkout" || event.Exception == nil || event.Exception.Values[0].Type != ferrors.TextCodeStoreReadFailed {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	if _, err := NewSentry("https://sentry.example.com/42"); err == nil {
+		t.Fatalf("expected a DSN without a public key to fail")
+	}
+}
+
+func TestHookRespectsSeverityPolicy(t *testing.T) {
+	var reports []Report
+	hook := NewHook(ReporterFunc(func(_ context.Context, report Report) error {
+		reports = append(reports, report)
+		return nil
+	}))
+	policy := ferrors.NewSeverityPolicy(ferrors.DowngradeNonStrict(ferrors.TextCodeStoreReadFailed, ferrors.SeverityInfo))
+	fg := resolver.New(
+		resolver.WithOverrideStore(failingStore{}),
+		resolver.WithSeverityPolicy(policy),
+		resolver.WithResolveHook(hook),
+	)
+	if _, _, err := fg.ResolveWithTrace(context.Background(), "checkout"); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if len(reports) != 0 {
+		t.Fatalf("expected downgraded failures dropped, got %+v", reports)
+	}
+
+	hook = NewHook(ReporterFunc(func(_ context.Context, report Report) error {
+		reports = append(reports, report)
+		return nil
+	}), WithMinSeverity(ferrors.SeverityInfo))
+	fg = resolver.New(
+		resolver.WithOverrideStore(failingStore{}),
+		resolver.WithSeverityPolicy(policy),
+		resolver.WithResolveHook(hook),
+	)
+	if _, _, err := fg.ResolveWithTrace(context.Background(), "checkout"); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if len(reports) != 1 || reports[0].Level != LevelInfo {
+		t.Fatalf("expected one info report, got %+v", reports)
+	}
+}
@@ -0,0 +1,335 @@
+// Package slogadapter logs feature flag activity with log/slog, for services that
+// do not use go-logger.
+//
+// Hook implements gate.ResolveHook and activity.Hook and writes one record per event
+// with typed attributes. Scope fields are nested in groups, and every attribute sits
+// under a "featuregate" group unless WithGroup changes it:
+//
+//	hook := slogadapter.New(slog.Default())
+//	fg := resolver.New(resolver.WithResolveHook(hook), resolver.WithActivityHook(hook))
+//
+// Successful resolutions log at Debug, failed ones at Error, degraded ones at Warn,
+// override updates at Info, and rollbacks at Warn. Errors downgraded by a
+// ferrors.SeverityPolicy log at the level of their severity. Records below the
+// handler's level are skipped before any attribute is built.
+package slogadapter
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const (
+	// DefaultGroup holds every attribute the hook writes.
+	DefaultGroup = "featuregate"
+
+	MessageResolved      = "feature resolved"
+	MessageResolveFailed = "feature resolve failed"
+	MessageUpdated       = "feature override updated"
+)
+
+// Option customizes Hook.
+type Option func(*Hook)
+
+// WithGroup sets the group holding every attribute. An empty name writes the
+// attributes at the top level.
+func WithGroup(name string) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.group = name
+	}
+}
+
+// WithResolveLevel sets the level of successful resolutions. Defaults to Debug.
+func WithResolveLevel(level slog.Level) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.resolveLevel = level
+	}
+}
+
+// WithDegradedLevel sets the level of resolutions served from a degraded source.
+// Defaults to Warn.
+func WithDegradedLevel(level slog.Level) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.degradedLevel = level
+	}
+}
+
+// WithErrorLevel sets the level of failed resolutions whose error has at least
+// ferrors.SeverityError. Defaults to Error.
+func WithErrorLevel(level slog.Level) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.errorLevel = level
+	}
+}
+
+// WithUpdateLevel sets the level of override updates. Defaults to Info; rollbacks
+// log at Warn unless WithActionLevel changes them.
+func WithUpdateLevel(level slog.Level) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		h.updateLevel = level
+	}
+}
+
+// WithActionLevel sets the level of override updates with the given action.
+func WithActionLevel(action activity.Action, level slog.Level) Option {
+	return func(h *Hook) {
+		if h == nil {
+			return
+		}
+		if h.actionLevels == nil {
+			h.actionLevels = map[activity.Action]slog.Level{}
+		}
+		h.actionLevels[action] = level
+	}
+}
+
+// Hook writes resolve and update events to a slog.Logger.
+type Hook struct {
+	logger        *slog.Logger
+	group         string
+	resolveLevel  slog.Level
+	degradedLevel slog.Level
+	errorLevel    slog.Level
+	updateLevel   slog.Level
+	actionLevels  map[activity.Action]slog.Level
+}
+
+// New returns a Hook writing to logger, or to slog.Default() when logger is nil.
+func New(logger *slog.Logger, opts ...Option) *Hook {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	h := &Hook{
+		logger:        logger,
+		group:         DefaultGroup,
+		resolveLevel:  slog.LevelDebug,
+		degradedLevel: slog.LevelWarn,
+		errorLevel:    slog.LevelError,
+		updateLevel:   slog.LevelInfo,
+		actionLevels:  map[activity.Action]slog.Level{activity.ActionRollback: slog.LevelWarn},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	return h
+}
+
+// OnResolve implements gate.ResolveHook.
+func (h *Hook) OnResolve(ctx context.Context, event gate.ResolveEvent) {
+	if h == nil {
+		return
+	}
+	level, msg := h.resolveLevel, MessageResolved
+	switch {
+	case event.Error != nil:
+		level, msg = h.errorLevel, MessageResolveFailed
+		switch severity := ferrors.SeverityOf(event.Error); {
+		case severity <= ferrors.SeverityDebug:
+			level = slog.LevelDebug
+		case severity == ferrors.SeverityInfo:
+			level = slog.LevelInfo
+		case severity == ferrors.SeverityWarning:
+			level = slog.LevelWarn
+		}
+	case event.Source == gate.ResolveSourceDegraded:
+		level = h.degradedLevel
+	}
+	if !h.logger.Enabled(ctx, level) {
+		return
+	}
+	h.log(ctx, level, msg, ResolveAttrs(event))
+}
+
+// OnUpdate implements activity.Hook.
+func (h *Hook) OnUpdate(ctx context.Context, event activity.UpdateEvent) {
+	if h == nil {
+		return
+	}
+	level, ok := h.actionLevels[event.Action]
+	if !ok {
+		level = h.updateLevel
+	}
+	if !h.logger.Enabled(ctx, level) {
+		return
+	}
+	h.log(ctx, level, MessageUpdated, UpdateAttrs(event))
+}
+
+func (h *Hook) log(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr) {
+	if h.group != "" {
+		attrs = []slog.Attr{{Key: h.group, Value: slog.GroupValue(attrs...)}}
+	}
+	h.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// ResolveAttrs returns the attributes logged for a resolve event: the key, value,
+// and source, the request's tenant, org, and user under "scope", the matching
+// override's scope under "match", the error with its text code and category, and
+// the event metadata under "meta".
+func ResolveAttrs(event gate.ResolveEvent) []slog.Attr {
+	key := event.NormalizedKey
+	if key == "" {
+		key = event.Key
+	}
+	attrs := []slog.Attr{
+		slog.String("key", key),
+		slog.Bool("value", event.Value),
+		slog.String("source", string(event.Source)),
+	}
+	if scope := chainAttrs(event.Chain); len(scope) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "scope", Value: slog.GroupValue(scope...)})
+	}
+	if event.Source == gate.ResolveSourceOverride {
+		attrs = append(attrs, scopeAttr("match", event.Trace.Override.Match))
+	}
+	if event.Error != nil {
+		attrs = append(attrs, errorAttrs(event.Error)...)
+	}
+	if len(event.Metadata) > 0 {
+		attrs = append(attrs, metadataAttr(event.Metadata))
+	}
+	return attrs
+}
+
+// UpdateAttrs returns the attributes logged for an update event: the key, action,
+// and event ID, the scope and actor as groups, the new value or limit, the expiry
+// and reason, and the reload diff counts under "diff".
+func UpdateAttrs(event activity.UpdateEvent) []slog.Attr {
+	key := event.NormalizedKey
+	if key == "" && event.Key != "" {
+		key = gate.NormalizeKey(event.Key)
+	}
+	attrs := []slog.Attr{slog.String("action", string(event.Action))}
+	if key != "" {
+		attrs = append(attrs, slog.String("key", key))
+	}
+	if event.ID != "" {
+		attrs = append(attrs, slog.String("id", event.ID))
+	}
+	if event.Action != activity.ActionReload {
+		attrs = append(attrs, scopeAttr("scope", event.Scope))
+	}
+	if event.Actor != (gate.ActorRef{}) {
+		attrs = append(attrs, slog.Group("actor",
+			slog.String("id", event.Actor.ID),
+			slog.String("type", event.Actor.Type),
+			slog.String("name", event.Actor.Name),
+		))
+	}
+	if event.Value != nil {
+		attrs = append(attrs, slog.Bool("value", *event.Value))
+	}
+	if event.Limit != nil {
+		attrs = append(attrs, slog.Int64("limit", *event.Limit))
+	}
+	if !event.ExpiresAt.IsZero() {
+		attrs = append(attrs, slog.Time("expires_at", event.ExpiresAt))
+	}
+	if event.Metadata.Reason != "" {
+		attrs = append(attrs, slog.String("reason", event.Metadata.Reason))
+	}
+	if event.Diff != nil {
+		attrs = append(attrs, slog.Group("diff",
+			slog.Int("added", len(event.Diff.Added)),
+			slog.Int("removed", len(event.Diff.Removed)),
+			slog.Int("changed", len(event.Diff.Changed)),
+		))
+	}
+	return attrs
+}
+
+func scopeAttr(name string, ref gate.ScopeRef) slog.Attr {
+	attrs := []slog.Attr{slog.String("kind", ref.Kind.String())}
+	if ref.ID != "" {
+		attrs = append(attrs, slog.String("id", ref.ID))
+	}
+	if ref.TenantID != "" {
+		attrs = append(attrs, slog.String("tenant_id", ref.TenantID))
+	}
+	if ref.OrgID != "" {
+		attrs = append(attrs, slog.String("org_id", ref.OrgID))
+	}
+	return slog.Attr{Key: name, Value: slog.GroupValue(attrs...)}
+}
+
+func chainAttrs(chain gate.ScopeChain) []slog.Attr {
+	var tenant, org, user string
+	for _, ref := range chain {
+		switch ref.Kind {
+		case gate.ScopeTenant:
+			if tenant == "" {
+				tenant = ref.ID
+			}
+		case gate.ScopeOrg:
+			if org == "" {
+				org = ref.ID
+			}
+		case gate.ScopeUser:
+			if user == "" {
+				user = ref.ID
+			}
+		}
+	}
+	var attrs []slog.Attr
+	if tenant != "" {
+		attrs = append(attrs, slog.String("tenant_id", tenant))
+	}
+	if org != "" {
+		attrs = append(attrs, slog.String("org_id", org))
+	}
+	if user != "" {
+		attrs = append(attrs, slog.String("user_id", user))
+	}
+	return attrs
+}
+
+func errorAttrs(err error) []slog.Attr {
+	attrs := []slog.Attr{slog.String("error", err.Error())}
+	if rich, ok := ferrors.As(err); ok {
+		if rich.TextCode != "" {
+			attrs = append(attrs, slog.String("error_code", rich.TextCode))
+		}
+		attrs = append(attrs, slog.String("error_category", rich.Category.String()))
+	}
+	return attrs
+}
+
+func metadataAttr(meta map[string]string) slog.Attr {
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, slog.String(key, meta[key]))
+	}
+	return slog.Attr{Key: "meta", Value: slog.GroupValue(attrs...)}
+}
+
+var (
+	_ gate.ResolveHook = (*Hook)(nil)
+	_ activity.Hook    = (*Hook)(nil)
+)
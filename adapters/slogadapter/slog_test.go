@@ -0,0 +1,112 @@
+package slogadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestHookLogsResolvesAndUpdatesWithNestedScopes(t *testing.T) {
+	var buf bytes.Buffer
+	hook := New(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	fg := resolver.New(
+		resolver.WithOverrideStore(store.NewMemoryStore()),
+		resolver.WithResolveHook(hook),
+		resolver.WithActivityHook(hook),
+	)
+	ctx := context.Background()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	if err := fg.Set(ctx, "billing.v2", tenant, true, gate.ActorRef{ID: "u1", Type: "user"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	claims := gate.WithClaims(gate.ActorClaims{TenantID: "acme", SubjectID: "u2"})
+	if _, _, err := fg.ResolveWithTrace(ctx, "billing.v2", claims); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	records := decodeRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("expected an update and a resolve record, got %d: %s", len(records), buf.String())
+	}
+	update, resolve := records[0], records[1]
+	if update["level"] != "INFO" || update["msg"] != MessageUpdated {
+		t.Fatalf("unexpected update record: %v", update)
+	}
+	fields := update[DefaultGroup].(map[string]any)
+	scope := fields["scope"].(map[string]any)
+	actor := fields["actor"].(map[string]any)
+	if fields["action"] != "set" || fields["key"] != "billing.v2" || fields["value"] != true ||
+		scope["kind"] != "tenant" || scope["id"] != "acme" || actor["type"] != "user" {
+		t.Fatalf("unexpected update fields: %v", fields)
+	}
+	if resolve["level"] != "DEBUG" || resolve["msg"] != MessageResolved {
+		t.Fatalf("unexpected resolve record: %v", resolve)
+	}
+	fields = resolve[DefaultGroup].(map[string]any)
+	scope = fields["scope"].(map[string]any)
+	match := fields["match"].(map[string]any)
+	if fields["value"] != true || fields["source"] != "override" ||
+		scope["tenant_id"] != "acme" || scope["user_id"] != "u2" || match["kind"] != "tenant" {
+		t.Fatalf("unexpected resolve fields: %v", fields)
+	}
+}
+
+func TestHookMapsLevels(t *testing.T) {
+	var buf bytes.Buffer
+	hook := New(slog.New(slog.NewJSONHandler(&buf, nil)), WithGroup(""), WithActionLevel(activity.ActionUnset, slog.LevelWarn))
+	ctx := context.Background()
+
+	hook.OnResolve(ctx, gate.ResolveEvent{NormalizedKey: "checkout", Source: gate.ResolveSourceDefault})
+	hook.OnResolve(ctx, gate.ResolveEvent{NormalizedKey: "checkout", Source: gate.ResolveSourceDegraded})
+	hook.OnResolve(ctx, gate.ResolveEvent{
+		NormalizedKey: "checkout",
+		Source:        gate.ResolveSourceFallback,
+		Error:         ferrors.WrapExternal(errors.New("down"), ferrors.TextCodeStoreReadFailed, "store read failed", nil),
+		Metadata:      map[string]string{"request_id": "r1"},
+	})
+	hook.OnUpdate(ctx, activity.UpdateEvent{Key: "checkout", Action: activity.ActionRollback})
+	hook.OnUpdate(ctx, activity.UpdateEvent{Key: "checkout", Action: activity.ActionUnset})
+
+	records := decodeRecords(t, &buf)
+	if len(records) != 4 {
+		t.Fatalf("expected the debug resolve to be skipped, got %d records: %s", len(records), buf.String())
+	}
+	levels := []string{"WARN", "ERROR", "WARN", "WARN"}
+	for i, record := range records {
+		if record["level"] != levels[i] {
+			t.Fatalf("record %d: expected level %s, got %v", i, levels[i], record)
+		}
+	}
+	failed := records[1]
+	meta := failed["meta"].(map[string]any)
+	if failed["msg"] != MessageResolveFailed || failed["error_code"] != ferrors.TextCodeStoreReadFailed ||
+		failed["error_category"] == "" || meta["request_id"] != "r1" {
+		t.Fatalf("unexpected error record: %v", failed)
+	}
+}
@@ -0,0 +1,235 @@
+// Package unleashadapter exposes a feature gate through the call shape of the Unleash
+// Go client, so code written against client.IsEnabled(name, unleash.WithContext(...))
+// can switch to go-featuregate by swapping the client.
+//
+// Unleash activation strategies map onto scope overrides: "default" is the system
+// scope (or the config default), "userWithId" is a user override, and tenant, org,
+// role, permission, and environment targeting come from the matching Context fields
+// and properties. Percentage rollouts and variants have no equivalent and are not
+// emulated.
+package unleashadapter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// Property names read from Context.Properties.
+const (
+	PropertyTenantID    = "tenantId"
+	PropertyOrgID       = "orgId"
+	PropertyRoles       = "roles"
+	PropertyPermissions = "permissions"
+)
+
+// Context mirrors the Unleash evaluation context.
+type Context struct {
+	UserId        string
+	SessionId     string
+	RemoteAddress string
+	Environment   string
+	AppName       string
+	Properties    map[string]string
+}
+
+// FeatureOption customizes a single IsEnabled call.
+type FeatureOption func(*featureOptions)
+
+type featureOptions struct {
+	ctx          *Context
+	goCtx        context.Context
+	fallback     *bool
+	fallbackFunc func(feature string, ctx *Context) bool
+}
+
+// WithContext sets the evaluation context for the call.
+func WithContext(ctx Context) FeatureOption {
+	return func(opts *featureOptions) {
+		if opts == nil {
+			return
+		}
+		opts.ctx = &ctx
+	}
+}
+
+// WithFallback sets the value returned when the gate fails.
+func WithFallback(fallback bool) FeatureOption {
+	return func(opts *featureOptions) {
+		if opts == nil {
+			return
+		}
+		opts.fallback = &fallback
+	}
+}
+
+// WithFallbackFunc computes the value returned when the gate fails.
+func WithFallbackFunc(fn func(feature string, ctx *Context) bool) FeatureOption {
+	return func(opts *featureOptions) {
+		if opts == nil || fn == nil {
+			return
+		}
+		opts.fallbackFunc = fn
+	}
+}
+
+// WithRequestContext passes a Go context to the gate for cancellation and request
+// values. The Unleash API has no equivalent; without it the client's base context is used.
+func WithRequestContext(ctx context.Context) FeatureOption {
+	return func(opts *featureOptions) {
+		if opts == nil || ctx == nil {
+			return
+		}
+		opts.goCtx = ctx
+	}
+}
+
+// ErrorHandler receives gate errors, which IsEnabled otherwise swallows like the Unleash client.
+type ErrorHandler func(feature string, err error)
+
+// Option customizes a Client.
+type Option func(*Client)
+
+// WithErrorHandler registers a handler for gate errors.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(c *Client) {
+		if c == nil {
+			return
+		}
+		c.onError = handler
+	}
+}
+
+// WithBaseContext sets the Go context used when a call has no WithRequestContext.
+func WithBaseContext(ctx context.Context) Option {
+	return func(c *Client) {
+		if c == nil || ctx == nil {
+			return
+		}
+		c.base = ctx
+	}
+}
+
+// WithChainMapper replaces Chain for converting an Unleash context into a scope chain.
+func WithChainMapper(mapper func(Context) gate.ScopeChain) Option {
+	return func(c *Client) {
+		if c == nil || mapper == nil {
+			return
+		}
+		c.chain = mapper
+	}
+}
+
+// Client answers Unleash-style feature checks from a feature gate.
+type Client struct {
+	gate    gate.FeatureGate
+	base    context.Context
+	chain   func(Context) gate.ScopeChain
+	onError ErrorHandler
+}
+
+// NewClient wraps a feature gate.
+func NewClient(fg gate.FeatureGate, opts ...Option) *Client {
+	c := &Client{gate: fg, base: context.Background(), chain: Chain}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// IsEnabled reports whether feature is enabled. Without WithContext the gate derives
+// scope from the Go context; gate errors return the fallback (false by default).
+func (c *Client) IsEnabled(feature string, options ...FeatureOption) bool {
+	opts := featureOptions{}
+	for _, opt := range options {
+		if opt != nil {
+			opt(&opts)
+		}
+	}
+	if c == nil || c.gate == nil {
+		return fallbackValue(feature, opts)
+	}
+	ctx := opts.goCtx
+	if ctx == nil {
+		ctx = c.base
+	}
+	var resolveOpts []gate.ResolveOption
+	if opts.ctx != nil {
+		resolveOpts = append(resolveOpts, gate.WithScopeChain(c.chain(*opts.ctx)))
+	}
+	enabled, err := c.gate.Enabled(ctx, feature, resolveOpts...)
+	if err != nil {
+		if c.onError != nil {
+			c.onError(feature, err)
+		}
+		return fallbackValue(feature, opts)
+	}
+	return enabled
+}
+
+// Close implements the Unleash client lifecycle; the gate needs no shutdown.
+func (c *Client) Close() error {
+	return nil
+}
+
+// Chain converts an Unleash context into a scope chain in the resolver's default order:
+// user, roles, permissions, org, tenant, environment, system. Roles and permissions are
+// comma-separated properties.
+func Chain(uctx Context) gate.ScopeChain {
+	tenantID := property(uctx, PropertyTenantID)
+	orgID := property(uctx, PropertyOrgID)
+	chain := make(gate.ScopeChain, 0, 6)
+	if userID := strings.TrimSpace(uctx.UserId); userID != "" {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeUser, ID: userID, TenantID: tenantID, OrgID: orgID})
+	}
+	for _, role := range splitList(property(uctx, PropertyRoles)) {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeRole, ID: role})
+	}
+	for _, perm := range splitList(property(uctx, PropertyPermissions)) {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopePerm, ID: perm})
+	}
+	if orgID != "" {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeOrg, ID: orgID, TenantID: tenantID, OrgID: orgID})
+	}
+	if tenantID != "" {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeTenant, ID: tenantID, TenantID: tenantID})
+	}
+	if env := strings.TrimSpace(uctx.Environment); env != "" {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeEnv, ID: env})
+	}
+	return append(chain, gate.ScopeRef{Kind: gate.ScopeSystem})
+}
+
+func fallbackValue(feature string, opts featureOptions) bool {
+	if opts.fallbackFunc != nil {
+		return opts.fallbackFunc(feature, opts.ctx)
+	}
+	if opts.fallback != nil {
+		return *opts.fallback
+	}
+	return false
+}
+
+func property(uctx Context, name string) string {
+	if uctx.Properties == nil {
+		return ""
+	}
+	return strings.TrimSpace(uctx.Properties[name])
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
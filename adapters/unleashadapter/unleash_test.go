@@ -0,0 +1,53 @@
+package unleashadapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestClientMapsUnleashContextToScopes(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"checkout.v2": false})),
+		resolver.WithOverrideStore(overrides),
+	)
+	actor := gate.ActorRef{ID: "admin"}
+	if err := fg.Set(ctx, "checkout.v2", gate.ScopeRef{Kind: gate.ScopeRole, ID: "beta"}, true, actor); err != nil {
+		t.Fatalf("set role override: %v", err)
+	}
+	if err := fg.Set(ctx, "checkout.v2", gate.ScopeRef{Kind: gate.ScopeEnv, ID: "staging"}, true, actor); err != nil {
+		t.Fatalf("set env override: %v", err)
+	}
+	client := NewClient(fg)
+
+	if client.IsEnabled("checkout.v2", WithContext(Context{UserId: "u-1"})) {
+		t.Fatalf("expected default for plain user")
+	}
+	if !client.IsEnabled("checkout.v2", WithContext(Context{UserId: "u-1", Properties: map[string]string{PropertyRoles: "staff, beta"}})) {
+		t.Fatalf("expected role override to enable")
+	}
+	if !client.IsEnabled("checkout.v2", WithContext(Context{Environment: "staging"})) {
+		t.Fatalf("expected env override to enable")
+	}
+}
+
+func TestClientReturnsFallbackOnError(t *testing.T) {
+	var reported error
+	client := NewClient(failingGate{}, WithErrorHandler(func(_ string, err error) { reported = err }))
+	if !client.IsEnabled("checkout.v2", WithFallback(true)) || reported == nil {
+		t.Fatalf("expected fallback and reported error, got %v", reported)
+	}
+}
+
+type failingGate struct{}
+
+func (failingGate) Enabled(context.Context, string, ...gate.ResolveOption) (bool, error) {
+	return false, errors.New("store down")
+}
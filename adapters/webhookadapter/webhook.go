@@ -0,0 +1,688 @@
+// Package webhookadapter posts feature flag activity to an HTTP endpoint, for example
+// a Slack incoming webhook or an ops notification service.
+//
+// Sink implements activity.Hook for override updates and gate.ResolveHook for
+// resolve errors (opt in with WithResolveErrors). Events are queued and posted in
+// batches from a background loop, one batch per tick, so hooks never wait on the
+// network; failed posts are retried with exponential backoff, and a batch that still
+// fails with a retryable error stays at the head of the queue for the next tick.
+// With WithSecret every request carries an HMAC-SHA256 signature of
+// "<timestamp>.<body>" that receivers check with Verify or VerifyAt.
+package webhookadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const adapterName = "webhook"
+
+const (
+	// DefaultBatchSize caps the events posted per request.
+	DefaultBatchSize = 20
+	// DefaultFlushInterval is the delay between background flushes.
+	DefaultFlushInterval = time.Second
+	// DefaultMaxPending caps the events held in memory; newer events are dropped.
+	DefaultMaxPending = 1000
+	// DefaultMaxAttempts is the number of tries per batch.
+	DefaultMaxAttempts = 3
+	// DefaultRetryBackoff is the delay before the first retry; it doubles per attempt.
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// SignatureHeader carries "sha256=<hex>" when a secret is configured.
+	SignatureHeader = "X-Featuregate-Signature"
+	// TimestampHeader carries the Unix time included in the signature.
+	TimestampHeader = "X-Featuregate-Timestamp"
+	// DefaultSignatureTolerance is how far a signed timestamp may be from the
+	// receiver's clock in Verify.
+	DefaultSignatureTolerance = 5 * time.Minute
+
+	TextCodeDeliveryFailed = "WEBHOOK_DELIVERY_FAILED"
+
+	MetaURL      = "url"
+	MetaStatus   = "status"
+	MetaAttempts = "attempts"
+)
+
+// EventType distinguishes update events from resolve errors.
+type EventType string
+
+const (
+	EventUpdate       EventType = "update"
+	EventResolveError EventType = "resolve_error"
+)
+
+// Scope is the JSON form of gate.ScopeRef.
+type Scope struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+	OrgID    string `json:"org_id,omitempty"`
+}
+
+// Actor is the JSON form of gate.ActorRef.
+type Actor struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Diff is the JSON form of activity.DiffSummary.
+type Diff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+// Event is one delivered activity record.
+type Event struct {
+	Type      EventType              `json:"type"`
+	ID        string                 `json:"id,omitempty"`
+	Time      time.Time              `json:"time"`
+	Key       string                 `json:"key,omitempty"`
+	Action    string                 `json:"action,omitempty"`
+	Scope     *Scope                 `json:"scope,omitempty"`
+	Actor     *Actor                 `json:"actor,omitempty"`
+	Value     *bool                  `json:"value,omitempty"`
+	Limit     *int64                 `json:"limit,omitempty"`
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+	Metadata  *gate.OverrideMetadata `json:"metadata,omitempty"`
+	Diff      *Diff                  `json:"diff,omitempty"`
+	Source    string                 `json:"source,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// Payload is the body JSONEncoder posts.
+type Payload struct {
+	Events []Event `json:"events"`
+}
+
+// Encoder renders a batch into a request body.
+type Encoder func(events []Event) ([]byte, error)
+
+// JSONEncoder posts {"events": [...]}.
+func JSONEncoder(events []Event) ([]byte, error) {
+	return json.Marshal(Payload{Events: events})
+}
+
+// SlackEncoder posts a Slack incoming webhook message with one line per event.
+func SlackEncoder(events []Event) ([]byte, error) {
+	lines := make([]string, 0, len(events))
+	for _, event := range events {
+		lines = append(lines, describe(event))
+	}
+	return json.Marshal(map[string]string{"text": strings.Join(lines, "\n")})
+}
+
+// Option customizes Sink.
+type Option func(*Sink)
+
+// WithHTTPClient sets the client used for posts. Defaults to a client with a 10s timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Sink) {
+		if s == nil || client == nil {
+			return
+		}
+		s.client = client
+	}
+}
+
+// WithHeader adds a request header, for example an authorization token.
+func WithHeader(name, value string) Option {
+	return func(s *Sink) {
+		if s == nil || name == "" {
+			return
+		}
+		s.header.Add(name, value)
+	}
+}
+
+// WithSecret signs every request with HMAC-SHA256.
+func WithSecret(secret []byte) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.secret = append([]byte(nil), secret...)
+	}
+}
+
+// WithEncoder sets how batches are rendered. Defaults to JSONEncoder.
+func WithEncoder(encoder Encoder) Option {
+	return func(s *Sink) {
+		if s == nil || encoder == nil {
+			return
+		}
+		s.encoder = encoder
+	}
+}
+
+// WithBatchSize caps the events posted per request. A full batch is flushed
+// without waiting for the interval.
+func WithBatchSize(size int) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.batchSize = size
+	}
+}
+
+// WithFlushInterval sets the delay between background flushes.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.interval = interval
+	}
+}
+
+// WithMaxPending caps the events held in memory. Events beyond the cap are dropped
+// and counted by Dropped.
+func WithMaxPending(limit int) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.maxPending = limit
+	}
+}
+
+// WithRetry sets the tries per batch and the initial backoff. Network errors, 429,
+// and 5xx responses are retried.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.maxAttempts = maxAttempts
+		s.backoff = backoff
+	}
+}
+
+// WithResolveErrors also delivers resolve events that failed. Errors a
+// ferrors.SeverityPolicy downgraded below ferrors.SeverityError are skipped.
+func WithResolveErrors(enabled bool) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.resolveErrors = enabled
+	}
+}
+
+// WithFilter delivers only the events fn accepts, e.g. production tenants.
+func WithFilter(fn func(Event) bool) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.filter = fn
+	}
+}
+
+// WithOnError registers a callback for batches that failed after every retry.
+func WithOnError(fn func(error)) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.onError = fn
+	}
+}
+
+// WithClock sets the clock used for event and signature timestamps.
+func WithClock(c clock.Clock) Option {
+	return func(s *Sink) {
+		if s == nil {
+			return
+		}
+		s.now = clock.NowFunc(c)
+	}
+}
+
+// Sink posts activity events to a webhook URL.
+type Sink struct {
+	url           string
+	client        *http.Client
+	header        http.Header
+	secret        []byte
+	encoder       Encoder
+	batchSize     int
+	interval      time.Duration
+	maxPending    int
+	maxAttempts   int
+	backoff       time.Duration
+	resolveErrors bool
+	filter        func(Event) bool
+	onError       func(error)
+	now           func() time.Time
+
+	mu      sync.Mutex
+	pending []Event
+	closed  bool
+	flushMu sync.Mutex
+	kick    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+// NewSink constructs a Sink posting to url and starts its background loop. Call
+// Close on shutdown to deliver queued events.
+func NewSink(url string, opts ...Option) *Sink {
+	s := &Sink{
+		url:         url,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		header:      http.Header{},
+		encoder:     JSONEncoder,
+		batchSize:   DefaultBatchSize,
+		interval:    DefaultFlushInterval,
+		maxPending:  DefaultMaxPending,
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultRetryBackoff,
+		now:         time.Now,
+		kick:        make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.batchSize < 1 {
+		s.batchSize = DefaultBatchSize
+	}
+	if s.interval <= 0 {
+		s.interval = DefaultFlushInterval
+	}
+	if s.maxPending < 1 {
+		s.maxPending = DefaultMaxPending
+	}
+	if s.maxAttempts < 1 {
+		s.maxAttempts = 1
+	}
+	if s.backoff < 0 {
+		s.backoff = 0
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	go s.loop()
+	return s
+}
+
+// OnUpdate implements activity.Hook.
+func (s *Sink) OnUpdate(_ context.Context, event activity.UpdateEvent) {
+	s.enqueue(s.updateEvent(event))
+}
+
+// OnResolve implements gate.ResolveHook. Only failed resolutions are delivered, and
+// only with WithResolveErrors.
+func (s *Sink) OnResolve(_ context.Context, event gate.ResolveEvent) {
+	if !s.resolveErrors || event.Error == nil || ferrors.SeverityOf(event.Error) < ferrors.SeverityError {
+		return
+	}
+	s.enqueue(Event{
+		Type:   EventResolveError,
+		Time:   s.now(),
+		Key:    event.NormalizedKey,
+		Source: string(event.Source),
+		Error:  event.Error.Error(),
+	})
+}
+
+// Pending reports the number of queued events.
+func (s *Sink) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// Dropped reports events discarded because the queue was full, the sink closed, or
+// Close could not deliver them.
+func (s *Sink) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Flush posts every queued event, one batch at a time, and stops at the first batch
+// that fails after every retry. That batch is reported to the error callback; it
+// stays queued when the failure is retryable and is discarded otherwise.
+func (s *Sink) Flush(ctx context.Context) error {
+	for {
+		sent, err := s.flushBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if sent == 0 {
+			return nil
+		}
+	}
+}
+
+// Close stops the background loop and delivers the remaining events. Events that
+// cannot be delivered are counted in Dropped.
+func (s *Sink) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+	close(s.stop)
+	<-s.done
+	err := s.Flush(ctx)
+	s.mu.Lock()
+	s.dropped.Add(uint64(len(s.pending)))
+	s.pending = nil
+	s.mu.Unlock()
+	return err
+}
+
+func (s *Sink) enqueue(event Event) {
+	if s == nil {
+		return
+	}
+	if s.filter != nil && !s.filter(event) {
+		return
+	}
+	s.mu.Lock()
+	if s.closed || len(s.pending) >= s.maxPending {
+		s.mu.Unlock()
+		s.dropped.Add(1)
+		return
+	}
+	s.pending = append(s.pending, event)
+	full := len(s.pending) >= s.batchSize
+	s.mu.Unlock()
+	if full {
+		select {
+		case s.kick <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *Sink) loop() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		case <-s.kick:
+		}
+		ctx, cancel := s.batchContext()
+		_, err := s.flushBatch(ctx)
+		cancel()
+		if err == nil && s.Pending() >= s.batchSize {
+			select {
+			case s.kick <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// batchContext bounds one batch: every attempt at the client timeout plus the
+// backoff between them. Without a client timeout the batch is unbounded.
+func (s *Sink) batchContext() (context.Context, context.CancelFunc) {
+	if s.client.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	budget := time.Duration(s.maxAttempts) * s.client.Timeout
+	for attempt := 1; attempt < s.maxAttempts; attempt++ {
+		budget += s.backoff << (attempt - 1)
+	}
+	return context.WithTimeout(context.Background(), budget)
+}
+
+func (s *Sink) flushBatch(ctx context.Context) (int, error) {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	n := min(len(s.pending), s.batchSize)
+	if n == 0 {
+		s.mu.Unlock()
+		return 0, nil
+	}
+	batch := append([]Event(nil), s.pending[:n]...)
+	s.pending = append(s.pending[:0], s.pending[n:]...)
+	s.mu.Unlock()
+
+	retry, err := s.send(ctx, batch)
+	if err == nil {
+		return n, nil
+	}
+	if retry {
+		s.requeue(batch)
+	}
+	if s.onError != nil {
+		s.onError(err)
+	}
+	return n, err
+}
+
+// requeue puts a failed batch back at the head of the queue. Events beyond
+// maxPending are dropped from the tail, newest first.
+func (s *Sink) requeue(batch []Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(batch, s.pending...)
+	if over := len(s.pending) - s.maxPending; over > 0 {
+		s.pending = s.pending[:s.maxPending]
+		s.dropped.Add(uint64(over))
+	}
+}
+
+// send posts one batch, retrying transient failures. It reports whether the last
+// failure was retryable.
+func (s *Sink) send(ctx context.Context, batch []Event) (bool, error) {
+	body, err := s.encoder(batch)
+	if err != nil {
+		return false, ferrors.WrapBadInput(err, TextCodeDeliveryFailed, "webhookadapter: encode events failed", map[string]any{
+			ferrors.MetaAdapter: adapterName,
+		})
+	}
+	var lastErr error
+	var retry bool
+	status, attempt := 0, 1
+	for ; attempt <= s.maxAttempts; attempt++ {
+		status, retry, lastErr = s.post(ctx, body)
+		if lastErr == nil {
+			return false, nil
+		}
+		if !retry || attempt == s.maxAttempts {
+			break
+		}
+		timer := time.NewTimer(s.backoff << (attempt - 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			lastErr = ctx.Err()
+		case <-timer.C:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return retry, ferrors.WrapExternal(lastErr, TextCodeDeliveryFailed, "webhookadapter: delivery failed", map[string]any{
+		ferrors.MetaAdapter: adapterName,
+		MetaURL:             s.url,
+		MetaStatus:          status,
+		MetaAttempts:        attempt,
+	})
+}
+
+// post sends body once, reporting the status and whether a failure is retryable.
+func (s *Sink) post(ctx context.Context, body []byte) (int, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	for name, values := range s.header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		timestamp := strconv.FormatInt(s.now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(s.secret, timestamp, body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, ctx.Err() == nil, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return resp.StatusCode, retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// Sign returns the SignatureHeader value for body sent at timestamp.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches body sent at timestamp and the
+// timestamp is within DefaultSignatureTolerance of the current time, so captured
+// requests cannot be replayed later.
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	return VerifyAt(secret, timestamp, body, signature, time.Now(), DefaultSignatureTolerance)
+}
+
+// VerifyAt is Verify against now, accepting timestamps up to tolerance away from it.
+func VerifyAt(secret []byte, timestamp string, body []byte, signature string, now time.Time, tolerance time.Duration) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if skew := now.Sub(time.Unix(unix, 0)); skew > tolerance || skew < -tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+func (s *Sink) updateEvent(event activity.UpdateEvent) Event {
+	out := Event{
+		Type:   EventUpdate,
+		ID:     event.ID,
+		Time:   s.now(),
+		Key:    event.NormalizedKey,
+		Action: string(event.Action),
+		Value:  event.Value,
+		Limit:  event.Limit,
+	}
+	if out.Key == "" {
+		out.Key = event.Key
+	}
+	if event.Action != activity.ActionReload {
+		out.Scope = &Scope{
+			Kind:     event.Scope.Kind.String(),
+			ID:       event.Scope.ID,
+			TenantID: event.Scope.TenantID,
+			OrgID:    event.Scope.OrgID,
+		}
+	}
+	if event.Actor != (gate.ActorRef{}) {
+		out.Actor = &Actor{ID: event.Actor.ID, Type: event.Actor.Type, Name: event.Actor.Name}
+	}
+	if !event.ExpiresAt.IsZero() {
+		expiresAt := event.ExpiresAt
+		out.ExpiresAt = &expiresAt
+	}
+	if !event.Metadata.IsZero() {
+		meta := event.Metadata
+		out.Metadata = &meta
+	}
+	if event.Diff != nil {
+		out.Diff = &Diff{Added: event.Diff.Added, Removed: event.Diff.Removed, Changed: event.Diff.Changed}
+	}
+	return out
+}
+
+// describe renders event as one line of Slack markdown.
+func describe(event Event) string {
+	if event.Type == EventResolveError {
+		return fmt.Sprintf(":warning: `%s` failed to resolve (%s): %s", event.Key, event.Source, event.Error)
+	}
+	actor := "someone"
+	if event.Actor != nil {
+		actor = firstNonEmpty(event.Actor.Name, event.Actor.ID, actor)
+	}
+	if event.Action == string(activity.ActionReload) {
+		line := fmt.Sprintf("*%s* reloaded feature configuration", actor)
+		if event.Diff != nil {
+			line += fmt.Sprintf(" (%d added, %d removed, %d changed)", len(event.Diff.Added), len(event.Diff.Removed), len(event.Diff.Changed))
+		}
+		return line
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s* %s `%s`", actor, event.Action, event.Key)
+	if event.Value != nil {
+		fmt.Fprintf(&b, " = %t", *event.Value)
+	}
+	if event.Limit != nil {
+		fmt.Fprintf(&b, " = %d", *event.Limit)
+	}
+	if event.Scope != nil {
+		b.WriteString(" on ")
+		b.WriteString(event.Scope.Kind)
+		if event.Scope.ID != "" {
+			b.WriteString(":" + event.Scope.ID)
+		}
+	}
+	if event.ExpiresAt != nil {
+		fmt.Fprintf(&b, " until %s", event.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if event.Metadata != nil && event.Metadata.Reason != "" {
+		fmt.Fprintf(&b, " (%s)", event.Metadata.Reason)
+	}
+	return b.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+var (
+	_ activity.Hook    = (*Sink)(nil)
+	_ gate.ResolveHook = (*Sink)(nil)
+)
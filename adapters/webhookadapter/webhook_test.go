@@ -0,0 +1,224 @@
+package webhookadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+type recorder struct {
+	mu       sync.Mutex
+	failures int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+}
+
+func TestSinkPostsSignedBatchesWithRetry(t *testing.T) {
+	rec := &recorder{failures: 1}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	secret := []byte("shh")
+	sink := NewSink(server.URL,
+		WithSecret(secret),
+		WithBatchSize(10),
+		WithFlushInterval(time.Hour),
+		WithRetry(2, time.Millisecond),
+		WithResolveErrors(true),
+	)
+	fg := resolver.New(resolver.WithOverrideStore(store.NewMemoryStore()), resolver.WithActivityHook(sink))
+	ctx := context.Background()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	if err := fg.Set(ctx, "checkout.v2", tenant, true, gate.ActorRef{ID: "u1", Name: "Ada"}, gate.WithReason("launch")); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	sink.OnResolve(ctx, gate.ResolveEvent{NormalizedKey: "search", Source: gate.ResolveSourceFallback, Error: errors.New("store down")})
+	sink.OnResolve(ctx, gate.ResolveEvent{NormalizedKey: "search", Source: gate.ResolveSourceDefault})
+
+	if err := sink.Close(ctx); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if len(rec.bodies) != 1 {
+		t.Fatalf("expected one delivered batch, got %d", len(rec.bodies))
+	}
+	header := rec.headers[0]
+	if !Verify(secret, header.Get(TimestampHeader), rec.bodies[0], header.Get(SignatureHeader)) {
+		t.Fatalf("expected a valid signature, got %q", header.Get(SignatureHeader))
+	}
+	var payload Payload
+	if err := json.Unmarshal(rec.bodies[0], &payload); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(payload.Events) != 2 {
+		t.Fatalf("expected update and resolve error, got %+v", payload.Events)
+	}
+	update, failed := payload.Events[0], payload.Events[1]
+	if update.Type != EventUpdate || update.Key != "checkout.v2" || update.Action != "set" ||
+		update.Scope == nil || update.Scope.Kind != "tenant" || update.Actor == nil || update.Actor.Name != "Ada" ||
+		update.Metadata == nil || update.Metadata.Reason != "launch" || update.Value == nil || !*update.Value {
+		t.Fatalf("unexpected update event: %+v", update)
+	}
+	if failed.Type != EventResolveError || failed.Error != "store down" {
+		t.Fatalf("unexpected resolve error event: %+v", failed)
+	}
+}
+
+func TestSinkReportsExhaustedRetries(t *testing.T) {
+	rec := &recorder{failures: 10}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	var reported error
+	sink := NewSink(server.URL, WithFlushInterval(time.Hour), WithRetry(3, 0), WithOnError(func(err error) { reported = err }))
+	defer sink.Close(context.Background())
+	sink.OnUpdate(context.Background(), activity.UpdateEvent{Key: "a", Action: activity.ActionUnset})
+
+	err := sink.Flush(context.Background())
+	rich, ok := ferrors.As(err)
+	if !ok || rich.TextCode != TextCodeDeliveryFailed || rich.Metadata[MetaAttempts] != 3 || reported == nil {
+		t.Fatalf("expected delivery failure after three attempts, got %v", err)
+	}
+	if rec.failures != 7 || sink.Pending() != 1 {
+		t.Fatalf("expected three posts and the batch kept for the next tick, failures left %d pending %d", rec.failures, sink.Pending())
+	}
+}
+
+func TestSinkLoopRequeuesFailedBatchesAtTheHead(t *testing.T) {
+	rec := &recorder{failures: 1}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	sink := NewSink(server.URL, WithBatchSize(2), WithFlushInterval(10*time.Millisecond), WithRetry(1, 0))
+	defer sink.Close(context.Background())
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		sink.OnUpdate(context.Background(), activity.UpdateEvent{NormalizedKey: key, Action: activity.ActionUnset})
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		rec.mu.Lock()
+		if len(rec.bodies) == 3 {
+			break
+		}
+		rec.mu.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the backlog to drain, %d pending", sink.Pending())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	defer rec.mu.Unlock()
+	var keys []string
+	for _, body := range rec.bodies {
+		var payload Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(payload.Events) > 2 {
+			t.Fatalf("expected one batch per post, got %d events", len(payload.Events))
+		}
+		for _, event := range payload.Events {
+			keys = append(keys, event.Key)
+		}
+	}
+	if strings.Join(keys, ",") != "a,b,c,d,e" {
+		t.Fatalf("expected the failed batch to be retried first, got %v", keys)
+	}
+	if sink.Dropped() != 0 {
+		t.Fatalf("expected no drops, got %d", sink.Dropped())
+	}
+}
+
+func TestVerifyAtRejectsStaleTimestamps(t *testing.T) {
+	secret := []byte("shh")
+	body := []byte(`{"events":[]}`)
+	sent := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(sent.Unix(), 10)
+	signature := Sign(secret, timestamp, body)
+
+	if !VerifyAt(secret, timestamp, body, signature, sent.Add(time.Minute), DefaultSignatureTolerance) {
+		t.Fatal("expected a fresh signature to verify")
+	}
+	if VerifyAt(secret, timestamp, body, signature, sent.Add(10*time.Minute), DefaultSignatureTolerance) {
+		t.Fatal("expected a replayed request to be rejected")
+	}
+	if VerifyAt(secret, timestamp, body, signature, sent.Add(-10*time.Minute), DefaultSignatureTolerance) {
+		t.Fatal("expected a timestamp from the future to be rejected")
+	}
+	if VerifyAt(secret, "not-a-time", body, Sign(secret, "not-a-time", body), sent, DefaultSignatureTolerance) {
+		t.Fatal("expected a malformed timestamp to be rejected")
+	}
+	if Verify(secret, timestamp, body, signature) {
+		t.Fatal("expected Verify to check freshness against the current time")
+	}
+}
+
+func TestSinkFlushesFullBatchesAndFilters(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	sink := NewSink(server.URL,
+		WithEncoder(SlackEncoder),
+		WithBatchSize(2),
+		WithFlushInterval(time.Hour),
+		WithFilter(func(event Event) bool { return event.Scope != nil && event.Scope.TenantID == "prod" }),
+	)
+	defer sink.Close(context.Background())
+	on := true
+	for _, tenant := range []string{"prod", "staging", "prod"} {
+		sink.OnUpdate(context.Background(), activity.UpdateEvent{
+			NormalizedKey: "billing",
+			Action:        activity.ActionSet,
+			Value:         &on,
+			Scope:         gate.ScopeRef{Kind: gate.ScopeTenant, ID: tenant, TenantID: tenant},
+			Actor:         gate.ActorRef{ID: "ops"},
+		})
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		rec.mu.Lock()
+		n := len(rec.bodies)
+		rec.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a full batch to flush without waiting for the interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	var message map[string]string
+	if err := json.Unmarshal(rec.bodies[0], &message); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	lines := strings.Split(message["text"], "\n")
+	if len(lines) != 2 || lines[0] != "*ops* set `billing` = true on tenant:prod" {
+		t.Fatalf("unexpected slack message: %q", message["text"])
+	}
+}
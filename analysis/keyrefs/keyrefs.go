@@ -0,0 +1,188 @@
+// Package keyrefs finds feature key references in Go code and compares them with a
+// catalog: catalog entries that nothing references, and keys passed to gate calls
+// that the catalog does not define.
+//
+// Analyzer reports missing keys per package (for go vet style drivers); the
+// featuregate-keys command aggregates references across packages to also report
+// unreferenced catalog entries.
+package keyrefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// KeyMethods are gate methods whose second argument (after ctx) is a feature key.
+// Calls only count when type information shows a context.Context first parameter.
+var KeyMethods = map[string]bool{
+	"Enabled":          true,
+	"ResolveWithTrace": true,
+	"Set":              true,
+	"Unset":            true,
+}
+
+// Reference is a feature key found in code.
+type Reference struct {
+	Key string
+	Pos token.Position
+	pos token.Pos
+}
+
+// Catalog is the set of normalized catalog keys.
+type Catalog map[string]bool
+
+// LoadCatalog reads a JSON catalog file in the shape accepted by configadapter.NewCatalog.
+func LoadCatalog(path string) (Catalog, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]any{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	keys := Catalog{}
+	for _, def := range configadapter.NewCatalog(data).List() {
+		if key := gate.NormalizeKey(def.Key); key != "" {
+			keys[key] = true
+		}
+	}
+	return keys, nil
+}
+
+// Collect scans files for references to catalog keys (string literals and uses of
+// string constants, including generated ones) and for literal keys passed to
+// KeyMethods that the catalog does not define. Generated files are skipped so the
+// constant declarations themselves do not count as references.
+func Collect(fset *token.FileSet, files []*ast.File, info *types.Info, catalog Catalog) (refs, unknown []Reference) {
+	for _, file := range files {
+		if ast.IsGenerated(file) {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.BasicLit:
+				if key, ok := literalKey(node); ok && catalog[key] {
+					refs = append(refs, Reference{Key: key, Pos: fset.Position(node.Pos()), pos: node.Pos()})
+				}
+			case *ast.Ident:
+				if key, ok := constKey(info, node); ok && catalog[key] {
+					refs = append(refs, Reference{Key: key, Pos: fset.Position(node.Pos()), pos: node.Pos()})
+				}
+			case *ast.CallExpr:
+				if lit, ok := keyArgument(info, node); ok {
+					if key, ok := literalKey(lit); ok && key != "" && !catalog[key] {
+						unknown = append(unknown, Reference{Key: key, Pos: fset.Position(lit.Pos()), pos: lit.Pos()})
+					}
+				}
+			}
+			return true
+		})
+	}
+	return refs, unknown
+}
+
+// Unreferenced lists catalog keys without references, sorted.
+func Unreferenced(catalog Catalog, refs []Reference) []string {
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		seen[ref.Key] = true
+	}
+	out := make([]string, 0)
+	for key := range catalog {
+		if !seen[key] {
+			out = append(out, key)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+var (
+	catalogPath  string
+	catalogOnce  sync.Once
+	catalogKeys  Catalog
+	catalogError error
+)
+
+// Analyzer reports literal keys passed to gate calls that are missing from the
+// catalog given with -catalog. Its result is the package's []Reference.
+var Analyzer = &analysis.Analyzer{
+	Name:       "featurekeys",
+	Doc:        "report feature keys missing from the catalog",
+	Run:        run,
+	ResultType: reflect.TypeOf([]Reference(nil)),
+}
+
+func init() {
+	Analyzer.Flags.StringVar(&catalogPath, "catalog", "", "path to a JSON catalog file")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	catalogOnce.Do(func() {
+		if catalogPath == "" {
+			catalogError = fmt.Errorf("featurekeys: -catalog is required")
+			return
+		}
+		catalogKeys, catalogError = LoadCatalog(catalogPath)
+	})
+	if catalogError != nil {
+		return nil, catalogError
+	}
+	refs, unknown := Collect(pass.Fset, pass.Files, pass.TypesInfo, catalogKeys)
+	for _, ref := range unknown {
+		pass.Reportf(ref.pos, "feature key %q is not in the catalog", ref.Key)
+	}
+	return refs, nil
+}
+
+func literalKey(lit *ast.BasicLit) (string, bool) {
+	if lit == nil || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return gate.NormalizeKey(value), true
+}
+
+func constKey(info *types.Info, ident *ast.Ident) (string, bool) {
+	if info == nil {
+		return "", false
+	}
+	obj, ok := info.Uses[ident].(*types.Const)
+	if !ok || obj.Val().Kind() != constant.String {
+		return "", false
+	}
+	return gate.NormalizeKey(constant.StringVal(obj.Val())), true
+}
+
+func keyArgument(info *types.Info, call *ast.CallExpr) (*ast.BasicLit, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !KeyMethods[sel.Sel.Name] || len(call.Args) < 2 {
+		return nil, false
+	}
+	if info != nil && info.Types != nil {
+		sig, ok := info.TypeOf(sel).(*types.Signature)
+		if !ok || sig.Params().Len() < 2 || sig.Params().At(0).Type().String() != "context.Context" {
+			return nil, false
+		}
+	}
+	lit, ok := call.Args[1].(*ast.BasicLit)
+	return lit, ok
+}
@@ -0,0 +1,68 @@
+package keyrefs
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+const generatedSrc = `// Code generated by featuregate-gen. DO NOT EDIT.
+
+package app
+
+const (
+	UsersSignup = "users.signup"
+	BillingV2   = "billing.v2"
+)
+`
+
+const appSrc = `package app
+
+import "context"
+
+type flags struct{}
+
+func (flags) Enabled(ctx context.Context, key string) (bool, error) { return false, nil }
+
+func use(ctx context.Context, fg flags) {
+	fg.Enabled(ctx, UsersSignup)
+	fg.Enabled(ctx, "dashboard")
+	fg.Enabled(ctx, "typo.key")
+	header{}.Set("Content-Type", "application/json")
+}
+
+type header struct{}
+
+func (header) Set(key, value string) {}
+`
+
+func TestCollectFindsReferencesAndUnknownKeys(t *testing.T) {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for name, src := range map[string]string{"flags_gen.go": generatedSrc, "app.go": appSrc} {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		files = append(files, file)
+	}
+	info := &types.Info{Uses: map[*ast.Ident]types.Object{}, Types: map[ast.Expr]types.TypeAndValue{}}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("app", fset, files, info); err != nil {
+		t.Fatalf("type check: %v", err)
+	}
+
+	catalog := Catalog{"users.signup": true, "billing.v2": true, "dashboard": true}
+	refs, unknown := Collect(fset, files, info, catalog)
+
+	if got := Unreferenced(catalog, refs); !reflect.DeepEqual(got, []string{"billing.v2"}) {
+		t.Fatalf("expected billing.v2 unreferenced, got %v", got)
+	}
+	if len(unknown) != 1 || unknown[0].Key != "typo.key" || unknown[0].Pos.Line != 12 {
+		t.Fatalf("expected typo.key unknown at line 12, got %+v", unknown)
+	}
+}
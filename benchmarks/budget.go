@@ -0,0 +1,44 @@
+// Package benchmarks measures resolve performance and holds the budgets it must
+// stay within.
+//
+// The benchmarks cover resolves with and without a cache, long scope chains, many
+// override matches, and concurrent access:
+//
+//	go test ./benchmarks -bench . -benchmem
+//
+// Every scenario reports its budget next to the measurement. Allocation budgets
+// are enforced by the package tests on every run; byte and time budgets are
+// enforced only when FEATUREGATE_PERF_BUDGET=strict is set, since they need full
+// benchmark runs and wall-clock numbers depend on the machine. Raise a budget in the same change that justifies it.
+package benchmarks
+
+// StrictEnv names the environment variable that enables time budgets.
+const StrictEnv = "FEATUREGATE_PERF_BUDGET"
+
+// Budget bounds the cost of a single resolve in a scenario.
+type Budget struct {
+	Scenario  string
+	MaxAllocs float64
+	MaxBytes  int64
+	MaxNs     int64
+}
+
+// Budgets lists the regression thresholds per scenario.
+var Budgets = []Budget{
+	{Scenario: "enabled", MaxAllocs: 2, MaxBytes: 512, MaxNs: 2_000},
+	{Scenario: "enabled_chain_cache", MaxAllocs: 1, MaxBytes: 256, MaxNs: 2_000},
+	{Scenario: "trace", MaxAllocs: 14, MaxBytes: 1_536, MaxNs: 8_000},
+	{Scenario: "cached", MaxAllocs: 6, MaxBytes: 512, MaxNs: 4_000},
+	{Scenario: "long_chain", MaxAllocs: 120, MaxBytes: 24_576, MaxNs: 60_000},
+	{Scenario: "many_matches", MaxAllocs: 200, MaxBytes: 65_536, MaxNs: 120_000},
+}
+
+// BudgetFor returns the budget registered for scenario.
+func BudgetFor(scenario string) (Budget, bool) {
+	for _, budget := range Budgets {
+		if budget.Scenario == scenario {
+			return budget, true
+		}
+	}
+	return Budget{}, false
+}
@@ -0,0 +1,45 @@
+package benchmarks
+
+import (
+	"os"
+	"testing"
+)
+
+func TestScenariosStayWithinAllocationBudget(t *testing.T) {
+	for _, sc := range scenarios {
+		budget, ok := BudgetFor(sc.name)
+		if !ok {
+			t.Fatalf("scenario %s has no budget", sc.name)
+		}
+		resolve := sc.setup(t)
+		allocs := testing.AllocsPerRun(200, func() {
+			if value, err := resolve(); err != nil || !value {
+				t.Fatalf("%s: expected checkout enabled, got %v (%v)", sc.name, value, err)
+			}
+		})
+		if allocs > budget.MaxAllocs {
+			t.Errorf("%s: %.0f allocs/op exceeds budget of %.0f", sc.name, allocs, budget.MaxAllocs)
+		}
+	}
+}
+
+func TestScenariosStayWithinTimeBudget(t *testing.T) {
+	if os.Getenv(StrictEnv) != "strict" {
+		t.Skipf("set %s=strict to enforce byte and time budgets", StrictEnv)
+	}
+	for _, sc := range scenarios {
+		budget, _ := BudgetFor(sc.name)
+		result := testing.Benchmark(func(b *testing.B) {
+			runScenario(b, sc)
+		})
+		if got := result.NsPerOp(); got > budget.MaxNs {
+			t.Errorf("%s: %d ns/op exceeds budget of %d", sc.name, got, budget.MaxNs)
+		}
+		if got := result.AllocedBytesPerOp(); got > budget.MaxBytes {
+			t.Errorf("%s: %d B/op exceeds budget of %d", sc.name, got, budget.MaxBytes)
+		}
+		if reported := result.Extra["budget-ns/op"]; reported != float64(budget.MaxNs) {
+			t.Errorf("%s: benchmark reported budget %.0f, want %d", sc.name, reported, budget.MaxNs)
+		}
+	}
+}
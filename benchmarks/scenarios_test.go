@@ -0,0 +1,164 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// scenario builds a gate and returns one resolve to measure.
+type scenario struct {
+	name  string
+	setup func(tb testing.TB) func() (bool, error)
+}
+
+var scenarios = []scenario{
+	{name: "enabled", setup: func(tb testing.TB) func() (bool, error) {
+		ctx := scope.WithTenantID(context.Background(), "acme")
+		g := resolver.New(resolver.WithOverrideStore(tenantOverride(tb, "checkout")))
+		return func() (bool, error) { return g.Enabled(ctx, "checkout") }
+	}},
+	{name: "enabled_chain_cache", setup: func(tb testing.TB) func() (bool, error) {
+		ctx := scope.WithChainCache(scope.WithTenantID(context.Background(), "acme"))
+		g := resolver.New(resolver.WithOverrideStore(tenantOverride(tb, "checkout")))
+		return func() (bool, error) { return g.Enabled(ctx, "checkout") }
+	}},
+	{name: "trace", setup: func(tb testing.TB) func() (bool, error) {
+		ctx := scope.WithTenantID(context.Background(), "acme")
+		g := resolver.New(resolver.WithOverrideStore(tenantOverride(tb, "checkout")))
+		return func() (bool, error) {
+			value, _, err := g.ResolveWithTrace(ctx, "checkout")
+			return value, err
+		}
+	}},
+	{name: "cached", setup: func(tb testing.TB) func() (bool, error) {
+		ctx := scope.WithTenantID(context.Background(), "acme")
+		g := resolver.New(resolver.WithOverrideStore(tenantOverride(tb, "checkout")), resolver.WithCache(&syncCache{}))
+		return func() (bool, error) { return g.Enabled(ctx, "checkout") }
+	}},
+	{name: "long_chain", setup: func(tb testing.TB) func() (bool, error) {
+		overrides := store.NewMemoryStore()
+		set(tb, overrides, "checkout", gate.ScopeRef{Kind: gate.ScopeSystem})
+		g := resolver.New(resolver.WithOverrideStore(overrides))
+		claims := gate.WithClaims(manyRoleClaims(20))
+		return func() (bool, error) {
+			value, _, err := g.ResolveWithTrace(context.Background(), "checkout", claims)
+			return value, err
+		}
+	}},
+	{name: "many_matches", setup: func(tb testing.TB) func() (bool, error) {
+		overrides := store.NewMemoryStore()
+		claims := manyRoleClaims(20)
+		for _, role := range claims.Roles {
+			set(tb, overrides, "checkout", gate.ScopeRef{Kind: gate.ScopeRole, ID: role, TenantID: claims.TenantID, OrgID: claims.OrgID})
+		}
+		g := resolver.New(resolver.WithOverrideStore(overrides))
+		opt := gate.WithClaims(claims)
+		return func() (bool, error) {
+			value, _, err := g.ResolveWithTrace(context.Background(), "checkout", opt)
+			return value, err
+		}
+	}},
+}
+
+func BenchmarkResolve(b *testing.B) {
+	for _, sc := range scenarios {
+		b.Run(sc.name, func(b *testing.B) {
+			runScenario(b, sc)
+		})
+	}
+}
+
+func BenchmarkResolveParallel(b *testing.B) {
+	ctx := scope.WithTenantID(context.Background(), "acme")
+	g := resolver.New(resolver.WithOverrideStore(tenantOverride(b, "checkout")), resolver.WithCache(&syncCache{}))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if value, err := g.Enabled(ctx, "checkout"); err != nil || !value {
+				b.Errorf("resolve: %v %v", value, err)
+				return
+			}
+		}
+	})
+}
+
+func runScenario(b *testing.B, sc scenario) {
+	resolve := sc.setup(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if value, err := resolve(); err != nil || !value {
+			b.Fatalf("expected checkout enabled, got %v (%v)", value, err)
+		}
+	}
+	b.StopTimer()
+	if budget, ok := BudgetFor(sc.name); ok {
+		b.ReportMetric(budget.MaxAllocs, "budget-allocs/op")
+		b.ReportMetric(float64(budget.MaxNs), "budget-ns/op")
+	}
+}
+
+func tenantOverride(tb testing.TB, key string) *store.MemoryStore {
+	overrides := store.NewMemoryStore()
+	set(tb, overrides, key, gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"})
+	return overrides
+}
+
+func set(tb testing.TB, overrides *store.MemoryStore, key string, ref gate.ScopeRef) {
+	tb.Helper()
+	if err := overrides.Set(context.Background(), key, ref, true, gate.ActorRef{}); err != nil {
+		tb.Fatalf("set: %v", err)
+	}
+}
+
+func manyRoleClaims(n int) gate.ActorClaims {
+	claims := gate.ActorClaims{TenantID: "acme", OrgID: "eng", SubjectID: "u1"}
+	for i := 0; i < n; i++ {
+		claims.Roles = append(claims.Roles, fmt.Sprintf("role-%02d", i))
+		claims.Perms = append(claims.Perms, fmt.Sprintf("perm-%02d", i))
+	}
+	return claims
+}
+
+// syncCache is a concurrency-safe cache keyed by feature key and scope chain.
+type syncCache struct {
+	entries sync.Map
+}
+
+func (c *syncCache) Get(_ context.Context, key string, chain gate.ScopeChain) (cache.Entry, bool) {
+	entry, ok := c.entries.Load(cacheKey(key, chain))
+	if !ok {
+		return cache.Entry{}, false
+	}
+	return entry.(cache.Entry), true
+}
+
+func (c *syncCache) Set(_ context.Context, key string, chain gate.ScopeChain, entry cache.Entry) {
+	c.entries.Store(cacheKey(key, chain), entry)
+}
+
+func (c *syncCache) Delete(_ context.Context, key string, chain gate.ScopeChain) {
+	c.entries.Delete(cacheKey(key, chain))
+}
+
+func (c *syncCache) Clear(context.Context) {
+	c.entries.Clear()
+}
+
+func cacheKey(key string, chain gate.ScopeChain) string {
+	var b strings.Builder
+	b.WriteString(key)
+	for _, ref := range chain {
+		b.WriteString("|" + ref.Kind.String() + ":" + ref.ID)
+	}
+	return b.String()
+}
@@ -0,0 +1,209 @@
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// DefaultVarName is the expvar name used by Publish.
+const DefaultVarName = "featuregate"
+
+// Snapshot records system flag state at boot alongside build version info.
+type Snapshot struct {
+	Version  string          `json:"version,omitempty"`
+	Revision string          `json:"revision,omitempty"`
+	Modified bool            `json:"modified,omitempty"`
+	TakenAt  time.Time       `json:"taken_at"`
+	Flags    map[string]bool `json:"flags"`
+}
+
+// Option customizes snapshot capture.
+type Option func(*config)
+
+type config struct {
+	version  string
+	revision string
+	now      func() time.Time
+	build    func() (*debug.BuildInfo, bool)
+}
+
+// WithVersion overrides the version read from build info (for example a value set via -ldflags).
+func WithVersion(version string) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.version = strings.TrimSpace(version)
+	}
+}
+
+// WithRevision overrides the VCS revision read from build info.
+func WithRevision(revision string) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.revision = strings.TrimSpace(revision)
+	}
+}
+
+// WithClock sets the clock used for TakenAt.
+func WithClock(c clock.Clock) Option {
+	return func(cfg *config) {
+		if cfg == nil {
+			return
+		}
+		cfg.now = clock.NowFunc(c)
+	}
+}
+
+// WithNowFunc overrides the clock used for TakenAt.
+func WithNowFunc(now func() time.Time) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.now = now
+	}
+}
+
+// Capture resolves the allowlisted keys at system scope and records them with build info.
+// Only allowlisted keys are captured so dashboards never see unexpected label cardinality.
+func Capture(ctx context.Context, fg gate.FeatureGate, allowlist []string, opts ...Option) (Snapshot, error) {
+	cfg := config{now: time.Now, build: debug.ReadBuildInfo}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.now == nil {
+		cfg.now = time.Now
+	}
+	if fg == nil {
+		return Snapshot{}, ferrors.WrapSentinel(ferrors.ErrGateRequired, "buildinfo: feature gate is required", nil)
+	}
+	snapshot := Snapshot{
+		TakenAt: cfg.now(),
+		Flags:   make(map[string]bool, len(allowlist)),
+	}
+	if info, ok := cfg.build(); ok && info != nil {
+		snapshot.Version = info.Main.Version
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				snapshot.Revision = setting.Value
+			case "vcs.modified":
+				snapshot.Modified = setting.Value == "true"
+			}
+		}
+	}
+	if cfg.version != "" {
+		snapshot.Version = cfg.version
+	}
+	if cfg.revision != "" {
+		snapshot.Revision = cfg.revision
+	}
+	system := gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})
+	for _, key := range allowlist {
+		normalized := gate.NormalizeKey(key)
+		if normalized == "" {
+			continue
+		}
+		value, err := fg.Enabled(ctx, normalized, system)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		snapshot.Flags[normalized] = value
+	}
+	return snapshot, nil
+}
+
+// Labels renders the snapshot as metric labels: version, revision, and one flag_<key> label per flag.
+// Key characters outside [a-zA-Z0-9_] become underscores.
+func (s Snapshot) Labels() map[string]string {
+	labels := make(map[string]string, len(s.Flags)+2)
+	if s.Version != "" {
+		labels["version"] = s.Version
+	}
+	if s.Revision != "" {
+		labels["revision"] = s.Revision
+	}
+	for key, value := range s.Flags {
+		labels["flag_"+labelName(key)] = strconv.FormatBool(value)
+	}
+	return labels
+}
+
+// Keys returns the captured keys in sorted order.
+func (s Snapshot) Keys() []string {
+	keys := make([]string, 0, len(s.Flags))
+	for key := range s.Flags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String implements expvar.Var.
+func (s Snapshot) String() string {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return "{}"
+	}
+	return string(raw)
+}
+
+// Publish exposes the snapshot under expvar name (DefaultVarName when empty).
+// Publishing again under the same name replaces the previous snapshot.
+func Publish(name string, snapshot Snapshot) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultVarName
+	}
+	if existing, ok := expvar.Get(name).(*published); ok {
+		existing.set(snapshot)
+		return
+	}
+	v := &published{}
+	v.set(snapshot)
+	expvar.Publish(name, v)
+}
+
+type published struct {
+	snapshot atomic.Pointer[Snapshot]
+}
+
+func (p *published) set(snapshot Snapshot) {
+	p.snapshot.Store(&snapshot)
+}
+
+func (p *published) String() string {
+	snapshot := p.snapshot.Load()
+	if snapshot == nil {
+		return "null"
+	}
+	return snapshot.String()
+}
+
+func labelName(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteByte('_')
+	}
+	return b.String()
+}
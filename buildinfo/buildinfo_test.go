@@ -0,0 +1,39 @@
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/resolver"
+)
+
+func TestCaptureLimitsFlagsToAllowlist(t *testing.T) {
+	fg := resolver.New(resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{
+		"checkout.v2":    true,
+		"secret.rollout": true,
+	})))
+	snapshot, err := Capture(context.Background(), fg, []string{"checkout.v2", "search"}, WithVersion("v1.4.0"))
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if len(snapshot.Flags) != 2 || !snapshot.Flags["checkout.v2"] || snapshot.Flags["search"] {
+		t.Fatalf("unexpected flags: %+v", snapshot.Flags)
+	}
+	labels := snapshot.Labels()
+	if labels["version"] != "v1.4.0" || labels["flag_checkout_v2"] != "true" {
+		t.Fatalf("unexpected labels: %+v", labels)
+	}
+
+	Publish("featuregate_test", snapshot)
+	Publish("featuregate_test", snapshot)
+	decoded := Snapshot{}
+	if err := json.Unmarshal([]byte(expvar.Get("featuregate_test").String()), &decoded); err != nil {
+		t.Fatalf("decode expvar: %v", err)
+	}
+	if decoded.Version != "v1.4.0" || !decoded.Flags["checkout.v2"] {
+		t.Fatalf("unexpected published snapshot: %+v", decoded)
+	}
+}
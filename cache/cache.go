@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	"github.com/goliatone/go-featuregate/gate"
 )
@@ -10,6 +11,13 @@ import (
 type Entry struct {
 	Value bool
 	Trace gate.ResolveTrace
+	// ExpiresAt is set when a Policy TTL applies; the resolver ignores the entry afterwards.
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the entry carries an expiry at or before now.
+func (e Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
 }
 
 // Cache stores resolved feature values by key and scope.
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// Policy controls how resolved values for matching keys use the cache.
+type Policy struct {
+	// TTL bounds entry lifetime through Entry.ExpiresAt. Zero leaves expiry to the cache.
+	TTL time.Duration
+	// Bypass skips the cache entirely: values are neither read nor written.
+	Bypass bool
+	// NoStore reads existing entries but never writes new ones.
+	NoStore bool
+}
+
+var (
+	// Default caches values with the cache's own lifetime.
+	Default = Policy{}
+	// Bypass always resolves against the stores.
+	Bypass = Policy{Bypass: true}
+	// NoStore never writes entries for matching keys.
+	NoStore = Policy{NoStore: true}
+)
+
+// TTL returns a policy that expires entries after ttl.
+func TTL(ttl time.Duration) Policy {
+	return Policy{TTL: ttl}
+}
+
+// Policies maps key patterns to cache policies. A pattern is an exact key, a
+// prefix ending in "*" (for example "billing.*"), or "*" for every key.
+// Exact keys win over prefixes, and longer prefixes win over shorter ones.
+type Policies struct {
+	exact    map[string]Policy
+	prefixes []prefixPolicy
+}
+
+type prefixPolicy struct {
+	prefix string
+	policy Policy
+}
+
+// Add registers policy for pattern, replacing an earlier policy for the same pattern.
+func (p *Policies) Add(pattern string, policy Policy) {
+	if p == nil {
+		return
+	}
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		prefix = gate.NormalizeKey(prefix)
+		for i := range p.prefixes {
+			if p.prefixes[i].prefix == prefix {
+				p.prefixes[i].policy = policy
+				return
+			}
+		}
+		p.prefixes = append(p.prefixes, prefixPolicy{prefix: prefix, policy: policy})
+		return
+	}
+	if p.exact == nil {
+		p.exact = map[string]Policy{}
+	}
+	p.exact[gate.NormalizeKey(pattern)] = policy
+}
+
+// Len reports the number of registered patterns.
+func (p *Policies) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.exact) + len(p.prefixes)
+}
+
+// Lookup returns the policy for a normalized key, or Default when none matches.
+func (p *Policies) Lookup(key string) Policy {
+	if p == nil {
+		return Default
+	}
+	if policy, ok := p.exact[key]; ok {
+		return policy
+	}
+	best, found := -1, Default
+	for _, candidate := range p.prefixes {
+		if len(candidate.prefix) > best && strings.HasPrefix(key, candidate.prefix) {
+			best, found = len(candidate.prefix), candidate.policy
+		}
+	}
+	return found
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/goliatone/go-featuregate/gate"
 )
@@ -15,10 +16,17 @@ type Message struct {
 	Args map[string]any
 }
 
-// FeatureDefinition describes a feature flag for UI and documentation.
+// FeatureDefinition describes a feature flag for UI and documentation. The lifecycle
+// fields track flag debt; see Stale and NewDeprecationHook. Groups bundle flags that
+// are toggled together, such as the flags behind a product tier; see ByGroup.
 type FeatureDefinition struct {
 	Key         string
 	Description Message
+	Status      Status
+	Owner       string
+	CreatedAt   time.Time
+	SunsetAt    time.Time
+	Groups      []string
 }
 
 // Catalog exposes feature definitions by key.
@@ -58,6 +66,9 @@ func NewStatic(defs map[string]FeatureDefinition) *StaticCatalog {
 		}
 		def.Key = normalized
 		def.Description = normalizeMessage(def.Description)
+		def.Status = Status(strings.ToLower(strings.TrimSpace(string(def.Status))))
+		def.Owner = strings.TrimSpace(def.Owner)
+		def.Groups = normalizeGroups(def.Groups)
 		out[normalized] = def
 	}
 	return &StaticCatalog{defs: out}
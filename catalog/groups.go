@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"sort"
+	"strings"
+)
+
+// InGroup reports whether the definition belongs to group, compared case-insensitively.
+func (d FeatureDefinition) InGroup(group string) bool {
+	group = strings.TrimSpace(group)
+	if group == "" {
+		return false
+	}
+	for _, member := range d.Groups {
+		if strings.EqualFold(member, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// ByGroup lists definitions that belong to group.
+func ByGroup(c Catalog, group string) []FeatureDefinition {
+	return filter(c, func(def FeatureDefinition) bool { return def.InGroup(group) })
+}
+
+// GroupKeys lists the keys of the definitions that belong to group.
+func GroupKeys(c Catalog, group string) []string {
+	defs := ByGroup(c, group)
+	if len(defs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(defs))
+	for _, def := range defs {
+		keys = append(keys, def.Key)
+	}
+	return keys
+}
+
+// Groups lists the group names declared in c, sorted and without duplicates.
+func Groups(c Catalog) []string {
+	if c == nil {
+		return nil
+	}
+	var all []string
+	for _, def := range c.List() {
+		all = append(all, def.Groups...)
+	}
+	out := normalizeGroups(all)
+	sort.Strings(out)
+	return out
+}
+
+func normalizeGroups(groups []string) []string {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(groups))
+	seen := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+		folded := strings.ToLower(group)
+		if _, ok := seen[folded]; ok {
+			continue
+		}
+		seen[folded] = struct{}{}
+		out = append(out, group)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupsBundleDefinitions(t *testing.T) {
+	cat := NewStatic(map[string]FeatureDefinition{
+		"reports.export": {Groups: []string{" Pro ", "pro", "enterprise"}},
+		"audit.log":      {Groups: []string{"enterprise"}},
+		"dashboard":      {},
+	})
+	if got := GroupKeys(cat, "PRO"); !reflect.DeepEqual(got, []string{"reports.export"}) {
+		t.Fatalf("expected case-insensitive group lookup, got %v", got)
+	}
+	if got := GroupKeys(cat, "enterprise"); !reflect.DeepEqual(got, []string{"audit.log", "reports.export"}) {
+		t.Fatalf("expected enterprise members in list order, got %v", got)
+	}
+	if def, _ := cat.Get("reports.export"); !reflect.DeepEqual(def.Groups, []string{"Pro", "enterprise"}) {
+		t.Fatalf("expected trimmed, deduplicated groups, got %v", def.Groups)
+	}
+	if got := Groups(cat); !reflect.DeepEqual(got, []string{"Pro", "enterprise"}) {
+		t.Fatalf("expected sorted group names, got %v", got)
+	}
+}
@@ -0,0 +1,249 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/logger"
+)
+
+// Status is a flag's lifecycle stage.
+type Status string
+
+const (
+	// StatusActive flags are expected to be removed once rolled out. An empty
+	// status is treated as active.
+	StatusActive Status = "active"
+	// StatusDeprecated flags should no longer be evaluated.
+	StatusDeprecated Status = "deprecated"
+	// StatusPermanent flags are long-lived operational toggles and never go stale.
+	StatusPermanent Status = "permanent"
+)
+
+// ParseStatus maps a status name to a Status.
+func ParseStatus(value string) (Status, bool) {
+	switch status := Status(strings.ToLower(strings.TrimSpace(value))); status {
+	case StatusActive, StatusDeprecated, StatusPermanent:
+		return status, true
+	case "":
+		return StatusActive, true
+	}
+	return "", false
+}
+
+// Lifecycle returns the definition's status, treating an empty status as active.
+func (d FeatureDefinition) Lifecycle() Status {
+	if d.Status == "" {
+		return StatusActive
+	}
+	return d.Status
+}
+
+// StaleReason explains why a flag is stale.
+type StaleReason string
+
+const (
+	StaleDeprecated StaleReason = "deprecated"
+	StalePastSunset StaleReason = "past_sunset"
+	StaleTooOld     StaleReason = "too_old"
+)
+
+// StaleFlag is a definition flagged by Stale.
+type StaleFlag struct {
+	Definition FeatureDefinition
+	Reasons    []StaleReason
+}
+
+// Stale lists flags that are deprecated, past their sunset date, or, when maxAge is
+// positive, active and created more than maxAge before now. Permanent flags are never
+// stale. Results follow the catalog's List order.
+func Stale(c Catalog, now time.Time, maxAge time.Duration) []StaleFlag {
+	if c == nil {
+		return nil
+	}
+	var out []StaleFlag
+	for _, def := range c.List() {
+		status := def.Lifecycle()
+		if status == StatusPermanent {
+			continue
+		}
+		var reasons []StaleReason
+		if status == StatusDeprecated {
+			reasons = append(reasons, StaleDeprecated)
+		}
+		if !def.SunsetAt.IsZero() && !now.Before(def.SunsetAt) {
+			reasons = append(reasons, StalePastSunset)
+		}
+		if maxAge > 0 && status == StatusActive && !def.CreatedAt.IsZero() && now.Sub(def.CreatedAt) > maxAge {
+			reasons = append(reasons, StaleTooOld)
+		}
+		if len(reasons) > 0 {
+			out = append(out, StaleFlag{Definition: def, Reasons: reasons})
+		}
+	}
+	return out
+}
+
+// ByStatus lists definitions with status.
+func ByStatus(c Catalog, status Status) []FeatureDefinition {
+	return filter(c, func(def FeatureDefinition) bool { return def.Lifecycle() == status })
+}
+
+// ByOwner lists definitions owned by owner, compared case-insensitively.
+func ByOwner(c Catalog, owner string) []FeatureDefinition {
+	owner = strings.TrimSpace(owner)
+	return filter(c, func(def FeatureDefinition) bool { return strings.EqualFold(def.Owner, owner) })
+}
+
+func filter(c Catalog, keep func(FeatureDefinition) bool) []FeatureDefinition {
+	if c == nil {
+		return nil
+	}
+	var out []FeatureDefinition
+	for _, def := range c.List() {
+		if keep(def) {
+			out = append(out, def)
+		}
+	}
+	return out
+}
+
+// DefaultWarnInterval is how often a deprecated key is reported.
+const DefaultWarnInterval = time.Hour
+
+// DeprecationWarning reports that a deprecated flag was evaluated.
+type DeprecationWarning struct {
+	Definition FeatureDefinition
+	Event      gate.ResolveEvent
+}
+
+// DeprecationOption customizes a DeprecationHook.
+type DeprecationOption func(*DeprecationHook)
+
+// WithWarnFunc replaces the default logger warning.
+func WithWarnFunc(fn func(ctx context.Context, warning DeprecationWarning)) DeprecationOption {
+	return func(h *DeprecationHook) {
+		if h == nil || fn == nil {
+			return
+		}
+		h.warn = fn
+	}
+}
+
+// WithWarnLogger sets the logger used by the default warning.
+func WithWarnLogger(lgr logger.Logger) DeprecationOption {
+	return func(h *DeprecationHook) {
+		if h == nil || lgr == nil {
+			return
+		}
+		h.logger = lgr
+	}
+}
+
+// WithWarnInterval reports each deprecated key at most once per interval. Zero
+// reports every evaluation.
+func WithWarnInterval(interval time.Duration) DeprecationOption {
+	return func(h *DeprecationHook) {
+		if h == nil || interval < 0 {
+			return
+		}
+		h.interval = interval
+	}
+}
+
+// WithWarnClock sets the clock used for the warn interval.
+func WithWarnClock(c clock.Clock) DeprecationOption {
+	return func(h *DeprecationHook) {
+		if h == nil {
+			return
+		}
+		h.now = clock.NowFunc(c)
+	}
+}
+
+// DeprecationHook is a gate.ResolveHook that warns when deprecated flags, or flags
+// past their sunset date, are evaluated.
+type DeprecationHook struct {
+	catalog  Catalog
+	warn     func(context.Context, DeprecationWarning)
+	logger   logger.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// NewDeprecationHook constructs a DeprecationHook over c.
+func NewDeprecationHook(c Catalog, opts ...DeprecationOption) *DeprecationHook {
+	h := &DeprecationHook{
+		catalog:  c,
+		logger:   logger.Default(),
+		interval: DefaultWarnInterval,
+		now:      time.Now,
+		last:     map[string]time.Time{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	if h.now == nil {
+		h.now = time.Now
+	}
+	if h.warn == nil {
+		h.warn = h.log
+	}
+	return h
+}
+
+// OnResolve implements gate.ResolveHook.
+func (h *DeprecationHook) OnResolve(ctx context.Context, event gate.ResolveEvent) {
+	if h == nil || h.catalog == nil {
+		return
+	}
+	def, ok := h.catalog.Get(event.NormalizedKey)
+	if !ok {
+		return
+	}
+	now := h.now()
+	pastSunset := !def.SunsetAt.IsZero() && !now.Before(def.SunsetAt) && def.Lifecycle() != StatusPermanent
+	if def.Lifecycle() != StatusDeprecated && !pastSunset {
+		return
+	}
+	if !h.due(def.Key, now) {
+		return
+	}
+	h.warn(ctx, DeprecationWarning{Definition: def, Event: event})
+}
+
+func (h *DeprecationHook) due(key string, now time.Time) bool {
+	if h.interval == 0 {
+		return true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if last, ok := h.last[key]; ok && now.Sub(last) < h.interval {
+		return false
+	}
+	h.last[key] = now
+	return true
+}
+
+func (h *DeprecationHook) log(ctx context.Context, warning DeprecationWarning) {
+	def := warning.Definition
+	args := []any{"key", def.Key, "status", def.Lifecycle()}
+	if def.Owner != "" {
+		args = append(args, "owner", def.Owner)
+	}
+	if !def.SunsetAt.IsZero() {
+		args = append(args, "sunset_at", def.SunsetAt.Format(time.DateOnly))
+	}
+	h.logger.WithContext(ctx).Warn("featuregate.deprecated_flag_evaluated", args...)
+}
+
+var _ gate.ResolveHook = (*DeprecationHook)(nil)
@@ -0,0 +1,68 @@
+package catalog
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func lifecycleCatalog(now time.Time) *StaticCatalog {
+	return NewStatic(map[string]FeatureDefinition{
+		"checkout.v2":   {Owner: "payments", CreatedAt: now.AddDate(0, -6, 0)},
+		"legacy.search": {Status: "Deprecated", Owner: "search", SunsetAt: now.AddDate(0, 0, -1)},
+		"ops.kill":      {Status: StatusPermanent, CreatedAt: now.AddDate(-3, 0, 0), SunsetAt: now.AddDate(0, 0, -1)},
+		"new.nav":       {CreatedAt: now.AddDate(0, 0, -3), Owner: "Payments"},
+	})
+}
+
+func TestStaleListsFlagDebt(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	cat := lifecycleCatalog(now)
+
+	stale := Stale(cat, now, 90*24*time.Hour)
+	got := map[string][]StaleReason{}
+	for _, flag := range stale {
+		got[flag.Definition.Key] = flag.Reasons
+	}
+	want := map[string][]StaleReason{
+		"checkout.v2":   {StaleTooOld},
+		"legacy.search": {StaleDeprecated, StalePastSunset},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected stale flags: %+v", got)
+	}
+	if len(Stale(cat, now, 0)) != 1 {
+		t.Fatalf("expected age to be ignored without maxAge")
+	}
+	if defs := ByStatus(cat, StatusActive); len(defs) != 2 {
+		t.Fatalf("expected empty statuses to count as active, got %+v", defs)
+	}
+	if defs := ByOwner(cat, "payments"); len(defs) != 2 {
+		t.Fatalf("expected owner lookup to ignore case, got %+v", defs)
+	}
+}
+
+func TestDeprecationHookWarnsOncePerInterval(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	manual := clock.NewManual(now)
+	var warned []string
+	hook := NewDeprecationHook(lifecycleCatalog(now),
+		WithWarnClock(manual),
+		WithWarnInterval(time.Minute),
+		WithWarnFunc(func(_ context.Context, warning DeprecationWarning) {
+			warned = append(warned, warning.Definition.Key)
+		}),
+	)
+	for _, key := range []string{"legacy.search", "legacy.search", "checkout.v2", "ops.kill", "unknown"} {
+		hook.OnResolve(context.Background(), gate.ResolveEvent{NormalizedKey: key})
+	}
+	manual.Advance(time.Minute)
+	hook.OnResolve(context.Background(), gate.ResolveEvent{NormalizedKey: "legacy.search"})
+	if !reflect.DeepEqual(warned, []string{"legacy.search", "legacy.search"}) {
+		t.Fatalf("unexpected warnings: %v", warned)
+	}
+}
@@ -0,0 +1,23 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualAndOffset(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	manual := NewManual(start)
+	skewed := Offset(manual, -2*time.Second)
+
+	manual.Advance(time.Minute)
+	if got := manual.Now(); !got.Equal(start.Add(time.Minute)) {
+		t.Fatalf("unexpected manual time: %v", got)
+	}
+	if got := skewed.Now(); !got.Equal(start.Add(time.Minute - 2*time.Second)) {
+		t.Fatalf("unexpected skewed time: %v", got)
+	}
+	if NowFunc(nil) == nil {
+		t.Fatalf("expected system fallback")
+	}
+}
@@ -0,0 +1,149 @@
+// Command featuregate-bundle produces and checks signed flag bundles for deployments
+// without store connectivity.
+//
+//	featuregate-bundle keygen -out release            # writes release.key and release.pub
+//	featuregate-bundle sign -in flags.json -version 2024.06.1 -key release.key -key-id release -out flags.bundle
+//	featuregate-bundle verify -pub release.pub flags.bundle
+//
+// The sign input is JSON with "defaults" and "catalog" (the featuregated config shape)
+// and optional "overrides" holding system-scope values.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/exchange"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: featuregate-bundle keygen|sign|verify [flags]")
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "keygen":
+		err = keygen(os.Args[2:])
+	case "sign":
+		err = sign(os.Args[2:])
+	case "verify":
+		err = verify(os.Stdout, os.Args[2:])
+	default:
+		err = fmt.Errorf("unknown command %q", os.Args[1])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "featuregate-bundle:", err)
+		os.Exit(1)
+	}
+}
+
+func keygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	out := fs.String("out", "bundle", "key file prefix")
+	_ = fs.Parse(args)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out+".key", exchange.EncodeKey(priv), 0o600); err != nil {
+		return err
+	}
+	return os.WriteFile(*out+".pub", exchange.EncodeKey(pub), 0o644)
+}
+
+func sign(args []string) error {
+	fs := flag.NewFlagSet("sign", flag.ExitOnError)
+	in := fs.String("in", "", "source JSON with defaults, catalog, and overrides")
+	version := fs.String("version", "", "bundle version")
+	keyPath := fs.String("key", "", "private key file")
+	keyID := fs.String("key-id", "", "key ID recorded in the bundle")
+	out := fs.String("out", "", "output bundle file (stdout when empty)")
+	_ = fs.Parse(args)
+
+	if *in == "" || *keyPath == "" {
+		return fmt.Errorf("-in and -key are required")
+	}
+	raw, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+	manifest := exchange.Manifest{}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return fmt.Errorf("decode %s: %w", *in, err)
+	}
+	if *version != "" {
+		manifest.Version = *version
+	}
+	keyData, err := os.ReadFile(*keyPath)
+	if err != nil {
+		return err
+	}
+	key, err := exchange.DecodePrivateKey(keyData)
+	if err != nil {
+		return err
+	}
+	bundle, err := exchange.Sign(manifest, key, *keyID)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(append(bundle, '\n'))
+		return err
+	}
+	return os.WriteFile(*out, append(bundle, '\n'), 0o644)
+}
+
+func verify(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	pubs := fs.String("pub", "", "comma-separated public key files; the file name without extension is the key ID")
+	_ = fs.Parse(args)
+
+	if *pubs == "" || fs.NArg() != 1 {
+		return fmt.Errorf("usage: verify -pub key.pub[,other.pub] bundle")
+	}
+	keys := exchange.KeyRing{}
+	for _, path := range strings.Split(*pubs, ",") {
+		path = strings.TrimSpace(path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		key, err := exchange.DecodePublicKey(data)
+		if err != nil {
+			return err
+		}
+		keys[strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))] = key
+	}
+	manifest, err := exchange.LoadSigned(fs.Arg(0), keys)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "version %s created %s: %d defaults, %d catalog entries, %d overrides\n",
+		manifest.Version, manifest.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		len(configKeys(manifest.Defaults)), len(manifest.CatalogView().List()), len(manifest.Overrides))
+	return nil
+}
+
+// configKeys counts leaf defaults in a nested map.
+func configKeys(data map[string]any) []string {
+	var out []string
+	for key, value := range data {
+		if nested, ok := value.(map[string]any); ok {
+			for _, child := range configKeys(nested) {
+				out = append(out, key+"."+child)
+			}
+			continue
+		}
+		out = append(out, key)
+	}
+	return out
+}
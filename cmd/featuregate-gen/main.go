@@ -0,0 +1,65 @@
+// Command featuregate-gen emits typed feature key constants from a catalog file.
+//
+// The catalog file is JSON using the same nested shape accepted by configadapter.NewCatalog.
+// Typical go:generate usage:
+//
+//	//go:generate go run github.com/goliatone/go-featuregate/cmd/featuregate-gen -in features.json -out flags_gen.go -package flags
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/codegen"
+)
+
+func main() {
+	in := flag.String("in", "", "path to a JSON catalog file")
+	out := flag.String("out", "", "output Go file (stdout when empty)")
+	pkg := flag.String("package", codegen.DefaultPackage, "generated package name")
+	typeName := flag.String("type", codegen.DefaultTypeName, "typed key name")
+	prefix := flag.String("prefix", "", "identifier prefix")
+	accessors := flag.Bool("accessors", true, "emit per-key accessor functions")
+	facade := flag.Bool("facade", false, "emit a typed facade with one method per feature")
+	facadeName := flag.String("facade-name", codegen.DefaultFacadeName, "facade type name")
+	flag.Parse()
+
+	if err := run(*in, *out, *pkg, *typeName, *prefix, *accessors, *facade, *facadeName); err != nil {
+		fmt.Fprintln(os.Stderr, "featuregate-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pkg, typeName, prefix string, accessors, facade bool, facadeName string) error {
+	if in == "" {
+		return fmt.Errorf("-in is required")
+	}
+	raw, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	data := map[string]any{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("decode %s: %w", in, err)
+	}
+	src, err := codegen.Generate(configadapter.NewCatalog(data).List(),
+		codegen.WithPackage(pkg),
+		codegen.WithTypeName(typeName),
+		codegen.WithPrefix(prefix),
+		codegen.WithAccessors(accessors),
+		codegen.WithFacade(facade),
+		codegen.WithFacadeName(facadeName),
+		codegen.WithSource(in),
+	)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -0,0 +1,87 @@
+// Command featuregate-keys compares feature key references in Go packages with a catalog.
+//
+// It reports catalog entries that no code references and literal keys passed to gate
+// calls that the catalog does not define, and exits non-zero when it finds either.
+// Typical CI usage:
+//
+//	go run github.com/goliatone/go-featuregate/cmd/featuregate-keys -catalog features.json ./...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/goliatone/go-featuregate/analysis/keyrefs"
+)
+
+func main() {
+	catalogPath := flag.String("catalog", "", "path to a JSON catalog file")
+	tests := flag.Bool("tests", false, "include test files")
+	flag.Parse()
+
+	issues, err := run(os.Stdout, *catalogPath, *tests, flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "featuregate-keys:", err)
+		os.Exit(2)
+	}
+	if issues > 0 {
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, catalogPath string, tests bool, patterns []string) (int, error) {
+	if catalogPath == "" {
+		return 0, fmt.Errorf("-catalog is required")
+	}
+	catalog, err := keyrefs.LoadCatalog(catalogPath)
+	if err != nil {
+		return 0, err
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Tests: tests,
+	}, patterns...)
+	if err != nil {
+		return 0, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 0, fmt.Errorf("failed to load packages")
+	}
+
+	var refs, unknown []keyrefs.Reference
+	seen := map[string]bool{}
+	for _, pkg := range pkgs {
+		// With tests enabled the same files appear in several package variants.
+		if seen[pkg.ID] {
+			continue
+		}
+		seen[pkg.ID] = true
+		pkgRefs, pkgUnknown := keyrefs.Collect(pkg.Fset, pkg.Syntax, pkg.TypesInfo, catalog)
+		refs = append(refs, pkgRefs...)
+		unknown = append(unknown, pkgUnknown...)
+	}
+
+	issues := 0
+	reported := map[string]bool{}
+	for _, ref := range unknown {
+		line := fmt.Sprintf("%s: feature key %q is not in the catalog", ref.Pos, ref.Key)
+		if reported[line] {
+			continue
+		}
+		reported[line] = true
+		fmt.Fprintln(w, line)
+		issues++
+	}
+	for _, key := range keyrefs.Unreferenced(catalog, refs) {
+		fmt.Fprintf(w, "%s: catalog key %q is never referenced\n", catalogPath, key)
+		issues++
+	}
+	return issues, nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// authorized reports whether an Authorization header value carries the admin token.
+// An empty token authorizes nothing.
+func authorized(header, token string) bool {
+	provided := strings.TrimPrefix(header, "Bearer ")
+	return token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// guardMutations passes GET and HEAD requests through and requires the admin token
+// for every other method, so overrides cannot be written without it.
+func guardMutations(next http.Handler, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead && !authorized(req.Header.Get("Authorization"), token) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// openCache builds the resolve cache cfg selects. The returned func releases the
+// Redis client, if any.
+func openCache(cfg CacheConfig) (cache.Cache, func() error, error) {
+	ttl := time.Duration(cfg.TTL)
+	switch cfg.Driver {
+	case CacheNone:
+		return cache.NoopCache{}, func() error { return nil }, nil
+	case CacheMemory:
+		return newMemoryCache(ttl), func() error { return nil }, nil
+	case CacheRedis:
+		if cfg.Addr == "" {
+			return nil, nil, fmt.Errorf("cache: redis driver requires an addr")
+		}
+		client := redis.NewClient(&redis.Options{Addr: cfg.Addr, Password: cfg.Password, DB: cfg.DB})
+		return &redisCache{client: client, prefix: cfg.Prefix + ":", ttl: ttl}, client.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("cache: unknown driver %q", cfg.Driver)
+	}
+}
+
+// cacheKey renders key and chain into one string, qualifiers included, so chains
+// that differ only by tenant or org never share an entry.
+func cacheKey(key string, chain gate.ScopeChain) string {
+	var b strings.Builder
+	b.WriteString(key)
+	for _, ref := range chain {
+		fmt.Fprintf(&b, "|%s:%s:%s:%s", ref.Kind, ref.ID, ref.TenantID, ref.OrgID)
+	}
+	return b.String()
+}
+
+// memoryCache keeps resolved values in process. Entries without a policy expiry
+// expire after ttl; a zero ttl keeps them until the next write or reload clears the cache.
+type memoryCache struct {
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[string]cache.Entry
+}
+
+func newMemoryCache(ttl time.Duration) *memoryCache {
+	return &memoryCache{ttl: ttl, entries: map[string]cache.Entry{}}
+}
+
+// Get implements cache.Cache.
+func (c *memoryCache) Get(_ context.Context, key string, chain gate.ScopeChain) (cache.Entry, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[cacheKey(key, chain)]
+	c.mu.RUnlock()
+	if !ok || entry.Expired(time.Now()) {
+		return cache.Entry{}, false
+	}
+	return entry, true
+}
+
+// Set implements cache.Cache.
+func (c *memoryCache) Set(_ context.Context, key string, chain gate.ScopeChain, entry cache.Entry) {
+	if entry.ExpiresAt.IsZero() && c.ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(c.ttl)
+	}
+	c.mu.Lock()
+	c.entries[cacheKey(key, chain)] = entry
+	c.mu.Unlock()
+}
+
+// Delete implements cache.Cache.
+func (c *memoryCache) Delete(_ context.Context, key string, chain gate.ScopeChain) {
+	c.mu.Lock()
+	delete(c.entries, cacheKey(key, chain))
+	c.mu.Unlock()
+}
+
+// Clear implements cache.Cache.
+func (c *memoryCache) Clear(context.Context) {
+	c.mu.Lock()
+	c.entries = map[string]cache.Entry{}
+	c.mu.Unlock()
+}
+
+// redisCache shares resolved values across server instances. Errors are treated as
+// misses: the resolver falls through to the store. Entries keep the value and the
+// trace fields a cache hit reports; the resolver fills in the chain.
+type redisCache struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// Get implements cache.Cache.
+func (c *redisCache) Get(ctx context.Context, key string, chain gate.ScopeChain) (cache.Entry, bool) {
+	raw, err := c.client.Get(ctx, c.prefix+cacheKey(key, chain)).Bytes()
+	if err != nil {
+		return cache.Entry{}, false
+	}
+	stored := redisEntry{}
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return cache.Entry{}, false
+	}
+	return cache.Entry{
+		Value: stored.Value,
+		Trace: gate.ResolveTrace{
+			Key:           stored.Key,
+			NormalizedKey: stored.NormalizedKey,
+			Alias:         stored.Alias,
+			Value:         stored.Value,
+			Source:        stored.Source,
+			Strategy:      stored.Strategy,
+		},
+		ExpiresAt: stored.ExpiresAt,
+	}, true
+}
+
+// redisEntry is the stored form of a cache.Entry. The full trace is not stored:
+// its chain is rebuilt per request and its JSON form does not round-trip.
+type redisEntry struct {
+	Value         bool               `json:"value"`
+	Source        gate.ResolveSource `json:"source"`
+	Key           string             `json:"key,omitempty"`
+	NormalizedKey string             `json:"normalized_key,omitempty"`
+	Alias         string             `json:"alias,omitempty"`
+	Strategy      string             `json:"strategy,omitempty"`
+	ExpiresAt     time.Time          `json:"expires_at,omitzero"`
+}
+
+// Set implements cache.Cache.
+func (c *redisCache) Set(ctx context.Context, key string, chain gate.ScopeChain, entry cache.Entry) {
+	ttl := c.ttl
+	if !entry.ExpiresAt.IsZero() {
+		ttl = time.Until(entry.ExpiresAt)
+		if ttl <= 0 {
+			return
+		}
+	}
+	raw, err := json.Marshal(redisEntry{
+		Value:         entry.Value,
+		Source:        entry.Trace.Source,
+		Key:           entry.Trace.Key,
+		NormalizedKey: entry.Trace.NormalizedKey,
+		Alias:         entry.Trace.Alias,
+		Strategy:      entry.Trace.Strategy,
+		ExpiresAt:     entry.ExpiresAt,
+	})
+	if err != nil {
+		return
+	}
+	c.client.Set(ctx, c.prefix+cacheKey(key, chain), raw, ttl)
+}
+
+// Delete implements cache.Cache.
+func (c *redisCache) Delete(ctx context.Context, key string, chain gate.ScopeChain) {
+	c.client.Del(ctx, c.prefix+cacheKey(key, chain))
+}
+
+// Clear implements cache.Cache by deleting every key under the prefix.
+func (c *redisCache) Clear(ctx context.Context) {
+	iter := c.client.Scan(ctx, 0, c.prefix+"*", 256).Iterator()
+	for iter.Next(ctx) {
+		c.client.Del(ctx, iter.Val())
+	}
+}
+
+var (
+	_ cache.Cache = (*memoryCache)(nil)
+	_ cache.Cache = (*redisCache)(nil)
+)
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/resolver"
+)
+
+const defaultAddr = ":8080"
+
+// Store drivers.
+const (
+	StoreMemory   = "memory"
+	StoreSQLite   = "sqlite"
+	StorePostgres = "postgres"
+)
+
+// Cache drivers.
+const (
+	CacheNone   = "none"
+	CacheMemory = "memory"
+	CacheRedis  = "redis"
+)
+
+// Config is the standalone server configuration file.
+type Config struct {
+	Addr        string `json:"addr"`
+	GRPCAddr    string `json:"grpc_addr"`
+	StrictStore bool   `json:"strict_store"`
+	// AdminToken guards POST /reload, PUT and DELETE /features/{key}, and the gRPC
+	// Set and Unset methods. Without it the server is read-only.
+	AdminToken string         `json:"admin_token"`
+	Store      StoreConfig    `json:"store"`
+	Cache      CacheConfig    `json:"cache"`
+	Defaults   map[string]any `json:"defaults"`
+	Catalog    map[string]any `json:"catalog"`
+	// Strategies maps key patterns (an exact key, "prefix.*", or "*") to registered
+	// resolve strategy names, for example {"billing.*": "most_restrictive_wins"}.
+	// Keys without one use the default strategy.
+	Strategies map[string]string `json:"strategies"`
+}
+
+// StoreConfig selects the override store. Memory keeps overrides for the life of the
+// process; sqlite and postgres persist them through the bun adapter, creating or
+// upgrading the table on start.
+type StoreConfig struct {
+	Driver string `json:"driver"`
+	DSN    string `json:"dsn"`
+	Table  string `json:"table"`
+	// CacheTTL wraps the store in a read-through cache when positive.
+	CacheTTL Duration `json:"cache_ttl"`
+}
+
+// CacheConfig selects the resolve cache. Redis shares resolved values, and the
+// clears that follow writes and reloads, across instances.
+type CacheConfig struct {
+	Driver   string   `json:"driver"`
+	TTL      Duration `json:"ttl"`
+	Addr     string   `json:"addr"`
+	Password string   `json:"password"`
+	DB       int      `json:"db"`
+	Prefix   string   `json:"prefix"`
+}
+
+// Duration reads a time.Duration from a JSON string such as "30s".
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(raw []byte) error {
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func loadConfig(path string) (Config, error) {
+	cfg := Config{}
+	if strings.TrimSpace(path) != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+	cfg.Addr = strings.TrimSpace(cfg.Addr)
+	cfg.GRPCAddr = strings.TrimSpace(cfg.GRPCAddr)
+	cfg.AdminToken = strings.TrimSpace(cfg.AdminToken)
+	if cfg.Addr == "" {
+		cfg.Addr = defaultAddr
+	}
+	cfg.Store.Driver = strings.ToLower(strings.TrimSpace(cfg.Store.Driver))
+	if cfg.Store.Driver == "" {
+		cfg.Store.Driver = StoreMemory
+	}
+	cfg.Cache.Driver = strings.ToLower(strings.TrimSpace(cfg.Cache.Driver))
+	if cfg.Cache.Driver == "" {
+		cfg.Cache.Driver = CacheNone
+	}
+	if cfg.Cache.Prefix == "" {
+		cfg.Cache.Prefix = "featuregate"
+	}
+	for pattern, name := range cfg.Strategies {
+		if _, ok := resolver.LookupStrategy(name); !ok {
+			return Config{}, fmt.Errorf("strategies: %q uses unknown strategy %q", pattern, name)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	goerrors "github.com/goliatone/go-errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/httpapi"
+)
+
+// grpcServiceName is the gRPC service the server registers. Messages are JSON
+// encoded: clients call with the "json" content subtype, for example
+// grpc.CallContentSubtype("json") in Go.
+const grpcServiceName = "featuregate.v1.FeatureGate"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes gRPC messages as JSON, so the service shares the httpapi payloads.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return "json" }
+
+// ResolveMessage is the Resolve request. The claims fields build the scope chain
+// the way gate.WithClaims does; System resolves at the system scope only.
+type ResolveMessage struct {
+	Key       string   `json:"key"`
+	System    bool     `json:"system,omitempty"`
+	SubjectID string   `json:"subject_id,omitempty"`
+	TenantID  string   `json:"tenant_id,omitempty"`
+	OrgID     string   `json:"org_id,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	Perms     []string `json:"perms,omitempty"`
+	Env       string   `json:"env,omitempty"`
+}
+
+// UpdateMessage is the Set and Unset request: the PUT and DELETE /features/{key}
+// body plus the key.
+type UpdateMessage struct {
+	Key string `json:"key"`
+	httpapi.UpdateRequest
+}
+
+// Empty is the Set and Unset response.
+type Empty struct{}
+
+type featureGateServer interface {
+	resolve(ctx context.Context, req *ResolveMessage) (any, error)
+	set(ctx context.Context, req *UpdateMessage) (any, error)
+	unset(ctx context.Context, req *UpdateMessage) (any, error)
+}
+
+// grpcServer serves Resolve, Set, and Unset. Set and Unset require the admin token
+// in the authorization metadata, as PUT and DELETE do over HTTP.
+type grpcServer struct {
+	gate  gate.FeatureGate
+	token string
+}
+
+func newGRPCServer(featureGate gate.FeatureGate, token string) *grpc.Server {
+	srv := grpc.NewServer()
+	srv.RegisterService(&featureGateServiceDesc, &grpcServer{gate: featureGate, token: token})
+	return srv
+}
+
+var featureGateServiceDesc = grpc.ServiceDesc{
+	ServiceName: grpcServiceName,
+	HandlerType: (*featureGateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Resolve", Handler: unaryHandler("Resolve", featureGateServer.resolve)},
+		{MethodName: "Set", Handler: unaryHandler("Set", featureGateServer.set)},
+		{MethodName: "Unset", Handler: unaryHandler("Unset", featureGateServer.unset)},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func unaryHandler[Req any](method string, call func(featureGateServer, context.Context, *Req) (any, error)) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return call(srv.(featureGateServer), ctx, req.(*Req))
+		}
+		if interceptor == nil {
+			return handler(ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + grpcServiceName + "/" + method}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+func (s *grpcServer) resolve(ctx context.Context, req *ResolveMessage) (any, error) {
+	opts := []gate.ResolveOption{gate.WithClaims(gate.ActorClaims{
+		SubjectID: req.SubjectID,
+		TenantID:  req.TenantID,
+		OrgID:     req.OrgID,
+		Roles:     req.Roles,
+		Perms:     req.Perms,
+		Env:       req.Env,
+	})}
+	if req.System {
+		opts = []gate.ResolveOption{gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})}
+	}
+	resp := httpapi.FeatureResponse{Key: gate.NormalizeKey(req.Key)}
+	if traceable, ok := s.gate.(gate.TraceableFeatureGate); ok {
+		value, trace, err := traceable.ResolveWithTrace(ctx, req.Key, opts...)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		resp.Enabled = value
+		resp.Source = trace.Source
+		if at, ok := trace.ActivatesAt(); ok {
+			resp.ActivatesAt = &at
+		}
+		return &resp, nil
+	}
+	value, err := s.gate.Enabled(ctx, req.Key, opts...)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp.Enabled = value
+	return &resp, nil
+}
+
+func (s *grpcServer) set(ctx context.Context, req *UpdateMessage) (any, error) {
+	mutable, scope, err := s.mutation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Enabled == nil {
+		return nil, status.Error(codes.InvalidArgument, "enabled is required")
+	}
+	meta := gate.WithMetadata(gate.OverrideMetadata{
+		Reason:    req.Reason,
+		TicketURL: req.TicketURL,
+		Owner:     req.Owner,
+		Labels:    req.Labels,
+	})
+	if err := mutable.Set(ctx, req.Key, scope, *req.Enabled, req.Actor.ActorRef(), meta, gate.WithPriority(req.Priority)); err != nil {
+		return nil, grpcError(err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *grpcServer) unset(ctx context.Context, req *UpdateMessage) (any, error) {
+	mutable, scope, err := s.mutation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := mutable.Unset(ctx, req.Key, scope, req.Actor.ActorRef()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &Empty{}, nil
+}
+
+// mutation checks the admin token and the gate, and parses the target scope.
+func (s *grpcServer) mutation(ctx context.Context, req *UpdateMessage) (gate.MutableFeatureGate, gate.ScopeRef, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := ""
+	if values := md.Get("authorization"); len(values) > 0 {
+		header = values[0]
+	}
+	if !authorized(header, s.token) {
+		return nil, gate.ScopeRef{}, status.Error(codes.Unauthenticated, "admin token required")
+	}
+	mutable, ok := s.gate.(gate.MutableFeatureGate)
+	if !ok {
+		return nil, gate.ScopeRef{}, status.Error(codes.Unimplemented, "feature gate is read-only")
+	}
+	scope, err := req.Scope.ScopeRef()
+	if err != nil {
+		return nil, gate.ScopeRef{}, grpcError(err)
+	}
+	return mutable, scope, nil
+}
+
+// grpcError maps gate errors to status codes the way httpapi maps them to HTTP statuses.
+func grpcError(err error) error {
+	code := codes.Internal
+	message := err.Error()
+	if rich, ok := ferrors.As(err); ok {
+		message = rich.Message
+		switch {
+		case rich.Category == goerrors.CategoryBadInput:
+			code = codes.InvalidArgument
+		case rich.TextCode == ferrors.TextCodeStoreUnavailable:
+			code = codes.Unimplemented
+		case rich.Category == goerrors.CategoryExternal:
+			code = codes.Unavailable
+		}
+	} else if errors.Is(err, context.Canceled) {
+		code = codes.Canceled
+	}
+	return status.Error(code, message)
+}
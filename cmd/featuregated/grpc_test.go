@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/httpapi"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func dialGRPC(t *testing.T, srv *server) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = srv.grpc.Serve(listener) }()
+	t.Cleanup(srv.grpc.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype("json")),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCResolveAndGuardedWrites(t *testing.T) {
+	srv := newServer("", Config{AdminToken: "secret", Defaults: map[string]any{"dashboard": false}}, store.NewMemoryStore(), cache.NoopCache{})
+	conn := dialGRPC(t, srv)
+	ctx := context.Background()
+
+	update := UpdateMessage{Key: "dashboard"}
+	enabled := true
+	update.Enabled = &enabled
+	update.Scope = httpapi.ScopePayload{Kind: "tenant", ID: "acme", TenantID: "acme"}
+
+	err := conn.Invoke(ctx, "/"+grpcServiceName+"/Set", &update, &Empty{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without token, got %v", err)
+	}
+	authed := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	if err := conn.Invoke(authed, "/"+grpcServiceName+"/Set", &update, &Empty{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	resp := httpapi.FeatureResponse{}
+	if err := conn.Invoke(ctx, "/"+grpcServiceName+"/Resolve", &ResolveMessage{Key: "dashboard", TenantID: "acme"}, &resp); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if !resp.Enabled || resp.Source != gate.ResolveSourceOverride {
+		t.Fatalf("expected the tenant override, got %+v", resp)
+	}
+	if err := conn.Invoke(ctx, "/"+grpcServiceName+"/Resolve", &ResolveMessage{Key: "dashboard", TenantID: "globex"}, &resp); err != nil || resp.Enabled {
+		t.Fatalf("expected the default for another tenant, got %+v (%v)", resp, err)
+	}
+
+	update.Scope = httpapi.ScopePayload{Kind: "galaxy"}
+	if err := conn.Invoke(authed, "/"+grpcServiceName+"/Unset", &update, &Empty{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an unknown scope, got %v", err)
+	}
+}
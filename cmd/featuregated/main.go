@@ -0,0 +1,167 @@
+// Command featuregated runs go-featuregate as a standalone flag service over HTTP
+// and gRPC.
+//
+// The server reads a JSON config file with config defaults, catalog definitions,
+// the override store (memory, sqlite, or postgres through the bun adapter), and
+// the resolve cache (none, memory, or redis). It exposes the gate through the
+// httpapi package, including /watch server-sent events, and, when grpc_addr is
+// set, through the featuregate.v1.FeatureGate gRPC service. Writes over either
+// transport require the admin token. Send SIGHUP or POST /reload (with the admin
+// token) to reload defaults, catalog, and per-key strategies without a restart.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/httpapi"
+	"github.com/goliatone/go-featuregate/logger"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+	"github.com/goliatone/go-featuregate/usage"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to a JSON config file")
+	addr := flag.String("addr", "", "listen address (overrides config)")
+	grpcAddr := flag.String("grpc-addr", "", "gRPC listen address (overrides config)")
+	flag.Parse()
+
+	lgr := logger.Default()
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		lgr.Error("featuregated.config_failed", "path", *configPath, "error", err)
+		os.Exit(1)
+	}
+	if *addr != "" {
+		cfg.Addr = *addr
+	}
+	if *grpcAddr != "" {
+		cfg.GRPCAddr = *grpcAddr
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	overrides, closeStore, err := openStore(ctx, cfg.Store)
+	if err != nil {
+		lgr.Error("featuregated.store_failed", "driver", cfg.Store.Driver, "error", err)
+		os.Exit(1)
+	}
+	defer closeStore()
+	resolveCache, closeCache, err := openCache(cfg.Cache)
+	if err != nil {
+		lgr.Error("featuregated.cache_failed", "driver", cfg.Cache.Driver, "error", err)
+		os.Exit(1)
+	}
+	defer closeCache()
+
+	hook := activity.HookFunc(func(_ context.Context, event activity.UpdateEvent) {
+		if event.Action == activity.ActionReload && event.Diff != nil {
+			lgr.Info("featuregated.reloaded", "added", event.Diff.Added, "removed", event.Diff.Removed, "changed", event.Diff.Changed)
+			return
+		}
+		lgr.Info("featuregated.updated", "action", event.Action, "key", event.NormalizedKey, "scope", event.Scope)
+	})
+	srv := newServer(*configPath, cfg, overrides, resolveCache, hook)
+
+	server := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           srv.handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				if _, err := srv.reloader.Reload(ctx, gate.ActorRef{Type: "signal", Name: "SIGHUP"}); err != nil {
+					lgr.Error("featuregated.reload_failed", "error", err)
+				}
+			}
+		}
+	}()
+
+	go func() {
+		lgr.Info("featuregated.listening", "addr", cfg.Addr, "store", cfg.Store.Driver, "cache", cfg.Cache.Driver)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			lgr.Error("featuregated.serve_failed", "error", err)
+			stop()
+		}
+	}()
+
+	if cfg.GRPCAddr != "" {
+		listener, err := net.Listen("tcp", cfg.GRPCAddr)
+		if err != nil {
+			lgr.Error("featuregated.grpc_listen_failed", "addr", cfg.GRPCAddr, "error", err)
+			stop()
+		} else {
+			go func() {
+				lgr.Info("featuregated.grpc_listening", "addr", cfg.GRPCAddr)
+				if err := srv.grpc.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+					lgr.Error("featuregated.grpc_serve_failed", "error", err)
+					stop()
+				}
+			}()
+		}
+	}
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	srv.grpc.GracefulStop()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		lgr.Error("featuregated.shutdown_failed", "error", err)
+	}
+}
+
+type server struct {
+	handler  http.Handler
+	grpc     *grpc.Server
+	reloader *reloader
+}
+
+func newServer(path string, cfg Config, overrides store.ReadWriter, resolveCache cache.Cache, hooks ...activity.Hook) *server {
+	watcher := activity.NewBroadcaster()
+	history := activity.NewLog()
+	hooks = append(hooks, watcher, history)
+	reload := newReloader(path, cfg, resolveCache, hooks...)
+	counter := usage.New(usage.WithCatalog(reload))
+	opts := []resolver.Option{
+		resolver.WithDefaults(reload),
+		resolver.WithNamedResolveStrategy("config", reload.ResolveStrategy),
+		resolver.WithOverrideStore(overrides),
+		resolver.WithStrictStore(cfg.StrictStore),
+		resolver.WithCache(resolveCache),
+		resolver.WithResolveHook(counter),
+	}
+	for _, hook := range hooks {
+		opts = append(opts, resolver.WithActivityHook(hook))
+	}
+	featureGate := resolver.New(opts...)
+
+	mux := http.NewServeMux()
+	api := httpapi.New(featureGate, httpapi.WithCatalog(reload), httpapi.WithWatcher(watcher), httpapi.WithUsage(counter), httpapi.WithHistory(history))
+	mux.Handle("/", guardMutations(api, cfg.AdminToken))
+	if cfg.AdminToken != "" {
+		mux.Handle("/reload", reloadHandler(reload, cfg.AdminToken))
+	}
+	return &server{handler: mux, grpc: newGRPCServer(featureGate, cfg.AdminToken), reloader: reload}
+}
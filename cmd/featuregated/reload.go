@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// reloader holds the reloadable parts of the server config: defaults, catalog, and
+// the per-key strategies that decide between overrides. Listen addresses, store,
+// cache, and token settings require a restart.
+type reloader struct {
+	path       string
+	defaults   atomic.Pointer[configadapter.Defaults]
+	catalog    atomic.Pointer[catalog.StaticCatalog]
+	strategies atomic.Pointer[strategyRules]
+	cache      cache.Cache
+	hooks      []activity.Hook
+	mu         sync.Mutex
+}
+
+func newReloader(path string, cfg Config, c cache.Cache, hooks ...activity.Hook) *reloader {
+	r := &reloader{path: path, cache: c, hooks: hooks}
+	r.apply(cfg)
+	return r
+}
+
+// Default implements resolver.Defaults.
+func (r *reloader) Default(ctx context.Context, key string) (resolver.DefaultResult, error) {
+	return r.defaults.Load().Default(ctx, key)
+}
+
+// Get implements catalog.Catalog.
+func (r *reloader) Get(key string) (catalog.FeatureDefinition, bool) {
+	return r.catalog.Load().Get(key)
+}
+
+// List implements catalog.Catalog.
+func (r *reloader) List() []catalog.FeatureDefinition {
+	return r.catalog.Load().List()
+}
+
+// ResolveStrategy implements resolver.ResolveStrategy with the configured
+// strategies. Keys without one, or whose strategy is no longer registered, use
+// resolver.DefaultResolveStrategy.
+func (r *reloader) ResolveStrategy(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch, opts resolver.ResolveOptions) (resolver.OverrideDecision, gate.ResolveTrace, error) {
+	if name := r.strategies.Load().lookup(key); name != "" {
+		if fn, ok := resolver.LookupStrategy(name); ok {
+			return fn(ctx, key, chain, matches, opts)
+		}
+	}
+	return resolver.DefaultResolveStrategy(ctx, key, chain, matches, opts)
+}
+
+// Reload re-reads the config file, swaps defaults, catalog, and strategies, clears
+// the cache, and emits a reload activity event with a diff summary.
+func (r *reloader) Reload(ctx context.Context, actor gate.ActorRef) (activity.DiffSummary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := loadConfig(r.path)
+	if err != nil {
+		return activity.DiffSummary{}, err
+	}
+	before := r.signatures()
+	r.apply(cfg)
+	diff := diffSignatures(before, r.signatures())
+
+	if r.cache != nil {
+		r.cache.Clear(ctx)
+	}
+	event := activity.UpdateEvent{
+		Action: activity.ActionReload,
+		Actor:  actor,
+		Diff:   &diff,
+	}
+	for _, hook := range r.hooks {
+		if hook != nil {
+			hook.OnUpdate(ctx, event)
+		}
+	}
+	return diff, nil
+}
+
+func (r *reloader) apply(cfg Config) {
+	r.defaults.Store(configadapter.NewDefaults(cfg.Defaults))
+	r.catalog.Store(configadapter.NewCatalog(cfg.Catalog))
+	r.strategies.Store(newStrategyRules(cfg.Strategies))
+}
+
+// signatures captures a comparable value per key (or strategy pattern) across
+// defaults, catalog, and strategies.
+func (r *reloader) signatures() map[string]string {
+	out := map[string]string{}
+	for key, def := range r.defaults.Load().Snapshot() {
+		out[key] = fmt.Sprintf("default=%t/%t", def.Set, def.Value)
+	}
+	for _, def := range r.List() {
+		out[def.Key] += fmt.Sprintf("|description=%s/%s", def.Description.Key, def.Description.Text)
+	}
+	for pattern, name := range r.strategies.Load().patterns {
+		out[pattern] += "|strategy=" + name
+	}
+	return out
+}
+
+// strategyRules maps key patterns to strategy names with the rules of
+// resolver.WithStrategyName: exact keys win over prefixes, and longer prefixes
+// win over shorter ones.
+type strategyRules struct {
+	patterns map[string]string
+	exact    map[string]string
+	prefixes map[string]string
+}
+
+func newStrategyRules(patterns map[string]string) *strategyRules {
+	rules := &strategyRules{patterns: map[string]string{}, exact: map[string]string{}, prefixes: map[string]string{}}
+	for pattern, name := range patterns {
+		pattern, name = strings.TrimSpace(pattern), strings.TrimSpace(name)
+		if pattern == "" || name == "" {
+			continue
+		}
+		rules.patterns[pattern] = name
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			rules.prefixes[gate.NormalizeKey(prefix)] = name
+			continue
+		}
+		rules.exact[gate.NormalizeKey(pattern)] = name
+	}
+	return rules
+}
+
+func (s *strategyRules) lookup(key string) string {
+	if name, ok := s.exact[key]; ok {
+		return name
+	}
+	best, found := -1, ""
+	for prefix, name := range s.prefixes {
+		if len(prefix) > best && strings.HasPrefix(key, prefix) {
+			best, found = len(prefix), name
+		}
+	}
+	return found
+}
+
+func diffSignatures(before, after map[string]string) activity.DiffSummary {
+	diff := activity.DiffSummary{}
+	for key, sig := range after {
+		prev, ok := before[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, key)
+		case prev != sig:
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
+
+// reloadHandler serves POST /reload guarded by the admin token.
+func reloadHandler(r *reloader, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		if !authorized(req.Header.Get("Authorization"), token) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		diff, err := r.Reload(req.Context(), gate.ActorRef{Type: "http", Name: "reload_endpoint"})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string][]string{
+			"added":   diff.Added,
+			"removed": diff.Removed,
+			"changed": diff.Changed,
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func writeConfig(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func TestReloadSwapsDefaultsAndEmitsDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "featuregated.json")
+	writeConfig(t, path, `{"admin_token": "secret", "defaults": {"dashboard": true, "cms": true}}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	var events []activity.UpdateEvent
+	hook := activity.HookFunc(func(_ context.Context, event activity.UpdateEvent) {
+		events = append(events, event)
+	})
+	srv := newServer(path, cfg, store.NewMemoryStore(), cache.NoopCache{}, hook)
+
+	writeConfig(t, path, `{"admin_token": "secret", "defaults": {"dashboard": false, "debug": true}}`)
+
+	rec := httptest.NewRecorder()
+	srv.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/reload", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	srv.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if len(events) != 1 || events[0].Action != activity.ActionReload || events[0].Diff == nil {
+		t.Fatalf("expected reload event, got %+v", events)
+	}
+	diff := events[0].Diff
+	if len(diff.Added) != 1 || diff.Added[0] != "debug" {
+		t.Fatalf("unexpected added keys: %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != "cms" {
+		t.Fatalf("unexpected removed keys: %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0] != "dashboard" {
+		t.Fatalf("unexpected changed keys: %v", diff.Changed)
+	}
+
+	value, err := srv.reloader.Default(context.Background(), "dashboard")
+	if err != nil || !value.Set || value.Value {
+		t.Fatalf("expected reloaded default, got %+v (%v)", value, err)
+	}
+}
+
+func TestReloadSwapsStrategies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "featuregated.json")
+	writeConfig(t, path, `{"admin_token": "secret", "defaults": {"reports": false}}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	srv := newServer(path, cfg, store.NewMemoryStore(), cache.NoopCache{})
+	for _, body := range []string{
+		`{"enabled": true, "scope": {"kind": "user", "id": "u1", "tenant_id": "acme"}}`,
+		`{"enabled": false, "scope": {"kind": "tenant", "id": "acme", "tenant_id": "acme"}}`,
+	} {
+		if rec := serve(srv, http.MethodPut, "/features/reports", "secret", body); rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	const resolvePath = "/features/reports?tenant_id=acme&user_id=u1"
+	if rec := serve(srv, http.MethodGet, resolvePath, "", ""); !strings.Contains(rec.Body.String(), `"enabled":true`) {
+		t.Fatalf("expected the user override to win by default, got %s", rec.Body.String())
+	}
+
+	writeConfig(t, path, `{"admin_token": "secret", "defaults": {"reports": false}, "strategies": {"report*": "most_restrictive_wins"}}`)
+	diff, err := srv.reloader.Reload(context.Background(), gate.ActorRef{})
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != "report*" {
+		t.Fatalf("expected the strategy pattern in the diff, got %+v", diff)
+	}
+	if rec := serve(srv, http.MethodGet, resolvePath, "", ""); !strings.Contains(rec.Body.String(), `"enabled":false`) {
+		t.Fatalf("expected the reloaded strategy to let the tenant deny win, got %s", rec.Body.String())
+	}
+
+	writeConfig(t, path, `{"strategies": {"reports": "no_such_strategy"}}`)
+	if _, err := srv.reloader.Reload(context.Background(), gate.ActorRef{}); err == nil {
+		t.Fatal("expected an unknown strategy to fail the reload")
+	}
+	if rec := serve(srv, http.MethodGet, resolvePath, "", ""); !strings.Contains(rec.Body.String(), `"enabled":false`) {
+		t.Fatalf("expected a failed reload to keep the previous strategies, got %s", rec.Body.String())
+	}
+}
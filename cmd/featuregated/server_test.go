@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func serve(srv *server, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	srv.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMutationsRequireAdminToken(t *testing.T) {
+	srv := newServer("", Config{AdminToken: "secret"}, store.NewMemoryStore(), cache.NoopCache{})
+	body := `{"enabled": true, "scope": {"kind": "system"}}`
+
+	if rec := serve(srv, http.MethodPut, "/features/dashboard", "", body); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for PUT without token, got %d", rec.Code)
+	}
+	if rec := serve(srv, http.MethodDelete, "/features/dashboard", "wrong", body); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for DELETE with a wrong token, got %d", rec.Code)
+	}
+	if rec := serve(srv, http.MethodPut, "/features/dashboard", "secret", body); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 with token, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(srv, http.MethodGet, "/features/dashboard?system=true", "", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"enabled":true`) {
+		t.Fatalf("expected reads without token, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	readOnly := newServer("", Config{}, store.NewMemoryStore(), cache.NoopCache{})
+	if rec := serve(readOnly, http.MethodPut, "/features/dashboard", "", body); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected writes to be refused without an admin token configured, got %d", rec.Code)
+	}
+}
+
+func TestLoadConfigBuildsStoreAndCache(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "featuregated.json")
+	writeConfig(t, path, `{
+		"admin_token": "secret",
+		"store": {"driver": "sqlite", "dsn": "file:`+filepath.Join(dir, "flags.db")+`", "cache_ttl": "1s"},
+		"cache": {"driver": "memory", "ttl": "30s"}
+	}`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AdminToken != "secret" || time.Duration(cfg.Cache.TTL) != 30*time.Second {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	ctx := context.Background()
+	overrides, closeStore, err := openStore(ctx, cfg.Store)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	resolveCache, _, err := openCache(cfg.Cache)
+	if err != nil {
+		t.Fatalf("open cache: %v", err)
+	}
+	srv := newServer(path, cfg, overrides, resolveCache)
+	if rec := serve(srv, http.MethodPut, "/features/dashboard", "secret", `{"enabled": true, "scope": {"kind": "tenant", "id": "acme", "tenant_id": "acme"}}`); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := closeStore(); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+
+	reopened, closeStore, err := openStore(ctx, cfg.Store)
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	defer closeStore()
+	matches, err := reopened.GetAll(ctx, "dashboard", gate.ScopeChain{{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}})
+	if err != nil || len(matches) != 1 || !matches[0].Override.Value {
+		t.Fatalf("expected the override to persist in sqlite, got %+v (%v)", matches, err)
+	}
+
+	for _, bad := range []Config{{Store: StoreConfig{Driver: "etcd"}}, {Store: StoreConfig{Driver: StorePostgres}}} {
+		if _, _, err := openStore(ctx, bad.Store); err == nil {
+			t.Fatalf("expected %+v to be rejected", bad.Store)
+		}
+	}
+	if _, _, err := openCache(CacheConfig{Driver: CacheRedis}); err == nil {
+		t.Fatal("expected a redis cache without an addr to be rejected")
+	}
+}
+
+func TestMemoryCacheExpiresAndClears(t *testing.T) {
+	ctx := context.Background()
+	c := newMemoryCache(time.Minute)
+	acme := gate.ScopeChain{{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}}
+	globex := gate.ScopeChain{{Kind: gate.ScopeTenant, ID: "globex", TenantID: "globex"}}
+
+	c.Set(ctx, "dashboard", acme, cache.Entry{Value: true})
+	if entry, ok := c.Get(ctx, "dashboard", acme); !ok || !entry.Value {
+		t.Fatalf("expected a hit, got %+v", entry)
+	}
+	if _, ok := c.Get(ctx, "dashboard", globex); ok {
+		t.Fatal("expected chains to be cached apart")
+	}
+	c.Set(ctx, "reports", acme, cache.Entry{Value: true, ExpiresAt: time.Now().Add(-time.Second)})
+	if _, ok := c.Get(ctx, "reports", acme); ok {
+		t.Fatal("expected an expired entry to miss")
+	}
+	c.Clear(ctx)
+	if _, ok := c.Get(ctx, "dashboard", acme); ok {
+		t.Fatal("expected clear to drop entries")
+	}
+}
+
+func TestRedisCacheRoundTripsEntries(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	c := &redisCache{client: client, prefix: "fg:", ttl: time.Minute}
+	acme := gate.ScopeChain{{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}}
+	globex := gate.ScopeChain{{Kind: gate.ScopeTenant, ID: "globex", TenantID: "globex"}}
+
+	c.Set(ctx, "dashboard", acme, cache.Entry{Value: true, Trace: gate.ResolveTrace{
+		Key:           "dashboard",
+		NormalizedKey: "dashboard",
+		Value:         true,
+		Source:        gate.ResolveSourceOverride,
+		Chain:         acme,
+	}})
+	entry, ok := c.Get(ctx, "dashboard", acme)
+	if !ok || !entry.Value {
+		t.Fatalf("expected a hit, got %+v", entry)
+	}
+	if entry.Trace.Source != gate.ResolveSourceOverride || entry.Trace.NormalizedKey != "dashboard" {
+		t.Fatalf("expected the trace to survive the round trip, got %+v", entry.Trace)
+	}
+	if _, ok := c.Get(ctx, "dashboard", globex); ok {
+		t.Fatal("expected chains to be cached apart")
+	}
+	if ttl := mr.TTL("fg:" + cacheKey("dashboard", acme)); ttl != time.Minute {
+		t.Fatalf("expected the configured ttl, got %v", ttl)
+	}
+	c.Clear(ctx)
+	if _, ok := c.Get(ctx, "dashboard", acme); ok {
+		t.Fatal("expected clear to drop entries")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/driver/sqliteshim"
+
+	"github.com/goliatone/go-featuregate/adapters/bunadapter"
+	"github.com/goliatone/go-featuregate/adapters/bunadapter/migrations"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// openStore builds the override store cfg selects. The returned func releases the
+// database handle, if any.
+func openStore(ctx context.Context, cfg StoreConfig) (store.ReadWriter, func() error, error) {
+	var overrides store.ReadWriter
+	closeStore := func() error { return nil }
+	switch cfg.Driver {
+	case StoreMemory:
+		overrides = store.NewMemoryStore()
+	case StoreSQLite, StorePostgres:
+		db, err := openDB(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		table := cfg.Table
+		if table == "" {
+			table = bunadapter.DefaultTable
+		}
+		if err := migrations.EnsureSchema(ctx, db, migrations.WithTable(table)); err != nil {
+			_ = db.Close()
+			return nil, nil, fmt.Errorf("store: migrate %s: %w", table, err)
+		}
+		overrides = bunadapter.NewStore(db, bunadapter.WithTable(table))
+		closeStore = db.Close
+	default:
+		return nil, nil, fmt.Errorf("store: unknown driver %q", cfg.Driver)
+	}
+	if ttl := time.Duration(cfg.CacheTTL); ttl > 0 {
+		overrides = store.NewCachedReadWriter(overrides, store.WithCacheTTL(ttl))
+	}
+	return overrides, closeStore, nil
+}
+
+func openDB(cfg StoreConfig) (*bun.DB, error) {
+	if cfg.DSN == "" {
+		return nil, fmt.Errorf("store: %s driver requires a dsn", cfg.Driver)
+	}
+	if cfg.Driver == StorePostgres {
+		sqlDB := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(cfg.DSN)))
+		return bun.NewDB(sqlDB, pgdialect.New()), nil
+	}
+	sqlDB, err := sql.Open(sqliteshim.ShimName, cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("store: open sqlite: %w", err)
+	}
+	// SQLite allows one writer at a time; a single connection avoids busy errors.
+	sqlDB.SetMaxOpenConns(1)
+	return bun.NewDB(sqlDB, sqlitedialect.New()), nil
+}
@@ -0,0 +1,295 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const (
+	// DefaultPackage is the package name used for generated files.
+	DefaultPackage = "flags"
+	// DefaultTypeName is the typed key name used for generated constants.
+	DefaultTypeName = "Key"
+	// DefaultFacadeName is the type name of the generated facade.
+	DefaultFacadeName = "Features"
+	// TextCodeIdentifierConflict signals two keys mapping to the same Go identifier.
+	TextCodeIdentifierConflict = "CODEGEN_IDENTIFIER_CONFLICT"
+)
+
+// Options configures code generation.
+type Options struct {
+	Package   string
+	TypeName  string
+	Prefix    string
+	Accessors bool
+	Facade    bool
+	// FacadeName names the facade type emitted when Facade is set.
+	FacadeName string
+	Source     string
+}
+
+// Option customizes code generation.
+type Option func(*Options)
+
+// WithPackage sets the generated package name.
+func WithPackage(name string) Option {
+	return func(opts *Options) {
+		if opts == nil {
+			return
+		}
+		opts.Package = strings.TrimSpace(name)
+	}
+}
+
+// WithTypeName sets the typed key name.
+func WithTypeName(name string) Option {
+	return func(opts *Options) {
+		if opts == nil {
+			return
+		}
+		opts.TypeName = strings.TrimSpace(name)
+	}
+}
+
+// WithPrefix prepends a prefix to every generated identifier.
+func WithPrefix(prefix string) Option {
+	return func(opts *Options) {
+		if opts == nil {
+			return
+		}
+		opts.Prefix = strings.TrimSpace(prefix)
+	}
+}
+
+// WithAccessors toggles per-key accessor functions (enabled by default).
+func WithAccessors(enabled bool) Option {
+	return func(opts *Options) {
+		if opts == nil {
+			return
+		}
+		opts.Accessors = enabled
+	}
+}
+
+// WithFacade toggles a typed facade struct with one method per feature.
+func WithFacade(enabled bool) Option {
+	return func(opts *Options) {
+		if opts == nil {
+			return
+		}
+		opts.Facade = enabled
+	}
+}
+
+// WithFacadeName sets the facade type name.
+func WithFacadeName(name string) Option {
+	return func(opts *Options) {
+		if opts == nil {
+			return
+		}
+		opts.FacadeName = strings.TrimSpace(name)
+	}
+}
+
+// WithSource records the catalog source path in the generated header.
+func WithSource(source string) Option {
+	return func(opts *Options) {
+		if opts == nil {
+			return
+		}
+		opts.Source = strings.TrimSpace(source)
+	}
+}
+
+// Flag is a single generated feature key.
+type Flag struct {
+	Ident       string
+	Key         string
+	Description string
+}
+
+// Generate renders a Go file with typed constants and accessors for the catalog definitions.
+func Generate(defs []catalog.FeatureDefinition, opts ...Option) ([]byte, error) {
+	cfg := Options{
+		Package:    DefaultPackage,
+		TypeName:   DefaultTypeName,
+		Accessors:  true,
+		FacadeName: DefaultFacadeName,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.Package == "" {
+		cfg.Package = DefaultPackage
+	}
+	if cfg.TypeName == "" {
+		cfg.TypeName = DefaultTypeName
+	}
+	if cfg.FacadeName == "" {
+		cfg.FacadeName = DefaultFacadeName
+	}
+
+	flags, err := Flags(defs, cfg.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, struct {
+		Options
+		Flags []Flag
+	}{Options: cfg, Flags: flags}); err != nil {
+		return nil, ferrors.WrapInternal(err, "", "codegen: render failed", nil)
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, ferrors.WrapInternal(err, "", "codegen: format failed", nil)
+	}
+	return out, nil
+}
+
+// Flags maps catalog definitions to generated identifiers, sorted by key.
+func Flags(defs []catalog.FeatureDefinition, prefix string) ([]Flag, error) {
+	out := make([]Flag, 0, len(defs))
+	seen := map[string]string{}
+	for _, def := range defs {
+		key := gate.NormalizeKey(def.Key)
+		if key == "" {
+			continue
+		}
+		ident := Identifier(prefix, key)
+		if existing, ok := seen[ident]; ok && existing != key {
+			return nil, ferrors.NewBadInput(TextCodeIdentifierConflict, fmt.Sprintf("codegen: keys %q and %q both map to %s", existing, key, ident), map[string]any{
+				ferrors.MetaFeatureKey: key,
+			})
+		}
+		seen[ident] = key
+		out = append(out, Flag{
+			Ident:       ident,
+			Key:         key,
+			Description: describe(def.Description),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// Identifier converts a feature key into an exported Go identifier.
+func Identifier(prefix, key string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	ident := b.String()
+	if ident == "" || !unicode.IsUpper([]rune(ident)[0]) || !token.IsIdentifier(ident) {
+		ident = "Feature" + ident
+	}
+	return ident
+}
+
+func describe(msg catalog.Message) string {
+	text := msg.Text
+	if text == "" {
+		text = msg.Key
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+var fileTemplate = template.Must(template.New("flags").Parse(`// Code generated by featuregate-gen. DO NOT EDIT.
+{{- if .Source }}
+// Source: {{ .Source }}
+{{- end }}
+
+package {{ .Package }}
+
+import (
+	"context"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// {{ .TypeName }} is a typed feature key.
+type {{ .TypeName }} string
+
+// String implements fmt.Stringer.
+func (k {{ .TypeName }}) String() string {
+	return string(k)
+}
+
+// Enabled resolves the key against the provided gate.
+func (k {{ .TypeName }}) Enabled(ctx context.Context, fg gate.FeatureGate, opts ...gate.ResolveOption) (bool, error) {
+	return fg.Enabled(ctx, string(k), opts...)
+}
+
+const (
+{{- range .Flags }}
+	// {{ .Ident }} is the "{{ .Key }}" feature key.{{ if .Description }}
+	// {{ .Description }}{{ end }}
+	{{ .Ident }} {{ $.TypeName }} = {{ printf "%q" .Key }}
+{{- end }}
+)
+
+// All{{ .TypeName }}s lists every generated feature key.
+func All{{ .TypeName }}s() []{{ .TypeName }} {
+	return []{{ .TypeName }}{
+{{- range .Flags }}
+		{{ .Ident }},
+{{- end }}
+	}
+}
+{{- if .Accessors }}
+{{ range .Flags }}
+// {{ .Ident }}Enabled reports whether "{{ .Key }}" is enabled.
+func {{ .Ident }}Enabled(ctx context.Context, fg gate.FeatureGate, opts ...gate.ResolveOption) (bool, error) {
+	return {{ .Ident }}.Enabled(ctx, fg, opts...)
+}
+{{ end }}
+{{- end }}
+{{- if .Facade }}
+
+// {{ .FacadeName }} wraps a gate with one method per feature, so call sites autocomplete
+// and flags removed from the catalog fail to compile.
+type {{ .FacadeName }} struct {
+	fg gate.FeatureGate
+}
+
+// New{{ .FacadeName }} wraps fg.
+func New{{ .FacadeName }}(fg gate.FeatureGate) {{ .FacadeName }} {
+	return {{ .FacadeName }}{fg: fg}
+}
+
+// Gate returns the wrapped gate.
+func (f {{ .FacadeName }}) Gate() gate.FeatureGate {
+	return f.fg
+}
+{{ range .Flags }}
+// {{ .Ident }}Enabled reports whether "{{ .Key }}" is enabled.{{ if .Description }}
+// {{ .Description }}{{ end }}
+func (f {{ $.FacadeName }}) {{ .Ident }}Enabled(ctx context.Context, opts ...gate.ResolveOption) (bool, error) {
+	return {{ .Ident }}.Enabled(ctx, f.fg, opts...)
+}
+{{ end }}
+{{- end }}
+`))
@@ -0,0 +1,97 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+func TestGenerateEmitsConstantsAndAccessors(t *testing.T) {
+	defs := []catalog.FeatureDefinition{
+		{Key: "users.signup", Description: catalog.Message{Text: "Allow self-signup"}},
+		{Key: "users.password_reset"},
+	}
+	src, err := Generate(defs, WithPackage("features"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"package features",
+		`UsersSignup Key = "users.signup"`,
+		`UsersPasswordReset Key = "users.password_reset"`,
+		"// Allow self-signup",
+		"func UsersSignupEnabled(",
+		"func AllKeys() []Key",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateWithoutAccessors(t *testing.T) {
+	src, err := Generate([]catalog.FeatureDefinition{{Key: "dashboard"}}, WithAccessors(false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(src), "DashboardEnabled(") {
+		t.Fatalf("expected accessors to be omitted")
+	}
+}
+
+func TestGenerateEmitsFacade(t *testing.T) {
+	defs := []catalog.FeatureDefinition{
+		{Key: "users.signup", Description: catalog.Message{Text: "Allow self-signup"}},
+	}
+	src, err := Generate(defs, WithFacade(true), WithFacadeName("Flags"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"type Flags struct",
+		"func NewFlags(fg gate.FeatureGate) Flags",
+		"func (f Flags) UsersSignupEnabled(ctx context.Context, opts ...gate.ResolveOption) (bool, error)",
+		"// Allow self-signup\nfunc (f Flags) UsersSignupEnabled",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q\n%s", want, out)
+		}
+	}
+	if src, _ := Generate(defs); strings.Contains(string(src), "type Features struct") {
+		t.Fatalf("expected facade to be opt-in")
+	}
+}
+
+func TestFlagsDetectsIdentifierConflicts(t *testing.T) {
+	_, err := Flags([]catalog.FeatureDefinition{
+		{Key: "users.signup"},
+		{Key: "users_signup"},
+	}, "")
+	if err == nil {
+		t.Fatalf("expected conflict error")
+	}
+	rich, ok := ferrors.As(err)
+	if !ok || rich.TextCode != TextCodeIdentifierConflict {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	cases := map[string]string{
+		"users.signup":         "UsersSignup",
+		"users.password_reset": "UsersPasswordReset",
+		"2fa.enabled":          "Feature2faEnabled",
+	}
+	for key, want := range cases {
+		if got := Identifier("", key); got != want {
+			t.Fatalf("Identifier(%q) = %q, want %q", key, got, want)
+		}
+	}
+	if got := Identifier("Feature", "dashboard"); got != "FeatureDashboard" {
+		t.Fatalf("unexpected prefixed identifier: %q", got)
+	}
+}
@@ -0,0 +1,223 @@
+// Package entitlement derives feature defaults from subscription plans.
+//
+// Plans list the features they include, and a PlanProvider returns the plan of the
+// tenant being resolved. A feature mapped to any plan defaults to enabled when the
+// tenant's plan includes it and disabled otherwise; the plan is recorded in the
+// resolve trace. Keys no plan mentions fall through to an optional fallback.
+//
+//	defaults := entitlement.NewDefaults(entitlement.Plans{
+//		"free": {"dashboard"},
+//		"pro":  {"dashboard", "reports.export"},
+//	}, entitlement.StaticPlans{"acme": "pro"})
+//	gate := resolver.New(resolver.WithDefaults(defaults))
+package entitlement
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+const (
+	TextCodePlanLookupFailed = "PLAN_LOOKUP_FAILED"
+
+	MetaTenant = "tenant_id"
+)
+
+// PlanProvider returns the subscription plan of a tenant. An empty plan means the
+// tenant has none, so plan-mapped features default to disabled.
+type PlanProvider interface {
+	Plan(ctx context.Context, tenantID string) (string, error)
+}
+
+// PlanProviderFunc adapts a function to PlanProvider.
+type PlanProviderFunc func(ctx context.Context, tenantID string) (string, error)
+
+// Plan implements PlanProvider.
+func (fn PlanProviderFunc) Plan(ctx context.Context, tenantID string) (string, error) {
+	if fn == nil {
+		return "", nil
+	}
+	return fn(ctx, tenantID)
+}
+
+// StaticPlans serves plans from a map keyed by tenant ID.
+type StaticPlans map[string]string
+
+// Plan implements PlanProvider.
+func (p StaticPlans) Plan(_ context.Context, tenantID string) (string, error) {
+	return p[tenantID], nil
+}
+
+// Plans maps plan names to the feature keys they include.
+type Plans map[string][]string
+
+// PlanLimits maps plan names to the numeric limits they grant, keyed by feature key.
+type PlanLimits map[string]map[string]int64
+
+// Option customizes Defaults.
+type Option func(*Defaults)
+
+// WithFallback resolves keys no plan mentions, and every key when the tenant is
+// unknown, through defaults.
+func WithFallback(defaults resolver.Defaults) Option {
+	return func(d *Defaults) {
+		if d == nil {
+			return
+		}
+		d.fallback = defaults
+	}
+}
+
+// WithPlanLimits serves limit defaults, such as "projects.max", from the tenant's
+// plan. A limit key mapped to any plan resolves to zero for plans without it.
+func WithPlanLimits(limits PlanLimits) Option {
+	return func(d *Defaults) {
+		if d == nil {
+			return
+		}
+		for plan, values := range limits {
+			plan = strings.TrimSpace(plan)
+			if plan == "" {
+				continue
+			}
+			planLimits := map[string]int64{}
+			for key, value := range values {
+				normalized := gate.NormalizeKey(key)
+				if normalized == "" {
+					continue
+				}
+				planLimits[normalized] = value
+				d.limitKeys[normalized] = struct{}{}
+			}
+			d.limits[plan] = planLimits
+		}
+	}
+}
+
+// Defaults implements resolver.Defaults and resolver.LimitDefaults from plan
+// entitlements.
+type Defaults struct {
+	plans     map[string]map[string]struct{}
+	mapped    map[string]struct{}
+	limits    map[string]map[string]int64
+	limitKeys map[string]struct{}
+	provider  PlanProvider
+	fallback  resolver.Defaults
+}
+
+// NewDefaults builds Defaults from plans and the provider that names each tenant's plan.
+func NewDefaults(plans Plans, provider PlanProvider, opts ...Option) *Defaults {
+	d := &Defaults{
+		plans:     map[string]map[string]struct{}{},
+		mapped:    map[string]struct{}{},
+		limits:    map[string]map[string]int64{},
+		limitKeys: map[string]struct{}{},
+		provider:  provider,
+	}
+	for plan, keys := range plans {
+		plan = strings.TrimSpace(plan)
+		if plan == "" {
+			continue
+		}
+		features := map[string]struct{}{}
+		for _, key := range keys {
+			normalized := gate.NormalizeKey(key)
+			if normalized == "" {
+				continue
+			}
+			features[normalized] = struct{}{}
+			d.mapped[normalized] = struct{}{}
+		}
+		d.plans[plan] = features
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	return d
+}
+
+// Default implements resolver.Defaults. The tenant comes from the chain being
+// resolved, or from the context when no chain is attached.
+func (d *Defaults) Default(ctx context.Context, key string) (resolver.DefaultResult, error) {
+	if d == nil {
+		return resolver.DefaultResult{}, nil
+	}
+	normalized := gate.NormalizeKey(key)
+	tenantID := tenantFor(ctx)
+	if _, ok := d.mapped[normalized]; !ok || tenantID == "" || d.provider == nil {
+		return d.fallbackDefault(ctx, normalized)
+	}
+	plan, err := d.plan(ctx, normalized, tenantID)
+	if err != nil {
+		return resolver.DefaultResult{}, err
+	}
+	_, included := d.plans[plan][normalized]
+	return resolver.DefaultResult{Set: true, Value: included, Plan: plan}, nil
+}
+
+// LimitDefault implements resolver.LimitDefaults. Limit keys no plan mentions fall
+// through to the fallback when it serves limits.
+func (d *Defaults) LimitDefault(ctx context.Context, key string) (resolver.LimitDefaultResult, error) {
+	if d == nil {
+		return resolver.LimitDefaultResult{}, nil
+	}
+	normalized := gate.NormalizeKey(key)
+	tenantID := tenantFor(ctx)
+	if _, ok := d.limitKeys[normalized]; !ok || tenantID == "" || d.provider == nil {
+		if fallback, ok := d.fallback.(resolver.LimitDefaults); ok {
+			return fallback.LimitDefault(ctx, normalized)
+		}
+		return resolver.LimitDefaultResult{}, nil
+	}
+	plan, err := d.plan(ctx, normalized, tenantID)
+	if err != nil {
+		return resolver.LimitDefaultResult{}, err
+	}
+	return resolver.LimitDefaultResult{Set: true, Value: d.limits[plan][normalized], Plan: plan}, nil
+}
+
+// Includes reports whether plan includes key.
+func (d *Defaults) Includes(plan, key string) bool {
+	if d == nil {
+		return false
+	}
+	_, ok := d.plans[strings.TrimSpace(plan)][gate.NormalizeKey(key)]
+	return ok
+}
+
+func (d *Defaults) plan(ctx context.Context, key, tenantID string) (string, error) {
+	plan, err := d.provider.Plan(ctx, tenantID)
+	if err != nil {
+		return "", ferrors.WrapExternal(err, TextCodePlanLookupFailed, "entitlement plan lookup failed", map[string]any{
+			ferrors.MetaFeatureKey: key,
+			MetaTenant:             tenantID,
+		})
+	}
+	return strings.TrimSpace(plan), nil
+}
+
+func (d *Defaults) fallbackDefault(ctx context.Context, key string) (resolver.DefaultResult, error) {
+	if d.fallback == nil {
+		return resolver.DefaultResult{}, nil
+	}
+	return d.fallback.Default(ctx, key)
+}
+
+func tenantFor(ctx context.Context) string {
+	if chain, ok := gate.ResolvedChain(ctx); ok {
+		return chain.TenantID()
+	}
+	return scope.TenantID(ctx)
+}
+
+var (
+	_ resolver.Defaults      = (*Defaults)(nil)
+	_ resolver.LimitDefaults = (*Defaults)(nil)
+)
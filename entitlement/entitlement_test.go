@@ -0,0 +1,73 @@
+package entitlement
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+type staticDefaults map[string]resolver.DefaultResult
+
+func (d staticDefaults) Default(_ context.Context, key string) (resolver.DefaultResult, error) {
+	return d[key], nil
+}
+
+func TestDefaultsFollowTenantPlan(t *testing.T) {
+	defaults := NewDefaults(Plans{
+		"free": {"dashboard"},
+		"pro":  {"dashboard", "reports.export"},
+	}, StaticPlans{"acme": "pro", "globex": "free"}, WithFallback(staticDefaults{"search": {Set: true, Value: true}}))
+	fg := resolver.New(resolver.WithDefaults(defaults))
+
+	value, trace, err := fg.ResolveWithTrace(context.Background(), "reports.export", gate.WithClaims(gate.ActorClaims{TenantID: "acme"}))
+	if err != nil || !value || trace.Source != gate.ResolveSourceDefault || trace.Default.Plan != "pro" {
+		t.Fatalf("expected pro plan to include reports.export, got %v %+v (%v)", value, trace.Default, err)
+	}
+	if explain := gate.Explain(trace); !strings.Contains(explain, "plan: pro") {
+		t.Fatalf("expected explain to mention the plan, got:\n%s", explain)
+	}
+
+	ctx := scope.WithTenantID(context.Background(), "globex")
+	value, trace, err = fg.ResolveWithTrace(ctx, "reports.export")
+	if err != nil || value || !trace.Default.Set || trace.Default.Plan != "free" {
+		t.Fatalf("expected free plan to exclude reports.export, got %v %+v (%v)", value, trace.Default, err)
+	}
+	if value, _ := fg.Enabled(ctx, "search"); !value {
+		t.Fatalf("expected unmapped keys to use the fallback defaults")
+	}
+	if _, trace, _ := fg.ResolveWithTrace(context.Background(), "dashboard"); trace.Default.Set {
+		t.Fatalf("expected no default without a tenant, got %+v", trace.Default)
+	}
+
+	failing := NewDefaults(Plans{"pro": {"dashboard"}}, PlanProviderFunc(func(context.Context, string) (string, error) {
+		return "", errors.New("billing down")
+	}))
+	if _, err := resolver.New(resolver.WithDefaults(failing)).Enabled(ctx, "dashboard"); err == nil {
+		t.Fatalf("expected plan lookup failures to surface")
+	}
+}
+
+func TestDefaultsServePlanLimits(t *testing.T) {
+	defaults := NewDefaults(nil, StaticPlans{"acme": "pro", "globex": "free", "initech": "trial"}, WithPlanLimits(PlanLimits{
+		"free": {"projects.max": 10},
+		"pro":  {"projects.max": 100},
+	}))
+	fg := resolver.New(resolver.WithDefaults(defaults))
+
+	value, trace, err := fg.LimitWithTrace(context.Background(), "projects.max", gate.WithClaims(gate.ActorClaims{TenantID: "acme"}))
+	if err != nil || value != 100 || trace.Source != gate.ResolveSourceDefault || trace.Plan != "pro" {
+		t.Fatalf("expected pro limit 100, got %d %+v (%v)", value, trace, err)
+	}
+	if value, _ := fg.Limit(scope.WithTenantID(context.Background(), "globex"), "projects.max"); value != 10 {
+		t.Fatalf("expected free limit 10, got %d", value)
+	}
+	value, trace, _ = fg.LimitWithTrace(scope.WithTenantID(context.Background(), "initech"), "projects.max")
+	if value != 0 || !trace.DefaultSet || trace.Plan != "trial" {
+		t.Fatalf("expected plans without the limit to resolve to zero, got %d %+v", value, trace)
+	}
+}
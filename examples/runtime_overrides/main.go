@@ -57,4 +57,24 @@ func main() {
 		return
 	}
 	fmt.Println("dashboard unset:", value)
+
+	// Chains derived from claims include role scopes, both unqualified and qualified
+	// by the tenant and org, so a role override reaches every admin.
+	admin := fggate.WithClaims(fggate.ActorClaims{
+		SubjectID: "user-7",
+		TenantID:  "acme",
+		OrgID:     "eng",
+		Roles:     []string{"admin"},
+	})
+	role := fggate.ScopeRef{Kind: fggate.ScopeRole, ID: "admin"}
+	if err := featureGate.Set(ctx, "dashboard", role, false, actor); err != nil {
+		fmt.Println("set role override error:", err)
+		return
+	}
+	value, trace, err := featureGate.ResolveWithTrace(ctx, "dashboard", admin)
+	if err != nil {
+		fmt.Println("role resolve error:", err)
+		return
+	}
+	fmt.Printf("dashboard for admins: %v (from %s:%s)\n", value, trace.Override.Match.Kind, trace.Override.Match.ID)
 }
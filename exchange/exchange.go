@@ -0,0 +1,74 @@
+// Package exchange converts flag definitions between go-featuregate and other systems.
+//
+// Importers produce a Bundle: catalog definitions, defaults, scoped overrides, and the
+// issues met while mapping foreign targeting onto featuregate scopes. Review the issues,
+// then feed the defaults to the resolver and Apply the overrides to a store.
+package exchange
+
+import (
+	"context"
+	"sort"
+
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+const (
+	TextCodeImportInvalid = "EXCHANGE_IMPORT_INVALID"
+	TextCodeApplyFailed   = "EXCHANGE_APPLY_FAILED"
+)
+
+// Issue describes part of a source flag that could not be imported as-is.
+type Issue struct {
+	Key    string
+	Path   string
+	Reason string
+}
+
+// Bundle is the result of an import.
+type Bundle struct {
+	Definitions []catalog.FeatureDefinition
+	Defaults    map[string]bool
+	Overrides   []store.OverrideRecord
+	Issues      []Issue
+}
+
+// Catalog returns the imported definitions as a static catalog.
+func (b Bundle) Catalog() *catalog.StaticCatalog {
+	defs := make(map[string]catalog.FeatureDefinition, len(b.Definitions))
+	for _, def := range b.Definitions {
+		defs[def.Key] = def
+	}
+	return catalog.NewStatic(defs)
+}
+
+// Apply writes the imported overrides through writer in bundle order, stopping at the
+// first failure.
+func (b Bundle) Apply(ctx context.Context, writer store.Writer, actor gate.ActorRef) error {
+	if writer == nil {
+		return ferrors.WrapSentinel(ferrors.ErrStoreRequired, "exchange: override writer is required", nil)
+	}
+	for _, record := range b.Overrides {
+		if !record.Override.HasValue() {
+			continue
+		}
+		err := writer.Set(ctx, record.Key, record.Scope, record.Override.Value, actor, gate.WithMetadata(record.Override.Metadata), gate.WithPriority(record.Override.Priority))
+		if err != nil {
+			return ferrors.WrapOperation(err, TextCodeApplyFailed, "exchange: failed to apply override", map[string]any{
+				ferrors.MetaFeatureKey: record.Key,
+				ferrors.MetaScope:      record.Scope,
+			})
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) addIssue(key, path, reason string) {
+	b.Issues = append(b.Issues, Issue{Key: key, Path: path, Reason: reason})
+}
+
+func (b *Bundle) sort() {
+	sort.Slice(b.Definitions, func(i, j int) bool { return b.Definitions[i].Key < b.Definitions[j].Key })
+}
@@ -0,0 +1,381 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+const (
+	// LabelSource is the override label naming the system an override was imported from.
+	LabelSource = "source"
+	// SourceLaunchDarkly is the LabelSource value for LaunchDarkly imports.
+	SourceLaunchDarkly = "launchdarkly"
+
+	MetaEnvironment = "environment"
+)
+
+// LaunchDarklyOption customizes ImportLaunchDarkly.
+type LaunchDarklyOption func(*launchDarklyImport)
+
+type launchDarklyImport struct {
+	environment string
+	keyMapper   func(string) string
+	scopeMapper func(gate.ScopeRef) gate.ScopeRef
+}
+
+// WithEnvironment selects the LaunchDarkly environment to import from a REST API
+// export. It is required when the export holds more than one environment.
+func WithEnvironment(env string) LaunchDarklyOption {
+	return func(opts *launchDarklyImport) {
+		if opts == nil {
+			return
+		}
+		opts.environment = strings.TrimSpace(env)
+	}
+}
+
+// WithKeyMapper rewrites LaunchDarkly flag keys, for example "new-checkout" to "checkout.new".
+func WithKeyMapper(mapper func(string) string) LaunchDarklyOption {
+	return func(opts *launchDarklyImport) {
+		if opts == nil || mapper == nil {
+			return
+		}
+		opts.keyMapper = mapper
+	}
+}
+
+// WithScopeMapper rewrites every imported scope, for example to stamp a tenant ID onto
+// user scopes so they match tenant-qualified chains.
+func WithScopeMapper(mapper func(gate.ScopeRef) gate.ScopeRef) LaunchDarklyOption {
+	return func(opts *launchDarklyImport) {
+		if opts == nil || mapper == nil {
+			return
+		}
+		opts.scopeMapper = mapper
+	}
+}
+
+type ldExport struct {
+	Items []ldFlag          `json:"items"`
+	Flags map[string]ldFlag `json:"flags"`
+}
+
+type ldFlag struct {
+	Key          string                   `json:"key"`
+	Name         string                   `json:"name"`
+	Description  string                   `json:"description"`
+	Kind         string                   `json:"kind"`
+	Archived     bool                     `json:"archived"`
+	Variations   []ldVariation            `json:"variations"`
+	Environments map[string]ldEnvironment `json:"environments"`
+	ldEnvironment
+}
+
+type ldVariation struct {
+	Value any `json:"value"`
+}
+
+type ldEnvironment struct {
+	On             bool             `json:"on"`
+	OffVariation   *int             `json:"offVariation"`
+	Fallthrough    ldServe          `json:"fallthrough"`
+	Targets        []ldTarget       `json:"targets"`
+	ContextTargets []ldTarget       `json:"contextTargets"`
+	Rules          []ldRule         `json:"rules"`
+	Prerequisites  []ldPrerequisite `json:"prerequisites"`
+}
+
+type ldServe struct {
+	Variation *int `json:"variation"`
+}
+
+type ldTarget struct {
+	Values      []string `json:"values"`
+	Variation   int      `json:"variation"`
+	ContextKind string   `json:"contextKind"`
+}
+
+type ldRule struct {
+	Clauses []ldClause `json:"clauses"`
+	ldServe
+}
+
+type ldClause struct {
+	Attribute   string `json:"attribute"`
+	Op          string `json:"op"`
+	Values      []any  `json:"values"`
+	Negate      bool   `json:"negate"`
+	ContextKind string `json:"contextKind"`
+}
+
+type ldPrerequisite struct {
+	Key string `json:"key"`
+}
+
+// ImportLaunchDarkly converts a LaunchDarkly flag export into a Bundle. It accepts the
+// REST API flag list ({"items": [...]}, one environment selected with WithEnvironment)
+// and the SDK data payload ({"flags": {...}}).
+//
+// Boolean flags map to a definition, a default (the off variation when the flag is off,
+// otherwise the fallthrough variation), and overrides for individual targets and for
+// rules with a single "in" clause on the context key or on role, permission, tenant,
+// org, or environment attributes. Everything else (percentage rollouts, segments,
+// prerequisites, negated or compound clauses, non-boolean flags) is reported as an Issue.
+func ImportLaunchDarkly(data []byte, opts ...LaunchDarklyOption) (Bundle, error) {
+	cfg := launchDarklyImport{keyMapper: func(key string) string { return key }}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	export := ldExport{}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return Bundle{}, ferrors.WrapBadInput(err, TextCodeImportInvalid, "exchange: invalid LaunchDarkly export", nil)
+	}
+	flags := export.Items
+	if len(flags) == 0 && len(export.Flags) > 0 {
+		keys := make([]string, 0, len(export.Flags))
+		for key := range export.Flags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flag := export.Flags[key]
+			if flag.Key == "" {
+				flag.Key = key
+			}
+			flags = append(flags, flag)
+		}
+	}
+
+	bundle := Bundle{Defaults: map[string]bool{}}
+	for _, flag := range flags {
+		env, err := cfg.selectEnvironment(flag)
+		if err != nil {
+			return Bundle{}, err
+		}
+		cfg.importFlag(&bundle, flag, env)
+	}
+	bundle.sort()
+	return bundle, nil
+}
+
+func (cfg launchDarklyImport) selectEnvironment(flag ldFlag) (ldEnvironment, error) {
+	if len(flag.Environments) == 0 {
+		return flag.ldEnvironment, nil
+	}
+	if cfg.environment != "" {
+		env, ok := flag.Environments[cfg.environment]
+		if !ok {
+			return ldEnvironment{}, ferrors.NewBadInput(TextCodeImportInvalid, "exchange: environment not found in LaunchDarkly export", map[string]any{
+				ferrors.MetaFeatureKey: flag.Key,
+				MetaEnvironment:        cfg.environment,
+			})
+		}
+		return env, nil
+	}
+	if len(flag.Environments) == 1 {
+		for _, env := range flag.Environments {
+			return env, nil
+		}
+	}
+	names := make([]string, 0, len(flag.Environments))
+	for name := range flag.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return ldEnvironment{}, ferrors.NewBadInput(TextCodeImportInvalid, "exchange: LaunchDarkly export has several environments; select one", map[string]any{
+		ferrors.MetaFeatureKey: flag.Key,
+		MetaEnvironment:        names,
+	})
+}
+
+func (cfg launchDarklyImport) importFlag(bundle *Bundle, flag ldFlag, env ldEnvironment) {
+	key := gate.NormalizeKey(cfg.keyMapper(flag.Key))
+	if key == "" {
+		bundle.addIssue(flag.Key, "key", "empty key after mapping")
+		return
+	}
+	if flag.Archived {
+		bundle.addIssue(key, "archived", "archived flag skipped")
+		return
+	}
+	values, ok := booleanVariations(flag.Variations)
+	if !ok || (flag.Kind != "" && flag.Kind != "boolean") {
+		bundle.addIssue(key, "variations", "non-boolean flag skipped")
+		return
+	}
+
+	description := flag.Description
+	if description == "" {
+		description = flag.Name
+	}
+	bundle.Definitions = append(bundle.Definitions, catalog.FeatureDefinition{
+		Key:         key,
+		Description: catalog.Message{Text: description},
+	})
+
+	offValue := false
+	if value, ok := variationValue(values, env.OffVariation); ok {
+		offValue = value
+	} else {
+		bundle.addIssue(key, "offVariation", "no off variation; defaulting to false")
+	}
+	for _, prereq := range env.Prerequisites {
+		bundle.addIssue(key, "prerequisites."+prereq.Key, "prerequisites are not supported")
+	}
+	if !env.On {
+		bundle.Defaults[key] = offValue
+		if len(env.Targets)+len(env.ContextTargets)+len(env.Rules) > 0 {
+			bundle.addIssue(key, "on", "flag is off; targeting not imported")
+		}
+		return
+	}
+	if value, ok := variationValue(values, env.Fallthrough.Variation); ok {
+		bundle.Defaults[key] = value
+	} else {
+		bundle.Defaults[key] = offValue
+		bundle.addIssue(key, "fallthrough", "percentage rollout fallthrough; default set to the off variation")
+	}
+
+	seen := map[string]bool{}
+	add := func(path string, ref gate.ScopeRef, value bool) {
+		if cfg.scopeMapper != nil {
+			ref = cfg.scopeMapper(ref)
+		}
+		id := ref.Kind.String() + ":" + ref.ID
+		if seen[id] {
+			bundle.addIssue(key, path, fmt.Sprintf("%s already targeted earlier; kept the first match", id))
+			return
+		}
+		seen[id] = true
+		override := store.DisabledOverride()
+		if value {
+			override = store.EnabledOverride()
+		}
+		override.Metadata = gate.OverrideMetadata{
+			Reason: "imported from LaunchDarkly " + path,
+			Labels: map[string]string{LabelSource: SourceLaunchDarkly},
+		}
+		bundle.Overrides = append(bundle.Overrides, store.OverrideRecord{Key: key, Scope: ref, Override: override})
+	}
+
+	targets := append(append([]ldTarget{}, env.Targets...), env.ContextTargets...)
+	for i, target := range targets {
+		path := fmt.Sprintf("targets[%d]", i)
+		if i >= len(env.Targets) {
+			path = fmt.Sprintf("contextTargets[%d]", i-len(env.Targets))
+		}
+		kind, ok := ldScopeKind(target.ContextKind)
+		if !ok {
+			if len(target.Values) > 0 {
+				bundle.addIssue(key, path, fmt.Sprintf("context kind %q has no matching scope", target.ContextKind))
+			}
+			continue
+		}
+		value, ok := variationValue(values, &target.Variation)
+		if !ok {
+			bundle.addIssue(key, path, "unknown variation")
+			continue
+		}
+		for _, id := range target.Values {
+			add(path, gate.ScopeRef{Kind: kind, ID: strings.TrimSpace(id)}, value)
+		}
+	}
+
+	for i, rule := range env.Rules {
+		path := fmt.Sprintf("rules[%d]", i)
+		value, ok := variationValue(values, rule.Variation)
+		if !ok {
+			bundle.addIssue(key, path, "percentage rollout rules are not supported")
+			continue
+		}
+		if len(rule.Clauses) != 1 {
+			bundle.addIssue(key, path, "rules with several clauses are not supported")
+			continue
+		}
+		clause := rule.Clauses[0]
+		if clause.Op != "in" {
+			bundle.addIssue(key, path, fmt.Sprintf("operator %q is not supported", clause.Op))
+			continue
+		}
+		if clause.Negate {
+			bundle.addIssue(key, path, "negated clauses are not supported")
+			continue
+		}
+		kind, ok := ldAttributeScope(clause)
+		if !ok {
+			bundle.addIssue(key, path, fmt.Sprintf("attribute %q has no matching scope", clause.Attribute))
+			continue
+		}
+		for _, raw := range clause.Values {
+			id, ok := raw.(string)
+			if !ok {
+				bundle.addIssue(key, path, fmt.Sprintf("non-string clause value %v skipped", raw))
+				continue
+			}
+			add(path, gate.ScopeRef{Kind: kind, ID: strings.TrimSpace(id)}, value)
+		}
+	}
+}
+
+func booleanVariations(variations []ldVariation) ([]bool, bool) {
+	if len(variations) == 0 {
+		return nil, false
+	}
+	out := make([]bool, len(variations))
+	for i, variation := range variations {
+		value, ok := variation.Value.(bool)
+		if !ok {
+			return nil, false
+		}
+		out[i] = value
+	}
+	return out, true
+}
+
+func variationValue(values []bool, index *int) (bool, bool) {
+	if index == nil || *index < 0 || *index >= len(values) {
+		return false, false
+	}
+	return values[*index], true
+}
+
+func ldScopeKind(contextKind string) (gate.ScopeKind, bool) {
+	switch strings.ToLower(strings.TrimSpace(contextKind)) {
+	case "", "user":
+		return gate.ScopeUser, true
+	case "org", "organization":
+		return gate.ScopeOrg, true
+	case "tenant":
+		return gate.ScopeTenant, true
+	case "env", "environment":
+		return gate.ScopeEnv, true
+	}
+	return 0, false
+}
+
+func ldAttributeScope(clause ldClause) (gate.ScopeKind, bool) {
+	switch strings.ToLower(strings.TrimSpace(clause.Attribute)) {
+	case "key":
+		return ldScopeKind(clause.ContextKind)
+	case "role", "roles":
+		return gate.ScopeRole, true
+	case "permission", "permissions", "perm", "perms":
+		return gate.ScopePerm, true
+	case "tenant", "tenant_id", "tenantid":
+		return gate.ScopeTenant, true
+	case "org", "org_id", "orgid", "organization":
+		return gate.ScopeOrg, true
+	case "env", "environment":
+		return gate.ScopeEnv, true
+	}
+	return 0, false
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+const ldExportJSON = `{
+  "items": [
+    {
+      "key": "new-checkout",
+      "name": "New checkout",
+      "kind": "boolean",
+      "variations": [{"value": true}, {"value": false}],
+      "environments": {
+        "production": {
+          "on": true,
+          "offVariation": 1,
+          "fallthrough": {"variation": 1},
+          "targets": [{"values": ["u-1"], "variation": 0}],
+          "rules": [
+            {"clauses": [{"attribute": "roles", "op": "in", "values": ["beta"]}], "variation": 0},
+            {"clauses": [{"attribute": "email", "op": "endsWith", "values": ["@acme.io"]}], "variation": 0},
+            {"clauses": [{"attribute": "key", "op": "in", "values": ["acme"], "contextKind": "organization"}], "rollout": {"variations": []}}
+          ]
+        },
+        "staging": {"on": false, "offVariation": 0, "fallthrough": {"variation": 0}}
+      }
+    },
+    {
+      "key": "theme",
+      "kind": "multivariate",
+      "variations": [{"value": "dark"}, {"value": "light"}],
+      "environments": {"production": {"on": true, "fallthrough": {"variation": 0}}, "staging": {}}
+    }
+  ]
+}`
+
+func TestImportLaunchDarklyMapsTargetsAndReportsUnmappableRules(t *testing.T) {
+	bundle, err := ImportLaunchDarkly([]byte(ldExportJSON),
+		WithEnvironment("production"),
+		WithKeyMapper(func(key string) string { return strings.ReplaceAll(key, "-", ".") }),
+	)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(bundle.Definitions) != 1 || bundle.Definitions[0].Key != "new.checkout" {
+		t.Fatalf("unexpected definitions: %+v", bundle.Definitions)
+	}
+	if value, ok := bundle.Defaults["new.checkout"]; !ok || value {
+		t.Fatalf("expected default false, got %v (%v)", value, ok)
+	}
+	if len(bundle.Overrides) != 2 {
+		t.Fatalf("expected user and role overrides, got %+v", bundle.Overrides)
+	}
+	if len(bundle.Issues) != 3 {
+		t.Fatalf("expected issues for the theme flag and two rules, got %+v", bundle.Issues)
+	}
+
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	if err := bundle.Apply(ctx, overrides, gate.ActorRef{ID: "migration"}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(bundle.Defaults)),
+		resolver.WithOverrideStore(overrides),
+	)
+	chain := gate.ScopeChain{{Kind: gate.ScopeRole, ID: "beta"}, {Kind: gate.ScopeSystem}}
+	enabled, err := fg.Enabled(ctx, "new.checkout", gate.WithScopeChain(chain))
+	if err != nil || !enabled {
+		t.Fatalf("expected beta role enabled, got %v (%v)", enabled, err)
+	}
+}
+
+func TestImportLaunchDarklyRequiresEnvironmentSelection(t *testing.T) {
+	if _, err := ImportLaunchDarkly([]byte(ldExportJSON)); err == nil {
+		t.Fatalf("expected error for ambiguous environment")
+	}
+}
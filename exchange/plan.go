@@ -0,0 +1,350 @@
+package exchange
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+const (
+	TextCodePlanNotFound = "EXCHANGE_PLAN_NOT_FOUND"
+	TextCodePlanStale    = "EXCHANGE_PLAN_STALE"
+
+	MetaPlanID = "plan_id"
+)
+
+// Action is the kind of change a plan makes to an override.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is one planned override write. Before is nil when no value is stored; After is
+// nil for deletes.
+type Change struct {
+	Action   Action
+	Key      string
+	Scope    gate.ScopeRef
+	Before   *bool
+	After    *bool
+	Metadata gate.OverrideMetadata
+}
+
+// Plan is a reviewable set of override changes. ID is derived from the changes, so the
+// same dry run yields the same ID.
+type Plan struct {
+	ID        string
+	CreatedAt time.Time
+	Changes   []Change
+}
+
+// NewPlan sorts changes by key and scope and assigns the content-derived ID.
+func NewPlan(changes []Change) Plan {
+	sorted := append([]Change(nil), changes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Scope.Kind != b.Scope.Kind {
+			return a.Scope.Kind < b.Scope.Kind
+		}
+		return scopeID(a.Scope) < scopeID(b.Scope)
+	})
+	raw, _ := json.Marshal(sorted)
+	sum := sha256.Sum256(raw)
+	return Plan{ID: hex.EncodeToString(sum[:6]), CreatedAt: time.Now().UTC(), Changes: sorted}
+}
+
+// Empty reports whether the plan has no changes.
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Counts returns the number of creates, updates, and deletes.
+func (p Plan) Counts() (creates, updates, deletes int) {
+	for _, change := range p.Changes {
+		switch change.Action {
+		case ActionCreate:
+			creates++
+		case ActionUpdate:
+			updates++
+		case ActionDelete:
+			deletes++
+		}
+	}
+	return creates, updates, deletes
+}
+
+// Apply executes the plan. Every change is first checked against the current store
+// value; if any Before no longer matches, nothing is written and a stale-plan error is
+// returned so the operator re-runs the dry run.
+func (p Plan) Apply(ctx context.Context, rw store.ReadWriter, actor gate.ActorRef) error {
+	if rw == nil {
+		return ferrors.WrapSentinel(ferrors.ErrStoreRequired, "exchange: override store is required", nil)
+	}
+	for _, change := range p.Changes {
+		current, err := currentValue(ctx, rw, change.Key, change.Scope)
+		if err != nil {
+			return err
+		}
+		if !sameValue(current, change.Before) {
+			return ferrors.NewBadInput(TextCodePlanStale, "exchange: store changed since the plan was made", map[string]any{
+				MetaPlanID:             p.ID,
+				ferrors.MetaFeatureKey: change.Key,
+				ferrors.MetaScope:      change.Scope,
+			})
+		}
+	}
+	for _, change := range p.Changes {
+		var err error
+		if change.After == nil {
+			err = rw.Unset(ctx, change.Key, change.Scope, actor)
+		} else {
+			err = rw.Set(ctx, change.Key, change.Scope, *change.After, actor, gate.WithMetadata(change.Metadata))
+		}
+		if err != nil {
+			return ferrors.WrapOperation(err, TextCodeApplyFailed, "exchange: failed to apply plan", map[string]any{
+				MetaPlanID:             p.ID,
+				ferrors.MetaFeatureKey: change.Key,
+				ferrors.MetaScope:      change.Scope,
+			})
+		}
+	}
+	return nil
+}
+
+// Plan is the dry run of Apply: creates and updates for the bundle's overrides against
+// the current store, skipping overrides that already hold the imported value.
+func (b Bundle) Plan(ctx context.Context, reader store.Reader) (Plan, error) {
+	changes := make([]Change, 0, len(b.Overrides))
+	for _, record := range b.Overrides {
+		if !record.Override.HasValue() {
+			continue
+		}
+		current, err := currentValue(ctx, reader, record.Key, record.Scope)
+		if err != nil {
+			return Plan{}, err
+		}
+		if change, ok := desiredChange(record, current); ok {
+			changes = append(changes, change)
+		}
+	}
+	return NewPlan(changes), nil
+}
+
+// PlanReconcile plans the writes that make the overrides selected by filter equal
+// desired: creates and updates for desired records, deletes for listed ones not desired.
+// Use a label filter (for example source=launchdarkly) to scope deletes to what a
+// previous import or bundle owns.
+func PlanReconcile(ctx context.Context, lister store.Lister, desired []store.OverrideRecord, filter store.ListFilter) (Plan, error) {
+	records, err := listRecords(ctx, lister, filter)
+	if err != nil {
+		return Plan{}, err
+	}
+	current := map[string]store.OverrideRecord{}
+	for _, record := range records {
+		if record.Override.HasValue() {
+			current[recordID(record.Key, record.Scope)] = record
+		}
+	}
+	wanted := map[string]bool{}
+	changes := make([]Change, 0)
+	for _, record := range desired {
+		if !record.Override.HasValue() {
+			continue
+		}
+		record.Key = gate.NormalizeKey(record.Key)
+		id := recordID(record.Key, record.Scope)
+		wanted[id] = true
+		var before *bool
+		if existing, ok := current[id]; ok {
+			before = boolPtr(existing.Override.Value)
+		}
+		if change, ok := desiredChange(record, before); ok {
+			changes = append(changes, change)
+		}
+	}
+	for id, record := range current {
+		if wanted[id] {
+			continue
+		}
+		changes = append(changes, Change{
+			Action: ActionDelete,
+			Key:    record.Key,
+			Scope:  record.Scope,
+			Before: boolPtr(record.Override.Value),
+		})
+	}
+	return NewPlan(changes), nil
+}
+
+// PlanGC plans deletes for overrides selected by filter that expired at or before now.
+func PlanGC(ctx context.Context, lister store.Lister, now time.Time, filter store.ListFilter) (Plan, error) {
+	records, err := listRecords(ctx, lister, filter)
+	if err != nil {
+		return Plan{}, err
+	}
+	changes := make([]Change, 0)
+	for _, record := range records {
+		if !record.Override.HasValue() || !record.Override.Expired(now) {
+			continue
+		}
+		changes = append(changes, Change{
+			Action: ActionDelete,
+			Key:    record.Key,
+			Scope:  record.Scope,
+			Before: boolPtr(record.Override.Value),
+		})
+	}
+	return NewPlan(changes), nil
+}
+
+// Plans holds reviewed plans so they can be applied later by ID.
+type Plans struct {
+	mu    sync.Mutex
+	plans map[string]Plan
+}
+
+// NewPlans constructs an empty plan registry.
+func NewPlans() *Plans {
+	return &Plans{plans: map[string]Plan{}}
+}
+
+// Add stores plan and returns its ID.
+func (p *Plans) Add(plan Plan) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.plans[plan.ID] = plan
+	return plan.ID
+}
+
+// Get returns the plan with id.
+func (p *Plans) Get(id string) (Plan, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	plan, ok := p.plans[id]
+	return plan, ok
+}
+
+// Apply applies the plan with id and forgets it once applied.
+func (p *Plans) Apply(ctx context.Context, id string, rw store.ReadWriter, actor gate.ActorRef) error {
+	plan, ok := p.Get(id)
+	if !ok {
+		return ferrors.NewBadInput(TextCodePlanNotFound, "exchange: plan not found", map[string]any{
+			MetaPlanID: id,
+		})
+	}
+	if err := plan.Apply(ctx, rw, actor); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	delete(p.plans, id)
+	p.mu.Unlock()
+	return nil
+}
+
+// MarshalJSON renders scope kinds by name for review output.
+func (c Change) MarshalJSON() ([]byte, error) {
+	type scopeJSON struct {
+		Kind     string `json:"kind"`
+		ID       string `json:"id,omitempty"`
+		TenantID string `json:"tenant_id,omitempty"`
+		OrgID    string `json:"org_id,omitempty"`
+	}
+	out := struct {
+		Action   Action                 `json:"action"`
+		Key      string                 `json:"key"`
+		Scope    scopeJSON              `json:"scope"`
+		Before   *bool                  `json:"before,omitempty"`
+		After    *bool                  `json:"after,omitempty"`
+		Metadata *gate.OverrideMetadata `json:"metadata,omitempty"`
+	}{
+		Action: c.Action,
+		Key:    c.Key,
+		Scope: scopeJSON{
+			Kind:     c.Scope.Kind.String(),
+			ID:       c.Scope.ID,
+			TenantID: c.Scope.TenantID,
+			OrgID:    c.Scope.OrgID,
+		},
+		Before: c.Before,
+		After:  c.After,
+	}
+	if !c.Metadata.IsZero() {
+		out.Metadata = &c.Metadata
+	}
+	return json.Marshal(out)
+}
+
+func desiredChange(record store.OverrideRecord, before *bool) (Change, bool) {
+	after := boolPtr(record.Override.Value)
+	change := Change{
+		Action:   ActionCreate,
+		Key:      record.Key,
+		Scope:    record.Scope,
+		Before:   before,
+		After:    after,
+		Metadata: record.Override.Metadata,
+	}
+	if before != nil {
+		if *before == *after {
+			return Change{}, false
+		}
+		change.Action = ActionUpdate
+	}
+	return change, true
+}
+
+func currentValue(ctx context.Context, reader store.Reader, key string, scopeRef gate.ScopeRef) (*bool, error) {
+	if reader == nil {
+		return nil, ferrors.WrapSentinel(ferrors.ErrStoreRequired, "exchange: override reader is required", nil)
+	}
+	matches, err := reader.GetAll(ctx, key, gate.ScopeChain{scopeRef})
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		if match.Override.HasValue() {
+			return boolPtr(match.Override.Value), nil
+		}
+	}
+	return nil, nil
+}
+
+func listRecords(ctx context.Context, lister store.Lister, filter store.ListFilter) ([]store.OverrideRecord, error) {
+	if lister == nil {
+		return nil, ferrors.WrapSentinel(ferrors.ErrStoreRequired, "exchange: override lister is required", nil)
+	}
+	return lister.List(ctx, filter)
+}
+
+func sameValue(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func recordID(key string, ref gate.ScopeRef) string {
+	return key + "|" + ref.Kind.String() + "|" + scopeID(ref)
+}
+
+func scopeID(ref gate.ScopeRef) string {
+	return ref.ID + "|" + ref.TenantID + "|" + ref.OrgID
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,69 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestPlanReconcileDryRunThenApplyByID(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	actor := gate.ActorRef{ID: "ops"}
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	owned := gate.WithMetadata(gate.OverrideMetadata{Labels: map[string]string{LabelSource: SourceBundle}})
+	_ = overrides.Set(ctx, "dashboard", system, false, actor, owned)
+	_ = overrides.Set(ctx, "legacy.banner", system, true, actor, owned)
+	_ = overrides.Set(ctx, "users.signup", system, true, actor)
+
+	manifest := Manifest{Version: "2", Overrides: map[string]bool{"dashboard": true, "billing.v2": true}}
+	filter := store.ListFilter{Labels: map[string]string{LabelSource: SourceBundle}}
+	plan, err := PlanReconcile(ctx, overrides, manifest.Records(), filter)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if creates, updates, deletes := plan.Counts(); creates != 1 || updates != 1 || deletes != 1 {
+		t.Fatalf("expected 1 create, 1 update, 1 delete, got %d/%d/%d: %+v", creates, updates, deletes, plan.Changes)
+	}
+	again, _ := PlanReconcile(ctx, overrides, manifest.Records(), filter)
+	if again.ID != plan.ID {
+		t.Fatalf("expected stable plan IDs, got %s and %s", plan.ID, again.ID)
+	}
+	if matches, _ := overrides.GetAll(ctx, "legacy.banner", gate.ScopeChain{system}); len(matches) != 1 || !matches[0].Override.HasValue() {
+		t.Fatalf("dry run must not write")
+	}
+
+	plans := NewPlans()
+	id := plans.Add(plan)
+	_ = overrides.Set(ctx, "dashboard", system, true, actor, owned)
+	if err := plans.Apply(ctx, id, overrides, actor); err == nil {
+		t.Fatalf("expected stale plan error after the store changed")
+	}
+	_ = overrides.Set(ctx, "dashboard", system, false, actor, owned)
+	if err := plans.Apply(ctx, id, overrides, actor); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if after, _ := PlanReconcile(ctx, overrides, manifest.Records(), filter); !after.Empty() {
+		t.Fatalf("expected no changes after apply, got %+v", after.Changes)
+	}
+	if _, ok := plans.Get(id); ok {
+		t.Fatalf("expected applied plan to be removed")
+	}
+}
+
+func TestPlanGCDeletesExpiredOverrides(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	overrides := store.NewMemoryStore()
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	_ = overrides.Set(ctx, "promo", system, true, gate.ActorRef{}, gate.WithExpiresAt(now.Add(time.Hour)))
+	_ = overrides.Set(ctx, "dashboard", system, true, gate.ActorRef{})
+
+	plan, err := PlanGC(ctx, overrides, now.Add(2*time.Hour), store.ListFilter{})
+	if err != nil || len(plan.Changes) != 1 || plan.Changes[0].Key != "promo" || plan.Changes[0].Action != ActionDelete {
+		t.Fatalf("expected one delete for promo, got %+v (%v)", plan.Changes, err)
+	}
+}
@@ -0,0 +1,229 @@
+package exchange
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// SignedFormat identifies the signed bundle format version.
+const SignedFormat = "featuregate.bundle.v1"
+
+const (
+	TextCodeBundleInvalid    = "EXCHANGE_BUNDLE_INVALID"
+	TextCodeSignatureInvalid = "EXCHANGE_SIGNATURE_INVALID"
+
+	// SourceBundle is the LabelSource value for overrides written from a signed bundle.
+	SourceBundle = "bundle"
+
+	MetaBundleVersion = "bundle_version"
+	MetaKeyID         = "key_id"
+)
+
+// Manifest is the signed content of a flag bundle for deployments without store
+// connectivity. Defaults and Catalog use the configadapter map shapes; Overrides are
+// system-scope values.
+type Manifest struct {
+	Format    string          `json:"format"`
+	Version   string          `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	Defaults  map[string]any  `json:"defaults,omitempty"`
+	Catalog   map[string]any  `json:"catalog,omitempty"`
+	Overrides map[string]bool `json:"overrides,omitempty"`
+}
+
+// KeyRing maps key IDs to trusted public keys.
+type KeyRing map[string]ed25519.PublicKey
+
+// signedEnvelope is the on-disk form. The payload is base64 so the signed bytes survive
+// reformatting of the envelope.
+type signedEnvelope struct {
+	Payload   []byte `json:"payload"`
+	KeyID     string `json:"key_id,omitempty"`
+	Signature []byte `json:"signature"`
+}
+
+// ManifestFromBundle builds a Manifest from an import Bundle, keeping system-scope overrides only.
+func ManifestFromBundle(b Bundle, version string) Manifest {
+	m := Manifest{
+		Version:   version,
+		Defaults:  map[string]any{},
+		Catalog:   map[string]any{},
+		Overrides: map[string]bool{},
+	}
+	for key, value := range b.Defaults {
+		m.Defaults[key] = value
+	}
+	for _, def := range b.Definitions {
+		entry := map[string]any{"description": def.Description.Text}
+		if def.Status != "" {
+			entry["status"] = string(def.Status)
+		}
+		if def.Owner != "" {
+			entry["owner"] = def.Owner
+		}
+		if !def.CreatedAt.IsZero() {
+			entry["created_at"] = def.CreatedAt.Format(time.RFC3339)
+		}
+		if !def.SunsetAt.IsZero() {
+			entry["sunset_at"] = def.SunsetAt.Format(time.RFC3339)
+		}
+		m.Catalog[def.Key] = entry
+	}
+	for _, record := range b.Overrides {
+		if record.Scope.Kind == gate.ScopeSystem && record.Override.HasValue() {
+			m.Overrides[record.Key] = record.Override.Value
+		}
+	}
+	return m
+}
+
+// Sign serializes and signs m. Format and CreatedAt are filled when empty.
+func Sign(m Manifest, key ed25519.PrivateKey, keyID string) ([]byte, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, ferrors.NewBadInput(TextCodeSignatureInvalid, "exchange: invalid signing key", nil)
+	}
+	if strings.TrimSpace(m.Version) == "" {
+		return nil, ferrors.NewBadInput(TextCodeBundleInvalid, "exchange: bundle version is required", nil)
+	}
+	if m.Format == "" {
+		m.Format = SignedFormat
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return nil, ferrors.WrapInternal(err, TextCodeBundleInvalid, "exchange: failed to encode bundle", nil)
+	}
+	return json.MarshalIndent(signedEnvelope{
+		Payload:   payload,
+		KeyID:     keyID,
+		Signature: ed25519.Sign(key, payload),
+	}, "", "  ")
+}
+
+// Verify checks the signature against keys and decodes the manifest. An envelope with a
+// key ID must match that key; one without is accepted by any key in the ring.
+func Verify(data []byte, keys KeyRing) (Manifest, error) {
+	envelope := signedEnvelope{}
+	if err := json.Unmarshal(data, &envelope); err != nil || len(envelope.Payload) == 0 {
+		return Manifest{}, ferrors.WrapBadInput(err, TextCodeBundleInvalid, "exchange: invalid bundle envelope", nil)
+	}
+	if !verifySignature(envelope, keys) {
+		return Manifest{}, ferrors.NewBadInput(TextCodeSignatureInvalid, "exchange: bundle signature does not verify", map[string]any{
+			MetaKeyID: envelope.KeyID,
+		})
+	}
+	m := Manifest{}
+	if err := json.Unmarshal(envelope.Payload, &m); err != nil {
+		return Manifest{}, ferrors.WrapBadInput(err, TextCodeBundleInvalid, "exchange: invalid bundle payload", nil)
+	}
+	if m.Format != SignedFormat {
+		return Manifest{}, ferrors.NewBadInput(TextCodeBundleInvalid, "exchange: unsupported bundle format", map[string]any{
+			MetaBundleVersion: m.Version,
+			"format":          m.Format,
+		})
+	}
+	return m, nil
+}
+
+// LoadSigned reads and verifies a signed bundle file.
+func LoadSigned(path string, keys KeyRing) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, ferrors.WrapBadInput(err, TextCodeBundleInvalid, "exchange: failed to read bundle", map[string]any{
+			ferrors.MetaPath: path,
+		})
+	}
+	return Verify(data, keys)
+}
+
+// CatalogView returns the manifest catalog.
+func (m Manifest) CatalogView() *catalog.StaticCatalog {
+	return configadapter.NewCatalog(m.Catalog)
+}
+
+// Options returns resolver options serving the manifest: its defaults, an in-memory
+// store seeded with its system overrides, and its version for Config reporting.
+func (m Manifest) Options(ctx context.Context) ([]resolver.Option, error) {
+	overrides := store.NewMemoryStore()
+	actor := gate.ActorRef{Type: "bundle", Name: m.Version}
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	for key, value := range m.Overrides {
+		if err := overrides.Set(ctx, key, system, value, actor); err != nil {
+			return nil, err
+		}
+	}
+	return []resolver.Option{
+		resolver.WithDefaults(configadapter.NewDefaults(m.Defaults)),
+		resolver.WithOverrideStore(overrides),
+		resolver.WithBundleVersion(m.Version),
+	}, nil
+}
+
+// Records returns the system-scope overrides as store records, for planning a bundle
+// apply against a live store with PlanReconcile.
+func (m Manifest) Records() []store.OverrideRecord {
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	records := make([]store.OverrideRecord, 0, len(m.Overrides))
+	for key, value := range m.Overrides {
+		override := store.DisabledOverride()
+		if value {
+			override = store.EnabledOverride()
+		}
+		override.Metadata = gate.OverrideMetadata{
+			Reason: "bundle " + m.Version,
+			Labels: map[string]string{LabelSource: SourceBundle},
+		}
+		records = append(records, store.OverrideRecord{Key: gate.NormalizeKey(key), Scope: system, Override: override})
+	}
+	return records
+}
+
+// EncodeKey renders a key as base64 text for key files.
+func EncodeKey(key []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(key) + "\n")
+}
+
+// DecodePublicKey parses a base64 public key file.
+func DecodePublicKey(data []byte) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, ferrors.WrapBadInput(err, TextCodeSignatureInvalid, "exchange: invalid public key", nil)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// DecodePrivateKey parses a base64 private key file.
+func DecodePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(raw) != ed25519.PrivateKeySize {
+		return nil, ferrors.WrapBadInput(err, TextCodeSignatureInvalid, "exchange: invalid private key", nil)
+	}
+	return ed25519.PrivateKey(raw), nil
+}
+
+func verifySignature(envelope signedEnvelope, keys KeyRing) bool {
+	if envelope.KeyID != "" {
+		key, ok := keys[envelope.KeyID]
+		return ok && len(key) == ed25519.PublicKeySize && ed25519.Verify(key, envelope.Payload, envelope.Signature)
+	}
+	for _, key := range keys {
+		if len(key) == ed25519.PublicKeySize && ed25519.Verify(key, envelope.Payload, envelope.Signature) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package exchange
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/resolver"
+)
+
+func TestSignedBundleVerifiesAndServesValues(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	data, err := Sign(Manifest{
+		Version:   "2024.06.1",
+		Defaults:  map[string]any{"users": map[string]any{"signup": true}},
+		Catalog:   map[string]any{"users.signup": map[string]any{"description": "Allow signups"}},
+		Overrides: map[string]bool{"dashboard": true},
+	}, priv, "release")
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	manifest, err := Verify(data, KeyRing{"release": pub})
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	ctx := context.Background()
+	opts, err := manifest.Options(ctx)
+	if err != nil {
+		t.Fatalf("options: %v", err)
+	}
+	fg := resolver.New(opts...)
+	if fg.Config().BundleVersion != "2024.06.1" {
+		t.Fatalf("expected bundle version in config, got %+v", fg.Config())
+	}
+	for _, key := range []string{"users.signup", "dashboard"} {
+		if enabled, err := fg.Enabled(ctx, key); err != nil || !enabled {
+			t.Fatalf("expected %s enabled, got %v (%v)", key, enabled, err)
+		}
+	}
+
+	other, _, _ := ed25519.GenerateKey(rand.Reader)
+	if _, err := Verify(data, KeyRing{"release": other}); err == nil {
+		t.Fatalf("expected signature failure with the wrong key")
+	}
+	tampered := []byte(string(data))
+	for i := range tampered {
+		if tampered[i] == '1' {
+			tampered[i] = '2'
+			break
+		}
+	}
+	if _, err := Verify(tampered, KeyRing{"release": pub}); err == nil {
+		t.Fatalf("expected tampered bundle to fail verification")
+	}
+}
@@ -0,0 +1,405 @@
+// Package experiment assigns subjects to A/B experiment variants on top of the
+// feature gate.
+//
+// An Experiment lists weighted variants, the share of eligible subjects enrolled, and
+// targeting: an optional feature flag that must be enabled for the subject, so
+// overrides, rules, and schedules decide who is eligible exactly as they do for
+// flags. Assignment hashes the subject deterministically, so the same subject lands
+// in the same variant on every instance, and an AssignmentStore makes assignments
+// sticky when weights change later.
+//
+//	assigner := experiment.New(experiment.StaticExperiments{
+//		"checkout.button": {Variants: []experiment.Variant{{Key: "blue", Weight: 50}, {Key: "green", Weight: 50}}},
+//	}, experiment.WithAssignmentStore(experiment.NewMemoryAssignments()))
+//	gate := resolver.New(resolver.WithExperiments(assigner))
+//	assignment, err := gate.Experiment(ctx, "checkout.button")
+package experiment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+const (
+	TextCodeExperimentUnknown = "EXPERIMENT_UNKNOWN"
+	TextCodeExperimentInvalid = "EXPERIMENT_INVALID"
+	TextCodeAssignmentFailed  = "EXPERIMENT_ASSIGNMENT_FAILED"
+
+	MetaExperiment = "experiment"
+)
+
+// Unit is the claim an experiment randomizes on.
+type Unit string
+
+const (
+	UnitUser   Unit = "user"
+	UnitTenant Unit = "tenant"
+	UnitOrg    Unit = "org"
+)
+
+// Reason explains an assignment.
+type Reason string
+
+const (
+	// ReasonAssigned means the subject was bucketed into a variant now.
+	ReasonAssigned Reason = "assigned"
+	// ReasonPersisted means a stored assignment was returned.
+	ReasonPersisted Reason = "persisted"
+	// ReasonPaused means the experiment is paused and serves its default variant.
+	ReasonPaused Reason = "paused"
+	// ReasonNoUnit means the claims carry no value for the experiment's unit.
+	ReasonNoUnit Reason = "no_unit"
+	// ReasonNotTargeted means targeting excluded the subject.
+	ReasonNotTargeted Reason = "not_targeted"
+	// ReasonTraffic means the subject fell outside the enrolled traffic share.
+	ReasonTraffic Reason = "traffic"
+)
+
+// Variant is one experiment arm. Weights are relative; when every weight is zero
+// the variants split traffic evenly.
+type Variant struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value,omitempty"`
+	Weight int    `json:"weight,omitempty"`
+}
+
+// Experiment defines an A/B test.
+type Experiment struct {
+	Key      string    `json:"key"`
+	Variants []Variant `json:"variants"`
+	// Default is the variant served to subjects outside the experiment; the first
+	// variant when empty.
+	Default string `json:"default,omitempty"`
+	// Traffic is the share of eligible subjects enrolled, in (0, 1]. Zero enrolls
+	// everyone; pause an experiment with Paused.
+	Traffic float64 `json:"traffic,omitempty"`
+	// Flag, when set, must be enabled for the subject to be eligible.
+	Flag string `json:"flag,omitempty"`
+	// Tenants, when set, limits eligibility to these tenant IDs.
+	Tenants []string `json:"tenants,omitempty"`
+	// Unit is the claim hashed for assignment; UnitUser when empty.
+	Unit Unit `json:"unit,omitempty"`
+	// Salt reshuffles assignments without renaming the experiment.
+	Salt   string `json:"salt,omitempty"`
+	Paused bool   `json:"paused,omitempty"`
+}
+
+// Validate reports definition errors.
+func (e Experiment) Validate() error {
+	problem := ""
+	seen := map[string]bool{}
+	switch {
+	case strings.TrimSpace(e.Key) == "":
+		problem = "experiment key is required"
+	case len(e.Variants) == 0:
+		problem = "experiment needs at least one variant"
+	case e.Traffic < 0 || e.Traffic > 1:
+		problem = "traffic must be between 0 and 1"
+	case e.Unit != "" && e.Unit != UnitUser && e.Unit != UnitTenant && e.Unit != UnitOrg:
+		problem = "unknown unit " + string(e.Unit)
+	}
+	for _, variant := range e.Variants {
+		if problem != "" {
+			break
+		}
+		switch {
+		case strings.TrimSpace(variant.Key) == "":
+			problem = "variant key is required"
+		case seen[variant.Key]:
+			problem = "duplicate variant " + variant.Key
+		case variant.Weight < 0:
+			problem = "variant " + variant.Key + " has a negative weight"
+		}
+		seen[variant.Key] = true
+	}
+	if problem == "" && e.Default != "" && !seen[e.Default] {
+		problem = "default variant " + e.Default + " is not defined"
+	}
+	if problem == "" {
+		return nil
+	}
+	return ferrors.NewBadInput(TextCodeExperimentInvalid, "experiment: "+problem, map[string]any{
+		MetaExperiment: e.Key,
+	})
+}
+
+// DefaultVariant returns the variant served outside the experiment.
+func (e Experiment) DefaultVariant() gate.Variant {
+	for _, variant := range e.Variants {
+		if e.Default == "" || variant.Key == e.Default {
+			return gate.Variant{Key: variant.Key, Value: variant.Value}
+		}
+	}
+	return gate.Variant{}
+}
+
+// Assignment is a subject's experiment result.
+type Assignment struct {
+	Experiment   string       `json:"experiment"`
+	Unit         Unit         `json:"unit"`
+	UnitID       string       `json:"unit_id,omitempty"`
+	Variant      gate.Variant `json:"variant"`
+	InExperiment bool         `json:"in_experiment"`
+	Reason       Reason       `json:"reason"`
+	AssignedAt   time.Time    `json:"assigned_at,omitzero"`
+}
+
+// Definitions looks up experiments by key.
+type Definitions interface {
+	Experiment(ctx context.Context, key string) (Experiment, bool, error)
+}
+
+// StaticExperiments serves definitions from a map keyed by experiment key. Entries
+// without a Key take the map key.
+type StaticExperiments map[string]Experiment
+
+// Experiment implements Definitions.
+func (s StaticExperiments) Experiment(_ context.Context, key string) (Experiment, bool, error) {
+	key = gate.NormalizeKey(key)
+	def, ok := s[key]
+	if ok && def.Key == "" {
+		def.Key = key
+	}
+	return def, ok, nil
+}
+
+// Option customizes an Assigner.
+type Option func(*Assigner)
+
+// WithAssignmentStore persists assignments so subjects keep their variant when the
+// definition changes.
+func WithAssignmentStore(store AssignmentStore) Option {
+	return func(a *Assigner) {
+		if a == nil {
+			return
+		}
+		a.store = store
+	}
+}
+
+// WithClaimsProvider sets how claims are read when the call passes none. Defaults to
+// scope.ClaimsFromContext.
+func WithClaimsProvider(provider gate.ClaimsProvider) Option {
+	return func(a *Assigner) {
+		if a == nil || provider == nil {
+			return
+		}
+		a.claims = provider
+	}
+}
+
+// WithClock sets the clock used for assignment timestamps.
+func WithClock(c clock.Clock) Option {
+	return func(a *Assigner) {
+		if a == nil {
+			return
+		}
+		a.now = clock.NowFunc(c)
+	}
+}
+
+// Assigner assigns subjects to experiment variants.
+type Assigner struct {
+	defs   Definitions
+	store  AssignmentStore
+	claims gate.ClaimsProvider
+	now    func() time.Time
+}
+
+// New constructs an Assigner over defs.
+func New(defs Definitions, opts ...Option) *Assigner {
+	a := &Assigner{defs: defs, now: time.Now}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(a)
+		}
+	}
+	if a.now == nil {
+		a.now = time.Now
+	}
+	return a
+}
+
+// Assign returns the assignment for key. Claims come from gate.WithClaims when
+// passed, otherwise from the claims provider. fg evaluates the experiment's Flag and
+// may be nil for experiments without one.
+func (a *Assigner) Assign(ctx context.Context, fg gate.FeatureGate, key string, opts ...gate.ResolveOption) (Assignment, error) {
+	if a == nil || a.defs == nil {
+		return Assignment{}, unknown(key)
+	}
+	key = gate.NormalizeKey(key)
+	def, ok, err := a.defs.Experiment(ctx, key)
+	if err != nil {
+		return Assignment{}, ferrors.WrapExternal(err, TextCodeAssignmentFailed, "experiment: definition lookup failed", map[string]any{
+			MetaExperiment: key,
+		})
+	}
+	if !ok {
+		return Assignment{}, unknown(key)
+	}
+	if def.Key == "" {
+		def.Key = key
+	}
+	if err := def.Validate(); err != nil {
+		return Assignment{}, err
+	}
+
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	claims, err := a.claimsFor(ctx, req)
+	if err != nil {
+		return Assignment{}, ferrors.WrapExternal(err, TextCodeAssignmentFailed, "experiment: claims lookup failed", map[string]any{
+			MetaExperiment: key,
+		})
+	}
+
+	unit := def.Unit
+	if unit == "" {
+		unit = UnitUser
+	}
+	out := Assignment{Experiment: def.Key, Unit: unit, UnitID: unitID(unit, claims), Variant: def.DefaultVariant()}
+	switch {
+	case def.Paused:
+		out.Reason = ReasonPaused
+		return out, nil
+	case out.UnitID == "":
+		out.Reason = ReasonNoUnit
+		return out, nil
+	}
+
+	if a.store != nil {
+		stored, found, err := a.store.GetAssignment(ctx, def.Key, unit, out.UnitID)
+		if err != nil {
+			return Assignment{}, ferrors.WrapExternal(err, TextCodeAssignmentFailed, "experiment: assignment lookup failed", map[string]any{
+				MetaExperiment: key,
+			})
+		}
+		if found {
+			stored.Reason = ReasonPersisted
+			return stored, nil
+		}
+	}
+
+	eligible, err := a.eligible(ctx, fg, def, claims, opts)
+	if err != nil {
+		return Assignment{}, err
+	}
+	if !eligible {
+		out.Reason = ReasonNotTargeted
+		return out, nil
+	}
+	if def.Traffic > 0 && Bucket("traffic", def.Salt, def.Key, out.UnitID) >= def.Traffic {
+		out.Reason = ReasonTraffic
+		return out, nil
+	}
+
+	out.Variant = pick(def, Bucket("variant", def.Salt, def.Key, out.UnitID))
+	out.InExperiment = true
+	out.Reason = ReasonAssigned
+	out.AssignedAt = a.now()
+	if a.store != nil {
+		if err := a.store.SaveAssignment(ctx, out); err != nil {
+			return Assignment{}, ferrors.WrapExternal(err, TextCodeAssignmentFailed, "experiment: assignment save failed", map[string]any{
+				MetaExperiment: key,
+			})
+		}
+	}
+	return out, nil
+}
+
+func (a *Assigner) claimsFor(ctx context.Context, req gate.ResolveRequest) (gate.ActorClaims, error) {
+	if req.Claims != nil {
+		return *req.Claims, nil
+	}
+	if a.claims != nil {
+		return a.claims.ClaimsFromContext(ctx)
+	}
+	return scope.ClaimsFromContext(ctx), nil
+}
+
+func (a *Assigner) eligible(ctx context.Context, fg gate.FeatureGate, def Experiment, claims gate.ActorClaims, opts []gate.ResolveOption) (bool, error) {
+	if len(def.Tenants) > 0 && !slices.Contains(def.Tenants, claims.TenantID) {
+		return false, nil
+	}
+	if strings.TrimSpace(def.Flag) == "" {
+		return true, nil
+	}
+	if fg == nil {
+		return false, ferrors.WrapSentinel(ferrors.ErrGateRequired, "experiment: feature gate is required for flag targeting", map[string]any{
+			MetaExperiment:         def.Key,
+			ferrors.MetaFeatureKey: def.Flag,
+		})
+	}
+	return fg.Enabled(ctx, def.Flag, append(opts, gate.WithClaims(claims))...)
+}
+
+// Bucket hashes parts into [0, 1). Assignment uses it with a "traffic" or "variant"
+// prefix, the salt, the experiment key, and the unit ID, so enrollment and variant
+// choice are independent and stable across processes.
+func Bucket(parts ...string) float64 {
+	h := sha256.New()
+	for i, part := range parts {
+		if i > 0 {
+			h.Write([]byte{0})
+		}
+		h.Write([]byte(part))
+	}
+	// Use the top 53 bits so the result is exactly representable.
+	return float64(binary.BigEndian.Uint64(h.Sum(nil))>>11) / (1 << 53)
+}
+
+// pick maps bucket onto the cumulative variant weights.
+func pick(def Experiment, bucket float64) gate.Variant {
+	total := 0
+	for _, variant := range def.Variants {
+		total += variant.Weight
+	}
+	equal := total == 0
+	if equal {
+		total = len(def.Variants)
+	}
+	target := int(math.Floor(bucket * float64(total)))
+	for _, variant := range def.Variants {
+		weight := variant.Weight
+		if equal {
+			weight = 1
+		}
+		if target < weight {
+			return gate.Variant{Key: variant.Key, Value: variant.Value}
+		}
+		target -= weight
+	}
+	last := def.Variants[len(def.Variants)-1]
+	return gate.Variant{Key: last.Key, Value: last.Value}
+}
+
+func unitID(unit Unit, claims gate.ActorClaims) string {
+	switch unit {
+	case UnitTenant:
+		return strings.TrimSpace(claims.TenantID)
+	case UnitOrg:
+		return strings.TrimSpace(claims.OrgID)
+	default:
+		return strings.TrimSpace(claims.SubjectID)
+	}
+}
+
+func unknown(key string) error {
+	return ferrors.NewBadInput(TextCodeExperimentUnknown, "experiment: unknown experiment", map[string]any{
+		MetaExperiment: key,
+	})
+}
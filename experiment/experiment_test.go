@@ -0,0 +1,109 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+type flagGate map[string]bool
+
+func (f flagGate) Enabled(_ context.Context, key string, opts ...gate.ResolveOption) (bool, error) {
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		opt(&req)
+	}
+	if req.Claims == nil {
+		return false, errors.New("expected claims")
+	}
+	return f[key+"/"+req.Claims.TenantID], nil
+}
+
+func TestAssignerSplitsTrafficDeterministically(t *testing.T) {
+	defs := StaticExperiments{"checkout.button": {
+		Variants: []Variant{{Key: "control", Weight: 1}, {Key: "green", Weight: 3}},
+		Traffic:  0.5,
+	}}
+	assigner := New(defs)
+	counts := map[string]int{}
+	out := 0
+	for i := 0; i < 4000; i++ {
+		ctx := scope.WithUserID(context.Background(), "user-"+strconv.Itoa(i))
+		first, err := assigner.Assign(ctx, nil, "checkout.button")
+		if err != nil {
+			t.Fatalf("assign: %v", err)
+		}
+		again, _ := assigner.Assign(ctx, nil, "checkout.button")
+		if again.Variant != first.Variant || again.InExperiment != first.InExperiment {
+			t.Fatalf("expected deterministic assignment, got %+v then %+v", first, again)
+		}
+		if !first.InExperiment {
+			if first.Reason != ReasonTraffic || first.Variant.Key != "control" {
+				t.Fatalf("unexpected out-of-experiment assignment: %+v", first)
+			}
+			out++
+			continue
+		}
+		counts[first.Variant.Key]++
+	}
+	if math.Abs(float64(out)/4000-0.5) > 0.05 {
+		t.Fatalf("expected half the traffic enrolled, %d of 4000 were not", out)
+	}
+	enrolled := float64(counts["control"] + counts["green"])
+	if math.Abs(float64(counts["green"])/enrolled-0.75) > 0.05 {
+		t.Fatalf("expected a 1:3 split, got %v", counts)
+	}
+}
+
+func TestAssignerTargetingAndPersistence(t *testing.T) {
+	defs := StaticExperiments{"search.ranking": {
+		Variants: []Variant{{Key: "bm25"}, {Key: "vector", Value: 2}},
+		Default:  "bm25",
+		Flag:     "search.beta",
+		Unit:     UnitTenant,
+	}}
+	store := NewMemoryAssignments()
+	assigner := New(defs, WithAssignmentStore(store))
+	fg := flagGate{"search.beta/acme": true}
+
+	denied, err := assigner.Assign(context.Background(), fg, "search.ranking", gate.WithClaims(gate.ActorClaims{TenantID: "globex"}))
+	if err != nil || denied.InExperiment || denied.Reason != ReasonNotTargeted || denied.Variant.Key != "bm25" {
+		t.Fatalf("expected untargeted tenant to get the default, got %+v (%v)", denied, err)
+	}
+	if none, _ := assigner.Assign(context.Background(), fg, "search.ranking"); none.Reason != ReasonNoUnit {
+		t.Fatalf("expected missing tenant to report no unit, got %+v", none)
+	}
+
+	acme := gate.WithClaims(gate.ActorClaims{TenantID: "acme"})
+	assigned, err := assigner.Assign(context.Background(), fg, "search.ranking", acme)
+	if err != nil || !assigned.InExperiment || assigned.Reason != ReasonAssigned || assigned.UnitID != "acme" {
+		t.Fatalf("expected acme to be assigned, got %+v (%v)", assigned, err)
+	}
+
+	// Changing the definition keeps the stored assignment.
+	def := defs["search.ranking"]
+	def.Salt = "rerun"
+	def.Variants = []Variant{{Key: "bm25", Weight: 1}, {Key: "vector", Weight: 0}, {Key: "hybrid", Weight: 1000}}
+	defs["search.ranking"] = def
+	sticky, err := assigner.Assign(context.Background(), fg, "search.ranking", acme)
+	if err != nil || sticky.Reason != ReasonPersisted || sticky.Variant != assigned.Variant {
+		t.Fatalf("expected stored assignment, got %+v (%v)", sticky, err)
+	}
+}
+
+func TestAssignerRejectsUnknownAndInvalidExperiments(t *testing.T) {
+	assigner := New(StaticExperiments{"bad": {Variants: []Variant{{Key: "a"}, {Key: "a"}}}})
+	cases := map[string]string{"missing": TextCodeExperimentUnknown, "bad": TextCodeExperimentInvalid}
+	for key, code := range cases {
+		_, err := assigner.Assign(context.Background(), nil, key, gate.WithClaims(gate.ActorClaims{SubjectID: "u1"}))
+		if rich, ok := ferrors.As(err); !ok || rich.TextCode != code {
+			t.Fatalf("%s: expected %s, got %v", key, code, err)
+		}
+	}
+}
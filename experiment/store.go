@@ -0,0 +1,59 @@
+package experiment
+
+import (
+	"context"
+	"sync"
+)
+
+// AssignmentStore persists assignments per experiment and unit.
+type AssignmentStore interface {
+	GetAssignment(ctx context.Context, experiment string, unit Unit, unitID string) (Assignment, bool, error)
+	SaveAssignment(ctx context.Context, assignment Assignment) error
+}
+
+// MemoryAssignments is an in-memory AssignmentStore.
+type MemoryAssignments struct {
+	mu          sync.RWMutex
+	assignments map[assignmentKey]Assignment
+}
+
+type assignmentKey struct {
+	experiment string
+	unit       Unit
+	unitID     string
+}
+
+// NewMemoryAssignments constructs an empty store.
+func NewMemoryAssignments() *MemoryAssignments {
+	return &MemoryAssignments{assignments: map[assignmentKey]Assignment{}}
+}
+
+// GetAssignment implements AssignmentStore.
+func (m *MemoryAssignments) GetAssignment(_ context.Context, experiment string, unit Unit, unitID string) (Assignment, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	assignment, ok := m.assignments[assignmentKey{experiment: experiment, unit: unit, unitID: unitID}]
+	return assignment, ok, nil
+}
+
+// SaveAssignment implements AssignmentStore.
+func (m *MemoryAssignments) SaveAssignment(_ context.Context, assignment Assignment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.assignments[assignmentKey{experiment: assignment.Experiment, unit: assignment.Unit, unitID: assignment.UnitID}] = assignment
+	return nil
+}
+
+// Delete removes every stored assignment for experiment, e.g. before a rerun.
+func (m *MemoryAssignments) Delete(_ context.Context, experiment string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.assignments {
+		if key.experiment == experiment {
+			delete(m.assignments, key)
+		}
+	}
+	return nil
+}
+
+var _ AssignmentStore = (*MemoryAssignments)(nil)
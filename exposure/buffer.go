@@ -0,0 +1,218 @@
+package exposure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultBatchSize caps the exposures written per sink call.
+	DefaultBatchSize = 100
+	// DefaultFlushInterval is the delay between background flushes.
+	DefaultFlushInterval = time.Second
+	// DefaultMaxPending caps the exposures held in memory.
+	DefaultMaxPending = 10000
+)
+
+// BufferOption customizes a Buffer.
+type BufferOption func(*Buffer)
+
+// WithBatchSize caps the exposures written per sink call. A full batch is flushed
+// without waiting for the interval.
+func WithBatchSize(size int) BufferOption {
+	return func(b *Buffer) {
+		if b == nil {
+			return
+		}
+		b.batchSize = size
+	}
+}
+
+// WithFlushInterval sets the delay between background flushes.
+func WithFlushInterval(interval time.Duration) BufferOption {
+	return func(b *Buffer) {
+		if b == nil {
+			return
+		}
+		b.interval = interval
+	}
+}
+
+// WithMaxPending caps the exposures held in memory. Exposures beyond the cap are
+// dropped and counted by Dropped.
+func WithMaxPending(limit int) BufferOption {
+	return func(b *Buffer) {
+		if b == nil {
+			return
+		}
+		b.maxPending = limit
+	}
+}
+
+// WithBufferOnError registers a callback for failed background flushes.
+func WithBufferOnError(fn func(error)) BufferOption {
+	return func(b *Buffer) {
+		if b == nil {
+			return
+		}
+		b.onError = fn
+	}
+}
+
+// Buffer is a Sink that queues exposures and writes them to an inner sink in batches
+// from a background loop, so Record never waits on the inner sink.
+type Buffer struct {
+	inner      Sink
+	batchSize  int
+	interval   time.Duration
+	maxPending int
+	onError    func(error)
+
+	mu      sync.Mutex
+	pending []Exposure
+	closed  bool
+	flushMu sync.Mutex
+	kick    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+// NewBuffer constructs a Buffer over inner and starts its background loop. Call Close
+// on shutdown to write the remaining exposures.
+func NewBuffer(inner Sink, opts ...BufferOption) *Buffer {
+	b := &Buffer{
+		inner:      inner,
+		batchSize:  DefaultBatchSize,
+		interval:   DefaultFlushInterval,
+		maxPending: DefaultMaxPending,
+		kick:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	if b.batchSize < 1 {
+		b.batchSize = DefaultBatchSize
+	}
+	if b.interval <= 0 {
+		b.interval = DefaultFlushInterval
+	}
+	if b.maxPending < 1 {
+		b.maxPending = DefaultMaxPending
+	}
+	go b.loop()
+	return b
+}
+
+// Record implements Sink by queueing exposures. It never fails; overflow is counted
+// by Dropped.
+func (b *Buffer) Record(_ context.Context, exposures []Exposure) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		b.dropped.Add(uint64(len(exposures)))
+		return nil
+	}
+	room := max(b.maxPending-len(b.pending), 0)
+	if len(exposures) > room {
+		b.dropped.Add(uint64(len(exposures) - room))
+		exposures = exposures[:room]
+	}
+	b.pending = append(b.pending, exposures...)
+	full := len(b.pending) >= b.batchSize
+	b.mu.Unlock()
+	if full {
+		select {
+		case b.kick <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Pending reports the number of queued exposures.
+func (b *Buffer) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Dropped reports exposures discarded because the buffer was full or closed.
+func (b *Buffer) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Flush writes every queued exposure, one batch at a time. Failed batches are
+// discarded and their errors joined.
+func (b *Buffer) Flush(ctx context.Context) error {
+	var errs []error
+	for {
+		written, err := b.flushBatch(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if written == 0 {
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// Close stops the background loop and writes the remaining exposures.
+func (b *Buffer) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+	close(b.stop)
+	<-b.done
+	return b.Flush(ctx)
+}
+
+func (b *Buffer) loop() {
+	defer close(b.done)
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+		case <-b.kick:
+		}
+		if err := b.Flush(context.Background()); err != nil && b.onError != nil {
+			b.onError(err)
+		}
+	}
+}
+
+func (b *Buffer) flushBatch(ctx context.Context) (int, error) {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	n := min(len(b.pending), b.batchSize)
+	if n == 0 {
+		b.mu.Unlock()
+		return 0, nil
+	}
+	batch := append([]Exposure(nil), b.pending[:n]...)
+	b.pending = append(b.pending[:0], b.pending[n:]...)
+	b.mu.Unlock()
+
+	if b.inner == nil {
+		return n, nil
+	}
+	return n, b.inner.Record(ctx, batch)
+}
+
+var _ Sink = (*Buffer)(nil)
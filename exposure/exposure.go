@@ -0,0 +1,285 @@
+// Package exposure records which subjects saw which feature values, for
+// experimentation analysis.
+//
+// A Tracker is a gate.ResolveHook that turns resolutions into Exposure records,
+// deduplicated per (subject, key, value), and hands them to a Sink. Wrap slow sinks
+// (warehouses, analytics APIs) in a Buffer to write them in batches:
+//
+//	buffer := exposure.NewBuffer(warehouseSink, exposure.WithBatchSize(500))
+//	defer buffer.Close(ctx)
+//	gate := resolver.New(resolver.WithResolveHook(exposure.NewTracker(buffer)))
+package exposure
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// DefaultDedupCapacity bounds the (subject, key, value) set a Tracker remembers.
+const DefaultDedupCapacity = 100000
+
+// Exposure records that a subject was served a feature value.
+type Exposure struct {
+	SubjectID string             `json:"subject_id"`
+	TenantID  string             `json:"tenant_id,omitempty"`
+	OrgID     string             `json:"org_id,omitempty"`
+	Key       string             `json:"key"`
+	Value     bool               `json:"value"`
+	Source    gate.ResolveSource `json:"source"`
+	Time      time.Time          `json:"time"`
+	Metadata  map[string]string  `json:"metadata,omitempty"`
+}
+
+// Sink stores exposures.
+type Sink interface {
+	Record(ctx context.Context, exposures []Exposure) error
+}
+
+// SinkFunc wraps a function as a Sink.
+type SinkFunc func(ctx context.Context, exposures []Exposure) error
+
+// Record implements Sink.
+func (fn SinkFunc) Record(ctx context.Context, exposures []Exposure) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, exposures)
+}
+
+// MemorySink keeps exposures in memory, for tests and development.
+type MemorySink struct {
+	mu        sync.Mutex
+	exposures []Exposure
+}
+
+// Record implements Sink.
+func (m *MemorySink) Record(_ context.Context, exposures []Exposure) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exposures = append(m.exposures, exposures...)
+	return nil
+}
+
+// Exposures returns a copy of the recorded exposures.
+func (m *MemorySink) Exposures() []Exposure {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Exposure(nil), m.exposures...)
+}
+
+// Option customizes a Tracker.
+type Option func(*Tracker)
+
+// WithDedupWindow records a (subject, key, value) again once window has passed since
+// it was last recorded. Zero, the default, records it once per Tracker lifetime
+// (bounded by the dedup capacity).
+func WithDedupWindow(window time.Duration) Option {
+	return func(t *Tracker) {
+		if t == nil || window < 0 {
+			return
+		}
+		t.window = window
+	}
+}
+
+// WithDedupCapacity bounds the remembered set. When it fills, expired entries are
+// dropped first; if none are, the set is cleared and exposures are recorded again.
+func WithDedupCapacity(capacity int) Option {
+	return func(t *Tracker) {
+		if t == nil {
+			return
+		}
+		t.capacity = capacity
+	}
+}
+
+// WithAnonymous records resolutions without a user scope under an empty subject.
+// They are skipped by default because they cannot be joined to experiment units.
+func WithAnonymous(enabled bool) Option {
+	return func(t *Tracker) {
+		if t == nil {
+			return
+		}
+		t.anonymous = enabled
+	}
+}
+
+// WithFilter records only the resolutions pred accepts, e.g. experiment keys.
+func WithFilter(pred gate.ResolvePredicate) Option {
+	return func(t *Tracker) {
+		if t == nil {
+			return
+		}
+		t.filter = pred
+	}
+}
+
+// WithOnError registers a callback for sink failures.
+func WithOnError(fn func(error)) Option {
+	return func(t *Tracker) {
+		if t == nil {
+			return
+		}
+		t.onError = fn
+	}
+}
+
+// WithClock sets the clock used for exposure timestamps and the dedup window.
+func WithClock(c clock.Clock) Option {
+	return func(t *Tracker) {
+		if t == nil {
+			return
+		}
+		t.now = clock.NowFunc(c)
+	}
+}
+
+// Tracker records exposures from resolve events.
+type Tracker struct {
+	sink      Sink
+	window    time.Duration
+	capacity  int
+	anonymous bool
+	filter    gate.ResolvePredicate
+	onError   func(error)
+	now       func() time.Time
+
+	mu       sync.Mutex
+	seen     map[dedupKey]time.Time
+	recorded atomic.Uint64
+}
+
+type dedupKey struct {
+	subject string
+	tenant  string
+	key     string
+	value   bool
+}
+
+// NewTracker constructs a Tracker writing to sink.
+func NewTracker(sink Sink, opts ...Option) *Tracker {
+	t := &Tracker{
+		sink:     sink,
+		capacity: DefaultDedupCapacity,
+		now:      time.Now,
+		seen:     map[dedupKey]time.Time{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(t)
+		}
+	}
+	if t.capacity < 1 {
+		t.capacity = DefaultDedupCapacity
+	}
+	if t.now == nil {
+		t.now = time.Now
+	}
+	return t
+}
+
+// OnResolve implements gate.ResolveHook. Failed resolutions are not exposures.
+func (t *Tracker) OnResolve(ctx context.Context, event gate.ResolveEvent) {
+	if t == nil || t.sink == nil || event.Error != nil || event.NormalizedKey == "" {
+		return
+	}
+	if t.filter != nil && !t.filter(event) {
+		return
+	}
+	exposure := Exposure{
+		Key:      event.NormalizedKey,
+		Value:    event.Value,
+		Source:   event.Source,
+		Time:     t.now(),
+		Metadata: event.Metadata,
+	}
+	for _, ref := range event.Chain {
+		switch ref.Kind {
+		case gate.ScopeUser:
+			if exposure.SubjectID == "" {
+				exposure.SubjectID = ref.ID
+			}
+		case gate.ScopeTenant:
+			if exposure.TenantID == "" {
+				exposure.TenantID = ref.ID
+			}
+		case gate.ScopeOrg:
+			if exposure.OrgID == "" {
+				exposure.OrgID = ref.ID
+			}
+		}
+	}
+	if exposure.SubjectID == "" && !t.anonymous {
+		return
+	}
+	if !t.first(exposure) {
+		return
+	}
+	if err := t.sink.Record(ctx, []Exposure{exposure}); err != nil {
+		t.forget(exposure)
+		if t.onError != nil {
+			t.onError(err)
+		}
+		return
+	}
+	t.recorded.Add(1)
+}
+
+// Recorded reports how many exposures reached the sink.
+func (t *Tracker) Recorded() uint64 {
+	return t.recorded.Load()
+}
+
+// Reset forgets every deduplicated exposure, e.g. when an experiment restarts.
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	t.seen = map[dedupKey]time.Time{}
+	t.mu.Unlock()
+}
+
+// first reports whether exposure is new for its dedup key and marks it seen.
+func (t *Tracker) first(exposure Exposure) bool {
+	key := dedupKey{subject: exposure.SubjectID, tenant: exposure.TenantID, key: exposure.Key, value: exposure.Value}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if at, ok := t.seen[key]; ok && (t.window == 0 || exposure.Time.Sub(at) < t.window) {
+		return false
+	}
+	if len(t.seen) >= t.capacity {
+		t.evict(exposure.Time)
+	}
+	t.seen[key] = exposure.Time
+	return true
+}
+
+// forget unmarks exposure so a failed write is retried on the next resolution.
+func (t *Tracker) forget(exposure Exposure) {
+	t.mu.Lock()
+	delete(t.seen, dedupKey{subject: exposure.SubjectID, tenant: exposure.TenantID, key: exposure.Key, value: exposure.Value})
+	t.mu.Unlock()
+}
+
+// evict drops expired entries, or everything when none have expired; callers hold t.mu.
+func (t *Tracker) evict(now time.Time) {
+	if t.window > 0 {
+		for key, at := range t.seen {
+			if now.Sub(at) >= t.window {
+				delete(t.seen, key)
+			}
+		}
+		if len(t.seen) < t.capacity {
+			return
+		}
+	}
+	t.seen = map[dedupKey]time.Time{}
+}
+
+var (
+	_ gate.ResolveHook = (*Tracker)(nil)
+	_ Sink             = (*MemorySink)(nil)
+)
@@ -0,0 +1,106 @@
+package exposure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestTrackerDedupesPerSubjectKeyAndValue(t *testing.T) {
+	ctx := context.Background()
+	manual := clock.NewManual(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	sink := &MemorySink{}
+	tracker := NewTracker(sink, WithClock(manual), WithDedupWindow(time.Hour))
+	overrides := store.NewMemoryStore()
+	fg := resolver.New(resolver.WithOverrideStore(overrides), resolver.WithResolveHook(tracker))
+
+	alice := scope.WithUserID(scope.WithTenantID(ctx, "acme"), "alice")
+	bob := scope.WithUserID(scope.WithTenantID(ctx, "acme"), "bob")
+	for i := 0; i < 3; i++ {
+		_, _ = fg.Enabled(alice, "checkout.v2")
+	}
+	_, _ = fg.Enabled(bob, "checkout.v2")
+	_, _ = fg.Enabled(ctx, "checkout.v2")
+
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "alice", TenantID: "acme"}
+	if err := overrides.Set(ctx, "checkout.v2", user, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	_, _ = fg.Enabled(alice, "checkout.v2")
+
+	got := sink.Exposures()
+	if len(got) != 3 {
+		t.Fatalf("expected alice off, bob off, alice on; got %+v", got)
+	}
+	if got[0].SubjectID != "alice" || got[0].TenantID != "acme" || got[0].Value || got[2].SubjectID != "alice" || !got[2].Value || got[2].Source != gate.ResolveSourceOverride {
+		t.Fatalf("unexpected exposures: %+v", got)
+	}
+
+	manual.Advance(time.Hour)
+	_, _ = fg.Enabled(alice, "checkout.v2")
+	if len(sink.Exposures()) != 4 || tracker.Recorded() != 4 {
+		t.Fatalf("expected the dedup window to re-record alice, got %d", len(sink.Exposures()))
+	}
+}
+
+func TestTrackerRetriesFailedWrites(t *testing.T) {
+	fail := true
+	var reported error
+	var recorded []Exposure
+	tracker := NewTracker(SinkFunc(func(_ context.Context, exposures []Exposure) error {
+		if fail {
+			return errors.New("warehouse down")
+		}
+		recorded = append(recorded, exposures...)
+		return nil
+	}), WithOnError(func(err error) { reported = err }), WithAnonymous(true))
+
+	event := gate.ResolveEvent{NormalizedKey: "search", Value: true}
+	tracker.OnResolve(context.Background(), event)
+	if reported == nil {
+		t.Fatalf("expected sink error to be reported")
+	}
+	fail = false
+	tracker.OnResolve(context.Background(), event)
+	tracker.OnResolve(context.Background(), event)
+	if len(recorded) != 1 || recorded[0].SubjectID != "" {
+		t.Fatalf("expected one anonymous exposure after the retry, got %+v", recorded)
+	}
+}
+
+func TestBufferBatchesWrites(t *testing.T) {
+	batches := make(chan []Exposure, 10)
+	buffer := NewBuffer(SinkFunc(func(_ context.Context, exposures []Exposure) error {
+		batches <- exposures
+		return nil
+	}), WithBatchSize(2), WithFlushInterval(time.Hour), WithMaxPending(4))
+
+	for _, subject := range []string{"a", "b", "c"} {
+		_ = buffer.Record(context.Background(), []Exposure{{SubjectID: subject, Key: "k"}})
+	}
+	select {
+	case batch := <-batches:
+		if len(batch) != 2 || batch[0].SubjectID != "a" {
+			t.Fatalf("unexpected first batch: %+v", batch)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected a full batch to flush without waiting for the interval")
+	}
+	if err := buffer.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if batch := <-batches; len(batch) != 1 || batch[0].SubjectID != "c" {
+		t.Fatalf("expected close to flush the rest, got %+v", batch)
+	}
+	_ = buffer.Record(context.Background(), []Exposure{{SubjectID: "d"}})
+	if buffer.Dropped() != 1 {
+		t.Fatalf("expected records after close to be dropped, got %d", buffer.Dropped())
+	}
+}
@@ -16,6 +16,12 @@ const (
 	MetaOperation            = "operation"
 	MetaStrict               = "strict"
 	MetaPath                 = "path"
+	MetaFeatureKeys          = "feature_keys"
+	MetaActivatesAt          = "activates_at"
+	MetaStrategy             = "strategy"
+	MetaGroup                = "group"
+	MetaAttempts             = "attempts"
+	MetaStoreErrors          = "store_errors"
 )
 
 const (
@@ -38,6 +44,13 @@ const (
 	TextCodeStoreWriteFailed         = "STORE_WRITE_FAILED"
 	TextCodeDefaultLookupFailed      = "DEFAULT_LOOKUP_FAILED"
 	TextCodeScopeResolveFailed       = "SCOPE_RESOLVE_FAILED"
+	TextCodeScheduleLookupFailed     = "SCHEDULE_LOOKUP_FAILED"
+	TextCodeFeatureDisabled          = "FEATURE_DISABLED"
+	TextCodeStrategyInvalid          = "STRATEGY_INVALID"
+	TextCodeStrategyUnknown          = "STRATEGY_UNKNOWN"
+	TextCodeKeyInvalid               = "FEATURE_KEY_INVALID"
+	TextCodeCatalogRequired          = "CATALOG_REQUIRED"
+	TextCodeGroupUnknown             = "FEATURE_GROUP_UNKNOWN"
 )
 
 var (
@@ -0,0 +1,88 @@
+package ferrors
+
+import (
+	"errors"
+
+	goerrors "github.com/goliatone/go-errors"
+)
+
+// ScopeInfo describes the scope recorded under MetaScope.
+type ScopeInfo struct {
+	Kind     string
+	ID       string
+	TenantID string
+	OrgID    string
+}
+
+// ScopeDescriber is implemented by scope values recorded under MetaScope, such as
+// gate.ScopeRef.
+type ScopeDescriber interface {
+	DescribeScope() ScopeInfo
+}
+
+// Meta returns the metadata value recorded under key by err or the errors it wraps,
+// outermost first, when it has type T.
+func Meta[T any](err error, key string) (T, bool) {
+	var zero T
+	for err != nil {
+		if rich, ok := err.(*goerrors.Error); ok {
+			if raw, ok := rich.Metadata[key]; ok {
+				value, ok := raw.(T)
+				return value, ok
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	return zero, false
+}
+
+// FeatureKey returns the feature key as the caller passed it.
+func FeatureKey(err error) (string, bool) {
+	return Meta[string](err, MetaFeatureKey)
+}
+
+// NormalizedFeatureKey returns the normalized feature key.
+func NormalizedFeatureKey(err error) (string, bool) {
+	return Meta[string](err, MetaFeatureKeyNormalized)
+}
+
+// Operation returns the operation that failed, such as "set" or "get_all".
+func Operation(err error) (string, bool) {
+	return Meta[string](err, MetaOperation)
+}
+
+// Store returns the store that failed, such as "override".
+func Store(err error) (string, bool) {
+	return Meta[string](err, MetaStore)
+}
+
+// Adapter returns the adapter that raised err.
+func Adapter(err error) (string, bool) {
+	return Meta[string](err, MetaAdapter)
+}
+
+// Strict reports whether err was raised in strict mode. ok is false when the error
+// site does not record it.
+func Strict(err error) (strict bool, ok bool) {
+	return Meta[bool](err, MetaStrict)
+}
+
+// Attempts returns the number of tries a retrying store made before giving up.
+func Attempts(err error) (int, bool) {
+	return Meta[int](err, MetaAttempts)
+}
+
+// StoreErrors returns the per-store failures a fallback store recorded, in store
+// order.
+func StoreErrors(err error) ([]string, bool) {
+	return Meta[[]string](err, MetaStoreErrors)
+}
+
+// Scope returns the scope recorded by err.
+func Scope(err error) (ScopeInfo, bool) {
+	describer, ok := Meta[ScopeDescriber](err, MetaScope)
+	if !ok || describer == nil {
+		return ScopeInfo{}, false
+	}
+	return describer.DescribeScope(), true
+}
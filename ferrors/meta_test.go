@@ -0,0 +1,53 @@
+package ferrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type testScope string
+
+func (s testScope) DescribeScope() ScopeInfo {
+	return ScopeInfo{Kind: "tenant", ID: string(s)}
+}
+
+func TestTypedMetadataAccessors(t *testing.T) {
+	inner := WrapExternal(errors.New("down"), TextCodeStoreWriteFailed, "override store set failed", map[string]any{
+		MetaFeatureKey:           " Billing.V2 ",
+		MetaFeatureKeyNormalized: "billing.v2",
+		MetaScope:                testScope("acme"),
+		MetaStore:                "override",
+		MetaOperation:            "set",
+		MetaStrict:               true,
+	})
+	err := fmt.Errorf("admin: %w", inner)
+
+	if key, ok := FeatureKey(err); !ok || key != " Billing.V2 " {
+		t.Fatalf("unexpected feature key %q %v", key, ok)
+	}
+	if key, ok := NormalizedFeatureKey(err); !ok || key != "billing.v2" {
+		t.Fatalf("unexpected normalized key %q %v", key, ok)
+	}
+	if op, ok := Operation(err); !ok || op != "set" {
+		t.Fatalf("unexpected operation %q %v", op, ok)
+	}
+	if store, ok := Store(err); !ok || store != "override" {
+		t.Fatalf("unexpected store %q %v", store, ok)
+	}
+	if strict, ok := Strict(err); !ok || !strict {
+		t.Fatalf("unexpected strict %v %v", strict, ok)
+	}
+	if scope, ok := Scope(err); !ok || scope != (ScopeInfo{Kind: "tenant", ID: "acme"}) {
+		t.Fatalf("unexpected scope %+v %v", scope, ok)
+	}
+	if _, ok := Adapter(err); ok {
+		t.Fatalf("expected no adapter metadata")
+	}
+	if _, ok := Meta[int](err, MetaOperation); ok {
+		t.Fatalf("expected a type mismatch to report false")
+	}
+	if _, ok := FeatureKey(errors.New("plain")); ok {
+		t.Fatalf("expected plain errors to have no metadata")
+	}
+}
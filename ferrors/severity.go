@@ -0,0 +1,127 @@
+package ferrors
+
+import (
+	"context"
+
+	goerrors "github.com/goliatone/go-errors"
+)
+
+// Severity is the go-errors severity carried by rich errors.
+type Severity = goerrors.Severity
+
+const (
+	SeverityDebug    = goerrors.SeverityDebug
+	SeverityInfo     = goerrors.SeverityInfo
+	SeverityWarning  = goerrors.SeverityWarning
+	SeverityError    = goerrors.SeverityError
+	SeverityCritical = goerrors.SeverityCritical
+)
+
+// SeverityRule sets the severity of errors with a text code.
+type SeverityRule struct {
+	TextCode string
+	// NonStrict limits the rule to errors raised outside strict mode, whose MetaStrict
+	// metadata is false: failures the resolver tolerated by serving a fallback.
+	NonStrict bool
+	Severity  Severity
+}
+
+// Downgrade returns a rule setting errors with textCode to severity.
+func Downgrade(textCode string, severity Severity) SeverityRule {
+	return SeverityRule{TextCode: textCode, Severity: severity}
+}
+
+// DowngradeNonStrict returns a rule setting errors with textCode to severity when
+// they were raised outside strict mode.
+func DowngradeNonStrict(textCode string, severity Severity) SeverityRule {
+	return SeverityRule{TextCode: textCode, NonStrict: true, Severity: severity}
+}
+
+// SeverityPolicy overrides the severity of errors by text code, so operators can
+// stop alerting on failures the resolver deliberately tolerates. The first matching
+// rule wins.
+type SeverityPolicy struct {
+	rules []SeverityRule
+}
+
+// NewSeverityPolicy returns a policy applying rules in order.
+func NewSeverityPolicy(rules ...SeverityRule) *SeverityPolicy {
+	return &SeverityPolicy{rules: append([]SeverityRule(nil), rules...)}
+}
+
+// Severity returns the severity of err under the policy.
+func (p *SeverityPolicy) Severity(err error) Severity {
+	if rule, ok := p.match(err); ok {
+		return rule.Severity
+	}
+	return SeverityOf(err)
+}
+
+// Apply returns err with its severity set by the policy. Errors no rule matches, and
+// rich errors wrapped in other errors, are returned unchanged; matching errors are
+// cloned, never modified.
+func (p *SeverityPolicy) Apply(err error) error {
+	rule, ok := p.match(err)
+	if !ok {
+		return err
+	}
+	rich, ok := err.(*goerrors.Error)
+	if !ok || rich.Severity == rule.Severity {
+		return err
+	}
+	return rich.Clone().WithSeverity(rule.Severity)
+}
+
+func (p *SeverityPolicy) match(err error) (SeverityRule, bool) {
+	if p == nil || err == nil {
+		return SeverityRule{}, false
+	}
+	rich, ok := As(err)
+	if !ok {
+		return SeverityRule{}, false
+	}
+	for _, rule := range p.rules {
+		if rule.TextCode != rich.TextCode {
+			continue
+		}
+		if rule.NonStrict {
+			if strict, ok := rich.Metadata[MetaStrict].(bool); !ok || strict {
+				continue
+			}
+		}
+		return rule, true
+	}
+	return SeverityRule{}, false
+}
+
+// SeverityOf returns the severity err carries: the rich error's severity, or
+// SeverityError for other errors. A nil error reports SeverityDebug.
+func SeverityOf(err error) Severity {
+	if err == nil {
+		return SeverityDebug
+	}
+	if rich, ok := As(err); ok {
+		return rich.Severity
+	}
+	return SeverityError
+}
+
+type severityPolicyKey struct{}
+
+// WithSeverityPolicy attaches policy to ctx. Gates apply it to the errors they hand
+// to resolve hooks, ahead of the policy set with resolver.WithSeverityPolicy.
+func WithSeverityPolicy(ctx context.Context, policy *SeverityPolicy) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, severityPolicyKey{}, policy)
+}
+
+// SeverityPolicyFromContext returns the policy attached to ctx, if any.
+func SeverityPolicyFromContext(ctx context.Context) *SeverityPolicy {
+	if ctx == nil {
+		return nil
+	}
+	policy, _ := ctx.Value(severityPolicyKey{}).(*SeverityPolicy)
+	return policy
+}
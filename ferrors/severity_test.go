@@ -0,0 +1,36 @@
+package ferrors
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSeverityPolicyDowngradesNonStrictErrors(t *testing.T) {
+	policy := NewSeverityPolicy(DowngradeNonStrict(TextCodeStoreReadFailed, SeverityWarning))
+	tolerated := WrapExternal(errors.New("down"), TextCodeStoreReadFailed, "override store read failed", map[string]any{MetaStrict: false})
+	strict := WrapExternal(errors.New("down"), TextCodeStoreReadFailed, "override store read failed", map[string]any{MetaStrict: true})
+	other := WrapExternal(errors.New("down"), TextCodeDefaultLookupFailed, "default lookup failed", map[string]any{MetaStrict: false})
+
+	applied := policy.Apply(tolerated)
+	if SeverityOf(applied) != SeverityWarning || policy.Severity(tolerated) != SeverityWarning {
+		t.Fatalf("expected the tolerated error downgraded, got %s", SeverityOf(applied))
+	}
+	if SeverityOf(tolerated) != SeverityError {
+		t.Fatalf("expected Apply to leave the original error untouched")
+	}
+	if !errors.Is(applied, tolerated.Source) {
+		t.Fatalf("expected the downgraded error to keep its cause")
+	}
+	if policy.Apply(strict) != error(strict) || policy.Apply(other) != error(other) {
+		t.Fatalf("expected strict and unrelated errors unchanged")
+	}
+	if SeverityOf(errors.New("plain")) != SeverityError || SeverityOf(nil) != SeverityDebug {
+		t.Fatalf("unexpected severity for plain or nil errors")
+	}
+
+	ctx := WithSeverityPolicy(context.Background(), policy)
+	if SeverityPolicyFromContext(ctx) != policy || SeverityPolicyFromContext(context.Background()) != nil {
+		t.Fatalf("expected the policy to round-trip through the context")
+	}
+}
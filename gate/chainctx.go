@@ -0,0 +1,34 @@
+package gate
+
+import "context"
+
+type resolvedChainKey struct{}
+
+// WithResolvedChain attaches the scope chain a resolution is using to ctx. Gates set
+// it for the defaults stage, so providers can read the tenant or user being
+// resolved even when it came from WithClaims rather than the request context.
+func WithResolvedChain(ctx context.Context, chain ScopeChain) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, resolvedChainKey{}, chain)
+}
+
+// ResolvedChain returns the chain attached with WithResolvedChain.
+func ResolvedChain(ctx context.Context) (ScopeChain, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	chain, ok := ctx.Value(resolvedChainKey{}).(ScopeChain)
+	return chain, ok
+}
+
+// TenantID returns the tenant of the first tenant-bound scope in the chain.
+func (c ScopeChain) TenantID() string {
+	for _, ref := range c {
+		if ref.TenantID != "" {
+			return ref.TenantID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultChangeCapacity bounds the (key, chain) pairs a ChangeDetector remembers.
+const DefaultChangeCapacity = 10000
+
+// ValueChangeEvent reports that the effective value of a key changed for a scope
+// chain since the detector last saw it resolve.
+type ValueChangeEvent struct {
+	Key            string
+	NormalizedKey  string
+	Chain          ScopeChain
+	Previous       bool
+	Value          bool
+	PreviousSource ResolveSource
+	Source         ResolveSource
+	Trace          ResolveTrace
+	Metadata       map[string]string
+}
+
+// String renders the change for logs, e.g. "checkout flipped from false to true for tenant:acme".
+func (e ValueChangeEvent) String() string {
+	out := fmt.Sprintf("%s flipped from %t to %t", e.NormalizedKey, e.Previous, e.Value)
+	if len(e.Chain) > 0 {
+		out += " for " + scopeLabel(e.Chain[0])
+	}
+	return out
+}
+
+// ValueChangeHook receives value change events.
+type ValueChangeHook interface {
+	OnValueChange(ctx context.Context, event ValueChangeEvent)
+}
+
+// ValueChangeHookFunc wraps a function as a ValueChangeHook.
+type ValueChangeHookFunc func(context.Context, ValueChangeEvent)
+
+// OnValueChange implements ValueChangeHook.
+func (fn ValueChangeHookFunc) OnValueChange(ctx context.Context, event ValueChangeEvent) {
+	if fn == nil {
+		return
+	}
+	fn(ctx, event)
+}
+
+// ChangeDetectorOption customizes a ChangeDetector.
+type ChangeDetectorOption func(*ChangeDetector)
+
+// WithChangeCapacity bounds the remembered (key, chain) pairs. When full the
+// detector forgets everything, so the next resolution of each pair is a baseline
+// rather than a change.
+func WithChangeCapacity(capacity int) ChangeDetectorOption {
+	return func(d *ChangeDetector) {
+		if d == nil {
+			return
+		}
+		d.capacity = capacity
+	}
+}
+
+// ChangeDetector is a ResolveHook that remembers the last value served per key and
+// scope chain and calls its ValueChangeHook when a later resolution differs. The
+// first resolution of a pair is a baseline, and failed resolutions are ignored.
+type ChangeDetector struct {
+	hook     ValueChangeHook
+	capacity int
+
+	mu   sync.Mutex
+	last map[string]lastValue
+}
+
+type lastValue struct {
+	value  bool
+	source ResolveSource
+}
+
+// NewChangeDetector constructs a detector reporting to hook.
+func NewChangeDetector(hook ValueChangeHook, opts ...ChangeDetectorOption) *ChangeDetector {
+	d := &ChangeDetector{hook: hook, capacity: DefaultChangeCapacity, last: map[string]lastValue{}}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	if d.capacity < 1 {
+		d.capacity = DefaultChangeCapacity
+	}
+	return d
+}
+
+// OnResolve implements ResolveHook.
+func (d *ChangeDetector) OnResolve(ctx context.Context, event ResolveEvent) {
+	if d == nil || d.hook == nil || event.Error != nil || event.NormalizedKey == "" {
+		return
+	}
+	id := memoKey(event.NormalizedKey, event.Chain)
+	d.mu.Lock()
+	previous, seen := d.last[id]
+	if !seen && len(d.last) >= d.capacity {
+		d.last = map[string]lastValue{}
+	}
+	d.last[id] = lastValue{value: event.Value, source: event.Source}
+	d.mu.Unlock()
+	if !seen || previous.value == event.Value {
+		return
+	}
+	d.hook.OnValueChange(ctx, ValueChangeEvent{
+		Key:            event.Key,
+		NormalizedKey:  event.NormalizedKey,
+		Chain:          event.Chain,
+		Previous:       previous.value,
+		Value:          event.Value,
+		PreviousSource: previous.source,
+		Source:         event.Source,
+		Trace:          event.Trace,
+		Metadata:       event.Metadata,
+	})
+}
+
+// Forget drops every remembered value, e.g. after a bulk reload that should not be
+// reported key by key.
+func (d *ChangeDetector) Forget() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.last = map[string]lastValue{}
+	d.mu.Unlock()
+}
+
+var _ ResolveHook = (*ChangeDetector)(nil)
@@ -0,0 +1,45 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestChangeDetectorReportsFlipsPerChain(t *testing.T) {
+	var changes []ValueChangeEvent
+	detector := NewChangeDetector(ValueChangeHookFunc(func(_ context.Context, event ValueChangeEvent) {
+		changes = append(changes, event)
+	}))
+	acme := ScopeChain{{Kind: ScopeTenant, ID: "acme", TenantID: "acme"}, {Kind: ScopeSystem}}
+	globex := ScopeChain{{Kind: ScopeTenant, ID: "globex", TenantID: "globex"}, {Kind: ScopeSystem}}
+	resolve := func(chain ScopeChain, value bool, source ResolveSource, err error) {
+		detector.OnResolve(context.Background(), ResolveEvent{NormalizedKey: "checkout", Chain: chain, Value: value, Source: source, Error: err})
+	}
+
+	resolve(acme, false, ResolveSourceDefault, nil)
+	resolve(globex, true, ResolveSourceOverride, nil)
+	resolve(acme, false, ResolveSourceDefault, nil)
+	resolve(acme, true, ResolveSourceFallback, errors.New("store down"))
+	if len(changes) != 0 {
+		t.Fatalf("expected baselines and errors not to report, got %+v", changes)
+	}
+
+	resolve(acme, true, ResolveSourceOverride, nil)
+	if len(changes) != 1 {
+		t.Fatalf("expected one change, got %+v", changes)
+	}
+	change := changes[0]
+	if change.Previous || !change.Value || change.PreviousSource != ResolveSourceDefault || change.Source != ResolveSourceOverride {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+	if got := change.String(); got != "checkout flipped from false to true for tenant:acme" {
+		t.Fatalf("unexpected description: %q", got)
+	}
+
+	detector.Forget()
+	resolve(acme, false, ResolveSourceDefault, nil)
+	if len(changes) != 1 {
+		t.Fatalf("expected Forget to reset baselines")
+	}
+}
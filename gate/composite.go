@@ -0,0 +1,277 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+// CompositePolicy decides how a CompositeGate combines component results.
+type CompositePolicy string
+
+const (
+	// CompositeFirstMatch returns the first component that resolves without error and,
+	// for traceable gates, from a source other than fallback.
+	CompositeFirstMatch CompositePolicy = "first_match"
+	// CompositeAnyTrue enables the feature when any component does.
+	CompositeAnyTrue CompositePolicy = "any_true"
+	// CompositeAllTrue enables the feature only when every component does.
+	CompositeAllTrue CompositePolicy = "all_true"
+)
+
+// ComponentTrace records one component's result inside a composite trace.
+// Selected marks the component that produced the composite value.
+type ComponentTrace struct {
+	Index    int
+	Name     string
+	Value    bool
+	Trace    ResolveTrace
+	Error    error
+	Selected bool
+}
+
+// ComposePolicy controls how Compose layers a primary gate over a secondary one.
+type ComposePolicy struct {
+	// Combine applies when both gates are consulted. Defaults to CompositeFirstMatch.
+	Combine CompositePolicy
+	// Keys limits the primary gate to these keys (exact or "prefix.*"). For a matching
+	// key the primary answers whenever it resolves without error; other keys go straight
+	// to the secondary. Empty consults both gates for every key using Combine.
+	Keys []string
+}
+
+// PriorityKeys returns a ComposePolicy where the primary gate wins for keys.
+func PriorityKeys(keys ...string) ComposePolicy {
+	return ComposePolicy{Combine: CompositeFirstMatch, Keys: keys}
+}
+
+// Union returns a ComposePolicy that enables a feature when either gate does.
+func Union() ComposePolicy {
+	return ComposePolicy{Combine: CompositeAnyTrue}
+}
+
+// CompositeGate queries several gates (for example a local config gate and a remote
+// gate during a backend migration) and combines their answers by policy.
+type CompositeGate struct {
+	policy CompositePolicy
+	gates  []FeatureGate
+	names  []string
+	keys   *keyPatterns
+}
+
+// Composite builds a CompositeGate. Nil gates are ignored; an unknown policy
+// behaves as CompositeFirstMatch.
+func Composite(policy CompositePolicy, gates ...FeatureGate) *CompositeGate {
+	c := &CompositeGate{policy: policy}
+	for _, g := range gates {
+		if g != nil {
+			c.gates = append(c.gates, g)
+		}
+	}
+	switch policy {
+	case CompositeFirstMatch, CompositeAnyTrue, CompositeAllTrue:
+	default:
+		c.policy = CompositeFirstMatch
+	}
+	return c
+}
+
+// Compose layers primary over secondary, for example a local emergency gate backed by a
+// file or environment over the shared remote gate. Components are named "primary" and
+// "secondary" in traces.
+func Compose(primary, secondary FeatureGate, policy ComposePolicy) *CompositeGate {
+	c := Composite(policy.Combine)
+	for i, g := range []FeatureGate{primary, secondary} {
+		if g == nil {
+			continue
+		}
+		c.gates = append(c.gates, g)
+		c.names = append(c.names, composeNames[i])
+	}
+	if len(policy.Keys) > 0 {
+		c.keys = newKeyPatterns(policy.Keys)
+	}
+	return c
+}
+
+var composeNames = [...]string{"primary", "secondary"}
+
+// Enabled implements FeatureGate.
+func (c *CompositeGate) Enabled(ctx context.Context, key string, opts ...ResolveOption) (bool, error) {
+	value, _, err := c.ResolveWithTrace(ctx, key, opts...)
+	return value, err
+}
+
+// ResolveWithTrace implements TraceableFeatureGate. The returned trace takes its
+// provenance from the deciding component and lists every evaluated component.
+func (c *CompositeGate) ResolveWithTrace(ctx context.Context, key string, opts ...ResolveOption) (bool, ResolveTrace, error) {
+	trace := ResolveTrace{
+		Key:           strings.TrimSpace(key),
+		NormalizedKey: NormalizeKey(key),
+		Source:        ResolveSourceFallback,
+	}
+	if c == nil || len(c.gates) == 0 {
+		return false, trace, errGateRequired(key)
+	}
+	trace.Strategy = "composite:" + string(c.policy)
+	if c.keys != nil {
+		return c.resolvePriority(ctx, key, opts, trace)
+	}
+
+	var errs []error
+	decided := -1
+	for i := range c.gates {
+		component := c.resolveComponent(ctx, i, key, opts)
+		trace.Components = append(trace.Components, component)
+		if component.Error != nil {
+			errs = append(errs, component.Error)
+			if c.policy == CompositeAllTrue {
+				decided = i
+				break
+			}
+			continue
+		}
+		switch c.policy {
+		case CompositeFirstMatch:
+			if component.Trace.Source == ResolveSourceFallback {
+				continue
+			}
+		case CompositeAnyTrue:
+			if !component.Value {
+				continue
+			}
+		case CompositeAllTrue:
+			if component.Value {
+				continue
+			}
+		}
+		decided = i
+		break
+	}
+
+	if decided < 0 && c.policy == CompositeAllTrue && len(errs) == 0 {
+		decided = len(trace.Components) - 1
+	}
+	if decided < 0 {
+		if len(errs) == len(trace.Components) {
+			return false, trace, errors.Join(errs...)
+		}
+		// No component matched: fall back to the last successful answer.
+		for i := len(trace.Components) - 1; i >= 0; i-- {
+			if trace.Components[i].Error == nil {
+				decided = i
+				break
+			}
+		}
+	}
+	return c.finish(trace, decided)
+}
+
+// resolvePriority consults the primary only for matching keys and lets it win whenever
+// it resolves; the secondary answers everything else.
+func (c *CompositeGate) resolvePriority(ctx context.Context, key string, opts []ResolveOption, trace ResolveTrace) (bool, ResolveTrace, error) {
+	trace.Strategy = "composite:priority"
+	var errs []error
+	for i := range c.gates {
+		if c.names[i] == composeNames[0] && !c.keys.match(trace.NormalizedKey) {
+			continue
+		}
+		component := c.resolveComponent(ctx, i, key, opts)
+		trace.Components = append(trace.Components, component)
+		if component.Error == nil {
+			return c.finish(trace, len(trace.Components)-1)
+		}
+		errs = append(errs, component.Error)
+	}
+	if len(errs) > 0 {
+		return false, trace, errors.Join(errs...)
+	}
+	return false, trace, nil
+}
+
+// finish merges the deciding component's trace into the composite trace.
+func (c *CompositeGate) finish(trace ResolveTrace, decided int) (bool, ResolveTrace, error) {
+	trace.Components[decided].Selected = true
+	winner := trace.Components[decided]
+	if winner.Error != nil {
+		return false, trace, winner.Error
+	}
+	merged := winner.Trace
+	merged.Key, merged.NormalizedKey = trace.Key, trace.NormalizedKey
+	merged.Value = winner.Value
+	merged.Strategy = trace.Strategy
+	merged.Components = trace.Components
+	if merged.Source == "" {
+		merged.Source = trace.Source
+	}
+	return winner.Value, merged, nil
+}
+
+func errGateRequired(key string) error {
+	return ferrors.WrapSentinel(ferrors.ErrGateRequired, "gate: feature gate is required", map[string]any{
+		ferrors.MetaFeatureKey: strings.TrimSpace(key),
+	})
+}
+
+func (c *CompositeGate) resolveComponent(ctx context.Context, index int, key string, opts []ResolveOption) ComponentTrace {
+	component := resolveComponent(ctx, c.gates[index], index, key, opts)
+	if index < len(c.names) {
+		component.Name = c.names[index]
+	}
+	return component
+}
+
+// keyPatterns matches normalized keys exactly or by "prefix.*".
+type keyPatterns struct {
+	exact    map[string]bool
+	prefixes []string
+}
+
+func newKeyPatterns(patterns []string) *keyPatterns {
+	k := &keyPatterns{exact: map[string]bool{}}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			k.prefixes = append(k.prefixes, prefix)
+			continue
+		}
+		if key := NormalizeKey(pattern); key != "" {
+			k.exact[key] = true
+		}
+	}
+	return k
+}
+
+func (k *keyPatterns) match(key string) bool {
+	if k.exact[key] {
+		return true
+	}
+	for _, prefix := range k.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveComponent resolves one component with the caller's context. The request
+// memo is shared, but its entries are keyed by the gate that records them, so
+// components that disagree keep their own answers for the rest of the request.
+func resolveComponent(ctx context.Context, g FeatureGate, index int, key string, opts []ResolveOption) ComponentTrace {
+	component := ComponentTrace{Index: index}
+	if traceable, ok := g.(TraceableFeatureGate); ok {
+		component.Value, component.Trace, component.Error = traceable.ResolveWithTrace(ctx, key, opts...)
+		return component
+	}
+	component.Value, component.Error = g.Enabled(ctx, key, opts...)
+	component.Trace = ResolveTrace{
+		Key:           strings.TrimSpace(key),
+		NormalizedKey: NormalizeKey(key),
+		Value:         component.Value,
+	}
+	return component
+}
+
+var _ TraceableFeatureGate = (*CompositeGate)(nil)
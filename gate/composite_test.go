@@ -0,0 +1,92 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type plainGate struct {
+	value bool
+	err   error
+}
+
+func (g plainGate) Enabled(context.Context, string, ...ResolveOption) (bool, error) {
+	return g.value, g.err
+}
+
+type tracedGate struct {
+	value  bool
+	source ResolveSource
+}
+
+func (g tracedGate) Enabled(ctx context.Context, key string, opts ...ResolveOption) (bool, error) {
+	value, _, err := g.ResolveWithTrace(ctx, key, opts...)
+	return value, err
+}
+
+func (g tracedGate) ResolveWithTrace(_ context.Context, key string, _ ...ResolveOption) (bool, ResolveTrace, error) {
+	return g.value, ResolveTrace{Key: key, NormalizedKey: key, Value: g.value, Source: g.source}, nil
+}
+
+func TestCompositePolicies(t *testing.T) {
+	ctx := context.Background()
+	down := plainGate{err: errors.New("remote down")}
+	local := tracedGate{value: false, source: ResolveSourceFallback}
+	remote := tracedGate{value: true, source: ResolveSourceOverride}
+
+	value, trace, err := Composite(CompositeFirstMatch, down, local, remote).ResolveWithTrace(ctx, "checkout")
+	if err != nil || !value || trace.Source != ResolveSourceOverride || len(trace.Components) != 3 {
+		t.Fatalf("expected first matching component to win, got %v %v (%+v)", value, err, trace)
+	}
+	if trace.Components[0].Error == nil || trace.Strategy != "composite:first_match" {
+		t.Fatalf("expected component error and strategy in trace, got %+v", trace)
+	}
+
+	if value, err := Composite(CompositeAnyTrue, local, down, remote).Enabled(ctx, "checkout"); err != nil || !value {
+		t.Fatalf("expected any_true to enable, got %v (%v)", value, err)
+	}
+	if value, err := Composite(CompositeAllTrue, remote, local).Enabled(ctx, "checkout"); err != nil || value {
+		t.Fatalf("expected all_true to disable, got %v (%v)", value, err)
+	}
+	if value, err := Composite(CompositeAllTrue, remote, down).Enabled(ctx, "checkout"); err == nil || value {
+		t.Fatalf("expected all_true to fail closed on error, got %v (%v)", value, err)
+	}
+	if value, err := Composite(CompositeAllTrue, remote, plainGate{value: true}).Enabled(ctx, "checkout"); err != nil || !value {
+		t.Fatalf("expected all_true to enable, got %v (%v)", value, err)
+	}
+
+	value, trace, err = Composite(CompositeFirstMatch, down, local).ResolveWithTrace(ctx, "checkout")
+	if err != nil || value || trace.Source != ResolveSourceFallback {
+		t.Fatalf("expected last successful answer without a match, got %v %v (%+v)", value, err, trace)
+	}
+	if _, err := Composite(CompositeAnyTrue, down, down).Enabled(ctx, "checkout"); err == nil {
+		t.Fatalf("expected error when every component fails")
+	}
+}
+
+func TestComposePriorityKeysPreferLocalGate(t *testing.T) {
+	ctx := context.Background()
+	local := tracedGate{value: false, source: ResolveSourceFallback}
+	remote := tracedGate{value: true, source: ResolveSourceOverride}
+	fg := Compose(local, remote, PriorityKeys("billing.*", "users.signup"))
+
+	value, trace, err := fg.ResolveWithTrace(ctx, "billing.v2")
+	if err != nil || value || len(trace.Components) != 1 {
+		t.Fatalf("expected local gate to win for billing.*, got %v %v (%+v)", value, err, trace)
+	}
+	if !trace.Components[0].Selected || trace.Components[0].Name != "primary" || trace.Strategy != "composite:priority" {
+		t.Fatalf("expected primary selected in trace, got %+v", trace)
+	}
+
+	value, trace, err = fg.ResolveWithTrace(ctx, "dashboard")
+	if err != nil || !value || len(trace.Components) != 1 || trace.Components[0].Name != "secondary" {
+		t.Fatalf("expected remote gate for unlisted key, got %v %v (%+v)", value, err, trace)
+	}
+
+	failing := Compose(plainGate{err: errors.New("file unreadable")}, remote, PriorityKeys("users.signup"))
+	value, trace, err = failing.ResolveWithTrace(ctx, "users.signup")
+	if err != nil || !value || !trace.Components[1].Selected {
+		t.Fatalf("expected fallthrough to remote when local fails, got %v %v (%+v)", value, err, trace)
+	}
+}
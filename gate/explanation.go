@@ -0,0 +1,212 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Explanation stages, in the order steps are recorded.
+const (
+	ExplainStageChain           = "chain"
+	ExplainStageHierarchy       = "hierarchy"
+	ExplainStageTimeout         = "timeout"
+	ExplainStageRequestOverride = "request_override"
+	ExplainStageMemo            = "memo"
+	ExplainStageCache           = "cache"
+	ExplainStageOverride        = "override"
+	ExplainStageStrategy        = "strategy"
+	ExplainStageDefault         = "default"
+	ExplainStageSchedule        = "schedule"
+	ExplainStageFallback        = "fallback"
+	ExplainStageResult          = "result"
+)
+
+// ExplanationStep is one step of a decision path.
+type ExplanationStep struct {
+	Stage  string `json:"stage"`
+	Detail string `json:"detail"`
+}
+
+// Explanation is a resolution rendered as the ordered steps that produced it, for
+// readers who do not know the trace layout.
+type Explanation struct {
+	Key    string            `json:"key"`
+	Value  bool              `json:"value"`
+	Source ResolveSource     `json:"source"`
+	Steps  []ExplanationStep `json:"steps"`
+	Trace  ResolveTrace      `json:"trace"`
+}
+
+// ExplainingFeatureGate is implemented by gates that explain their decisions.
+type ExplainingFeatureGate interface {
+	Explain(ctx context.Context, key string, opts ...ResolveOption) (Explanation, error)
+}
+
+// ExplainDecision explains key through fg, deriving the explanation from the trace
+// when fg does not implement ExplainingFeatureGate.
+func ExplainDecision(ctx context.Context, fg TraceableFeatureGate, key string, opts ...ResolveOption) (Explanation, error) {
+	if explainer, ok := fg.(ExplainingFeatureGate); ok {
+		return explainer.Explain(ctx, key, opts...)
+	}
+	_, trace, err := fg.ResolveWithTrace(ctx, key, opts...)
+	return NewExplanation(trace), err
+}
+
+// NewExplanation derives the decision path from a trace.
+func NewExplanation(t ResolveTrace) Explanation {
+	key := t.NormalizedKey
+	if key == "" {
+		key = t.Key
+	}
+	e := Explanation{Key: key, Value: t.Value, Source: t.Source, Trace: t}
+	if len(t.Chain) > 0 {
+		e.Add(ExplainStageChain, chainLabel(t.Chain))
+	} else {
+		e.Add(ExplainStageChain, "empty")
+	}
+	for _, inherited := range t.Inherited {
+		e.Add(ExplainStageHierarchy, scopeLabel(inherited.Scope)+" inherited through "+scopeLabel(inherited.From))
+	}
+	for _, stage := range t.TimedOut {
+		e.Add(ExplainStageTimeout, stage+" stage timed out")
+	}
+
+	switch {
+	case t.Source == ResolveSourceRequestOverride:
+		e.Add(ExplainStageRequestOverride, "set for this request → "+valueLabel(t.Value))
+		return e.result()
+	case t.Memoized:
+		e.Add(ExplainStageMemo, "resolved earlier in this request → "+valueLabel(t.Value))
+		return e.result()
+	case t.CacheHit:
+		e.Add(ExplainStageCache, "hit → "+valueLabel(t.Value))
+		return e.result()
+	}
+
+	e.explainOverride(t)
+	if t.Strategy != "" && t.Strategy != "default" {
+		e.Add(ExplainStageStrategy, t.Strategy)
+	}
+	if t.Rule.Expression != "" {
+		rule := t.Rule.Expression + " => " + t.Rule.Result
+		if t.Rule.Error != nil {
+			rule = t.Rule.Expression + " failed: " + t.Rule.Error.Error()
+		}
+		e.Add(ExplainStageStrategy, "rule "+rule)
+	}
+	if t.Source == ResolveSourceOverride {
+		return e.result()
+	}
+
+	switch {
+	case t.Default.Error != nil:
+		e.Add(ExplainStageDefault, "lookup failed: "+t.Default.Error.Error())
+	case t.Default.Set:
+		detail := "set → " + valueLabel(t.Default.Value)
+		if t.Default.Plan != "" {
+			detail += " (plan " + t.Default.Plan + ")"
+		}
+		e.Add(ExplainStageDefault, detail)
+	default:
+		e.Add(ExplainStageDefault, "not set")
+	}
+	if t.Schedule.Set {
+		switch {
+		case t.Schedule.Active:
+			e.Add(ExplainStageSchedule, "window active")
+		case t.Schedule.Pending:
+			e.Add(ExplainStageSchedule, "pending until "+t.Schedule.Schedule.StartsAt.UTC().Format(time.RFC3339)+" → disabled")
+		default:
+			e.Add(ExplainStageSchedule, "window closed → disabled")
+		}
+	} else if t.Schedule.Error != nil {
+		e.Add(ExplainStageSchedule, "lookup failed: "+t.Schedule.Error.Error())
+	}
+	switch t.Source {
+	case ResolveSourceFallback:
+		e.Add(ExplainStageFallback, "nothing set → "+valueLabel(t.Value))
+	case ResolveSourceCallerFallback:
+		e.Add(ExplainStageFallback, "caller fallback → "+valueLabel(t.Value))
+	}
+	return e.result()
+}
+
+// Add appends a step.
+func (e *Explanation) Add(stage, detail string) {
+	e.Steps = append(e.Steps, ExplanationStep{Stage: stage, Detail: detail})
+}
+
+// String renders the explanation as a numbered list of steps.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s = %s (source: %s)", e.Key, valueLabel(e.Value), e.Source)
+	for i, step := range e.Steps {
+		fmt.Fprintf(&b, "\n  %d. %s: %s", i+1, step.Stage, step.Detail)
+	}
+	return b.String()
+}
+
+func (e *Explanation) explainOverride(t ResolveTrace) {
+	if t.Override.Error != nil {
+		e.Add(ExplainStageOverride, "lookup failed: "+t.Override.Error.Error())
+		return
+	}
+	found := false
+	for _, lookup := range t.Override.Scopes {
+		switch lookup.Outcome {
+		case ScopeOutcomeOverridden:
+			found = true
+			e.Add(ExplainStageOverride, e.overrideFound(t, lookup.Scope))
+		case ScopeOutcomeShadowed:
+			e.Add(ExplainStageOverride, string(lookup.State)+" at "+scopeLabel(lookup.Scope)+" shadowed")
+		case ScopeOutcomeExpired:
+			e.Add(ExplainStageOverride, string(lookup.State)+" at "+scopeLabel(lookup.Scope)+" expired")
+		}
+	}
+	if !found && t.Source == ResolveSourceOverride {
+		e.Add(ExplainStageOverride, e.overrideFound(t, t.Override.Match))
+		return
+	}
+	if !found && len(t.Override.Scopes) == 0 {
+		e.Add(ExplainStageOverride, "none in the chain")
+	} else if !found {
+		e.Add(ExplainStageOverride, "none applied")
+	}
+}
+
+func (e *Explanation) overrideFound(t ResolveTrace, scope ScopeRef) string {
+	detail := "found at " + scopeLabel(scope) + " → " + valueLabel(t.Value)
+	if t.Alias != "" && t.Alias != t.Key {
+		detail += " (stored under " + t.Alias + ")"
+	}
+	if !t.Override.ExpiresAt.IsZero() {
+		detail += " until " + t.Override.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if t.Override.Metadata.Reason != "" {
+		detail += ", reason: " + strconv.Quote(t.Override.Metadata.Reason)
+	}
+	return detail
+}
+
+func (e Explanation) result() Explanation {
+	e.Add(ExplainStageResult, valueLabel(e.Value)+" from "+string(e.Source))
+	return e
+}
+
+func chainLabel(chain ScopeChain) string {
+	scopes := make([]string, 0, len(chain))
+	for _, ref := range chain {
+		scopes = append(scopes, scopeLabel(ref))
+	}
+	return strings.Join(scopes, " > ")
+}
+
+func valueLabel(value bool) string {
+	if value {
+		return "enabled"
+	}
+	return "disabled"
+}
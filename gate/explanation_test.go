@@ -0,0 +1,48 @@
+package gate
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type traceOnlyGate struct {
+	trace ResolveTrace
+}
+
+func (g traceOnlyGate) Enabled(context.Context, string, ...ResolveOption) (bool, error) {
+	return g.trace.Value, nil
+}
+
+func (g traceOnlyGate) ResolveWithTrace(context.Context, string, ...ResolveOption) (bool, ResolveTrace, error) {
+	return g.trace.Value, g.trace, nil
+}
+
+func TestExplanationFromTrace(t *testing.T) {
+	trace := ResolveTrace{
+		NormalizedKey: "search",
+		Chain:         ScopeChain{{Kind: ScopeTenant, ID: "acme", TenantID: "acme"}, {Kind: ScopeSystem}},
+		Source:        ResolveSourceFallback,
+		Override:      OverrideTrace{Scopes: []ScopeLookupTrace{{Scope: ScopeRef{Kind: ScopeSystem}, Outcome: ScopeOutcomeNoOverride}}},
+	}
+	explanation, err := ExplainDecision(context.Background(), traceOnlyGate{trace: trace}, "search")
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	want := strings.Join([]string{
+		"search = disabled (source: fallback)",
+		"  1. chain: tenant:acme > system",
+		"  2. override: none applied",
+		"  3. default: not set",
+		"  4. fallback: nothing set → disabled",
+		"  5. result: disabled from fallback",
+	}, "\n")
+	if got := explanation.String(); got != want {
+		t.Fatalf("unexpected explanation:\n%s\nwant:\n%s", got, want)
+	}
+
+	explanation = NewExplanation(ResolveTrace{NormalizedKey: "search", Value: true, Source: ResolveSourceRequestOverride})
+	if len(explanation.Steps) != 3 || explanation.Steps[1].Detail != "set for this request → enabled" {
+		t.Fatalf("expected request overrides to end the path, got:\n%s", explanation)
+	}
+}
@@ -1,6 +1,12 @@
 package gate
 
-import "context"
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+)
 
 // ScopeKind defines supported scope types.
 type ScopeKind uint8
@@ -12,8 +18,55 @@ const (
 	ScopeUser
 	ScopeRole
 	ScopePerm
+	// ScopeEnv targets a deployment environment (dev, staging, prod). It sits between
+	// tenant and system in the default order, so one store can hold per-environment overrides.
+	ScopeEnv
 )
 
+// String returns the canonical name for the scope kind.
+func (k ScopeKind) String() string {
+	switch k {
+	case ScopeSystem:
+		return "system"
+	case ScopeTenant:
+		return "tenant"
+	case ScopeOrg:
+		return "org"
+	case ScopeUser:
+		return "user"
+	case ScopeRole:
+		return "role"
+	case ScopePerm:
+		return "perm"
+	case ScopeEnv:
+		return "env"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseScopeKind maps a canonical scope name to a ScopeKind.
+func ParseScopeKind(value string) (ScopeKind, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "system":
+		return ScopeSystem, true
+	case "tenant":
+		return ScopeTenant, true
+	case "org":
+		return ScopeOrg, true
+	case "user":
+		return ScopeUser, true
+	case "role":
+		return ScopeRole, true
+	case "perm":
+		return ScopePerm, true
+	case "env", "environment":
+		return ScopeEnv, true
+	default:
+		return ScopeSystem, false
+	}
+}
+
 // ScopeRef identifies a single scope target.
 type ScopeRef struct {
 	Kind     ScopeKind
@@ -22,6 +75,34 @@ type ScopeRef struct {
 	OrgID    string
 }
 
+// DescribeScope implements ferrors.ScopeDescriber, so ferrors.Scope reads the scope
+// gate errors record.
+func (r ScopeRef) DescribeScope() ferrors.ScopeInfo {
+	return ferrors.ScopeInfo{Kind: r.Kind.String(), ID: r.ID, TenantID: r.TenantID, OrgID: r.OrgID}
+}
+
+// QualifiedRef returns the ref a chain built from claims carries for a scope of kind
+// and id, so overrides written for it match at resolve time. Tenant refs are
+// qualified by their own ID, org refs by the claims tenant and their own ID, and user,
+// role, and perm refs by the claims tenant and org; env and system refs carry no
+// qualifiers.
+func QualifiedRef(kind ScopeKind, id string, claims ActorClaims) ScopeRef {
+	ref := ScopeRef{Kind: kind, ID: strings.TrimSpace(id)}
+	switch kind {
+	case ScopeTenant:
+		ref.TenantID = ref.ID
+	case ScopeOrg:
+		ref.TenantID = claims.TenantID
+		ref.OrgID = ref.ID
+	case ScopeUser, ScopeRole, ScopePerm:
+		ref.TenantID = claims.TenantID
+		ref.OrgID = claims.OrgID
+	case ScopeSystem:
+		ref.ID = ""
+	}
+	return ref
+}
+
 // ScopeChain is an ordered list of scope references.
 type ScopeChain []ScopeRef
 
@@ -32,6 +113,7 @@ type ActorClaims struct {
 	OrgID     string
 	Roles     []string
 	Perms     []string
+	Env       string
 }
 
 // ClaimsProvider derives claims from context.
@@ -39,6 +121,13 @@ type ClaimsProvider interface {
 	ClaimsFromContext(ctx context.Context) (ActorClaims, error)
 }
 
+// TenantHierarchyProvider reports parent tenants and orgs so overrides set on an
+// ancestor cascade to its descendants. Parents are returned nearest first.
+type TenantHierarchyProvider interface {
+	ParentTenants(ctx context.Context, tenantID string) ([]string, error)
+	ParentOrgs(ctx context.Context, tenantID, orgID string) ([]string, error)
+}
+
 // PermissionProvider derives permissions from claims/roles.
 type PermissionProvider interface {
 	Permissions(ctx context.Context, claims ActorClaims) ([]string, error)
@@ -50,6 +139,8 @@ type ResolveOption func(*ResolveRequest)
 // ResolveRequest captures optional inputs for a resolve call.
 type ResolveRequest struct {
 	ScopeChain *ScopeChain
+	Claims     *ActorClaims
+	Fallback   *bool
 }
 
 // WithScopeChain forces a specific scope chain instead of deriving it from context.
@@ -62,6 +153,199 @@ func WithScopeChain(chain ScopeChain) ResolveOption {
 	}
 }
 
+// WithClaims derives the chain from claims instead of the context's claims provider,
+// so callers without context claims still get user, role, perm, org, tenant, and env
+// scopes. The permission provider still runs; WithScopeChain takes precedence.
+func WithClaims(claims ActorClaims) ResolveOption {
+	return func(req *ResolveRequest) {
+		if req == nil {
+			return
+		}
+		req.Claims = &claims
+	}
+}
+
+// WithFallback replaces the false fallback used when neither an override nor a
+// default exists for the key, letting a call site fail open. The result is traced
+// with ResolveSourceCallerFallback. Lookup errors still resolve to false.
+func WithFallback(value bool) ResolveOption {
+	return func(req *ResolveRequest) {
+		if req == nil {
+			return
+		}
+		req.Fallback = &value
+	}
+}
+
+// MutationOption mutates an override write request.
+type MutationOption func(*MutationRequest)
+
+// MutationRequest captures optional inputs for a Set call.
+type MutationRequest struct {
+	TTL       time.Duration
+	ExpiresAt time.Time
+	Metadata  OverrideMetadata
+	Priority  int
+}
+
+// OverrideMetadata records why an override exists and who owns it.
+// Labels are free-form tags (for example incident=INC-1234) used to find related overrides.
+type OverrideMetadata struct {
+	Reason    string            `json:"reason,omitempty"`
+	TicketURL string            `json:"ticket_url,omitempty"`
+	Owner     string            `json:"owner,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// IsZero reports whether no metadata is set.
+func (m OverrideMetadata) IsZero() bool {
+	return m.Reason == "" && m.TicketURL == "" && m.Owner == "" && len(m.Labels) == 0
+}
+
+// MatchesLabels reports whether every label in filter is set to the same value.
+// An empty filter matches all metadata.
+func (m OverrideMetadata) MatchesLabels(filter map[string]string) bool {
+	for name, value := range filter {
+		if got, ok := m.Labels[name]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// WithTTL expires the override after the provided duration.
+func WithTTL(ttl time.Duration) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.TTL = ttl
+	}
+}
+
+// WithExpiresAt expires the override at the provided time.
+func WithExpiresAt(expiresAt time.Time) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.ExpiresAt = expiresAt
+	}
+}
+
+// WithMetadata attaches ownership and reason metadata to the override.
+func WithMetadata(meta OverrideMetadata) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.Metadata = OverrideMetadata{
+			Reason:    strings.TrimSpace(meta.Reason),
+			TicketURL: strings.TrimSpace(meta.TicketURL),
+			Owner:     strings.TrimSpace(meta.Owner),
+			Labels:    normalizeLabels(nil, meta.Labels),
+		}
+	}
+}
+
+// WithPriority orders the override against others in the same chain for strategies
+// that honor it; higher values win. Stores persist it alongside the override.
+func WithPriority(priority int) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.Priority = priority
+	}
+}
+
+// WithLabels adds labels to the override, replacing existing values for the same names.
+func WithLabels(labels map[string]string) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.Metadata.Labels = normalizeLabels(req.Metadata.Labels, labels)
+	}
+}
+
+// WithLabel adds a single label to the override.
+func WithLabel(name, value string) MutationOption {
+	return WithLabels(map[string]string{name: value})
+}
+
+func normalizeLabels(existing, labels map[string]string) map[string]string {
+	if len(existing) == 0 && len(labels) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(existing)+len(labels))
+	for name, value := range existing {
+		out[name] = value
+	}
+	for name, value := range labels {
+		if name = strings.TrimSpace(name); name != "" {
+			out[name] = strings.TrimSpace(value)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// WithReason records why the override was set.
+func WithReason(reason string) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.Metadata.Reason = strings.TrimSpace(reason)
+	}
+}
+
+// WithTicketURL links the override to a ticket or change request.
+func WithTicketURL(url string) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.Metadata.TicketURL = strings.TrimSpace(url)
+	}
+}
+
+// WithOwner records the team or person responsible for the override.
+func WithOwner(owner string) MutationOption {
+	return func(req *MutationRequest) {
+		if req == nil {
+			return
+		}
+		req.Metadata.Owner = strings.TrimSpace(owner)
+	}
+}
+
+// ApplyMutationOptions builds a MutationRequest from options.
+func ApplyMutationOptions(opts ...MutationOption) MutationRequest {
+	req := MutationRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	return req
+}
+
+// Expiry returns the absolute expiry for the request, or the zero time when none is set.
+// ExpiresAt takes precedence over TTL.
+func (r MutationRequest) Expiry(now time.Time) time.Time {
+	if !r.ExpiresAt.IsZero() {
+		return r.ExpiresAt
+	}
+	if r.TTL > 0 {
+		return now.Add(r.TTL)
+	}
+	return time.Time{}
+}
+
 // FeatureGate resolves feature enablement for the current scope.
 type FeatureGate interface {
 	Enabled(ctx context.Context, key string, opts ...ResolveOption) (bool, error)
@@ -76,7 +360,7 @@ type TraceableFeatureGate interface {
 // MutableFeatureGate supports runtime overrides for feature values.
 type MutableFeatureGate interface {
 	FeatureGate
-	Set(ctx context.Context, key string, scope ScopeRef, enabled bool, actor ActorRef) error
+	Set(ctx context.Context, key string, scope ScopeRef, enabled bool, actor ActorRef, opts ...MutationOption) error
 	Unset(ctx context.Context, key string, scope ScopeRef, actor ActorRef) error
 }
 
@@ -0,0 +1,401 @@
+// Package gatetest provides a configurable fake feature gate so services can unit test
+// feature-gated code paths without building a resolver, stores, or claims providers.
+//
+// Gate answers from values set per key and per scope, returns scripted errors, and
+// records every call:
+//
+//	fg := gatetest.New(
+//		gatetest.WithValue("checkout.v2", true),
+//		gatetest.WithScopeValue("checkout.v2", gate.ScopeRef{Kind: gate.ScopeTenant, ID: "legacy"}, false),
+//	)
+//	fg.FailNext("billing.sync", errors.New("store down"))
+//
+//	handler := NewHandler(fg)
+//	...
+//	if fg.CallCount("checkout.v2") != 1 {
+//		t.Fatalf("expected one checkout.v2 check, got %+v", fg.Calls())
+//	}
+//
+// Gate implements gate.FeatureGate, gate.TraceableFeatureGate, and
+// gate.MutableFeatureGate, and is safe for concurrent use.
+package gatetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scopechain"
+)
+
+// AnyKey scripts an error for every key.
+const AnyKey = "*"
+
+// Method names recorded in Call.
+const (
+	MethodEnabled          = "Enabled"
+	MethodResolveWithTrace = "ResolveWithTrace"
+	MethodSet              = "Set"
+	MethodUnset            = "Unset"
+)
+
+// Call records one call made to the gate.
+type Call struct {
+	Method string
+	Key    string
+	// Chain is the scope chain the call resolved with: the WithScopeChain chain, the
+	// chain built from WithClaims, the chain attached to the context, or the gate's
+	// WithChain chain, in that order.
+	Chain gate.ScopeChain
+	// Claims is set when the call passed gate.WithClaims.
+	Claims *gate.ActorClaims
+	// Scope, Enabled, and Actor are set for Set and Unset calls.
+	Scope   gate.ScopeRef
+	Enabled bool
+	Actor   gate.ActorRef
+	Value   bool
+	Err     error
+}
+
+// Option customizes Gate.
+type Option func(*Gate)
+
+// WithValue sets the value served for key when no scope value matches.
+func WithValue(key string, enabled bool) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.values[gate.NormalizeKey(key)] = enabled
+	}
+}
+
+// WithValues sets the values served for several keys.
+func WithValues(values map[string]bool) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		for key, enabled := range values {
+			g.values[gate.NormalizeKey(key)] = enabled
+		}
+	}
+}
+
+// WithScopeValue sets the value served for key when scope is in the call's chain.
+// Scopes match on kind and ID; the first matching scope of the chain wins.
+func WithScopeValue(key string, scope gate.ScopeRef, enabled bool) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.setScopeValue(key, scope, enabled)
+	}
+}
+
+// WithError makes every call for key fail with err until cleared with Fail(key, nil).
+// Use AnyKey to fail every key.
+func WithError(key string, err error) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.setError(key, err)
+	}
+}
+
+// WithFallback sets the value served for keys without a value. Defaults to false;
+// gate.WithFallback on a call takes precedence.
+func WithFallback(enabled bool) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.fallback = enabled
+	}
+}
+
+// WithChain sets the chain used by calls that neither pass a chain or claims nor
+// carry a chain in their context.
+func WithChain(chain gate.ScopeChain) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.chain = chain
+	}
+}
+
+// Gate is a fake feature gate.
+type Gate struct {
+	mu       sync.Mutex
+	builder  *scopechain.Builder
+	values   map[string]bool
+	scoped   map[string][]scopeValue
+	errors   map[string]error
+	scripted map[string][]error
+	fallback bool
+	chain    gate.ScopeChain
+	calls    []Call
+}
+
+type scopeValue struct {
+	scope   gate.ScopeRef
+	enabled bool
+}
+
+// New returns a Gate serving false for every key until values are set.
+func New(opts ...Option) *Gate {
+	g := &Gate{
+		builder:  scopechain.New(),
+		values:   map[string]bool{},
+		scoped:   map[string][]scopeValue{},
+		errors:   map[string]error{},
+		scripted: map[string][]error{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(g)
+		}
+	}
+	return g
+}
+
+// SetValue sets the value served for key when no scope value matches.
+func (g *Gate) SetValue(key string, enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[gate.NormalizeKey(key)] = enabled
+}
+
+// SetScopeValue sets the value served for key when scope is in the call's chain.
+func (g *Gate) SetScopeValue(key string, scope gate.ScopeRef, enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.setScopeValue(key, scope, enabled)
+}
+
+// Fail makes every call for key fail with err. A nil err clears the failure.
+func (g *Gate) Fail(key string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.setError(key, err)
+}
+
+// FailNext queues errs for the next calls for key, one per call, before values or
+// Fail errors apply again.
+func (g *Gate) FailNext(key string, errs ...error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	normalized := errorKey(key)
+	g.scripted[normalized] = append(g.scripted[normalized], errs...)
+}
+
+// Calls returns the calls made so far, in order.
+func (g *Gate) Calls() []Call {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Call(nil), g.calls...)
+}
+
+// CallsFor returns the calls made for key, in order.
+func (g *Gate) CallsFor(key string) []Call {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	normalized := gate.NormalizeKey(key)
+	var calls []Call
+	for _, call := range g.calls {
+		if call.Key == normalized {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CallCount returns the number of calls made for key.
+func (g *Gate) CallCount(key string) int {
+	return len(g.CallsFor(key))
+}
+
+// ResetCalls forgets the recorded calls.
+func (g *Gate) ResetCalls() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.calls = nil
+}
+
+// Enabled implements gate.FeatureGate.
+func (g *Gate) Enabled(ctx context.Context, key string, opts ...gate.ResolveOption) (bool, error) {
+	value, _, err := g.resolve(ctx, MethodEnabled, key, opts)
+	return value, err
+}
+
+// ResolveWithTrace implements gate.TraceableFeatureGate. The trace records the key,
+// chain, value, and source: override for scope values, default for key values, and
+// fallback or caller fallback otherwise.
+func (g *Gate) ResolveWithTrace(ctx context.Context, key string, opts ...gate.ResolveOption) (bool, gate.ResolveTrace, error) {
+	return g.resolve(ctx, MethodResolveWithTrace, key, opts)
+}
+
+// Set implements gate.MutableFeatureGate by setting a scope value.
+func (g *Gate) Set(_ context.Context, key string, scope gate.ScopeRef, enabled bool, actor gate.ActorRef, _ ...gate.MutationOption) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	normalized := gate.NormalizeKey(key)
+	err := g.nextError(normalized)
+	if err == nil {
+		g.setScopeValue(key, scope, enabled)
+	}
+	g.calls = append(g.calls, Call{Method: MethodSet, Key: normalized, Scope: scope, Enabled: enabled, Actor: actor, Err: err})
+	return err
+}
+
+// Unset implements gate.MutableFeatureGate by removing the scope value.
+func (g *Gate) Unset(_ context.Context, key string, scope gate.ScopeRef, actor gate.ActorRef) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	normalized := gate.NormalizeKey(key)
+	err := g.nextError(normalized)
+	if err == nil {
+		g.scoped[normalized] = removeScope(g.scoped[normalized], g.builder.NormalizeRef(scope))
+	}
+	g.calls = append(g.calls, Call{Method: MethodUnset, Key: normalized, Scope: scope, Actor: actor, Err: err})
+	return err
+}
+
+func (g *Gate) resolve(ctx context.Context, method, key string, opts []gate.ResolveOption) (bool, gate.ResolveTrace, error) {
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	normalized := gate.NormalizeKey(key)
+	chain := g.chainFor(ctx, req)
+	trace := gate.ResolveTrace{Key: key, NormalizedKey: normalized, Chain: chain}
+	call := Call{Method: method, Key: normalized, Chain: chain, Claims: req.Claims}
+
+	if err := g.nextError(normalized); err != nil {
+		trace.Source = gate.ResolveSourceFallback
+		call.Err = err
+		g.calls = append(g.calls, call)
+		return false, trace, err
+	}
+
+	if match, ok := g.matchScope(normalized, chain); ok {
+		value := match.enabled
+		trace.Value = value
+		trace.Source = gate.ResolveSourceOverride
+		trace.Override.Match = match.scope
+		trace.Override.State = overrideState(value)
+		trace.Override.Value = &value
+	} else if enabled, ok := g.values[normalized]; ok {
+		trace.Value = enabled
+		trace.Source = gate.ResolveSourceDefault
+		trace.Default = gate.DefaultTrace{Set: true, Value: enabled}
+	} else if req.Fallback != nil {
+		trace.Value = *req.Fallback
+		trace.Source = gate.ResolveSourceCallerFallback
+	} else {
+		trace.Value = g.fallback
+		trace.Source = gate.ResolveSourceFallback
+	}
+	call.Value = trace.Value
+	g.calls = append(g.calls, call)
+	return trace.Value, trace, nil
+}
+
+func (g *Gate) chainFor(ctx context.Context, req gate.ResolveRequest) gate.ScopeChain {
+	if req.ScopeChain != nil {
+		return *req.ScopeChain
+	}
+	if req.Claims != nil {
+		return g.builder.Build(*req.Claims)
+	}
+	if chain, ok := gate.ResolvedChain(ctx); ok {
+		return chain
+	}
+	return g.chain
+}
+
+func (g *Gate) matchScope(key string, chain gate.ScopeChain) (scopeValue, bool) {
+	values := g.scoped[key]
+	if len(values) == 0 {
+		return scopeValue{}, false
+	}
+	for _, ref := range chain {
+		ref = g.builder.NormalizeRef(ref)
+		for _, value := range values {
+			if sameScope(value.scope, ref) {
+				return value, true
+			}
+		}
+	}
+	return scopeValue{}, false
+}
+
+func (g *Gate) setScopeValue(key string, scope gate.ScopeRef, enabled bool) {
+	normalized := gate.NormalizeKey(key)
+	scope = g.builder.NormalizeRef(scope)
+	values := removeScope(g.scoped[normalized], scope)
+	g.scoped[normalized] = append(values, scopeValue{scope: scope, enabled: enabled})
+}
+
+func (g *Gate) setError(key string, err error) {
+	normalized := errorKey(key)
+	if err == nil {
+		delete(g.errors, normalized)
+		return
+	}
+	g.errors[normalized] = err
+}
+
+func (g *Gate) nextError(key string) error {
+	for _, candidate := range []string{key, AnyKey} {
+		if queue := g.scripted[candidate]; len(queue) > 0 {
+			g.scripted[candidate] = queue[1:]
+			return queue[0]
+		}
+	}
+	if err, ok := g.errors[key]; ok {
+		return err
+	}
+	return g.errors[AnyKey]
+}
+
+func errorKey(key string) string {
+	if key == AnyKey {
+		return AnyKey
+	}
+	return gate.NormalizeKey(key)
+}
+
+func removeScope(values []scopeValue, scope gate.ScopeRef) []scopeValue {
+	out := values[:0:0]
+	for _, value := range values {
+		if !sameScope(value.scope, scope) {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+func sameScope(a, b gate.ScopeRef) bool {
+	return a.Kind == b.Kind && a.ID == b.ID
+}
+
+func overrideState(enabled bool) gate.OverrideState {
+	if enabled {
+		return gate.OverrideStateEnabled
+	}
+	return gate.OverrideStateDisabled
+}
+
+var (
+	_ gate.FeatureGate          = (*Gate)(nil)
+	_ gate.TraceableFeatureGate = (*Gate)(nil)
+	_ gate.MutableFeatureGate   = (*Gate)(nil)
+)
@@ -0,0 +1,95 @@
+package gatetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func TestGateServesScopeValuesBeforeKeyValues(t *testing.T) {
+	ctx := context.Background()
+	legacy := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "legacy"}
+	fg := New(
+		WithValue("checkout.v2", true),
+		WithScopeValue("checkout.v2", legacy, false),
+		WithScopeValue("checkout.v2", gate.ScopeRef{Kind: gate.ScopeRole, ID: "Beta"}, true),
+	)
+
+	if enabled, err := fg.Enabled(ctx, "checkout.v2"); err != nil || !enabled {
+		t.Fatalf("expected the key value, got %v (%v)", enabled, err)
+	}
+	enabled, trace, err := fg.ResolveWithTrace(ctx, "checkout.v2", gate.WithClaims(gate.ActorClaims{TenantID: "legacy"}))
+	if err != nil || enabled || trace.Source != gate.ResolveSourceOverride || trace.Override.Match.ID != "legacy" {
+		t.Fatalf("expected the tenant scope value, got %v %+v (%v)", enabled, trace, err)
+	}
+	chain := gate.ScopeChain{{Kind: gate.ScopeRole, ID: "beta"}, {Kind: gate.ScopeTenant, ID: "legacy", TenantID: "legacy"}}
+	if enabled, _ := fg.Enabled(ctx, "checkout.v2", gate.WithScopeChain(chain)); !enabled {
+		t.Fatal("expected the first matching scope of the chain to win")
+	}
+	if enabled, _ := fg.Enabled(ctx, "unknown", gate.WithFallback(true)); !enabled {
+		t.Fatal("expected the caller fallback for keys without a value")
+	}
+
+	if err := fg.Unset(ctx, "checkout.v2", legacy, gate.ActorRef{}); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+	if enabled, _ := fg.Enabled(ctx, "checkout.v2", gate.WithClaims(gate.ActorClaims{TenantID: "legacy"})); !enabled {
+		t.Fatal("expected the key value once the scope value is unset")
+	}
+}
+
+func TestGateScriptsErrors(t *testing.T) {
+	ctx := context.Background()
+	down := errors.New("store down")
+	fg := New(WithValue("billing.sync", true))
+	fg.FailNext("billing.sync", down)
+
+	if enabled, err := fg.Enabled(ctx, "billing.sync"); !errors.Is(err, down) || enabled {
+		t.Fatalf("expected the scripted error, got %v (%v)", enabled, err)
+	}
+	if enabled, err := fg.Enabled(ctx, "billing.sync"); err != nil || !enabled {
+		t.Fatalf("expected the value after the scripted error, got %v (%v)", enabled, err)
+	}
+
+	fg.Fail(AnyKey, down)
+	if _, err := fg.Enabled(ctx, "other"); !errors.Is(err, down) {
+		t.Fatalf("expected every key to fail, got %v", err)
+	}
+	fg.Fail(AnyKey, nil)
+	if _, err := fg.Enabled(ctx, "other"); err != nil {
+		t.Fatalf("expected the failure cleared, got %v", err)
+	}
+}
+
+func TestGateRecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	fg := New(WithChain(gate.ScopeChain{{Kind: gate.ScopeSystem}}))
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1"}
+	actor := gate.ActorRef{ID: "ops"}
+
+	_, _ = fg.Enabled(ctx, "search")
+	_ = fg.Set(ctx, "search", user, true, actor)
+	_, _ = fg.Enabled(ctx, "search", gate.WithScopeChain(gate.ScopeChain{user}))
+	_, _ = fg.Enabled(ctx, "other")
+
+	calls := fg.CallsFor("search")
+	if len(calls) != 3 || fg.CallCount("other") != 1 {
+		t.Fatalf("expected three search calls and one other call, got %+v", fg.Calls())
+	}
+	if calls[0].Method != MethodEnabled || len(calls[0].Chain) != 1 || calls[0].Chain[0].Kind != gate.ScopeSystem {
+		t.Fatalf("expected the default chain recorded, got %+v", calls[0])
+	}
+	if calls[1].Method != MethodSet || calls[1].Scope != user || !calls[1].Enabled || calls[1].Actor != actor {
+		t.Fatalf("expected the set recorded, got %+v", calls[1])
+	}
+	if !calls[2].Value {
+		t.Fatalf("expected the set value served, got %+v", calls[2])
+	}
+
+	fg.ResetCalls()
+	if len(fg.Calls()) != 0 {
+		t.Fatalf("expected calls cleared, got %+v", fg.Calls())
+	}
+}
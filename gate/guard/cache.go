@@ -0,0 +1,25 @@
+package guard
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+)
+
+// WithRequestCache prepares ctx so Require, RequireAll, RequireAny, RequireNone, and
+// Middleware resolve each key at most once per scope chain for the request. It
+// attaches a gate.EvaluationMemo, which holds the decisions, and a scope.ChainCache;
+// either is kept when ctx already has one, so calling it twice is harmless. Gate
+// errors are not memoized, and a check under a different scope chain resolves on
+// its own.
+func WithRequestCache(ctx context.Context) context.Context {
+	return scope.WithChainCache(gate.WithEvaluationMemo(ctx))
+}
+
+// CacheDecisions is HTTP middleware that calls WithRequestCache for every request.
+// It is equivalent to Memoize.
+func CacheDecisions(next http.Handler) http.Handler {
+	return Memoize(next)
+}
@@ -0,0 +1,42 @@
+package guard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestWithRequestCacheResolvesEachKeyOnce(t *testing.T) {
+	overrides := &countingStore{MemoryStore: store.NewMemoryStore()}
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"reports": true, "exports": false})),
+		resolver.WithOverrideStore(overrides),
+	)
+	ctx := WithRequestCache(context.Background())
+	if again := WithRequestCache(ctx); gate.EvaluationMemoFromContext(again) != gate.EvaluationMemoFromContext(ctx) {
+		t.Fatal("expected a second call to keep the existing memo")
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := Require(ctx, fg, "reports"); err != nil {
+			t.Fatalf("Require = %v", err)
+		}
+		if err := RequireNone(ctx, fg, "exports"); err != nil {
+			t.Fatalf("RequireNone = %v", err)
+		}
+	}
+	if overrides.reads != 2 {
+		t.Fatalf("expected one store read per key, got %d", overrides.reads)
+	}
+
+	if err := fg.Set(context.Background(), "reports", gate.ScopeRef{Kind: gate.ScopeSystem}, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := Require(ctx, fg, "reports"); err != nil {
+		t.Fatalf("expected the request to keep its first decision, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package guard
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// RequireAll returns nil when every key is enabled, mirroring the feature_all helper.
+// Gate errors fail closed. WithOverrides keys grant access when the check denies.
+// If a gate is nil, RequireAll returns nil.
+func RequireAll(ctx context.Context, fg gate.FeatureGate, keys ...string) error {
+	return RequireAllWith(ctx, fg, keys)
+}
+
+// RequireAny returns nil when at least one key is enabled, mirroring feature_any.
+// A gate error on one key does not deny while another key is enabled; when none is,
+// the first error is returned.
+func RequireAny(ctx context.Context, fg gate.FeatureGate, keys ...string) error {
+	return RequireAnyWith(ctx, fg, keys)
+}
+
+// RequireNone returns nil when no key is enabled, mirroring feature_none. The
+// disabled error lists the keys that were enabled.
+func RequireNone(ctx context.Context, fg gate.FeatureGate, keys ...string) error {
+	return RequireNoneWith(ctx, fg, keys)
+}
+
+// RequireAllWith is RequireAll with options.
+func RequireAllWith(ctx context.Context, fg gate.FeatureGate, keys []string, opts ...Option) error {
+	return requireCombined(ctx, fg, keys, combineAll, opts)
+}
+
+// RequireAnyWith is RequireAny with options.
+func RequireAnyWith(ctx context.Context, fg gate.FeatureGate, keys []string, opts ...Option) error {
+	return requireCombined(ctx, fg, keys, combineAny, opts)
+}
+
+// RequireNoneWith is RequireNone with options.
+func RequireNoneWith(ctx context.Context, fg gate.FeatureGate, keys []string, opts ...Option) error {
+	return requireCombined(ctx, fg, keys, combineNone, opts)
+}
+
+type combineMode int
+
+const (
+	combineAll combineMode = iota
+	combineAny
+	combineNone
+)
+
+// requireCombined evaluates keys in order and stops as soon as the outcome is known.
+// An empty key list is denied, like the template helpers.
+func requireCombined(ctx context.Context, fg gate.FeatureGate, keys []string, mode combineMode, opts []Option) error {
+	if fg == nil {
+		return nil
+	}
+	cfg := newConfig(opts...)
+	keys = cleanKeys(keys)
+
+	var denied []string
+	var firstErr error
+	var activatesAt time.Time
+	var chain gate.ScopeChain
+	for _, key := range keys {
+		result, err := resolveKey(ctx, fg, key)
+		if err != nil {
+			if mode != combineAny {
+				return mapErr(cfg, err)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		switch mode {
+		case combineAll:
+			if !result.enabled {
+				denied, activatesAt, chain = []string{key}, result.activatesAt, result.chain
+			}
+		case combineAny:
+			if result.enabled {
+				return nil
+			}
+			chain = result.chain
+		case combineNone:
+			if result.enabled {
+				denied, chain = []string{key}, result.chain
+			}
+		}
+		if len(denied) > 0 {
+			break
+		}
+	}
+
+	switch {
+	case mode == combineAny:
+		if firstErr != nil {
+			return mapErr(cfg, firstErr)
+		}
+		denied = keys
+	case len(keys) > 0 && len(denied) == 0:
+		return nil
+	}
+
+	ok, err := overrideAllows(ctx, fg, cfg)
+	if err != nil {
+		return mapErr(cfg, err)
+	}
+	if ok {
+		return nil
+	}
+	disabled := DisabledError{Keys: denied, ActivatesAt: activatesAt}
+	if len(denied) == 1 {
+		disabled.Key = denied[0]
+	}
+	return disabledErr(cfg, disabled, chain)
+}
+
+func cleanKeys(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			out = append(out, key)
+		}
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequireCombinations(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubGate{enabled: map[string]bool{"a": true, "b": false, "c": false}}
+
+	if err := RequireAll(ctx, stub, "a", " "); err != nil {
+		t.Fatalf("RequireAll(a) = %v", err)
+	}
+	var disabled DisabledError
+	if err := RequireAll(ctx, stub, "a", "b", "c"); !errors.As(err, &disabled) || disabled.Key != "b" {
+		t.Fatalf("RequireAll(a,b,c) = %v, want disabled b", err)
+	}
+	if err := RequireAny(ctx, stub, "b", "a"); err != nil {
+		t.Fatalf("RequireAny(b,a) = %v", err)
+	}
+	if err := RequireAny(ctx, stub, "b", "c"); !errors.As(err, &disabled) || len(disabled.Keys) != 2 {
+		t.Fatalf("RequireAny(b,c) = %v, want both keys", err)
+	}
+	if err := RequireNone(ctx, stub, "b", "c"); err != nil {
+		t.Fatalf("RequireNone(b,c) = %v", err)
+	}
+	if err := RequireNone(ctx, stub, "b", "a"); !errors.As(err, &disabled) || disabled.Key != "a" {
+		t.Fatalf("RequireNone(b,a) = %v, want a listed", err)
+	}
+	if err := RequireAll(ctx, stub); !errors.Is(err, ErrFeatureDisabled) {
+		t.Fatalf("RequireAll() = %v, want empty key list denied", err)
+	}
+}
+
+func TestRequireCombinationOptions(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubGate{enabled: map[string]bool{"b": false, "override": true}}
+	custom := errors.New("nope")
+
+	if err := RequireAllWith(ctx, stub, []string{"b"}, WithDisabledError(custom)); err != custom {
+		t.Fatalf("expected custom disabled error, got %v", err)
+	}
+	if err := RequireAllWith(ctx, stub, []string{"b"}, WithOverrides("override")); err != nil {
+		t.Fatalf("expected override to grant access, got %v", err)
+	}
+
+	gateErr := errors.New("gate failed")
+	mapped := errors.New("mapped")
+	failing := &stubGate{err: gateErr}
+	if err := RequireAnyWith(ctx, failing, []string{"a", "b"}, WithErrorMapper(func(error) error { return mapped })); err != mapped {
+		t.Fatalf("expected mapped error, got %v", err)
+	}
+	if err := RequireNone(ctx, nil, "a"); err != nil {
+		t.Fatalf("nil gate should allow, got %v", err)
+	}
+}
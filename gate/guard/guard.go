@@ -4,7 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	goerrors "github.com/goliatone/go-errors"
+
+	"github.com/goliatone/go-featuregate/ferrors"
 	"github.com/goliatone/go-featuregate/gate"
 )
 
@@ -12,15 +18,26 @@ import (
 var ErrFeatureDisabled = errors.New("feature disabled")
 
 // DisabledError includes the disabled feature key and unwraps to ErrFeatureDisabled.
+// ActivatesAt is set when the feature is disabled only because its schedule has not started.
+// Keys lists the keys that denied a RequireAll, RequireAny, or RequireNone check; for
+// RequireNone they are the keys that were enabled.
 type DisabledError struct {
-	Key string
+	Key         string
+	Keys        []string
+	ActivatesAt time.Time
 }
 
 func (e DisabledError) Error() string {
-	if e.Key == "" {
-		return ErrFeatureDisabled.Error()
+	msg := ErrFeatureDisabled.Error()
+	if len(e.Keys) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(e.Keys, ", "))
+	} else if e.Key != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Key)
+	}
+	if !e.ActivatesAt.IsZero() {
+		msg = fmt.Sprintf("%s (available on %s)", msg, e.ActivatesAt.UTC().Format(time.RFC3339))
 	}
-	return fmt.Sprintf("%s: %s", ErrFeatureDisabled.Error(), e.Key)
+	return msg
 }
 
 func (e DisabledError) Unwrap() error {
@@ -31,9 +48,16 @@ func (e DisabledError) Unwrap() error {
 type Option func(*config)
 
 type config struct {
-	disabledErr error
-	errorMapper func(error) error
-	overrides   []string
+	disabledErr     error
+	richErrors      bool
+	errorMapper     func(error) error
+	overrides       []string
+	statusCode      int
+	errorStatusCode int
+	body            []byte
+	contentType     string
+	routeKeys       map[string]string
+	keyFunc         func(*http.Request) string
 }
 
 // WithDisabledError sets the error returned when the gate is disabled.
@@ -46,6 +70,18 @@ func WithDisabledError(err error) Option {
 	}
 }
 
+// WithRichErrors returns a ferrors-rich error (category authorization, text code
+// FEATURE_DISABLED, metadata with the key and scope) instead of a plain DisabledError
+// when the feature is disabled. The rich error unwraps to the DisabledError.
+func WithRichErrors(enabled bool) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.richErrors = enabled
+	}
+}
+
 // WithErrorMapper transforms gate errors before returning them.
 func WithErrorMapper(mapper func(error) error) Option {
 	return func(c *config) {
@@ -66,6 +102,25 @@ func WithOverrides(keys ...string) Option {
 	}
 }
 
+func richDisabledErr(disabled DisabledError, chain gate.ScopeChain) error {
+	meta := map[string]any{}
+	if disabled.Key != "" {
+		meta[ferrors.MetaFeatureKey] = disabled.Key
+	}
+	if len(disabled.Keys) > 0 {
+		meta[ferrors.MetaFeatureKeys] = append([]string(nil), disabled.Keys...)
+	}
+	if len(chain) > 0 {
+		meta[ferrors.MetaScope] = chain[0]
+		meta[ferrors.MetaChain] = chain
+	}
+	if !disabled.ActivatesAt.IsZero() {
+		meta[ferrors.MetaActivatesAt] = disabled.ActivatesAt
+	}
+	return ferrors.Wrap(disabled, goerrors.CategoryAuthz, ferrors.TextCodeFeatureDisabled, disabled.Error(), meta).
+		WithCode(goerrors.CodeForbidden)
+}
+
 // Require checks a feature gate and returns an error when access is denied.
 // If a gate is nil, Require returns nil.
 func Require(ctx context.Context, fg gate.FeatureGate, key string, opts ...Option) error {
@@ -73,36 +128,97 @@ func Require(ctx context.Context, fg gate.FeatureGate, key string, opts ...Optio
 		return nil
 	}
 
+	cfg := newConfig(opts...)
+	result, err := check(ctx, fg, key, cfg)
+	if err != nil {
+		return mapErr(cfg, err)
+	}
+	if result.enabled {
+		return nil
+	}
+	return disabledErr(cfg, DisabledError{Key: key, ActivatesAt: result.activatesAt}, result.chain)
+}
+
+func newConfig(opts ...Option) *config {
 	cfg := &config{}
 	for _, opt := range opts {
 		if opt != nil {
 			opt(cfg)
 		}
 	}
+	return cfg
+}
+
+type checkResult struct {
+	enabled     bool
+	activatesAt time.Time
+	chain       gate.ScopeChain
+}
 
-	enabled, err := fg.Enabled(ctx, key)
+// check resolves the primary key and any override keys, returning the raw gate error.
+// Traceable gates also report when a scheduled primary key becomes available.
+func check(ctx context.Context, fg gate.FeatureGate, key string, cfg *config) (checkResult, error) {
+	result, err := resolveKey(ctx, fg, key)
 	if err != nil {
-		return mapErr(cfg, err)
+		return checkResult{}, err
 	}
-	if enabled {
-		return nil
+	if result.enabled {
+		return result, nil
+	}
+	ok, err := overrideAllows(ctx, fg, cfg)
+	if err != nil {
+		return checkResult{}, err
+	}
+	if ok {
+		return checkResult{enabled: true}, nil
 	}
+	return result, nil
+}
 
+// resolveKey resolves a single key, reporting a pending schedule for traceable gates.
+// Repeated checks within a request are answered by the gate.EvaluationMemo that
+// Middleware and Memoize attach, not by the guard.
+func resolveKey(ctx context.Context, fg gate.FeatureGate, key string) (checkResult, error) {
+	result := checkResult{}
+	var err error
+	if traceable, ok := fg.(gate.TraceableFeatureGate); ok {
+		var trace gate.ResolveTrace
+		result.enabled, trace, err = traceable.ResolveWithTrace(ctx, key)
+		result.activatesAt, _ = trace.ActivatesAt()
+		result.chain = trace.Chain
+	} else {
+		result.enabled, err = fg.Enabled(ctx, key)
+	}
+	if err != nil {
+		return checkResult{}, err
+	}
+	return result, nil
+}
+
+// overrideAllows reports whether any override key is enabled.
+func overrideAllows(ctx context.Context, fg gate.FeatureGate, cfg *config) (bool, error) {
 	for _, override := range cfg.overrides {
-		ok, err := fg.Enabled(ctx, override)
+		result, err := resolveKey(ctx, fg, override)
 		if err != nil {
-			return mapErr(cfg, err)
+			return false, err
 		}
-		if ok {
-			return nil
+		if result.enabled {
+			return true, nil
 		}
 	}
+	return false, nil
+}
 
-	if cfg.disabledErr != nil {
+// disabledErr returns the configured disabled error, the rich form of disabled, or
+// disabled itself.
+func disabledErr(cfg *config, disabled DisabledError, chain gate.ScopeChain) error {
+	if cfg != nil && cfg.disabledErr != nil {
 		return cfg.disabledErr
 	}
-
-	return DisabledError{Key: key}
+	if cfg != nil && cfg.richErrors {
+		return richDisabledErr(disabled, chain)
+	}
+	return disabled
 }
 
 func mapErr(cfg *config, err error) error {
@@ -3,8 +3,12 @@ package guard
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
+	goerrors "github.com/goliatone/go-errors"
+
+	"github.com/goliatone/go-featuregate/ferrors"
 	"github.com/goliatone/go-featuregate/gate"
 )
 
@@ -100,3 +104,42 @@ func TestRequireDefaultDisabledError(t *testing.T) {
 		t.Fatalf("expected ErrFeatureDisabled, got %v", err)
 	}
 }
+
+type traceStub struct {
+	stubGate
+	chain gate.ScopeChain
+}
+
+func (s *traceStub) ResolveWithTrace(ctx context.Context, key string, opts ...gate.ResolveOption) (bool, gate.ResolveTrace, error) {
+	value, err := s.Enabled(ctx, key, opts...)
+	return value, gate.ResolveTrace{Key: key, Chain: s.chain, Value: value}, err
+}
+
+func TestRequireRichErrors(t *testing.T) {
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	stub := &traceStub{
+		stubGate: stubGate{enabled: map[string]bool{"billing.v2": false}},
+		chain:    gate.ScopeChain{tenant, {Kind: gate.ScopeSystem}},
+	}
+
+	err := Require(context.Background(), stub, "billing.v2", WithRichErrors(true))
+	rich, ok := ferrors.As(err)
+	if !ok {
+		t.Fatalf("expected rich error, got %T %v", err, err)
+	}
+	if rich.Category != goerrors.CategoryAuthz || rich.TextCode != ferrors.TextCodeFeatureDisabled || rich.Code != http.StatusForbidden {
+		t.Fatalf("unexpected rich error %+v", rich)
+	}
+	if rich.Metadata[ferrors.MetaFeatureKey] != "billing.v2" || rich.Metadata[ferrors.MetaScope] != tenant {
+		t.Fatalf("unexpected metadata %+v", rich.Metadata)
+	}
+	var disabled DisabledError
+	if !errors.Is(err, ErrFeatureDisabled) || !errors.As(err, &disabled) || disabled.Key != "billing.v2" {
+		t.Fatalf("expected rich error to unwrap to DisabledError, got %v", err)
+	}
+
+	err = RequireAllWith(context.Background(), stub, []string{"billing.v2"}, WithRichErrors(true))
+	if rich, ok := ferrors.As(err); !ok || rich.TextCode != ferrors.TextCodeFeatureDisabled {
+		t.Fatalf("expected rich error from RequireAllWith, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package guard
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const (
+	// DefaultDisabledStatus is the status code written when a feature is disabled.
+	DefaultDisabledStatus = http.StatusForbidden
+	// DefaultErrorStatus is the status code written when the gate fails to resolve.
+	DefaultErrorStatus = http.StatusServiceUnavailable
+)
+
+// WithStatusCode sets the status code written by Middleware when the feature is disabled.
+func WithStatusCode(code int) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.statusCode = code
+	}
+}
+
+// WithErrorStatusCode sets the status code written by Middleware when the gate returns an error.
+func WithErrorStatusCode(code int) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.errorStatusCode = code
+	}
+}
+
+// WithResponseBody sets the response body written by Middleware when the feature is disabled.
+// An empty content type defaults to text/plain.
+func WithResponseBody(contentType string, body []byte) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.contentType = strings.TrimSpace(contentType)
+		c.body = append([]byte(nil), body...)
+	}
+}
+
+// WithRouteKeys maps request path prefixes to feature keys.
+// The longest matching prefix wins; unmatched requests use the Middleware key.
+func WithRouteKeys(routes map[string]string) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		if c.routeKeys == nil {
+			c.routeKeys = make(map[string]string, len(routes))
+		}
+		for prefix, key := range routes {
+			c.routeKeys[prefix] = key
+		}
+	}
+}
+
+// WithKeyFunc derives the feature key from the request.
+// An empty result falls back to route keys and then the Middleware key.
+func WithKeyFunc(fn func(*http.Request) string) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.keyFunc = fn
+	}
+}
+
+// Middleware returns an HTTP middleware that short-circuits requests when the feature is disabled.
+// Disabled features write the configured status code and body (403 by default); gate errors write
+// the error status code (503 by default). Features waiting on a schedule also set Retry-After to
+// the activation time. Requests resolving to an empty key pass through.
+// If a gate is nil, requests pass through. The request context gains a gate.EvaluationMemo
+// so downstream handlers see the value the guard checked.
+func Middleware(fg gate.FeatureGate, key string, opts ...Option) func(http.Handler) http.Handler {
+	cfg := newConfig(opts...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fg == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			routeKey := cfg.keyFor(r, key)
+			if routeKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r = r.WithContext(WithRequestCache(r.Context()))
+			result, err := check(r.Context(), fg, routeKey, cfg)
+			if err != nil {
+				writeStatus(w, cfg.errorStatus(), nil, "")
+				return
+			}
+			if !result.enabled {
+				if !result.activatesAt.IsZero() {
+					w.Header().Set("Retry-After", result.activatesAt.UTC().Format(http.TimeFormat))
+				}
+				writeStatus(w, cfg.disabledStatus(), cfg.body, cfg.contentType)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Memoize attaches a gate.EvaluationMemo to each request so every check of a key
+// during the request (guards, template helpers, handlers) returns the same value,
+// and a scope.ChainCache so the scope chain is derived once per set of claims.
+func Memoize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithRequestCache(r.Context())))
+	})
+}
+
+// FreezeOnFirstRead pins the first resolution of each key and scope chain for the rest
+// of the request, even across cache invalidation. See gate.FreezeOnFirstRead.
+func FreezeOnFirstRead(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(gate.FreezeOnFirstRead(r.Context())))
+	})
+}
+
+func (c *config) keyFor(r *http.Request, fallback string) string {
+	if c.keyFunc != nil {
+		if key := strings.TrimSpace(c.keyFunc(r)); key != "" {
+			return key
+		}
+	}
+	if len(c.routeKeys) > 0 && r.URL != nil {
+		matched := ""
+		key := ""
+		for prefix, candidate := range c.routeKeys {
+			if !strings.HasPrefix(r.URL.Path, prefix) || len(prefix) < len(matched) {
+				continue
+			}
+			matched = prefix
+			key = candidate
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			return key
+		}
+	}
+	return strings.TrimSpace(fallback)
+}
+
+func (c *config) disabledStatus() int {
+	if c.statusCode > 0 {
+		return c.statusCode
+	}
+	return DefaultDisabledStatus
+}
+
+func (c *config) errorStatus() int {
+	if c.errorStatusCode > 0 {
+		return c.errorStatusCode
+	}
+	return DefaultErrorStatus
+}
+
+func writeStatus(w http.ResponseWriter, status int, body []byte, contentType string) {
+	if len(body) == 0 {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,213 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestMiddlewareBlocksDisabledFeature(t *testing.T) {
+	stub := &stubGate{
+		enabled: map[string]bool{
+			"users.signup": false,
+		},
+	}
+	handler := Middleware(stub, "users.signup",
+		WithStatusCode(http.StatusNotFound),
+		WithResponseBody("application/json", []byte(`{"error":"not found"}`)),
+	)(okHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("unexpected content type: %q", got)
+	}
+	if rec.Body.String() != `{"error":"not found"}` {
+		t.Fatalf("unexpected body: %q", rec.Body.String())
+	}
+}
+
+func TestMiddlewarePassesEnabledFeature(t *testing.T) {
+	stub := &stubGate{}
+	handler := Middleware(stub, "users.signup")(okHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestMiddlewareGateErrorStatus(t *testing.T) {
+	stub := &stubGate{err: errors.New("gate failed")}
+	handler := Middleware(stub, "users.signup")(okHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", nil))
+	if rec.Code != DefaultErrorStatus {
+		t.Fatalf("expected %d, got %d", DefaultErrorStatus, rec.Code)
+	}
+}
+
+func TestMiddlewareRouteKeys(t *testing.T) {
+	stub := &stubGate{
+		enabled: map[string]bool{
+			"api":         true,
+			"api.v2":      false,
+			"api.v2.beta": true,
+		},
+	}
+	handler := Middleware(stub, "api", WithRouteKeys(map[string]string{
+		"/v2/":      "api.v2",
+		"/v2/beta/": "api.v2.beta",
+	}))(okHandler())
+
+	cases := map[string]int{
+		"/v1/users":      http.StatusOK,
+		"/v2/users":      DefaultDisabledStatus,
+		"/v2/beta/users": http.StatusOK,
+	}
+	for path, want := range cases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Fatalf("%s: expected %d, got %d", path, want, rec.Code)
+		}
+	}
+}
+
+func TestMiddlewareSetsRetryAfterForScheduledFeature(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	startsAt := now.Add(48 * time.Hour)
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"users.signup": true})),
+		resolver.WithSchedules(resolver.StaticSchedules{"users.signup": {StartsAt: startsAt}}),
+		resolver.WithNowFunc(func() time.Time { return now }),
+	)
+	handler := Middleware(fg, "users.signup")(okHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", nil))
+	if rec.Code != DefaultDisabledStatus {
+		t.Fatalf("expected %d, got %d", DefaultDisabledStatus, rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != startsAt.Format(http.TimeFormat) {
+		t.Fatalf("unexpected Retry-After: %q", got)
+	}
+
+	var disabled DisabledError
+	if err := Require(context.Background(), fg, "users.signup"); !errors.As(err, &disabled) || !disabled.ActivatesAt.Equal(startsAt) {
+		t.Fatalf("expected activation time on disabled error, got %v", err)
+	}
+}
+
+func TestMiddlewareKeepsValueConsistentWithinRequest(t *testing.T) {
+	overrides := store.NewMemoryStore()
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"users.signup": true})),
+		resolver.WithOverrideStore(overrides),
+	)
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	handler := Middleware(fg, "users.signup")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fg.Set(r.Context(), "users.signup", system, false, gate.ActorRef{}); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if err := Require(r.Context(), fg, "users.signup"); err != nil {
+			t.Fatalf("expected memoized value within request, got %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", nil))
+	if rec.Code != DefaultDisabledStatus {
+		t.Fatalf("expected next request to see the override, got %d", rec.Code)
+	}
+}
+
+type countingStore struct {
+	*store.MemoryStore
+	reads int
+}
+
+func (s *countingStore) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]store.OverrideMatch, error) {
+	s.reads++
+	return s.MemoryStore.GetAll(ctx, key, chain)
+}
+
+func TestMemoizeAnswersRepeatedChecksPerChain(t *testing.T) {
+	overrides := &countingStore{MemoryStore: store.NewMemoryStore()}
+	fg := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"reports": true})),
+		resolver.WithOverrideStore(overrides),
+	)
+	acme := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	if err := fg.Set(context.Background(), "reports", acme, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	handler := Memoize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 5; i++ {
+			if err := Require(r.Context(), fg, "reports"); err != nil {
+				t.Fatalf("Require = %v", err)
+			}
+		}
+		if overrides.reads != 1 {
+			t.Fatalf("expected one store read for repeated checks, got %d", overrides.reads)
+		}
+		tenant := scope.WithTenantID(r.Context(), "acme")
+		if err := Require(tenant, fg, "reports"); !errors.Is(err, ErrFeatureDisabled) {
+			t.Fatalf("expected another chain to resolve on its own, got %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestMemoizeKeepsCompositeComponentsApart(t *testing.T) {
+	local := resolver.New(resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"reports": true})))
+	remote := resolver.New(resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"reports": false})))
+	anyTrue := gate.Composite(gate.CompositeAnyTrue, remote, local)
+	allTrue := gate.Composite(gate.CompositeAllTrue, local, remote)
+
+	handler := Memoize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		for i := 0; i < 2; i++ {
+			value, trace, err := anyTrue.ResolveWithTrace(ctx, "reports")
+			if err != nil || !value || len(trace.Components) != 2 || trace.Components[0].Value || !trace.Components[1].Value {
+				t.Fatalf("expected each component to answer for itself, got %v (%+v, %v)", value, trace.Components, err)
+			}
+			if err := Require(ctx, allTrue, "reports"); !errors.Is(err, ErrFeatureDisabled) {
+				t.Fatalf("expected all_true to see the disabled component, got %v", err)
+			}
+			if err := Require(ctx, local, "reports"); err != nil {
+				t.Fatalf("expected the local gate to keep its own memoized value, got %v", err)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,116 @@
+package gate
+
+import (
+	"context"
+	"math/rand/v2"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+// ResolvePredicate reports whether a resolve event should reach a hook.
+type ResolvePredicate func(event ResolveEvent) bool
+
+// FilterHook forwards only the events pred accepts to hook, so expensive sinks see
+// the events they care about. A nil pred forwards everything.
+func FilterHook(hook ResolveHook, pred ResolvePredicate) ResolveHook {
+	if hook == nil || pred == nil {
+		return hook
+	}
+	return ResolveHookFunc(func(ctx context.Context, event ResolveEvent) {
+		if pred(event) {
+			hook.OnResolve(ctx, event)
+		}
+	})
+}
+
+// SampleHook forwards a random fraction rate of events to hook. A rate <= 0 drops
+// every event and a rate >= 1 forwards every event.
+func SampleHook(hook ResolveHook, rate float64) ResolveHook {
+	if hook == nil || rate >= 1 {
+		return hook
+	}
+	return ResolveHookFunc(func(ctx context.Context, event ResolveEvent) {
+		if rate > 0 && rand.Float64() < rate {
+			hook.OnResolve(ctx, event)
+		}
+	})
+}
+
+// OnlyErrors accepts events whose resolution failed.
+func OnlyErrors() ResolvePredicate {
+	return func(event ResolveEvent) bool {
+		return event.Error != nil
+	}
+}
+
+// OnlyErrorsAtLeast accepts events whose resolution failed with an error of at
+// least min severity, so errors a severity policy downgraded stay out of alerting
+// sinks.
+func OnlyErrorsAtLeast(min ferrors.Severity) ResolvePredicate {
+	return func(event ResolveEvent) bool {
+		return event.Error != nil && ferrors.SeverityOf(event.Error) >= min
+	}
+}
+
+// OnlyCacheMisses accepts events that were not served from the cache or the
+// evaluation memo.
+func OnlyCacheMisses() ResolvePredicate {
+	return func(event ResolveEvent) bool {
+		return !event.Trace.CacheHit && !event.Trace.Memoized
+	}
+}
+
+// OnlySources accepts events resolved from one of sources.
+func OnlySources(sources ...ResolveSource) ResolvePredicate {
+	set := make(map[ResolveSource]struct{}, len(sources))
+	for _, source := range sources {
+		set[source] = struct{}{}
+	}
+	return func(event ResolveEvent) bool {
+		_, ok := set[event.Source]
+		return ok
+	}
+}
+
+// OnlyKeys accepts events for the given feature keys, compared after normalization.
+func OnlyKeys(keys ...string) ResolvePredicate {
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[NormalizeKey(key)] = struct{}{}
+	}
+	return func(event ResolveEvent) bool {
+		_, ok := set[event.NormalizedKey]
+		return ok
+	}
+}
+
+// AnyOf accepts events accepted by at least one predicate.
+func AnyOf(preds ...ResolvePredicate) ResolvePredicate {
+	return func(event ResolveEvent) bool {
+		for _, pred := range preds {
+			if pred != nil && pred(event) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllOf accepts events accepted by every predicate.
+func AllOf(preds ...ResolvePredicate) ResolvePredicate {
+	return func(event ResolveEvent) bool {
+		for _, pred := range preds {
+			if pred != nil && !pred(event) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Not inverts pred.
+func Not(pred ResolvePredicate) ResolvePredicate {
+	return func(event ResolveEvent) bool {
+		return pred == nil || !pred(event)
+	}
+}
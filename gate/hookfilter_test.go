@@ -0,0 +1,80 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+func TestFilterHookAppliesPredicates(t *testing.T) {
+	var seen []string
+	sink := ResolveHookFunc(func(_ context.Context, event ResolveEvent) {
+		seen = append(seen, event.NormalizedKey)
+	})
+	events := []ResolveEvent{
+		{NormalizedKey: "a", Source: ResolveSourceOverride},
+		{NormalizedKey: "b", Source: ResolveSourceDefault, Trace: ResolveTrace{CacheHit: true}},
+		{NormalizedKey: "c", Source: ResolveSourceFallback, Error: errors.New("store down")},
+	}
+	cases := map[string]struct {
+		pred ResolvePredicate
+		want string
+	}{
+		"errors":  {OnlyErrors(), "c"},
+		"misses":  {OnlyCacheMisses(), "ac"},
+		"sources": {OnlySources(ResolveSourceDefault, ResolveSourceFallback), "bc"},
+		"keys":    {OnlyKeys(" a ", "b"), "ab"},
+		"any":     {AnyOf(OnlyErrors(), OnlyKeys("a")), "ac"},
+		"all":     {AllOf(OnlyCacheMisses(), Not(OnlyErrors())), "a"},
+		"nil":     {nil, "abc"},
+	}
+	for name, tc := range cases {
+		seen = nil
+		hook := FilterHook(sink, tc.pred)
+		for _, event := range events {
+			hook.OnResolve(context.Background(), event)
+		}
+		got := ""
+		for _, key := range seen {
+			got += key
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", name, tc.want, got)
+		}
+	}
+}
+
+func TestSampleHookForwardsFraction(t *testing.T) {
+	count := 0
+	sink := ResolveHookFunc(func(context.Context, ResolveEvent) { count++ })
+	run := func(rate float64, n int) int {
+		count = 0
+		hook := SampleHook(sink, rate)
+		for i := 0; i < n; i++ {
+			hook.OnResolve(context.Background(), ResolveEvent{})
+		}
+		return count
+	}
+	if got := run(0, 100); got != 0 {
+		t.Fatalf("expected rate 0 to drop everything, got %d", got)
+	}
+	if got := run(1, 100); got != 100 {
+		t.Fatalf("expected rate 1 to forward everything, got %d", got)
+	}
+	if got := run(0.5, 4000); got < 1600 || got > 2400 {
+		t.Fatalf("expected roughly half of the events, got %d", got)
+	}
+}
+
+func TestOnlyErrorsAtLeastSkipsDowngradedErrors(t *testing.T) {
+	pred := OnlyErrorsAtLeast(ferrors.SeverityError)
+	downgraded := ferrors.NewExternal(ferrors.TextCodeStoreReadFailed, "store down", nil).WithSeverity(ferrors.SeverityWarning)
+	if pred(ResolveEvent{}) || pred(ResolveEvent{Error: downgraded}) {
+		t.Fatalf("expected successes and downgraded errors to be skipped")
+	}
+	if !pred(ResolveEvent{Error: errors.New("store down")}) {
+		t.Fatalf("expected plain errors to count as errors")
+	}
+}
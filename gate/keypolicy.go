@@ -0,0 +1,104 @@
+package gate
+
+import (
+	"strings"
+	"unicode"
+
+	goerrors "github.com/goliatone/go-errors"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+// Key policy rule names, reported as the field of each validation error.
+const (
+	KeyRuleRequired = "required"
+	KeyRuleChars    = "chars"
+	KeyRuleSegment  = "segment"
+	KeyRuleDepth    = "depth"
+	KeyRulePrefix   = "prefix"
+)
+
+// KeyPolicy describes the shape feature keys must have. The zero value keeps
+// NormalizeKey behavior and accepts every non-empty key.
+type KeyPolicy struct {
+	// FoldCase lowercases keys after alias resolution, so "Billing.V2" and
+	// "billing.v2" address the same flag.
+	FoldCase bool `json:"fold_case,omitempty"`
+	// AllowedChars lists the characters keys may contain besides letters and
+	// digits, for example "._-". Empty allows any character.
+	AllowedChars string `json:"allowed_chars,omitempty"`
+	// MaxDepth caps the number of dot-separated segments and rejects empty
+	// segments. Zero means unlimited.
+	MaxDepth int `json:"max_depth,omitempty"`
+	// RequiredPrefix must start every key, for example "billing.". It is folded
+	// along with keys when FoldCase is set.
+	RequiredPrefix string `json:"required_prefix,omitempty"`
+}
+
+// IsZero reports whether the policy has no rules.
+func (p KeyPolicy) IsZero() bool {
+	return p == KeyPolicy{}
+}
+
+// Normalize applies NormalizeKey and, when enabled, case folding.
+func (p KeyPolicy) Normalize(key string) string {
+	key = NormalizeKey(key)
+	if p.FoldCase {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// Check normalizes key and validates it against every rule. The error lists one
+// validation entry per failed rule, keyed by the KeyRule* names.
+func (p KeyPolicy) Check(key string) (string, error) {
+	normalized := p.Normalize(key)
+	violations := p.violations(normalized)
+	if len(violations) == 0 {
+		return normalized, nil
+	}
+	err := ferrors.NewBadInput(ferrors.TextCodeKeyInvalid, "feature key violates key policy", map[string]any{
+		ferrors.MetaFeatureKey:           strings.TrimSpace(key),
+		ferrors.MetaFeatureKeyNormalized: normalized,
+	})
+	err.ValidationErrors = violations
+	return normalized, err
+}
+
+func (p KeyPolicy) violations(key string) goerrors.ValidationErrors {
+	if key == "" {
+		return goerrors.ValidationErrors{{Field: KeyRuleRequired, Message: "key is empty"}}
+	}
+	var out goerrors.ValidationErrors
+	if p.AllowedChars != "" {
+		for _, r := range key {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(p.AllowedChars, r) {
+				out = append(out, goerrors.FieldError{Field: KeyRuleChars, Message: "character " + string(r) + " is not allowed", Value: key})
+				break
+			}
+		}
+	}
+	if p.MaxDepth > 0 {
+		segments := strings.Split(key, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				out = append(out, goerrors.FieldError{Field: KeyRuleSegment, Message: "key has an empty segment", Value: key})
+				break
+			}
+		}
+		if len(segments) > p.MaxDepth {
+			out = append(out, goerrors.FieldError{Field: KeyRuleDepth, Message: "key is deeper than the allowed depth", Value: len(segments)})
+		}
+	}
+	if prefix := p.prefix(); prefix != "" && !strings.HasPrefix(key, prefix) {
+		out = append(out, goerrors.FieldError{Field: KeyRulePrefix, Message: "key must start with " + prefix, Value: key})
+	}
+	return out
+}
+
+func (p KeyPolicy) prefix() string {
+	if p.FoldCase {
+		return strings.ToLower(p.RequiredPrefix)
+	}
+	return p.RequiredPrefix
+}
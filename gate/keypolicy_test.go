@@ -0,0 +1,32 @@
+package gate
+
+import (
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+func TestKeyPolicyReportsEachFailedRule(t *testing.T) {
+	policy := KeyPolicy{FoldCase: true, AllowedChars: "._", MaxDepth: 2, RequiredPrefix: "Billing."}
+
+	if key, err := policy.Check(" Billing.V2 "); err != nil || key != "billing.v2" {
+		t.Fatalf("expected folded valid key, got %q (%v)", key, err)
+	}
+
+	_, err := policy.Check("search.v2.beta-1")
+	rich, ok := ferrors.As(err)
+	if !ok || rich.TextCode != ferrors.TextCodeKeyInvalid {
+		t.Fatalf("expected key invalid error, got %v", err)
+	}
+	rules := map[string]bool{}
+	for _, violation := range rich.ValidationErrors {
+		rules[violation.Field] = true
+	}
+	if len(rules) != 3 || !rules[KeyRuleChars] || !rules[KeyRuleDepth] || !rules[KeyRulePrefix] {
+		t.Fatalf("expected chars, depth, and prefix violations, got %+v", rich.ValidationErrors)
+	}
+
+	if _, err := (KeyPolicy{}).Check("Any Key..Goes"); err != nil {
+		t.Fatalf("expected zero policy to accept any non-empty key, got %v", err)
+	}
+}
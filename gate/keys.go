@@ -3,6 +3,7 @@ package gate
 import (
 	"sort"
 	"strings"
+	"sync"
 )
 
 const (
@@ -12,14 +13,57 @@ const (
 	FeatureUsersPasswordResetFinalize = "users.password_reset.finalize"
 )
 
-var keyAliases = map[string]string{} // Legacy aliases are intentionally disabled.
+var (
+	aliasMu    sync.RWMutex
+	keyAliases = map[string]string{} // Empty until RegisterAliases is called.
+)
+
+// RegisterAliases maps legacy keys to canonical ones, so renamed flags keep
+// resolving, and overrides stored under the old name keep applying, during a
+// migration window. Aliases are process-wide: register them at startup. A later
+// registration replaces an earlier one for the same legacy key; empty keys and
+// self-mappings are ignored.
+func RegisterAliases(aliases map[string]string) {
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+	for legacy, canonical := range aliases {
+		legacy = strings.TrimSpace(legacy)
+		canonical = strings.TrimSpace(canonical)
+		if legacy == "" || canonical == "" || legacy == canonical {
+			continue
+		}
+		keyAliases[legacy] = canonical
+	}
+}
+
+// UnregisterAliases removes legacy keys, ending their migration window.
+func UnregisterAliases(legacy ...string) {
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+	for _, key := range legacy {
+		delete(keyAliases, strings.TrimSpace(key))
+	}
+}
+
+// Aliases returns a copy of the registered legacy-to-canonical key map.
+func Aliases() map[string]string {
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
+	out := make(map[string]string, len(keyAliases))
+	for legacy, canonical := range keyAliases {
+		out[legacy] = canonical
+	}
+	return out
+}
 
-// NormalizeKey trims whitespace and resolves any known aliases (if configured).
+// NormalizeKey trims whitespace and resolves any registered aliases.
 func NormalizeKey(key string) string {
 	key = strings.TrimSpace(key)
 	if key == "" {
 		return ""
 	}
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
 	if alias, ok := keyAliases[key]; ok {
 		return alias
 	}
@@ -37,6 +81,8 @@ func ResolveAlias(key string) (string, bool) {
 
 // IsAlias reports whether the key is a known alias.
 func IsAlias(key string) bool {
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
 	_, ok := keyAliases[strings.TrimSpace(key)]
 	return ok
 }
@@ -47,12 +93,14 @@ func AliasesFor(key string) []string {
 	if normalized == "" {
 		return nil
 	}
-	aliases := make([]string, 0, len(keyAliases))
+	aliasMu.RLock()
+	var aliases []string
 	for alias, canonical := range keyAliases {
 		if canonical == normalized {
 			aliases = append(aliases, alias)
 		}
 	}
+	aliasMu.RUnlock()
 	if len(aliases) == 0 {
 		return nil
 	}
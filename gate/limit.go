@@ -0,0 +1,74 @@
+package gate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LimitFeatureGate is implemented by gates that serve numeric limits, such as
+// "projects.max", through the same scope chain as boolean flags.
+type LimitFeatureGate interface {
+	Limit(ctx context.Context, key string, opts ...ResolveOption) (int64, error)
+}
+
+// LimitTrace captures provenance for a single limit resolution. Source is
+// ResolveSourceOverride when the override at Match won, ResolveSourceDefault when
+// a limit default applied, and ResolveSourceFallback when neither did.
+type LimitTrace struct {
+	Key           string
+	NormalizedKey string
+	Chain         ScopeChain
+	Value         int64
+	Source        ResolveSource
+	Match         ScopeRef
+	Matches       []LimitMatchTrace
+	OverrideError error
+	DefaultSet    bool
+	DefaultError  error
+	// Plan is the subscription plan an entitlement provider derived the default from.
+	Plan string
+}
+
+// LimitMatchTrace records a limit override found in the chain.
+type LimitMatchTrace struct {
+	Scope ScopeRef
+	Value int64
+}
+
+// ExplainLimit renders a limit trace as a short human-readable summary.
+func ExplainLimit(t LimitTrace) string {
+	var b strings.Builder
+	key := t.NormalizedKey
+	if key == "" {
+		key = t.Key
+	}
+	fmt.Fprintf(&b, "%s = %d (source: %s)\n", key, t.Value, t.Source)
+	if len(t.Chain) > 0 {
+		scopes := make([]string, 0, len(t.Chain))
+		for _, ref := range t.Chain {
+			scopes = append(scopes, scopeLabel(ref))
+		}
+		fmt.Fprintf(&b, "  chain: %s\n", strings.Join(scopes, " > "))
+	}
+	override := string(OverrideStateMissing)
+	if t.Source == ResolveSourceOverride {
+		override = "at " + scopeLabel(t.Match)
+	}
+	if t.OverrideError != nil {
+		override += " (error: " + t.OverrideError.Error() + ")"
+	}
+	fmt.Fprintf(&b, "  override: %s\n", override)
+	def := "unset"
+	if t.DefaultSet {
+		def = "set"
+	}
+	if t.Plan != "" {
+		def += " (plan: " + t.Plan + ")"
+	}
+	if t.DefaultError != nil {
+		def += " (error: " + t.DefaultError.Error() + ")"
+	}
+	fmt.Fprintf(&b, "  default: %s\n", def)
+	return b.String()
+}
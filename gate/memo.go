@@ -0,0 +1,114 @@
+package gate
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+type evaluationMemoKey struct{}
+
+// EvaluationMemo records resolutions made while serving one request. Gates that
+// honor it return the recorded value for a repeated key and scope chain, so guards,
+// template helpers, and handlers agree even if an override changes mid-request.
+// Entries are keyed by the gate that recorded them as well, so gates that disagree
+// on a key, such as the components of a Composite, never answer for each other.
+type EvaluationMemo struct {
+	mu      sync.Mutex
+	entries map[memoEntryKey]ResolveTrace
+	order   []memoEntryKey
+}
+
+type memoEntryKey struct {
+	owner any
+	id    string
+}
+
+// WithEvaluationMemo attaches an empty memo to ctx unless one is already present.
+func WithEvaluationMemo(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if EvaluationMemoFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, evaluationMemoKey{}, &EvaluationMemo{})
+}
+
+// FreezeOnFirstRead attaches a memo to ctx unless one is already present, for
+// background transactions that want request-level consistency. The first resolution
+// of a key for a scope chain pins its value for the rest of the transaction, even
+// across override writes and cache invalidation; other chains resolve on their own.
+func FreezeOnFirstRead(ctx context.Context) context.Context {
+	return WithEvaluationMemo(ctx)
+}
+
+// EvaluationMemoFromContext returns the memo attached to ctx, if any.
+func EvaluationMemoFromContext(ctx context.Context) *EvaluationMemo {
+	if ctx == nil {
+		return nil
+	}
+	memo, _ := ctx.Value(evaluationMemoKey{}).(*EvaluationMemo)
+	return memo
+}
+
+// Lookup returns the trace owner recorded for key and chain. key must already be
+// normalized the way the owner normalizes keys, as trace.NormalizedKey is.
+func (m *EvaluationMemo) Lookup(owner any, key string, chain ScopeChain) (ResolveTrace, bool) {
+	if m == nil {
+		return ResolveTrace{}, false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	trace, ok := m.entries[memoEntryKey{owner: owner, id: memoKey(key, chain)}]
+	return trace, ok
+}
+
+// Record stores a trace owner resolved under its normalized key and chain. The
+// first record wins.
+func (m *EvaluationMemo) Record(owner any, trace ResolveTrace) {
+	if m == nil || trace.NormalizedKey == "" {
+		return
+	}
+	id := memoEntryKey{owner: owner, id: memoKey(trace.NormalizedKey, trace.Chain)}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = map[memoEntryKey]ResolveTrace{}
+	}
+	if _, ok := m.entries[id]; ok {
+		return
+	}
+	m.entries[id] = trace
+	m.order = append(m.order, id)
+}
+
+// Evaluations returns the recorded traces in evaluation order.
+func (m *EvaluationMemo) Evaluations() []ResolveTrace {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]ResolveTrace, 0, len(m.order))
+	for _, id := range m.order {
+		out = append(out, m.entries[id])
+	}
+	return out
+}
+
+func memoKey(key string, chain ScopeChain) string {
+	var b strings.Builder
+	b.WriteString(key)
+	for _, ref := range chain {
+		b.WriteByte('|')
+		b.WriteString(ref.Kind.String())
+		b.WriteByte(':')
+		b.WriteString(ref.ID)
+		b.WriteByte(':')
+		b.WriteString(ref.TenantID)
+		b.WriteByte(':')
+		b.WriteString(ref.OrgID)
+	}
+	return b.String()
+}
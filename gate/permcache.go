@@ -0,0 +1,152 @@
+package gate
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+)
+
+// PermissionCacheOption customizes a PermissionCache.
+type PermissionCacheOption func(*PermissionCache)
+
+// WithPermissionCacheClock sets the clock used to expire entries.
+func WithPermissionCacheClock(c clock.Clock) PermissionCacheOption {
+	return func(p *PermissionCache) {
+		if p == nil || c == nil {
+			return
+		}
+		p.now = clock.NowFunc(c)
+	}
+}
+
+// PermissionCache memoizes an inner PermissionProvider per subject for a TTL, so an
+// external RBAC lookup runs once per subject instead of on every resolution. Entries
+// are keyed by subject, tenant, org, and roles, so a subject acting in another tenant
+// or with different roles is looked up again. Errors are not cached.
+type PermissionCache struct {
+	inner PermissionProvider
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]permissionEntry
+	nextPrune time.Time
+}
+
+type permissionEntry struct {
+	subject   string
+	perms     []string
+	expiresAt time.Time
+}
+
+// CachedPermissionProvider wraps inner with a PermissionCache. A ttl <= 0 disables
+// caching and every call reaches inner.
+func CachedPermissionProvider(inner PermissionProvider, ttl time.Duration, opts ...PermissionCacheOption) *PermissionCache {
+	p := &PermissionCache{
+		inner:   inner,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]permissionEntry{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+	return p
+}
+
+// Permissions implements PermissionProvider.
+func (p *PermissionCache) Permissions(ctx context.Context, claims ActorClaims) ([]string, error) {
+	if p == nil || p.inner == nil {
+		return nil, nil
+	}
+	if p.ttl <= 0 {
+		return p.inner.Permissions(ctx, claims)
+	}
+	key := permissionCacheKey(claims)
+	now := p.now()
+	p.mu.Lock()
+	entry, ok := p.entries[key]
+	p.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return append([]string(nil), entry.perms...), nil
+	}
+
+	perms, err := p.inner.Permissions(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.prune(now)
+	p.entries[key] = permissionEntry{
+		subject:   strings.TrimSpace(claims.SubjectID),
+		perms:     append([]string(nil), perms...),
+		expiresAt: now.Add(p.ttl),
+	}
+	p.mu.Unlock()
+	return append([]string(nil), perms...), nil
+}
+
+// Invalidate drops every cached entry for subject, e.g. after a role change.
+func (p *PermissionCache) Invalidate(subject string) {
+	if p == nil {
+		return
+	}
+	subject = strings.TrimSpace(subject)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, entry := range p.entries {
+		if entry.subject == subject {
+			delete(p.entries, key)
+		}
+	}
+}
+
+// Purge drops every cached entry.
+func (p *PermissionCache) Purge() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.entries = map[string]permissionEntry{}
+	p.mu.Unlock()
+}
+
+// Len reports the number of cached entries, including expired ones not yet pruned.
+func (p *PermissionCache) Len() int {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.entries)
+}
+
+// prune drops expired entries at most once per TTL; callers hold p.mu.
+func (p *PermissionCache) prune(now time.Time) {
+	if now.Before(p.nextPrune) {
+		return
+	}
+	p.nextPrune = now.Add(p.ttl)
+	for key, entry := range p.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(p.entries, key)
+		}
+	}
+}
+
+func permissionCacheKey(claims ActorClaims) string {
+	parts := []string{
+		strings.TrimSpace(claims.SubjectID),
+		strings.TrimSpace(claims.TenantID),
+		strings.TrimSpace(claims.OrgID),
+		strings.Join(claims.Roles, ","),
+	}
+	return strings.Join(parts, "\x00")
+}
+
+var _ PermissionProvider = (*PermissionCache)(nil)
@@ -0,0 +1,64 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+)
+
+type countingPermissions struct {
+	calls int
+	err   error
+}
+
+func (c *countingPermissions) Permissions(_ context.Context, claims ActorClaims) ([]string, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []string{"perm:" + claims.SubjectID}, nil
+}
+
+func TestCachedPermissionProviderMemoizesPerSubject(t *testing.T) {
+	ctx := context.Background()
+	manual := clock.NewManual(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	inner := &countingPermissions{}
+	cache := CachedPermissionProvider(inner, time.Minute, WithPermissionCacheClock(manual))
+	alice := ActorClaims{SubjectID: "alice", TenantID: "acme"}
+
+	for i := 0; i < 3; i++ {
+		perms, err := cache.Permissions(ctx, alice)
+		if err != nil || len(perms) != 1 || perms[0] != "perm:alice" {
+			t.Fatalf("unexpected permissions: %v (%v)", perms, err)
+		}
+		perms[0] = "mutated"
+	}
+	if inner.calls != 1 {
+		t.Fatalf("expected one inner call, got %d", inner.calls)
+	}
+	if _, err := cache.Permissions(ctx, ActorClaims{SubjectID: "alice", TenantID: "other"}); err != nil || inner.calls != 2 {
+		t.Fatalf("expected another tenant to miss, calls=%d err=%v", inner.calls, err)
+	}
+
+	manual.Advance(time.Minute)
+	if _, err := cache.Permissions(ctx, alice); err != nil || inner.calls != 3 {
+		t.Fatalf("expected expiry to refetch, calls=%d err=%v", inner.calls, err)
+	}
+	cache.Invalidate("alice")
+	if cache.Len() != 0 {
+		t.Fatalf("expected invalidate to drop alice entries, got %d", cache.Len())
+	}
+
+	failing := CachedPermissionProvider(&countingPermissions{err: errors.New("rbac down")}, time.Minute)
+	for i := 0; i < 2; i++ {
+		if _, err := failing.Permissions(ctx, alice); err == nil {
+			t.Fatalf("expected inner error")
+		}
+	}
+	if failing.Len() != 0 {
+		t.Fatalf("expected errors not to be cached")
+	}
+}
@@ -0,0 +1,123 @@
+package gate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// TraceRedactor rewrites a trace before it leaves the gate through hooks, caches,
+// or explanations, so identifiers in scope refs can be hashed or dropped. Cached
+// traces are redacted again when they are served, so redactors must be idempotent.
+type TraceRedactor func(ResolveTrace) ResolveTrace
+
+// HashedIDPrefix marks scope IDs replaced by HashScopeIDs.
+const HashedIDPrefix = "hmac:"
+
+// RedactScopes returns a redactor that applies fn to every scope ref in a trace:
+// the chain, override matches and lookups, inherited scopes, and component traces.
+func RedactScopes(fn func(ScopeRef) ScopeRef) TraceRedactor {
+	var redact TraceRedactor
+	redact = func(t ResolveTrace) ResolveTrace {
+		if fn == nil {
+			return t
+		}
+		t.Chain = redactChain(t.Chain, fn)
+		t.Override.Match = fn(t.Override.Match)
+		t.Override.Matches = redactMatches(t.Override.Matches, fn)
+		t.Override.Expired = redactMatches(t.Override.Expired, fn)
+		if len(t.Override.Scopes) > 0 {
+			scopes := make([]ScopeLookupTrace, len(t.Override.Scopes))
+			for i, lookup := range t.Override.Scopes {
+				lookup.Scope = fn(lookup.Scope)
+				scopes[i] = lookup
+			}
+			t.Override.Scopes = scopes
+		}
+		if len(t.Inherited) > 0 {
+			inherited := make([]InheritedScope, len(t.Inherited))
+			for i, scope := range t.Inherited {
+				inherited[i] = InheritedScope{Scope: fn(scope.Scope), From: fn(scope.From)}
+			}
+			t.Inherited = inherited
+		}
+		if len(t.Components) > 0 {
+			components := make([]ComponentTrace, len(t.Components))
+			for i, component := range t.Components {
+				component.Trace = redact(component.Trace)
+				components[i] = component
+			}
+			t.Components = components
+		}
+		return t
+	}
+	return redact
+}
+
+// HashScopeIDs replaces the IDs of scopes of the given kinds, user scopes when none
+// are given, with a keyed SHA-256 digest prefixed by HashedIDPrefix. Equal IDs hash
+// equally, so hashed traces still group by user; IDs already hashed are kept.
+func HashScopeIDs(key []byte, kinds ...ScopeKind) TraceRedactor {
+	kinds = redactedKinds(kinds)
+	return RedactScopes(func(ref ScopeRef) ScopeRef {
+		if ref.ID == "" || !containsKind(kinds, ref.Kind) || strings.HasPrefix(ref.ID, HashedIDPrefix) {
+			return ref
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(ref.ID))
+		ref.ID = HashedIDPrefix + hex.EncodeToString(mac.Sum(nil))[:16]
+		return ref
+	})
+}
+
+// DropScopeIDs clears the IDs of scopes of the given kinds, user scopes when none
+// are given.
+func DropScopeIDs(kinds ...ScopeKind) TraceRedactor {
+	kinds = redactedKinds(kinds)
+	return RedactScopes(func(ref ScopeRef) ScopeRef {
+		if containsKind(kinds, ref.Kind) {
+			ref.ID = ""
+		}
+		return ref
+	})
+}
+
+func redactChain(chain ScopeChain, fn func(ScopeRef) ScopeRef) ScopeChain {
+	if len(chain) == 0 {
+		return chain
+	}
+	out := make(ScopeChain, len(chain))
+	for i, ref := range chain {
+		out[i] = fn(ref)
+	}
+	return out
+}
+
+func redactMatches(matches []OverrideMatchTrace, fn func(ScopeRef) ScopeRef) []OverrideMatchTrace {
+	if len(matches) == 0 {
+		return matches
+	}
+	out := make([]OverrideMatchTrace, len(matches))
+	for i, match := range matches {
+		match.Scope = fn(match.Scope)
+		out[i] = match
+	}
+	return out
+}
+
+func redactedKinds(kinds []ScopeKind) []ScopeKind {
+	if len(kinds) == 0 {
+		return []ScopeKind{ScopeUser}
+	}
+	return kinds
+}
+
+func containsKind(kinds []ScopeKind, kind ScopeKind) bool {
+	for _, candidate := range kinds {
+		if candidate == kind {
+			return true
+		}
+	}
+	return false
+}
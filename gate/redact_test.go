@@ -0,0 +1,39 @@
+package gate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashScopeIDsIsIdempotent(t *testing.T) {
+	user := ScopeRef{Kind: ScopeUser, ID: "jane@example.com", TenantID: "acme"}
+	tenant := ScopeRef{Kind: ScopeTenant, ID: "acme", TenantID: "acme"}
+	trace := ResolveTrace{
+		Chain:    ScopeChain{user, tenant},
+		Override: OverrideTrace{Match: user, Scopes: []ScopeLookupTrace{{Scope: user, Outcome: ScopeOutcomeOverridden}}},
+		Components: []ComponentTrace{
+			{Trace: ResolveTrace{Chain: ScopeChain{user}}},
+		},
+	}
+	redact := HashScopeIDs([]byte("secret"))
+	once := redact(trace)
+	hashed := once.Chain[0].ID
+	if !strings.HasPrefix(hashed, HashedIDPrefix) || strings.Contains(hashed, "jane") {
+		t.Fatalf("expected a hashed user ID, got %q", hashed)
+	}
+	if once.Chain[1].ID != "acme" || once.Override.Match.ID != hashed || once.Override.Scopes[0].Scope.ID != hashed {
+		t.Fatalf("expected every user ref hashed and tenants kept, got %+v", once)
+	}
+	if once.Components[0].Trace.Chain[0].ID != hashed {
+		t.Fatalf("expected component traces redacted, got %+v", once.Components[0].Trace.Chain)
+	}
+	if trace.Chain[0].ID != "jane@example.com" {
+		t.Fatalf("expected the original trace untouched, got %q", trace.Chain[0].ID)
+	}
+	if twice := redact(once); twice.Chain[0].ID != hashed {
+		t.Fatalf("expected hashing to be idempotent, got %q", twice.Chain[0].ID)
+	}
+	if dropped := DropScopeIDs()(trace); dropped.Chain[0].ID != "" || dropped.Chain[1].ID != "acme" {
+		t.Fatalf("expected only user IDs dropped, got %+v", dropped.Chain)
+	}
+}
@@ -0,0 +1,52 @@
+package gate
+
+import "context"
+
+type requestOverridesKey struct{}
+
+// WithRequestOverrides pins feature values for the lifetime of ctx.
+// Pinned values take precedence over overrides and defaults and are traced
+// with ResolveSourceRequestOverride. Keys are normalized; later calls merge
+// with and replace earlier pins.
+func WithRequestOverrides(ctx context.Context, values map[string]bool) context.Context {
+	if ctx == nil || len(values) == 0 {
+		return ctx
+	}
+	existing, _ := ctx.Value(requestOverridesKey{}).(map[string]bool)
+	merged := make(map[string]bool, len(existing)+len(values))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range values {
+		normalized := NormalizeKey(key)
+		if normalized == "" {
+			continue
+		}
+		merged[normalized] = value
+	}
+	return context.WithValue(ctx, requestOverridesKey{}, merged)
+}
+
+// RequestOverride returns the pinned value for a key, if any.
+func RequestOverride(ctx context.Context, key string) (bool, bool) {
+	if ctx == nil {
+		return false, false
+	}
+	values, _ := ctx.Value(requestOverridesKey{}).(map[string]bool)
+	if len(values) == 0 {
+		return false, false
+	}
+	value, ok := values[NormalizeKey(key)]
+	return value, ok
+}
+
+// DetachRequest returns ctx without request overrides or an evaluation memo, so a
+// resolution made on behalf of someone other than the caller neither sees the
+// caller's pinned values nor lands in the caller's memo.
+func DetachRequest(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	ctx = context.WithValue(ctx, requestOverridesKey{}, map[string]bool(nil))
+	return context.WithValue(ctx, evaluationMemoKey{}, (*EvaluationMemo)(nil))
+}
@@ -0,0 +1,43 @@
+package gate
+
+import "time"
+
+// Schedule is an activation window for a feature. A zero StartsAt or EndsAt leaves
+// that side of the window open.
+type Schedule struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// IsZero reports whether the schedule has no bounds.
+func (s Schedule) IsZero() bool {
+	return s.StartsAt.IsZero() && s.EndsAt.IsZero()
+}
+
+// Active reports whether now falls inside the window.
+func (s Schedule) Active(now time.Time) bool {
+	return !s.Pending(now) && (s.EndsAt.IsZero() || now.Before(s.EndsAt))
+}
+
+// Pending reports whether the window has not started yet.
+func (s Schedule) Pending(now time.Time) bool {
+	return !s.StartsAt.IsZero() && now.Before(s.StartsAt)
+}
+
+// ScheduleTrace captures schedule evaluation details.
+type ScheduleTrace struct {
+	Set      bool
+	Schedule Schedule
+	Active   bool
+	Pending  bool
+	Error    error
+}
+
+// ActivatesAt returns the schedule start when the feature resolved disabled only
+// because its window has not started.
+func (t ResolveTrace) ActivatesAt() (time.Time, bool) {
+	if t.Value || !t.Schedule.Set || !t.Schedule.Pending {
+		return time.Time{}, false
+	}
+	return t.Schedule.Schedule.StartsAt, true
+}
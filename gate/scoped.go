@@ -0,0 +1,198 @@
+package gate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+)
+
+// ScopedGate confines a gate to one tenant. Resolves ignore the tenant carried by
+// the context and mutations only reach scopes inside the tenant, so an admin
+// backend serving many tenants cannot read or write across them by accident.
+type ScopedGate struct {
+	inner    FeatureGate
+	tenantID string
+}
+
+// ScopedTo returns a view of fg restricted to tenantID.
+//
+// Resolves derive the chain from WithClaims with the tenant forced, or from the
+// tenant alone when no claims are passed; user, org, and role scopes therefore come
+// only from explicit claims. A WithScopeChain or WithClaims option naming another
+// tenant is rejected; tenant, org, and user refs of a WithScopeChain chain are
+// qualified with the tenant. Set and Unset accept tenant scopes for tenantID and
+// org, user, role, or perm scopes whose TenantID is tenantID or empty, and qualify
+// them as gate.QualifiedRef does, so they match chains built from claims. User,
+// role, and perm scopes keep their OrgID: set it to the org the actor's claims carry.
+// System and env scopes are rejected. An empty tenantID rejects every call.
+func ScopedTo(fg FeatureGate, tenantID string) *ScopedGate {
+	return &ScopedGate{inner: fg, tenantID: strings.TrimSpace(tenantID)}
+}
+
+// TenantID returns the tenant the view is restricted to.
+func (s *ScopedGate) TenantID() string {
+	return s.tenantID
+}
+
+// Enabled resolves key inside the tenant.
+func (s *ScopedGate) Enabled(ctx context.Context, key string, opts ...ResolveOption) (bool, error) {
+	value, _, err := s.ResolveWithTrace(ctx, key, opts...)
+	return value, err
+}
+
+// ResolveWithTrace resolves key inside the tenant with a trace. Gates that are not
+// traceable get a trace carrying only the key and value.
+func (s *ScopedGate) ResolveWithTrace(ctx context.Context, key string, opts ...ResolveOption) (bool, ResolveTrace, error) {
+	if s.inner == nil {
+		return false, ResolveTrace{}, errGateRequired(key)
+	}
+	opts, err := s.scopeResolve(key, opts)
+	if err != nil {
+		return false, ResolveTrace{}, err
+	}
+	component := resolveComponent(ctx, s.inner, 0, key, opts)
+	return component.Value, component.Trace, component.Error
+}
+
+// Set writes an override when scope lies inside the tenant.
+func (s *ScopedGate) Set(ctx context.Context, key string, scope ScopeRef, enabled bool, actor ActorRef, opts ...MutationOption) error {
+	mutable, err := s.mutable(key, "set")
+	if err != nil {
+		return err
+	}
+	if scope, err = s.scopeRef(key, scope, "set"); err != nil {
+		return err
+	}
+	return mutable.Set(ctx, key, scope, enabled, actor, opts...)
+}
+
+// Unset clears an override when scope lies inside the tenant.
+func (s *ScopedGate) Unset(ctx context.Context, key string, scope ScopeRef, actor ActorRef) error {
+	mutable, err := s.mutable(key, "unset")
+	if err != nil {
+		return err
+	}
+	if scope, err = s.scopeRef(key, scope, "unset"); err != nil {
+		return err
+	}
+	return mutable.Unset(ctx, key, scope, actor)
+}
+
+func (s *ScopedGate) mutable(key, operation string) (MutableFeatureGate, error) {
+	if s.inner == nil {
+		return nil, errGateRequired(key)
+	}
+	mutable, ok := s.inner.(MutableFeatureGate)
+	if !ok {
+		return nil, ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "gate: scoped gate is not mutable", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(key),
+			ferrors.MetaOperation:  operation,
+		})
+	}
+	return mutable, nil
+}
+
+// scopeResolve validates caller options and appends one that pins the tenant.
+func (s *ScopedGate) scopeResolve(key string, opts []ResolveOption) ([]ResolveOption, error) {
+	if s.tenantID == "" {
+		return nil, s.scopeErr(key, nil, "resolve")
+	}
+	req := ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	if req.ScopeChain != nil {
+		chain := make(ScopeChain, 0, len(*req.ScopeChain))
+		for _, ref := range *req.ScopeChain {
+			if !s.readable(ref) {
+				return nil, s.scopeErr(key, ref, "resolve")
+			}
+			switch ref.Kind {
+			case ScopeTenant, ScopeOrg, ScopeUser:
+				ref = s.qualify(ref)
+			}
+			chain = append(chain, ref)
+		}
+		scoped := make([]ResolveOption, 0, len(opts)+1)
+		scoped = append(scoped, opts...)
+		return append(scoped, WithScopeChain(chain)), nil
+	}
+	claims := ActorClaims{}
+	if req.Claims != nil {
+		claims = *req.Claims
+	}
+	if claims.TenantID != "" && claims.TenantID != s.tenantID {
+		return nil, s.scopeErr(key, claims, "resolve")
+	}
+	claims.TenantID = s.tenantID
+	scoped := make([]ResolveOption, 0, len(opts)+1)
+	scoped = append(scoped, opts...)
+	return append(scoped, WithClaims(claims)), nil
+}
+
+// readable reports whether a chain entry stays inside the tenant. System, env, and
+// unqualified role and perm scopes are shared and allowed; tenant, org, and user
+// entries are qualified with the tenant before they reach the inner gate.
+func (s *ScopedGate) readable(ref ScopeRef) bool {
+	switch ref.Kind {
+	case ScopeTenant:
+		return ref.ID == s.tenantID && (ref.TenantID == "" || ref.TenantID == s.tenantID)
+	case ScopeOrg:
+		return ref.ID != "" && (ref.TenantID == "" || ref.TenantID == s.tenantID) && (ref.OrgID == "" || ref.OrgID == ref.ID)
+	}
+	return ref.TenantID == "" || ref.TenantID == s.tenantID
+}
+
+// scopeRef pins a mutation target to the tenant or rejects it, qualifying it the
+// way chains built from claims are.
+func (s *ScopedGate) scopeRef(key string, ref ScopeRef, operation string) (ScopeRef, error) {
+	if s.tenantID == "" {
+		return ref, s.scopeErr(key, ref, operation)
+	}
+	switch ref.Kind {
+	case ScopeTenant:
+		if ref.ID == "" {
+			ref.ID = ref.TenantID
+		}
+		if ref.ID != s.tenantID || (ref.TenantID != "" && ref.TenantID != s.tenantID) {
+			return ref, s.scopeErr(key, ref, operation)
+		}
+	case ScopeOrg:
+		if ref.ID == "" || (ref.TenantID != "" && ref.TenantID != s.tenantID) || (ref.OrgID != "" && ref.OrgID != ref.ID) {
+			return ref, s.scopeErr(key, ref, operation)
+		}
+	case ScopeUser, ScopeRole, ScopePerm:
+		if ref.ID == "" || (ref.TenantID != "" && ref.TenantID != s.tenantID) {
+			return ref, s.scopeErr(key, ref, operation)
+		}
+	default:
+		return ref, s.scopeErr(key, ref, operation)
+	}
+	return s.qualify(ref), nil
+}
+
+// qualify fills the tenant, and the org for org refs, the way scopechain.Builder
+// does for chains built from claims with the view's tenant.
+func (s *ScopedGate) qualify(ref ScopeRef) ScopeRef {
+	return QualifiedRef(ref.Kind, ref.ID, ActorClaims{TenantID: s.tenantID, OrgID: ref.OrgID})
+}
+
+func (s *ScopedGate) scopeErr(key string, scope any, operation string) error {
+	meta := map[string]any{
+		ferrors.MetaFeatureKey: strings.TrimSpace(key),
+		ferrors.MetaScope:      scope,
+		ferrors.MetaOperation:  operation,
+	}
+	if s.tenantID == "" {
+		return ferrors.WrapSentinel(ferrors.ErrScopeRequired, "gate: scoped gate has no tenant", meta)
+	}
+	return ferrors.NewBadInput(ferrors.TextCodeScopeInvalid, "gate: scope is outside tenant "+s.tenantID, meta)
+}
+
+var (
+	_ TraceableFeatureGate = (*ScopedGate)(nil)
+	_ MutableFeatureGate   = (*ScopedGate)(nil)
+)
@@ -0,0 +1,113 @@
+package gate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type recordingGate struct {
+	requests []ResolveRequest
+	scopes   []ScopeRef
+}
+
+func (g *recordingGate) Enabled(_ context.Context, _ string, opts ...ResolveOption) (bool, error) {
+	req := ResolveRequest{}
+	for _, opt := range opts {
+		opt(&req)
+	}
+	g.requests = append(g.requests, req)
+	return true, nil
+}
+
+func (g *recordingGate) Set(_ context.Context, _ string, scope ScopeRef, _ bool, _ ActorRef, _ ...MutationOption) error {
+	g.scopes = append(g.scopes, scope)
+	return nil
+}
+
+func (g *recordingGate) Unset(_ context.Context, _ string, scope ScopeRef, _ ActorRef) error {
+	g.scopes = append(g.scopes, scope)
+	return nil
+}
+
+func TestScopedGatePinsResolvesToTenant(t *testing.T) {
+	inner := &recordingGate{}
+	scoped := ScopedTo(inner, "acme")
+	ctx := context.Background()
+
+	if _, err := scoped.Enabled(ctx, "checkout"); err != nil {
+		t.Fatalf("enabled: %v", err)
+	}
+	if _, err := scoped.Enabled(ctx, "checkout", WithClaims(ActorClaims{SubjectID: "u1", Roles: []string{"admin"}})); err != nil {
+		t.Fatalf("enabled with claims: %v", err)
+	}
+	if len(inner.requests) != 2 {
+		t.Fatalf("expected two resolves, got %d", len(inner.requests))
+	}
+	if claims := inner.requests[0].Claims; claims == nil || claims.TenantID != "acme" {
+		t.Fatalf("expected tenant claims, got %+v", claims)
+	}
+	if claims := inner.requests[1].Claims; claims == nil || claims.TenantID != "acme" || claims.SubjectID != "u1" {
+		t.Fatalf("expected caller claims pinned to tenant, got %+v", claims)
+	}
+
+	if _, err := scoped.Enabled(ctx, "checkout", WithClaims(ActorClaims{TenantID: "globex"})); err == nil {
+		t.Fatalf("expected foreign tenant claims to be rejected")
+	}
+	foreign := ScopeChain{{Kind: ScopeUser, ID: "u2", TenantID: "globex"}, {Kind: ScopeSystem}}
+	if _, err := scoped.Enabled(ctx, "checkout", WithScopeChain(foreign)); err == nil {
+		t.Fatalf("expected foreign chain to be rejected")
+	}
+	own := ScopeChain{{Kind: ScopeRole, ID: "admin"}, {Kind: ScopeTenant, ID: "acme", TenantID: "acme"}, {Kind: ScopeSystem}}
+	if _, err := scoped.Enabled(ctx, "checkout", WithScopeChain(own)); err != nil {
+		t.Fatalf("expected tenant chain to resolve: %v", err)
+	}
+	if _, err := ScopedTo(inner, " ").Enabled(ctx, "checkout"); err == nil {
+		t.Fatalf("expected empty tenant to be rejected")
+	}
+}
+
+func TestScopedGateRestrictsMutations(t *testing.T) {
+	inner := &recordingGate{}
+	scoped := ScopedTo(inner, "acme")
+	ctx := context.Background()
+	actor := ActorRef{ID: "ops"}
+
+	if err := scoped.Set(ctx, "checkout", ScopeRef{Kind: ScopeUser, ID: "u1"}, true, actor); err != nil {
+		t.Fatalf("set user: %v", err)
+	}
+	if err := scoped.Unset(ctx, "checkout", ScopeRef{Kind: ScopeTenant, ID: "acme"}, actor); err != nil {
+		t.Fatalf("unset tenant: %v", err)
+	}
+	if err := scoped.Set(ctx, "checkout", ScopeRef{Kind: ScopeOrg, ID: "o1"}, true, actor); err != nil {
+		t.Fatalf("set org: %v", err)
+	}
+	want := []ScopeRef{
+		{Kind: ScopeUser, ID: "u1", TenantID: "acme"},
+		{Kind: ScopeTenant, ID: "acme", TenantID: "acme"},
+		{Kind: ScopeOrg, ID: "o1", TenantID: "acme", OrgID: "o1"},
+	}
+	if !reflect.DeepEqual(inner.scopes, want) {
+		t.Fatalf("expected scopes qualified like claims chains, got %+v", inner.scopes)
+	}
+
+	rejected := []ScopeRef{
+		{Kind: ScopeTenant, ID: "globex"},
+		{Kind: ScopeOrg, ID: "o1", TenantID: "globex"},
+		{Kind: ScopeOrg, ID: "o1", OrgID: "o2"},
+		{Kind: ScopeUser},
+		{Kind: ScopeSystem},
+		{Kind: ScopeEnv, ID: "prod"},
+	}
+	for _, ref := range rejected {
+		if err := scoped.Set(ctx, "checkout", ref, true, actor); err == nil {
+			t.Fatalf("expected %+v to be rejected", ref)
+		}
+	}
+	if len(inner.scopes) != 3 {
+		t.Fatalf("rejected writes reached the gate: %+v", inner.scopes)
+	}
+	if err := ScopedTo(plainGate{}, "acme").Set(ctx, "checkout", ScopeRef{Kind: ScopeTenant, ID: "acme"}, true, actor); err == nil {
+		t.Fatalf("expected read-only gate to reject writes")
+	}
+}
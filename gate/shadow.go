@@ -0,0 +1,195 @@
+package gate
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultShadowTimeout bounds each secondary resolution.
+const DefaultShadowTimeout = time.Second
+
+// DefaultShadowConcurrency bounds in-flight secondary resolutions.
+const DefaultShadowConcurrency = 64
+
+// ShadowMismatch describes a secondary result that disagreed with the primary,
+// or failed. Traces are zero for gates that are not traceable.
+type ShadowMismatch struct {
+	Key            string
+	Primary        bool
+	Secondary      bool
+	PrimaryTrace   ResolveTrace
+	SecondaryTrace ResolveTrace
+	SecondaryError error
+}
+
+// ShadowReporter receives shadow mismatches.
+type ShadowReporter interface {
+	OnMismatch(ctx context.Context, mismatch ShadowMismatch)
+}
+
+// ShadowReporterFunc wraps a function as a ShadowReporter.
+type ShadowReporterFunc func(context.Context, ShadowMismatch)
+
+// OnMismatch implements ShadowReporter.
+func (fn ShadowReporterFunc) OnMismatch(ctx context.Context, mismatch ShadowMismatch) {
+	if fn == nil {
+		return
+	}
+	fn(ctx, mismatch)
+}
+
+// ShadowOption customizes a ShadowGate.
+type ShadowOption func(*ShadowGate)
+
+// WithShadowReporter sets the mismatch reporter.
+func WithShadowReporter(reporter ShadowReporter) ShadowOption {
+	return func(s *ShadowGate) {
+		if s == nil {
+			return
+		}
+		s.reporter = reporter
+	}
+}
+
+// WithShadowTimeout bounds each secondary resolution.
+func WithShadowTimeout(timeout time.Duration) ShadowOption {
+	return func(s *ShadowGate) {
+		if s == nil {
+			return
+		}
+		s.timeout = timeout
+	}
+}
+
+// WithShadowConcurrency bounds in-flight secondary resolutions. Comparisons beyond
+// the limit are skipped and counted by Dropped.
+func WithShadowConcurrency(limit int) ShadowOption {
+	return func(s *ShadowGate) {
+		if s == nil {
+			return
+		}
+		s.limit = limit
+	}
+}
+
+// ShadowGate returns the primary gate's answers while resolving the same keys against
+// a secondary gate in the background and reporting disagreements. Use it to gain
+// confidence before switching backends.
+type ShadowGate struct {
+	primary   FeatureGate
+	secondary FeatureGate
+	reporter  ShadowReporter
+	timeout   time.Duration
+	limit     int
+
+	slots      chan struct{}
+	wg         sync.WaitGroup
+	dropped    atomic.Uint64
+	mismatches atomic.Uint64
+}
+
+// Shadow wraps primary, shadowing every resolution against secondary.
+func Shadow(primary, secondary FeatureGate, opts ...ShadowOption) *ShadowGate {
+	s := &ShadowGate{
+		primary:   primary,
+		secondary: secondary,
+		timeout:   DefaultShadowTimeout,
+		limit:     DefaultShadowConcurrency,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.timeout <= 0 {
+		s.timeout = DefaultShadowTimeout
+	}
+	if s.limit < 1 {
+		s.limit = DefaultShadowConcurrency
+	}
+	s.slots = make(chan struct{}, s.limit)
+	return s
+}
+
+// Enabled implements FeatureGate.
+func (s *ShadowGate) Enabled(ctx context.Context, key string, opts ...ResolveOption) (bool, error) {
+	value, _, err := s.ResolveWithTrace(ctx, key, opts...)
+	return value, err
+}
+
+// ResolveWithTrace implements TraceableFeatureGate with the primary's result.
+// Primary errors are returned as-is and skip the shadow comparison.
+func (s *ShadowGate) ResolveWithTrace(ctx context.Context, key string, opts ...ResolveOption) (bool, ResolveTrace, error) {
+	if s == nil || s.primary == nil {
+		return false, ResolveTrace{Key: strings.TrimSpace(key), NormalizedKey: NormalizeKey(key)}, errGateRequired(key)
+	}
+	component := resolveComponent(ctx, s.primary, 0, key, opts)
+	if component.Error == nil && s.secondary != nil {
+		s.compare(ctx, key, opts, component)
+	}
+	return component.Value, component.Trace, component.Error
+}
+
+// Wait blocks until in-flight secondary resolutions finish.
+func (s *ShadowGate) Wait() {
+	if s == nil {
+		return
+	}
+	s.wg.Wait()
+}
+
+// Dropped reports comparisons skipped because the concurrency limit was reached.
+func (s *ShadowGate) Dropped() uint64 {
+	if s == nil {
+		return 0
+	}
+	return s.dropped.Load()
+}
+
+// Mismatches reports how many mismatches were detected.
+func (s *ShadowGate) Mismatches() uint64 {
+	if s == nil {
+		return 0
+	}
+	return s.mismatches.Load()
+}
+
+func (s *ShadowGate) compare(ctx context.Context, key string, opts []ResolveOption, primary ComponentTrace) {
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.dropped.Add(1)
+		return
+	}
+	// Detach from request cancellation and hide the request's evaluation memo, so the
+	// secondary resolves on its own instead of replaying the primary's memoized value.
+	shadowCtx := context.WithValue(context.WithoutCancel(ctx), evaluationMemoKey{}, (*EvaluationMemo)(nil))
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() { <-s.slots }()
+		shadowCtx, cancel := context.WithTimeout(shadowCtx, s.timeout)
+		defer cancel()
+		secondary := resolveComponent(shadowCtx, s.secondary, 1, key, opts)
+		if secondary.Error == nil && secondary.Value == primary.Value {
+			return
+		}
+		s.mismatches.Add(1)
+		if s.reporter == nil {
+			return
+		}
+		s.reporter.OnMismatch(shadowCtx, ShadowMismatch{
+			Key:            NormalizeKey(key),
+			Primary:        primary.Value,
+			Secondary:      secondary.Value,
+			PrimaryTrace:   primary.Trace,
+			SecondaryTrace: secondary.Trace,
+			SecondaryError: secondary.Error,
+		})
+	}()
+}
+
+var _ TraceableFeatureGate = (*ShadowGate)(nil)
@@ -0,0 +1,48 @@
+package gate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestShadowReportsMismatchesWithoutChangingResult(t *testing.T) {
+	ctx := WithEvaluationMemo(context.Background())
+	var mu sync.Mutex
+	var reported []ShadowMismatch
+	reporter := ShadowReporterFunc(func(_ context.Context, m ShadowMismatch) {
+		mu.Lock()
+		reported = append(reported, m)
+		mu.Unlock()
+	})
+
+	primary := tracedGate{value: true, source: ResolveSourceOverride}
+	shadow := Shadow(primary, plainGate{value: false}, WithShadowReporter(reporter))
+	value, trace, err := shadow.ResolveWithTrace(ctx, "checkout")
+	if err != nil || !value || trace.Source != ResolveSourceOverride {
+		t.Fatalf("expected primary result, got %v %v (%+v)", value, err, trace)
+	}
+	shadow.Wait()
+	if len(reported) != 1 || reported[0].Key != "checkout" || !reported[0].Primary || reported[0].Secondary {
+		t.Fatalf("expected one mismatch, got %+v", reported)
+	}
+
+	agreeing := Shadow(primary, plainGate{value: true}, WithShadowReporter(reporter))
+	if _, err := agreeing.Enabled(ctx, "checkout"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	agreeing.Wait()
+	if len(reported) != 1 || agreeing.Mismatches() != 0 {
+		t.Fatalf("expected agreeing gates not to report, got %+v", reported)
+	}
+
+	failing := Shadow(primary, plainGate{err: errors.New("remote down")}, WithShadowReporter(reporter))
+	if value, err := failing.Enabled(ctx, "checkout"); err != nil || !value {
+		t.Fatalf("expected secondary errors not to affect the result, got %v (%v)", value, err)
+	}
+	failing.Wait()
+	if len(reported) != 2 || reported[1].SecondaryError == nil {
+		t.Fatalf("expected secondary error to be reported, got %+v", reported)
+	}
+}
@@ -1,23 +1,96 @@
 package gate
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ResolveSource captures which layer produced the final value.
+// The set is open: strategies may report custom sources, so consumers
+// switching on the values below should keep a default branch.
 type ResolveSource string
 
 const (
-	ResolveSourceOverride ResolveSource = "override"
-	ResolveSourceDefault  ResolveSource = "default"
-	ResolveSourceFallback ResolveSource = "fallback"
+	ResolveSourceOverride        ResolveSource = "override"
+	ResolveSourceDefault         ResolveSource = "default"
+	ResolveSourceFallback        ResolveSource = "fallback"
+	ResolveSourceRequestOverride ResolveSource = "request_override"
+	ResolveSourcePinned          ResolveSource = "pinned"
+	ResolveSourceRule            ResolveSource = "rule"
+	ResolveSourceRollout         ResolveSource = "rollout"
+	ResolveSourceDegraded        ResolveSource = "degraded"
+	ResolveSourceCallerFallback  ResolveSource = "caller_fallback"
 )
 
+var builtinResolveSources = []ResolveSource{
+	ResolveSourceOverride,
+	ResolveSourceDefault,
+	ResolveSourceFallback,
+	ResolveSourceRequestOverride,
+	ResolveSourcePinned,
+	ResolveSourceRule,
+	ResolveSourceRollout,
+	ResolveSourceDegraded,
+	ResolveSourceCallerFallback,
+}
+
+// BuiltinResolveSources lists the sources defined by this package, for use as
+// hook or metric dimensions.
+func BuiltinResolveSources() []ResolveSource {
+	return append([]ResolveSource(nil), builtinResolveSources...)
+}
+
+// String implements fmt.Stringer.
+func (s ResolveSource) String() string {
+	return string(s)
+}
+
+// IsBuiltin reports whether the source is defined by this package.
+func (s ResolveSource) IsBuiltin() bool {
+	for _, builtin := range builtinResolveSources {
+		if s == builtin {
+			return true
+		}
+	}
+	return false
+}
+
 // OverrideTrace captures override resolution details.
 type OverrideTrace struct {
-	State OverrideState
-	Value *bool
-	Error error
-	Match ScopeRef
-	Matches []OverrideMatchTrace
+	State     OverrideState
+	Value     *bool
+	Error     error
+	Match     ScopeRef
+	ExpiresAt time.Time
+	Metadata  OverrideMetadata
+	Matches   []OverrideMatchTrace
+	Expired   []OverrideMatchTrace
+	// Scopes lists every scope of the chain, in chain order, with what the override
+	// lookup found there.
+	Scopes []ScopeLookupTrace
+}
+
+// ScopeOutcome describes what the override lookup found at one scope of the chain.
+type ScopeOutcome string
+
+const (
+	// ScopeOutcomeNoOverride means nothing is stored at the scope.
+	ScopeOutcomeNoOverride ScopeOutcome = "no_override"
+	// ScopeOutcomeOverridden marks the scope whose override decided the value.
+	ScopeOutcomeOverridden ScopeOutcome = "overridden"
+	// ScopeOutcomeShadowed means the scope has an override that lost to a higher
+	// scope group, or to another scope in its group.
+	ScopeOutcomeShadowed ScopeOutcome = "shadowed"
+	// ScopeOutcomeExpired means the override at the scope has expired.
+	ScopeOutcomeExpired ScopeOutcome = "expired"
+)
+
+// ScopeLookupTrace records the override lookup outcome for one scope. State is the
+// state stored at the scope, empty when there is no override.
+type ScopeLookupTrace struct {
+	Scope   ScopeRef
+	Outcome ScopeOutcome
+	State   OverrideState
 }
 
 // DefaultTrace captures config default resolution details.
@@ -25,23 +98,80 @@ type DefaultTrace struct {
 	Set   bool
 	Value bool
 	Error error
+	// Plan is the subscription plan an entitlement provider derived the default from.
+	Plan string
+}
+
+// RuleTrace captures a rule expression evaluated by a strategy.
+type RuleTrace struct {
+	Expression string
+	Result     string
+	Error      error
 }
 
 // ResolveTrace captures provenance for a single feature resolution.
 type ResolveTrace struct {
-	Key           string
-	NormalizedKey string
-	Chain         ScopeChain
-	Value         bool
-	Source        ResolveSource
-	Override      OverrideTrace
-	Default       DefaultTrace
-	CacheHit      bool
-	Strategy      string
+	Key               string
+	NormalizedKey     string
+	Chain             ScopeChain
+	Value             bool
+	Source            ResolveSource
+	Override          OverrideTrace
+	Default           DefaultTrace
+	Schedule          ScheduleTrace
+	CacheHit          bool
+	Memoized          bool
+	Strategy          string
+	Rule              RuleTrace
 	ClaimsFailureMode string
+	TimedOut          []string
+	Components        []ComponentTrace
+	Inherited         []InheritedScope
+	// Alias is the legacy key the resolution went through: the key the caller
+	// passed, or the key the matching override is stored under.
+	Alias string
 }
 
+// InheritedScope records an ancestor scope a TenantHierarchyProvider added to the
+// chain and the descendant it was inherited through.
+type InheritedScope struct {
+	Scope ScopeRef
+	From  ScopeRef
+}
+
+// InheritedFrom reports the descendant scope ref was inherited through, when ref was
+// added to the chain by a tenant hierarchy.
+func (t ResolveTrace) InheritedFrom(ref ScopeRef) (ScopeRef, bool) {
+	for _, inherited := range t.Inherited {
+		if inherited.Scope == ref {
+			return inherited.From, true
+		}
+	}
+	return ScopeRef{}, false
+}
+
+// Resolve stages recorded in ResolveTrace.TimedOut when a call exceeds the resolve timeout.
+const (
+	StageClaims      = "claims"
+	StagePermissions = "permissions"
+	StageOverride    = "override"
+	StageDefault     = "default"
+	StageSchedule    = "schedule"
+	StageHierarchy   = "hierarchy"
+)
+
+// Request metadata keys populated by the matching context extractors.
+const (
+	EventMetaRequestID = "request_id"
+	EventMetaTraceID   = "trace_id"
+	EventMetaRoute     = "route"
+)
+
+// ContextExtractor reads a request identifier from the context. Empty values are skipped.
+type ContextExtractor func(ctx context.Context) string
+
 // ResolveEvent is emitted after resolution for hooks.
+// Metadata carries request identifiers from the gate's context extractors.
 type ResolveEvent struct {
 	Key           string
 	NormalizedKey string
@@ -50,6 +180,7 @@ type ResolveEvent struct {
 	Source        ResolveSource
 	Error         error
 	Trace         ResolveTrace
+	Metadata      map[string]string
 }
 
 // ResolveHook receives resolution events.
@@ -59,9 +190,12 @@ type ResolveHook interface {
 
 // OverrideMatchTrace captures a single matched override for trace output.
 type OverrideMatchTrace struct {
-	Scope ScopeRef
-	State OverrideState
-	Value *bool
+	Scope     ScopeRef
+	State     OverrideState
+	Value     *bool
+	ExpiresAt time.Time
+	Metadata  OverrideMetadata
+	Priority  int
 }
 
 // ResolveHookFunc wraps a function as a ResolveHook.
@@ -0,0 +1,340 @@
+package gate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type scopeRefJSON struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+	OrgID    string `json:"org_id,omitempty"`
+}
+
+type overrideMatchJSON struct {
+	Scope     scopeRefJSON      `json:"scope"`
+	State     OverrideState     `json:"state"`
+	Value     *bool             `json:"value,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	Metadata  *OverrideMetadata `json:"metadata,omitempty"`
+	Priority  int               `json:"priority,omitempty"`
+}
+
+type inheritedScopeJSON struct {
+	Scope scopeRefJSON `json:"scope"`
+	From  scopeRefJSON `json:"from"`
+}
+
+type overrideTraceJSON struct {
+	State     OverrideState       `json:"state,omitempty"`
+	Value     *bool               `json:"value,omitempty"`
+	Error     string              `json:"error,omitempty"`
+	Match     *scopeRefJSON       `json:"match,omitempty"`
+	ExpiresAt *time.Time          `json:"expires_at,omitempty"`
+	Metadata  *OverrideMetadata   `json:"metadata,omitempty"`
+	Matches   []overrideMatchJSON `json:"matches,omitempty"`
+	Expired   []overrideMatchJSON `json:"expired,omitempty"`
+	Scopes    []scopeLookupJSON   `json:"scopes,omitempty"`
+}
+
+type scopeLookupJSON struct {
+	Scope   scopeRefJSON  `json:"scope"`
+	Outcome ScopeOutcome  `json:"outcome"`
+	State   OverrideState `json:"state,omitempty"`
+}
+
+type defaultTraceJSON struct {
+	Set   bool   `json:"set"`
+	Value bool   `json:"value"`
+	Error string `json:"error,omitempty"`
+	Plan  string `json:"plan,omitempty"`
+}
+
+type scheduleTraceJSON struct {
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+	Active   bool       `json:"active"`
+	Pending  bool       `json:"pending"`
+	Error    string     `json:"error,omitempty"`
+}
+
+type ruleTraceJSON struct {
+	Expression string `json:"expression"`
+	Result     string `json:"result,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type componentTraceJSON struct {
+	Index    int          `json:"index"`
+	Name     string       `json:"name,omitempty"`
+	Value    bool         `json:"value"`
+	Error    string       `json:"error,omitempty"`
+	Selected bool         `json:"selected,omitempty"`
+	Trace    ResolveTrace `json:"trace"`
+}
+
+type resolveTraceJSON struct {
+	Key               string               `json:"key"`
+	NormalizedKey     string               `json:"normalized_key"`
+	Alias             string               `json:"alias,omitempty"`
+	Chain             []scopeRefJSON       `json:"chain"`
+	Value             bool                 `json:"value"`
+	Source            ResolveSource        `json:"source"`
+	Override          overrideTraceJSON    `json:"override"`
+	Default           defaultTraceJSON     `json:"default"`
+	Schedule          *scheduleTraceJSON   `json:"schedule,omitempty"`
+	CacheHit          bool                 `json:"cache_hit"`
+	Memoized          bool                 `json:"memoized,omitempty"`
+	Strategy          string               `json:"strategy,omitempty"`
+	Rule              *ruleTraceJSON       `json:"rule,omitempty"`
+	ClaimsFailureMode string               `json:"claims_failure_mode,omitempty"`
+	TimedOut          []string             `json:"timed_out,omitempty"`
+	Components        []componentTraceJSON `json:"components,omitempty"`
+	Inherited         []inheritedScopeJSON `json:"inherited,omitempty"`
+}
+
+// MarshalJSON renders scope kinds by name and errors as strings.
+func (t ResolveTrace) MarshalJSON() ([]byte, error) {
+	out := resolveTraceJSON{
+		Key:           t.Key,
+		NormalizedKey: t.NormalizedKey,
+		Alias:         t.Alias,
+		Chain:         make([]scopeRefJSON, 0, len(t.Chain)),
+		Value:         t.Value,
+		Source:        t.Source,
+		Override: overrideTraceJSON{
+			State:     t.Override.State,
+			Value:     t.Override.Value,
+			Error:     errString(t.Override.Error),
+			ExpiresAt: timePtr(t.Override.ExpiresAt),
+			Metadata:  metadataPtr(t.Override.Metadata),
+			Matches:   matchesJSON(t.Override.Matches),
+			Expired:   matchesJSON(t.Override.Expired),
+		},
+		Default: defaultTraceJSON{
+			Set:   t.Default.Set,
+			Value: t.Default.Value,
+			Error: errString(t.Default.Error),
+			Plan:  t.Default.Plan,
+		},
+		CacheHit:          t.CacheHit,
+		Memoized:          t.Memoized,
+		Strategy:          t.Strategy,
+		ClaimsFailureMode: t.ClaimsFailureMode,
+		TimedOut:          t.TimedOut,
+	}
+	for _, ref := range t.Chain {
+		out.Chain = append(out.Chain, scopeJSON(ref))
+	}
+	for _, lookup := range t.Override.Scopes {
+		out.Override.Scopes = append(out.Override.Scopes, scopeLookupJSON{
+			Scope:   scopeJSON(lookup.Scope),
+			Outcome: lookup.Outcome,
+			State:   lookup.State,
+		})
+	}
+	for _, inherited := range t.Inherited {
+		out.Inherited = append(out.Inherited, inheritedScopeJSON{
+			Scope: scopeJSON(inherited.Scope),
+			From:  scopeJSON(inherited.From),
+		})
+	}
+	for _, component := range t.Components {
+		out.Components = append(out.Components, componentTraceJSON{
+			Index:    component.Index,
+			Name:     component.Name,
+			Value:    component.Value,
+			Error:    errString(component.Error),
+			Selected: component.Selected,
+			Trace:    component.Trace,
+		})
+	}
+	if t.Override.State == OverrideStateEnabled || t.Override.State == OverrideStateDisabled {
+		match := scopeJSON(t.Override.Match)
+		out.Override.Match = &match
+	}
+	if t.Rule.Expression != "" {
+		out.Rule = &ruleTraceJSON{
+			Expression: t.Rule.Expression,
+			Result:     t.Rule.Result,
+			Error:      errString(t.Rule.Error),
+		}
+	}
+	if t.Schedule.Set || t.Schedule.Error != nil {
+		out.Schedule = &scheduleTraceJSON{
+			StartsAt: timePtr(t.Schedule.Schedule.StartsAt),
+			EndsAt:   timePtr(t.Schedule.Schedule.EndsAt),
+			Active:   t.Schedule.Active,
+			Pending:  t.Schedule.Pending,
+			Error:    errString(t.Schedule.Error),
+		}
+	}
+	return json.Marshal(out)
+}
+
+// Explain renders a trace as a short human-readable summary.
+func Explain(t ResolveTrace) string {
+	var b strings.Builder
+	key := t.NormalizedKey
+	if key == "" {
+		key = t.Key
+	}
+	fmt.Fprintf(&b, "%s = %t (source: %s", key, t.Value, t.Source)
+	if t.CacheHit {
+		b.WriteString(", cached")
+	}
+	if t.Memoized {
+		b.WriteString(", memoized")
+	}
+	b.WriteString(")\n")
+
+	if t.Alias != "" {
+		fmt.Fprintf(&b, "  alias: %s\n", t.Alias)
+	}
+	if len(t.Chain) > 0 {
+		scopes := make([]string, 0, len(t.Chain))
+		for _, ref := range t.Chain {
+			scopes = append(scopes, scopeLabel(ref))
+		}
+		fmt.Fprintf(&b, "  chain: %s\n", strings.Join(scopes, " > "))
+	}
+
+	override := string(t.Override.State)
+	if override == "" {
+		override = string(OverrideStateMissing)
+	}
+	if t.Override.State == OverrideStateEnabled || t.Override.State == OverrideStateDisabled {
+		override += " at " + scopeLabel(t.Override.Match)
+		if from, ok := t.InheritedFrom(t.Override.Match); ok {
+			override += " (inherited by " + scopeLabel(from) + ")"
+		}
+	}
+	if !t.Override.ExpiresAt.IsZero() {
+		override += " until " + t.Override.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if t.Override.Error != nil {
+		override += " (error: " + t.Override.Error.Error() + ")"
+	}
+	fmt.Fprintf(&b, "  override: %s\n", override)
+	for _, expired := range t.Override.Expired {
+		fmt.Fprintf(&b, "  expired: %s at %s\n", expired.State, scopeLabel(expired.Scope))
+	}
+	for _, lookup := range t.Override.Scopes {
+		if lookup.Outcome == ScopeOutcomeShadowed {
+			fmt.Fprintf(&b, "  shadowed: %s at %s\n", lookup.State, scopeLabel(lookup.Scope))
+		}
+	}
+
+	def := "unset"
+	if t.Default.Set {
+		def = fmt.Sprintf("%t", t.Default.Value)
+	}
+	if t.Default.Plan != "" {
+		def += " (plan: " + t.Default.Plan + ")"
+	}
+	if t.Default.Error != nil {
+		def += " (error: " + t.Default.Error.Error() + ")"
+	}
+	fmt.Fprintf(&b, "  default: %s\n", def)
+
+	if t.Schedule.Set {
+		state := "inactive"
+		switch {
+		case t.Schedule.Active:
+			state = "active"
+		case t.Schedule.Pending:
+			state = "pending until " + t.Schedule.Schedule.StartsAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(&b, "  schedule: %s\n", state)
+	}
+	if t.Strategy != "" {
+		fmt.Fprintf(&b, "  strategy: %s\n", t.Strategy)
+	}
+	if t.Rule.Expression != "" {
+		rule := t.Rule.Expression + " => " + t.Rule.Result
+		if t.Rule.Error != nil {
+			rule = t.Rule.Expression + " (error: " + t.Rule.Error.Error() + ")"
+		}
+		fmt.Fprintf(&b, "  rule: %s\n", rule)
+	}
+	if t.ClaimsFailureMode != "" {
+		fmt.Fprintf(&b, "  claims failure mode: %s\n", t.ClaimsFailureMode)
+	}
+	if len(t.TimedOut) > 0 {
+		fmt.Fprintf(&b, "  timed out: %s\n", strings.Join(t.TimedOut, ", "))
+	}
+	for _, component := range t.Components {
+		result := fmt.Sprintf("%t (source: %s)", component.Value, component.Trace.Source)
+		if component.Error != nil {
+			result = "error: " + component.Error.Error()
+		}
+		label := strconv.Itoa(component.Index)
+		if component.Name != "" {
+			label += " (" + component.Name + ")"
+		}
+		if component.Selected {
+			result += " [selected]"
+		}
+		fmt.Fprintf(&b, "  component %s: %s\n", label, result)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func scopeJSON(ref ScopeRef) scopeRefJSON {
+	return scopeRefJSON{
+		Kind:     ref.Kind.String(),
+		ID:       ref.ID,
+		TenantID: ref.TenantID,
+		OrgID:    ref.OrgID,
+	}
+}
+
+func scopeLabel(ref ScopeRef) string {
+	if ref.ID == "" {
+		return ref.Kind.String()
+	}
+	return ref.Kind.String() + ":" + ref.ID
+}
+
+func matchesJSON(matches []OverrideMatchTrace) []overrideMatchJSON {
+	if len(matches) == 0 {
+		return nil
+	}
+	out := make([]overrideMatchJSON, 0, len(matches))
+	for _, match := range matches {
+		out = append(out, overrideMatchJSON{
+			Scope:     scopeJSON(match.Scope),
+			State:     match.State,
+			Value:     match.Value,
+			ExpiresAt: timePtr(match.ExpiresAt),
+			Metadata:  metadataPtr(match.Metadata),
+			Priority:  match.Priority,
+		})
+	}
+	return out
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func metadataPtr(meta OverrideMetadata) *OverrideMetadata {
+	if meta.IsZero() {
+		return nil
+	}
+	return &meta
+}
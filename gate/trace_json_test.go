@@ -0,0 +1,61 @@
+package gate
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResolveTraceMarshalJSON(t *testing.T) {
+	enabled := true
+	trace := ResolveTrace{
+		Key:           "Dashboard",
+		NormalizedKey: "dashboard",
+		Chain:         ScopeChain{{Kind: ScopeTenant, ID: "acme", TenantID: "acme"}, {Kind: ScopeSystem}},
+		Value:         true,
+		Source:        ResolveSourceOverride,
+		Override: OverrideTrace{
+			State:    OverrideStateEnabled,
+			Value:    &enabled,
+			Match:    ScopeRef{Kind: ScopeTenant, ID: "acme", TenantID: "acme"},
+			Metadata: OverrideMetadata{Reason: "demo"},
+			Scopes: []ScopeLookupTrace{
+				{Scope: ScopeRef{Kind: ScopeTenant, ID: "acme", TenantID: "acme"}, Outcome: ScopeOutcomeOverridden, State: OverrideStateEnabled},
+				{Scope: ScopeRef{Kind: ScopeSystem}, Outcome: ScopeOutcomeNoOverride},
+			},
+		},
+		Default: DefaultTrace{Error: errors.New("defaults offline")},
+	}
+
+	raw, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	chain := decoded["chain"].([]any)
+	if chain[0].(map[string]any)["kind"] != "tenant" {
+		t.Fatalf("expected scope kind name, got %s", raw)
+	}
+	if decoded["default"].(map[string]any)["error"] != "defaults offline" {
+		t.Fatalf("expected error string, got %s", raw)
+	}
+	override := decoded["override"].(map[string]any)
+	if override["match"].(map[string]any)["id"] != "acme" || override["metadata"].(map[string]any)["reason"] != "demo" {
+		t.Fatalf("unexpected override payload: %s", raw)
+	}
+	scopes := override["scopes"].([]any)
+	if len(scopes) != 2 || scopes[1].(map[string]any)["outcome"] != "no_override" {
+		t.Fatalf("unexpected scope outcomes: %s", raw)
+	}
+
+	explained := Explain(trace)
+	for _, want := range []string{"dashboard = true (source: override)", "chain: tenant:acme > system", "override: enabled at tenant:acme", "default: unset (error: defaults offline)"} {
+		if !strings.Contains(explained, want) {
+			t.Fatalf("expected %q in explanation:\n%s", want, explained)
+		}
+	}
+}
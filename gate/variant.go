@@ -0,0 +1,47 @@
+package gate
+
+import "context"
+
+// Variant keys used when a boolean result stands in for a variant.
+const (
+	VariantOn  = "on"
+	VariantOff = "off"
+)
+
+// Variant is a multivariate feature result: a stable key for branching and
+// analytics, and the value served with it.
+type Variant struct {
+	Key   string `json:"key"`
+	Value any    `json:"value,omitempty"`
+}
+
+// BoolVariant maps a boolean result to the VariantOn or VariantOff variant.
+func BoolVariant(enabled bool) Variant {
+	if enabled {
+		return Variant{Key: VariantOn, Value: true}
+	}
+	return Variant{Key: VariantOff, Value: false}
+}
+
+// VariantFeatureGate is implemented by gates that serve multivariate values.
+// Callers that need a variant from a plain FeatureGate use ResolveVariant.
+type VariantFeatureGate interface {
+	FeatureGate
+	Variant(ctx context.Context, key string, opts ...ResolveOption) (Variant, error)
+}
+
+// ResolveVariant returns the gate's variant for key, or the BoolVariant of Enabled
+// when the gate only serves booleans.
+func ResolveVariant(ctx context.Context, fg FeatureGate, key string, opts ...ResolveOption) (Variant, error) {
+	if fg == nil {
+		return Variant{}, errGateRequired(key)
+	}
+	if variants, ok := fg.(VariantFeatureGate); ok {
+		return variants.Variant(ctx, key, opts...)
+	}
+	enabled, err := fg.Enabled(ctx, key, opts...)
+	if err != nil {
+		return Variant{}, err
+	}
+	return BoolVariant(enabled), nil
+}
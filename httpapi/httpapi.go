@@ -0,0 +1,555 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	goerrors "github.com/goliatone/go-errors"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+	"github.com/goliatone/go-featuregate/usage"
+)
+
+const (
+	QueryTenantID  = scope.MetadataTenantID
+	QueryOrgID     = scope.MetadataOrgID
+	QueryUserID    = scope.MetadataUserID
+	QuerySystem    = "system"
+	QueryKey       = "key"
+	QueryLabel     = "label"
+	QueryScopeKind = "kind"
+	QueryScopeID   = "id"
+)
+
+// OverrideLister is implemented by gates that can enumerate stored overrides.
+type OverrideLister interface {
+	ListOverrides(ctx context.Context, filter store.ListFilter) ([]store.OverrideRecord, error)
+}
+
+// ConfigProvider is implemented by gates that report their behavioral configuration.
+type ConfigProvider interface {
+	Config() resolver.Config
+}
+
+// Handler exposes a feature gate over HTTP.
+type Handler struct {
+	gate    gate.FeatureGate
+	catalog catalog.Catalog
+	watcher *activity.Broadcaster
+	usage   *usage.Counter
+	history activity.HistoryReader
+	now     func() time.Time
+	mux     *http.ServeMux
+}
+
+// Option customizes a Handler.
+type Option func(*Handler)
+
+// WithCatalog sets the catalog used to list features.
+func WithCatalog(c catalog.Catalog) Option {
+	return func(h *Handler) {
+		if h == nil {
+			return
+		}
+		h.catalog = c
+	}
+}
+
+// WithWatcher enables GET /watch, streaming override updates from the broadcaster as
+// server-sent events. Register the same broadcaster with resolver.WithActivityHook.
+func WithWatcher(b *activity.Broadcaster) Option {
+	return func(h *Handler) {
+		if h == nil {
+			return
+		}
+		h.watcher = b
+	}
+}
+
+// WithUsage enables GET /debug/usage, reporting per-key resolution counts from the counter.
+// Register the same counter with resolver.WithResolveHook.
+func WithUsage(counter *usage.Counter) Option {
+	return func(h *Handler) {
+		if h == nil {
+			return
+		}
+		h.usage = counter
+	}
+}
+
+// WithHistory enables GET /features/{key}/history, building value timelines from the
+// reader's audit entries. activity.Log is an in-memory reader.
+func WithHistory(reader activity.HistoryReader) Option {
+	return func(h *Handler) {
+		if h == nil {
+			return
+		}
+		h.history = reader
+	}
+}
+
+// WithClock sets the clock used to close the current history segment.
+func WithClock(c clock.Clock) Option {
+	return func(h *Handler) {
+		if h == nil {
+			return
+		}
+		h.now = clock.NowFunc(c)
+	}
+}
+
+// New constructs an HTTP handler backed by the provided feature gate.
+//
+// Routes:
+//
+//	GET    /healthz            (includes the gate config when the gate is a ConfigProvider)
+//	GET    /features           (requires a catalog)
+//	GET    /features/{key}
+//	GET    /features/{key}/history (requires WithHistory; scope from ?kind=, ?id=, ?tenant_id=, ?org_id=)
+//	PUT    /features/{key}     (requires a MutableFeatureGate)
+//	DELETE /features/{key}     (requires a MutableFeatureGate)
+//	GET    /overrides          (requires an OverrideLister; filter with ?key= and ?label=name=value)
+//	GET    /watch              (requires WithWatcher; server-sent events)
+//	GET    /debug/usage        (requires WithUsage)
+func New(featureGate gate.FeatureGate, opts ...Option) *Handler {
+	h := &Handler{gate: featureGate, now: time.Now}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", h.health)
+	mux.HandleFunc("GET /features", h.list)
+	mux.HandleFunc("GET /features/{key}", h.get)
+	mux.HandleFunc("GET /features/{key}/history", h.keyHistory)
+	mux.HandleFunc("PUT /features/{key}", h.set)
+	mux.HandleFunc("DELETE /features/{key}", h.unset)
+	mux.HandleFunc("GET /overrides", h.listOverrides)
+	mux.HandleFunc("GET /watch", h.watch)
+	mux.HandleFunc("GET /debug/usage", h.usageReport)
+	h.mux = mux
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// FeatureResponse is the JSON payload for a resolved feature.
+// ActivatesAt is set when the feature is disabled until its schedule starts.
+type FeatureResponse struct {
+	Key         string             `json:"key"`
+	Enabled     bool               `json:"enabled"`
+	Source      gate.ResolveSource `json:"source,omitempty"`
+	Description string             `json:"description,omitempty"`
+	ActivatesAt *time.Time         `json:"activates_at,omitempty"`
+}
+
+// ScopePayload is the JSON representation of a scope reference.
+type ScopePayload struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+	OrgID    string `json:"org_id,omitempty"`
+}
+
+// ActorPayload is the JSON representation of an actor reference.
+type ActorPayload struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// UpdateRequest is the JSON payload for PUT and DELETE requests.
+type UpdateRequest struct {
+	Enabled   *bool             `json:"enabled,omitempty"`
+	Scope     ScopePayload      `json:"scope"`
+	Actor     ActorPayload      `json:"actor"`
+	Reason    string            `json:"reason,omitempty"`
+	TicketURL string            `json:"ticket_url,omitempty"`
+	Owner     string            `json:"owner,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Priority  int               `json:"priority,omitempty"`
+}
+
+// OverrideResponse is the JSON payload for a stored override.
+type OverrideResponse struct {
+	Key       string                 `json:"key"`
+	Scope     ScopePayload           `json:"scope"`
+	State     gate.OverrideState     `json:"state"`
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+	Metadata  *gate.OverrideMetadata `json:"metadata,omitempty"`
+}
+
+// HistoryResponse is the value timeline for one key in one scope, oldest first.
+type HistoryResponse struct {
+	Key      string           `json:"key"`
+	Scope    ScopePayload     `json:"scope"`
+	Segments []SegmentPayload `json:"segments"`
+}
+
+// SegmentPayload is one span of the timeline. A null value means the default applied;
+// End is omitted for the current segment.
+type SegmentPayload struct {
+	Value           *bool        `json:"value"`
+	Action          string       `json:"action"`
+	Actor           ActorPayload `json:"actor"`
+	Reason          string       `json:"reason,omitempty"`
+	Start           time.Time    `json:"start"`
+	End             *time.Time   `json:"end,omitempty"`
+	DurationSeconds float64      `json:"duration_seconds"`
+}
+
+// HealthResponse is the JSON payload for the health endpoint.
+type HealthResponse struct {
+	Status string           `json:"status"`
+	Config *resolver.Config `json:"config,omitempty"`
+}
+
+// ErrorResponse is the JSON payload for failed requests.
+type ErrorResponse struct {
+	Error    string `json:"error"`
+	TextCode string `json:"text_code,omitempty"`
+}
+
+func (h *Handler) health(w http.ResponseWriter, _ *http.Request) {
+	resp := HealthResponse{Status: "ok"}
+	if provider, ok := h.gate.(ConfigProvider); ok {
+		cfg := provider.Config()
+		resp.Config = &cfg
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	if h.catalog == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("catalog not configured"))
+		return
+	}
+	opts := resolveOptionsFromQuery(r)
+	defs := h.catalog.List()
+	out := make([]FeatureResponse, 0, len(defs))
+	for _, def := range defs {
+		resp, err := h.resolve(r, def.Key, opts)
+		if err != nil {
+			writeGateError(w, err)
+			return
+		}
+		out = append(out, resp)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.resolve(r, r.PathValue("key"), resolveOptionsFromQuery(r))
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) set(w http.ResponseWriter, r *http.Request) {
+	mutable, req, ok := h.decodeUpdate(w, r)
+	if !ok {
+		return
+	}
+	if req.Enabled == nil {
+		writeError(w, http.StatusBadRequest, errors.New("enabled is required"))
+		return
+	}
+	scopeRef, err := req.Scope.ScopeRef()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	meta := gate.WithMetadata(gate.OverrideMetadata{
+		Reason:    req.Reason,
+		TicketURL: req.TicketURL,
+		Owner:     req.Owner,
+		Labels:    req.Labels,
+	})
+	if err := mutable.Set(r.Context(), r.PathValue("key"), scopeRef, *req.Enabled, req.Actor.ActorRef(), meta, gate.WithPriority(req.Priority)); err != nil {
+		writeGateError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) unset(w http.ResponseWriter, r *http.Request) {
+	mutable, req, ok := h.decodeUpdate(w, r)
+	if !ok {
+		return
+	}
+	scopeRef, err := req.Scope.ScopeRef()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := mutable.Unset(r.Context(), r.PathValue("key"), scopeRef, req.Actor.ActorRef()); err != nil {
+		writeGateError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listOverrides(w http.ResponseWriter, r *http.Request) {
+	lister, ok := h.gate.(OverrideLister)
+	if !ok || lister == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("feature gate cannot list overrides"))
+		return
+	}
+	query := r.URL.Query()
+	filter := store.ListFilter{Key: query.Get(QueryKey)}
+	for _, label := range query[QueryLabel] {
+		name, value, ok := strings.Cut(label, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			writeError(w, http.StatusBadRequest, errors.New("label filter must be name=value"))
+			return
+		}
+		if filter.Labels == nil {
+			filter.Labels = map[string]string{}
+		}
+		filter.Labels[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	records, err := lister.ListOverrides(r.Context(), filter)
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	out := make([]OverrideResponse, 0, len(records))
+	for _, record := range records {
+		resp := OverrideResponse{
+			Key: record.Key,
+			Scope: ScopePayload{
+				Kind:     record.Scope.Kind.String(),
+				ID:       record.Scope.ID,
+				TenantID: record.Scope.TenantID,
+				OrgID:    record.Scope.OrgID,
+			},
+			State: record.Override.State,
+		}
+		if expiresAt := record.Override.ExpiresAt; !expiresAt.IsZero() {
+			resp.ExpiresAt = &expiresAt
+		}
+		if meta := record.Override.Metadata; !meta.IsZero() {
+			resp.Metadata = &meta
+		}
+		out = append(out, resp)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) keyHistory(w http.ResponseWriter, r *http.Request) {
+	if h.history == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("history reader not configured"))
+		return
+	}
+	key := gate.NormalizeKey(strings.TrimSpace(r.PathValue("key")))
+	ref, err := historyScopeFromQuery(r)
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	entries, err := h.history.History(r.Context(), key, ref)
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	segments := activity.Timeline(entries, h.now())
+	resp := HistoryResponse{
+		Key: key,
+		Scope: ScopePayload{
+			Kind:     ref.Kind.String(),
+			ID:       ref.ID,
+			TenantID: ref.TenantID,
+			OrgID:    ref.OrgID,
+		},
+		Segments: make([]SegmentPayload, 0, len(segments)),
+	}
+	for _, segment := range segments {
+		payload := SegmentPayload{
+			Value:  segment.Value,
+			Action: string(segment.Action),
+			Actor: ActorPayload{
+				ID:   segment.Actor.ID,
+				Type: segment.Actor.Type,
+				Name: segment.Actor.Name,
+			},
+			Reason:          segment.Reason,
+			Start:           segment.Start,
+			DurationSeconds: segment.Duration.Seconds(),
+		}
+		if !segment.Current() {
+			end := segment.End
+			payload.End = &end
+		}
+		resp.Segments = append(resp.Segments, payload)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) usageReport(w http.ResponseWriter, _ *http.Request) {
+	if h.usage == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("usage counter not configured"))
+		return
+	}
+	writeJSON(w, http.StatusOK, h.usage.Snapshot())
+}
+
+func (h *Handler) decodeUpdate(w http.ResponseWriter, r *http.Request) (gate.MutableFeatureGate, UpdateRequest, bool) {
+	mutable, ok := h.gate.(gate.MutableFeatureGate)
+	if !ok || mutable == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("feature gate is read-only"))
+		return nil, UpdateRequest{}, false
+	}
+	req := UpdateRequest{}
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return nil, UpdateRequest{}, false
+		}
+	}
+	return mutable, req, true
+}
+
+func (h *Handler) resolve(r *http.Request, key string, opts []gate.ResolveOption) (FeatureResponse, error) {
+	if h.gate == nil {
+		return FeatureResponse{}, ferrors.WrapSentinel(ferrors.ErrGateRequired, "", nil)
+	}
+	resp := FeatureResponse{Key: gate.NormalizeKey(key)}
+	if traceable, ok := h.gate.(gate.TraceableFeatureGate); ok {
+		value, trace, err := traceable.ResolveWithTrace(r.Context(), key, opts...)
+		if err != nil {
+			return FeatureResponse{}, err
+		}
+		resp.Enabled = value
+		resp.Source = trace.Source
+		if at, ok := trace.ActivatesAt(); ok {
+			resp.ActivatesAt = &at
+		}
+	} else {
+		value, err := h.gate.Enabled(r.Context(), key, opts...)
+		if err != nil {
+			return FeatureResponse{}, err
+		}
+		resp.Enabled = value
+	}
+	if h.catalog != nil {
+		if def, ok := h.catalog.Get(resp.Key); ok {
+			text, _ := catalog.PlainResolver{}.Resolve(r.Context(), "", def.Description)
+			resp.Description = text
+		}
+	}
+	return resp, nil
+}
+
+// ScopeRef converts the payload into a gate.ScopeRef. An empty kind maps to the system scope.
+func (p ScopePayload) ScopeRef() (gate.ScopeRef, error) {
+	if strings.TrimSpace(p.Kind) == "" {
+		return gate.ScopeRef{Kind: gate.ScopeSystem}, nil
+	}
+	kind, ok := gate.ParseScopeKind(p.Kind)
+	if !ok {
+		return gate.ScopeRef{}, ferrors.NewBadInput(ferrors.TextCodeScopeInvalid, "httpapi: unknown scope kind", map[string]any{
+			ferrors.MetaScope: p.Kind,
+		})
+	}
+	return gate.ScopeRef{
+		Kind:     kind,
+		ID:       strings.TrimSpace(p.ID),
+		TenantID: strings.TrimSpace(p.TenantID),
+		OrgID:    strings.TrimSpace(p.OrgID),
+	}, nil
+}
+
+// ActorRef converts the payload into a gate.ActorRef.
+func (p ActorPayload) ActorRef() gate.ActorRef {
+	return gate.ActorRef{
+		ID:   strings.TrimSpace(p.ID),
+		Type: strings.TrimSpace(p.Type),
+		Name: strings.TrimSpace(p.Name),
+	}
+}
+
+// resolveOptionsFromQuery builds an explicit scope chain from query parameters.
+// Requests without scope parameters derive scope from the request context.
+func resolveOptionsFromQuery(r *http.Request) []gate.ResolveOption {
+	query := r.URL.Query()
+	if query.Get(QuerySystem) == "true" {
+		return []gate.ResolveOption{gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})}
+	}
+	tenantID := strings.TrimSpace(query.Get(QueryTenantID))
+	orgID := strings.TrimSpace(query.Get(QueryOrgID))
+	userID := strings.TrimSpace(query.Get(QueryUserID))
+	if tenantID == "" && orgID == "" && userID == "" {
+		return nil
+	}
+	chain := make(gate.ScopeChain, 0, 4)
+	if userID != "" {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeUser, ID: userID, TenantID: tenantID, OrgID: orgID})
+	}
+	if orgID != "" {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeOrg, ID: orgID, TenantID: tenantID, OrgID: orgID})
+	}
+	if tenantID != "" {
+		chain = append(chain, gate.ScopeRef{Kind: gate.ScopeTenant, ID: tenantID, TenantID: tenantID})
+	}
+	chain = append(chain, gate.ScopeRef{Kind: gate.ScopeSystem})
+	return []gate.ResolveOption{gate.WithScopeChain(chain)}
+}
+
+// historyScopeFromQuery reads the exact scope from ?kind=, ?id=, ?tenant_id=, and ?org_id=.
+// An empty kind selects the system scope.
+func historyScopeFromQuery(r *http.Request) (gate.ScopeRef, error) {
+	query := r.URL.Query()
+	return ScopePayload{
+		Kind:     query.Get(QueryScopeKind),
+		ID:       query.Get(QueryScopeID),
+		TenantID: query.Get(QueryTenantID),
+		OrgID:    query.Get(QueryOrgID),
+	}.ScopeRef()
+}
+
+func writeGateError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if rich, ok := ferrors.As(err); ok {
+		switch {
+		case rich.Category == goerrors.CategoryBadInput:
+			status = http.StatusBadRequest
+		case rich.TextCode == ferrors.TextCodeStoreUnavailable:
+			status = http.StatusNotImplemented
+		case rich.Category == goerrors.CategoryExternal:
+			status = http.StatusBadGateway
+		}
+	}
+	writeError(w, status, err)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	resp := ErrorResponse{Error: err.Error()}
+	if rich, ok := ferrors.As(err); ok {
+		resp.Error = rich.Message
+		resp.TextCode = rich.TextCode
+	}
+	writeJSON(w, status, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,260 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+	"github.com/goliatone/go-featuregate/usage"
+)
+
+func newTestHandler() *Handler {
+	featureGate := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{
+			"users.signup": true,
+		})),
+		resolver.WithOverrideStore(store.NewMemoryStore()),
+	)
+	return New(featureGate)
+}
+
+func decodeFeature(t *testing.T, rec *httptest.ResponseRecorder) FeatureResponse {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	resp := FeatureResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return resp
+}
+
+func TestHandlerResolvesDefault(t *testing.T) {
+	h := newTestHandler()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/features/users.signup", nil))
+
+	resp := decodeFeature(t, rec)
+	if !resp.Enabled || resp.Source != gate.ResolveSourceDefault {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestHandlerSetAndUnsetTenantOverride(t *testing.T) {
+	h := newTestHandler()
+	body := `{"enabled": false, "scope": {"kind": "tenant", "id": "acme", "tenant_id": "acme"}, "actor": {"id": "admin"}}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/features/users.signup", strings.NewReader(body)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/features/users.signup?tenant_id=acme", nil))
+	resp := decodeFeature(t, rec)
+	if resp.Enabled || resp.Source != gate.ResolveSourceOverride {
+		t.Fatalf("expected tenant override, got %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/features/users.signup", strings.NewReader(`{"scope": {"kind": "tenant", "id": "acme", "tenant_id": "acme"}}`)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/features/users.signup?tenant_id=acme", nil))
+	if resp := decodeFeature(t, rec); !resp.Enabled {
+		t.Fatalf("expected default after unset, got %+v", resp)
+	}
+}
+
+func TestHandlerRejectsUnknownScopeKind(t *testing.T) {
+	h := newTestHandler()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/features/users.signup", strings.NewReader(`{"enabled": true, "scope": {"kind": "galaxy"}}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandlerListsOverridesByLabel(t *testing.T) {
+	h := newTestHandler()
+	bodies := []string{
+		`{"enabled": false, "scope": {"kind": "tenant", "id": "acme", "tenant_id": "acme"}, "labels": {"incident": "INC-1234"}}`,
+		`{"enabled": true, "scope": {"kind": "system"}, "labels": {"incident": "INC-9999"}}`,
+	}
+	for _, body := range bodies {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/features/users.signup", strings.NewReader(body)))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/overrides?label=incident=INC-1234", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var records []OverrideResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &records); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(records) != 1 || records[0].Scope.Kind != "tenant" || records[0].Metadata.Labels["incident"] != "INC-1234" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+}
+
+func TestHandlerHealthReportsGateConfig(t *testing.T) {
+	h := newTestHandler()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	resp := HealthResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Status != "ok" || resp.Config == nil {
+		t.Fatalf("expected config in health response, got %s", rec.Body.String())
+	}
+	if resp.Config.FailureMode != resolver.FailOpen || resp.Config.Strategy != resolver.StrategyDefault {
+		t.Fatalf("unexpected config: %+v", resp.Config)
+	}
+}
+
+func TestHandlerWatchStreamsUpdates(t *testing.T) {
+	watcher := activity.NewBroadcaster()
+	featureGate := resolver.New(
+		resolver.WithOverrideStore(store.NewMemoryStore()),
+		resolver.WithActivityHook(watcher),
+	)
+	srv := httptest.NewServer(New(featureGate, WithWatcher(watcher)))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/watch", nil)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("unexpected content type: %q", got)
+	}
+
+	scopeRef := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme"}
+	if err := featureGate.Set(ctx, "users.signup", scopeRef, true, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	for _, want := range []string{"event: update\n", "data: "} {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		if !strings.HasPrefix(line, want) {
+			t.Fatalf("expected %q, got %q", want, line)
+		}
+		if want == "data: " {
+			event := WatchEvent{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, want)), &event); err != nil {
+				t.Fatalf("decode event: %v", err)
+			}
+			if event.Key != "users.signup" || event.Action != activity.ActionSet || event.Enabled == nil || !*event.Enabled {
+				t.Fatalf("unexpected event: %+v", event)
+			}
+		}
+	}
+}
+
+func TestHandlerReportsUsage(t *testing.T) {
+	counter := usage.New(usage.WithCatalog(configadapter.NewCatalog(map[string]any{
+		"users.signup": map[string]any{"description": "Signup"},
+		"billing.v2":   map[string]any{"description": "Billing"},
+	})))
+	featureGate := resolver.New(
+		resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{"users.signup": true})),
+		resolver.WithResolveHook(counter),
+	)
+	h := New(featureGate, WithUsage(counter))
+	for i := 0; i < 2; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/features/users.signup", nil))
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/usage", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	report := usage.Report{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if len(report.Keys) != 2 || report.Keys[1].Key != "users.signup" || report.Keys[1].Count != 2 {
+		t.Fatalf("unexpected report: %+v", report.Keys)
+	}
+	if unused := report.Unused(); len(unused) != 1 || unused[0] != "billing.v2" {
+		t.Fatalf("expected billing.v2 unused, got %v", unused)
+	}
+}
+
+func TestHandlerKeyHistory(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	clk := clock.NewManual(start)
+	log := activity.NewLog(activity.WithLogClock(clk))
+	featureGate := resolver.New(
+		resolver.WithOverrideStore(store.NewMemoryStore()),
+		resolver.WithActivityHook(log),
+	)
+	h := New(featureGate, WithHistory(log), WithClock(clk))
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme"}
+	if err := featureGate.Set(context.Background(), "billing.v2", tenant, true, gate.ActorRef{ID: "alice"}, gate.WithReason("launch")); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	clk.Advance(10 * time.Minute)
+	if err := featureGate.Unset(context.Background(), "billing.v2", tenant, gate.ActorRef{ID: "bob"}); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+	clk.Advance(5 * time.Minute)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/features/billing.v2/history?kind=tenant&id=acme", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	resp := HistoryResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Segments) != 2 {
+		t.Fatalf("expected 2 segments, got %+v", resp.Segments)
+	}
+	first, last := resp.Segments[0], resp.Segments[1]
+	if first.Value == nil || !*first.Value || first.Actor.ID != "alice" || first.Reason != "launch" || first.DurationSeconds != 600 || first.End == nil {
+		t.Fatalf("unexpected first segment %+v", first)
+	}
+	if last.Value != nil || last.Action != "unset" || last.End != nil || last.DurationSeconds != 300 {
+		t.Fatalf("unexpected current segment %+v", last)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/features/billing.v2/history?kind=galaxy", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown kind, got %d", rec.Code)
+	}
+}
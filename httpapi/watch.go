@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const (
+	// EventUpdate is the SSE event name for override updates.
+	EventUpdate = "update"
+	// EventResync is sent when the watcher fell behind and events were dropped.
+	// Clients should reload full state (for example GET /overrides) before applying further updates.
+	EventResync = "resync"
+)
+
+// WatchEvent is the SSE payload for an override update.
+type WatchEvent struct {
+	Key       string                 `json:"key"`
+	Scope     ScopePayload           `json:"scope"`
+	Action    activity.Action        `json:"action"`
+	Enabled   *bool                  `json:"enabled,omitempty"`
+	Actor     ActorPayload           `json:"actor"`
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+	Metadata  *gate.OverrideMetadata `json:"metadata,omitempty"`
+}
+
+// ResyncEvent is the SSE payload sent when events were dropped. Dropped counts
+// the events lost since the previous batch sent to this watcher.
+type ResyncEvent struct {
+	Dropped uint64 `json:"dropped"`
+}
+
+func (h *Handler) watch(w http.ResponseWriter, r *http.Request) {
+	if h.watcher == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("watch not configured"))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+	sub := h.watcher.Subscribe()
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-sub.Ready():
+			events, dropped := sub.Drain()
+			if dropped > 0 {
+				if err := writeEvent(w, EventResync, ResyncEvent{Dropped: dropped}); err != nil {
+					return
+				}
+			}
+			for _, event := range events {
+				if err := writeEvent(w, EventUpdate, watchEventFromUpdate(event)); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func watchEventFromUpdate(event activity.UpdateEvent) WatchEvent {
+	out := WatchEvent{
+		Key: event.NormalizedKey,
+		Scope: ScopePayload{
+			Kind:     event.Scope.Kind.String(),
+			ID:       event.Scope.ID,
+			TenantID: event.Scope.TenantID,
+			OrgID:    event.Scope.OrgID,
+		},
+		Action:  event.Action,
+		Enabled: event.Value,
+		Actor: ActorPayload{
+			ID:   event.Actor.ID,
+			Type: event.Actor.Type,
+			Name: event.Actor.Name,
+		},
+	}
+	if out.Key == "" {
+		out.Key = event.Key
+	}
+	if !event.ExpiresAt.IsZero() {
+		expiresAt := event.ExpiresAt
+		out.ExpiresAt = &expiresAt
+	}
+	if !event.Metadata.IsZero() {
+		meta := event.Metadata
+		out.Metadata = &meta
+	}
+	return out
+}
+
+func writeEvent(w http.ResponseWriter, name string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
@@ -0,0 +1,253 @@
+// Package idgen generates time-ordered identifiers for update events and queued
+// mutations. Pick the scheme that matches your ID conventions; all of them sort by
+// creation time as plain strings, and IDs from one generator strictly increase,
+// including within a millisecond.
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+)
+
+// Generator produces unique identifiers.
+type Generator interface {
+	NewID() string
+}
+
+// Func adapts a function to Generator.
+type Func func() string
+
+// NewID implements Generator.
+func (fn Func) NewID() string {
+	if fn == nil {
+		return ""
+	}
+	return fn()
+}
+
+// Option customizes the built-in generators.
+type Option func(*config)
+
+type config struct {
+	now     func() time.Time
+	entropy io.Reader
+	epoch   time.Time
+	node    int64
+}
+
+// WithClock sets the clock used for the timestamp component.
+func WithClock(c clock.Clock) Option {
+	return func(cfg *config) {
+		if cfg == nil {
+			return
+		}
+		cfg.now = clock.NowFunc(c)
+	}
+}
+
+// WithEntropy sets the random source for UUIDv7 and ULID. Defaults to crypto/rand.
+func WithEntropy(r io.Reader) Option {
+	return func(cfg *config) {
+		if cfg == nil {
+			return
+		}
+		cfg.entropy = r
+	}
+}
+
+// WithEpoch sets the snowflake epoch. Defaults to DefaultSnowflakeEpoch.
+func WithEpoch(epoch time.Time) Option {
+	return func(cfg *config) {
+		if cfg == nil {
+			return
+		}
+		cfg.epoch = epoch
+	}
+}
+
+// DefaultSnowflakeEpoch is the zero point for snowflake timestamps.
+var DefaultSnowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		now:     time.Now,
+		entropy: rand.Reader,
+		epoch:   DefaultSnowflakeEpoch,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.now == nil {
+		cfg.now = time.Now
+	}
+	if cfg.entropy == nil {
+		cfg.entropy = rand.Reader
+	}
+	return cfg
+}
+
+// Default is the generator used when none is configured (UUIDv7).
+var Default Generator = UUIDv7()
+
+// Or returns gen, or Default when gen is nil.
+func Or(gen Generator) Generator {
+	if gen == nil {
+		return Default
+	}
+	return gen
+}
+
+// UUIDv7 returns a generator of RFC 9562 version 7 UUIDs in canonical form.
+// Within a millisecond the 12-bit rand_a field is a counter (RFC 9562 method 1),
+// seeded randomly with its top bit clear.
+func UUIDv7(opts ...Option) Generator {
+	cfg := newConfig(opts)
+	var (
+		mu      sync.Mutex
+		lastMS  int64
+		counter uint16
+	)
+	return Func(func() string {
+		var b [16]byte
+		_, _ = io.ReadFull(cfg.entropy, b[6:])
+		mu.Lock()
+		ms := max(cfg.now().UnixMilli(), lastMS)
+		if ms == lastMS {
+			counter++
+			if counter > 0x0fff {
+				ms++
+				counter = 0
+			}
+		} else {
+			counter = uint16(b[6]&0x07)<<8 | uint16(b[7])
+		}
+		lastMS = ms
+		seq := counter
+		mu.Unlock()
+		for i := 0; i < 6; i++ {
+			b[i] = byte(uint64(ms) >> (40 - 8*i))
+		}
+		b[6] = 0x70 | byte(seq>>8)
+		b[7] = byte(seq)
+		b[8] = (b[8] & 0x3f) | 0x80
+		var out [36]byte
+		hex.Encode(out[0:8], b[0:4])
+		out[8] = '-'
+		hex.Encode(out[9:13], b[4:6])
+		out[13] = '-'
+		hex.Encode(out[14:18], b[6:8])
+		out[18] = '-'
+		hex.Encode(out[19:23], b[8:10])
+		out[23] = '-'
+		hex.Encode(out[24:], b[10:])
+		return string(out[:])
+	})
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID returns a generator of 26-character Crockford base32 ULIDs. Within a
+// millisecond the random component is incremented, as in the ULID monotonic mode.
+func ULID(opts ...Option) Generator {
+	cfg := newConfig(opts)
+	var (
+		mu         sync.Mutex
+		lastMS     int64
+		lastRandom [10]byte
+	)
+	return Func(func() string {
+		var b [16]byte
+		mu.Lock()
+		ms := max(cfg.now().UnixMilli(), lastMS)
+		if ms == lastMS && increment(lastRandom[:]) {
+			copy(b[6:], lastRandom[:])
+		} else {
+			if ms == lastMS {
+				ms++
+			}
+			_, _ = io.ReadFull(cfg.entropy, b[6:])
+			copy(lastRandom[:], b[6:])
+		}
+		lastMS = ms
+		mu.Unlock()
+		for i := 0; i < 6; i++ {
+			b[i] = byte(uint64(ms) >> (40 - 8*i))
+		}
+		var out [26]byte
+		// 128 bits encoded as 26 base32 digits; the first digit carries the top 3 bits.
+		var hi, lo uint64
+		for i := 0; i < 8; i++ {
+			hi = hi<<8 | uint64(b[i])
+			lo = lo<<8 | uint64(b[8+i])
+		}
+		for i := 25; i >= 0; i-- {
+			out[i] = crockford[lo&0x1f]
+			lo = lo>>5 | hi<<59
+			hi >>= 5
+		}
+		return string(out[:])
+	})
+}
+
+// increment adds one to b as a big-endian number and reports false on overflow.
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxNode      = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+// Snowflake returns a generator of 64-bit snowflake IDs (41-bit milliseconds since
+// the epoch, 10-bit node, 12-bit sequence) rendered as zero-padded decimal strings so
+// they sort lexically. node is masked to 10 bits.
+func Snowflake(node int64, opts ...Option) Generator {
+	cfg := newConfig(opts)
+	cfg.node = node & snowflakeMaxNode
+	var (
+		mu       sync.Mutex
+		lastMS   int64
+		sequence int64
+	)
+	return Func(func() string {
+		mu.Lock()
+		ms := cfg.now().Sub(cfg.epoch).Milliseconds()
+		if ms < lastMS {
+			ms = lastMS
+		}
+		if ms == lastMS {
+			sequence = (sequence + 1) & snowflakeMaxSequence
+			if sequence == 0 {
+				ms++
+			}
+		} else {
+			sequence = 0
+		}
+		lastMS = ms
+		id := ms<<(snowflakeNodeBits+snowflakeSequenceBits) | cfg.node<<snowflakeSequenceBits | sequence
+		mu.Unlock()
+		s := strconv.FormatInt(id, 10)
+		const width = 19
+		if len(s) < width {
+			s = "0000000000000000000"[:width-len(s)] + s
+		}
+		return s
+	})
+}
@@ -0,0 +1,64 @@
+package idgen
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+)
+
+func TestGeneratorsSortByTime(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name    string
+		build   func(clock.Clock) Generator
+		pattern *regexp.Regexp
+	}{
+		{"uuidv7", func(c clock.Clock) Generator { return UUIDv7(WithClock(c)) }, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"ulid", func(c clock.Clock) Generator { return ULID(WithClock(c)) }, regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)},
+		{"snowflake", func(c clock.Clock) Generator { return Snowflake(7, WithClock(c)) }, regexp.MustCompile(`^[0-9]{19}$`)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clk := clock.NewManual(start)
+			gen := tc.build(clk)
+			ids := make([]string, 0, 20)
+			seen := map[string]bool{}
+			for i := 0; i < 20; i++ {
+				id := gen.NewID()
+				if !tc.pattern.MatchString(id) {
+					t.Fatalf("unexpected format %q", id)
+				}
+				if seen[id] {
+					t.Fatalf("duplicate id %q", id)
+				}
+				seen[id] = true
+				ids = append(ids, id)
+				clk.Advance(time.Millisecond)
+			}
+			if !sort.StringsAreSorted(ids) {
+				t.Fatalf("expected ids to sort by time, got %v", ids)
+			}
+		})
+	}
+}
+
+func TestGeneratorsIncreaseWithinMillisecond(t *testing.T) {
+	clk := clock.NewManual(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	for name, gen := range map[string]Generator{
+		"uuidv7":    UUIDv7(WithClock(clk)),
+		"ulid":      ULID(WithClock(clk)),
+		"snowflake": Snowflake(1, WithClock(clk)),
+	} {
+		prev := gen.NewID()
+		for i := 0; i < 5000; i++ {
+			next := gen.NewID()
+			if next <= prev {
+				t.Fatalf("%s: expected increasing ids within one millisecond, got %s then %s", name, prev, next)
+			}
+			prev = next
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package incident
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+const (
+	// LabelIncident is the override label carrying the incident ID.
+	LabelIncident = "incident"
+
+	TextCodeIDRequired = "INCIDENT_ID_REQUIRED"
+	TextCodeExists     = "INCIDENT_EXISTS"
+	TextCodeNotFound   = "INCIDENT_NOT_FOUND"
+	TextCodeClosed     = "INCIDENT_CLOSED"
+)
+
+// Manager tracks incident sessions and the flag changes made during them.
+type Manager struct {
+	mu       sync.Mutex
+	gate     gate.MutableFeatureGate
+	reader   store.Reader
+	now      func() time.Time
+	sessions map[string]*Session
+}
+
+// Option customizes a Manager.
+type Option func(*Manager)
+
+// WithReader sets the override reader used to capture state before the first change.
+// Without a reader, Revert unsets every changed override.
+func WithReader(reader store.Reader) Option {
+	return func(m *Manager) {
+		if m == nil {
+			return
+		}
+		m.reader = reader
+	}
+}
+
+// WithClock sets the clock used for session timestamps and timeboxes.
+func WithClock(c clock.Clock) Option {
+	return func(m *Manager) {
+		if m == nil {
+			return
+		}
+		m.now = clock.NowFunc(c)
+	}
+}
+
+// WithNowFunc overrides the clock used for session timestamps.
+func WithNowFunc(now func() time.Time) Option {
+	return func(m *Manager) {
+		if m == nil {
+			return
+		}
+		m.now = now
+	}
+}
+
+// New constructs a Manager that applies changes through the provided gate.
+func New(fg gate.MutableFeatureGate, opts ...Option) *Manager {
+	m := &Manager{
+		gate:     fg,
+		now:      time.Now,
+		sessions: map[string]*Session{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	if m.now == nil {
+		m.now = time.Now
+	}
+	return m
+}
+
+// Change records a single flag change made during an incident.
+// A nil Enabled means the override was unset.
+type Change struct {
+	Key      string
+	Scope    gate.ScopeRef
+	Enabled  *bool
+	Actor    gate.ActorRef
+	At       time.Time
+	Previous store.Override
+}
+
+// Summary describes an incident session and its changes.
+type Summary struct {
+	ID          string
+	Description string
+	OpenedBy    gate.ActorRef
+	OpenedAt    time.Time
+	ExpiresAt   time.Time
+	ClosedAt    time.Time
+	Reverted    bool
+	Changes     []Change
+	Keys        []string
+}
+
+// Session groups flag changes under an incident ID.
+type Session struct {
+	manager *Manager
+
+	mu          sync.Mutex
+	id          string
+	description string
+	openedBy    gate.ActorRef
+	openedAt    time.Time
+	expiresAt   time.Time
+	closedAt    time.Time
+	reverted    bool
+	changes     []Change
+	previous    map[target]store.Override
+	order       []target
+}
+
+type target struct {
+	key   string
+	scope gate.ScopeRef
+}
+
+// Open starts an incident session. A positive timebox expires every override
+// set during the session and rejects changes once it elapses.
+func (m *Manager) Open(_ context.Context, id, description string, actor gate.ActorRef, timebox time.Duration) (*Session, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, ferrors.NewBadInput(TextCodeIDRequired, "incident: id is required", nil)
+	}
+	if m.gate == nil {
+		return nil, ferrors.WrapSentinel(ferrors.ErrGateRequired, "incident: feature gate is required", nil)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.sessions[id]; ok && existing.open(m.now()) {
+		return nil, ferrors.NewBadInput(TextCodeExists, "incident: session already open", map[string]any{
+			"incident": id,
+		})
+	}
+	now := m.now()
+	session := &Session{
+		manager:     m,
+		id:          id,
+		description: strings.TrimSpace(description),
+		openedBy:    actor,
+		openedAt:    now,
+		previous:    map[target]store.Override{},
+	}
+	if timebox > 0 {
+		session.expiresAt = now.Add(timebox)
+	}
+	m.sessions[id] = session
+	return session, nil
+}
+
+// Get returns a session by ID, including closed sessions.
+func (m *Manager) Get(id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[strings.TrimSpace(id)]
+	if !ok {
+		return nil, ferrors.NewBadInput(TextCodeNotFound, "incident: session not found", map[string]any{
+			"incident": id,
+		})
+	}
+	return session, nil
+}
+
+// Active lists open sessions sorted by ID.
+func (m *Manager) Active() []*Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	out := make([]*Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		if session.open(now) {
+			out = append(out, session)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].id < out[j].id })
+	return out
+}
+
+// ID returns the incident ID.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Set enables or disables a flag, attributing the change to the incident.
+// The override is labeled with the incident ID and expires with the timebox.
+func (s *Session) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	t, err := s.capture(ctx, key, scopeRef)
+	if err != nil {
+		return err
+	}
+	base := []gate.MutationOption{gate.WithLabel(LabelIncident, s.id)}
+	if s.description != "" {
+		base = append(base, gate.WithReason(s.description))
+	}
+	if !s.expiresAt.IsZero() {
+		base = append(base, gate.WithExpiresAt(s.expiresAt))
+	}
+	if err := s.manager.gate.Set(ctx, key, scopeRef, enabled, actor, append(base, opts...)...); err != nil {
+		return err
+	}
+	s.record(t, &enabled, actor)
+	return nil
+}
+
+// Unset clears a flag override, attributing the change to the incident.
+func (s *Session) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	t, err := s.capture(ctx, key, scopeRef)
+	if err != nil {
+		return err
+	}
+	if err := s.manager.gate.Unset(ctx, key, scopeRef, actor); err != nil {
+		return err
+	}
+	s.record(t, nil, actor)
+	return nil
+}
+
+// Close ends the session and returns its summary. Overrides are left in place.
+func (s *Session) Close(_ context.Context) (Summary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closedAt.IsZero() {
+		s.closedAt = s.manager.now()
+	}
+	return s.summary(), nil
+}
+
+// Revert restores every flag changed during the session to its state before the
+// first change, newest first, and closes the session.
+func (s *Session) Revert(ctx context.Context, actor gate.ActorRef) (Summary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.manager.now()
+	for i := len(s.order) - 1; i >= 0; i-- {
+		t := s.order[i]
+		if err := restore(ctx, s.manager.gate, t, s.previous[t], actor, now); err != nil {
+			return s.summary(), err
+		}
+	}
+	s.reverted = true
+	if s.closedAt.IsZero() {
+		s.closedAt = now
+	}
+	return s.summary(), nil
+}
+
+// Summary returns the current session summary.
+func (s *Session) Summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.summary()
+}
+
+func (s *Session) open(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closedAt.IsZero() && (s.expiresAt.IsZero() || now.Before(s.expiresAt))
+}
+
+func (s *Session) checkOpen() error {
+	now := s.manager.now()
+	if s.closedAt.IsZero() && (s.expiresAt.IsZero() || now.Before(s.expiresAt)) {
+		return nil
+	}
+	return ferrors.NewBadInput(TextCodeClosed, "incident: session is closed", map[string]any{
+		"incident": s.id,
+	})
+}
+
+// capture stores the override state before the first change to a key and scope.
+func (s *Session) capture(ctx context.Context, key string, scopeRef gate.ScopeRef) (target, error) {
+	t := target{key: gate.NormalizeKey(key), scope: scopeRef}
+	if _, ok := s.previous[t]; ok {
+		return t, nil
+	}
+	prev, err := readPrevious(ctx, s.manager.reader, t)
+	if err != nil {
+		return t, err
+	}
+	s.previous[t] = prev
+	s.order = append(s.order, t)
+	return t, nil
+}
+
+// readPrevious returns the stored override for t, or a missing override without a reader.
+func readPrevious(ctx context.Context, reader store.Reader, t target) (store.Override, error) {
+	prev := store.MissingOverride()
+	if reader == nil || t.key == "" {
+		return prev, nil
+	}
+	matches, err := reader.GetAll(ctx, t.key, gate.ScopeChain{t.scope})
+	if err != nil {
+		return prev, ferrors.WrapExternal(err, ferrors.TextCodeStoreReadFailed, "incident: read previous override failed", map[string]any{
+			ferrors.MetaFeatureKey: t.key,
+			ferrors.MetaScope:      t.scope,
+			ferrors.MetaOperation:  "capture",
+		})
+	}
+	for _, match := range matches {
+		if match.Scope == t.scope {
+			prev = match.Override
+		}
+	}
+	return prev, nil
+}
+
+// restore writes prev back for t, unsetting when it held no live value.
+func restore(ctx context.Context, fg gate.MutableFeatureGate, t target, prev store.Override, actor gate.ActorRef, now time.Time) error {
+	if prev.HasValue() && !prev.Expired(now) {
+		opts := []gate.MutationOption{gate.WithMetadata(prev.Metadata), gate.WithPriority(prev.Priority)}
+		if !prev.ExpiresAt.IsZero() {
+			opts = append(opts, gate.WithExpiresAt(prev.ExpiresAt))
+		}
+		return fg.Set(ctx, t.key, t.scope, prev.Value, actor, opts...)
+	}
+	return fg.Unset(ctx, t.key, t.scope, actor)
+}
+
+func (s *Session) record(t target, enabled *bool, actor gate.ActorRef) {
+	s.changes = append(s.changes, Change{
+		Key:      t.key,
+		Scope:    t.scope,
+		Enabled:  enabled,
+		Actor:    actor,
+		At:       s.manager.now(),
+		Previous: s.previous[t],
+	})
+}
+
+func (s *Session) summary() Summary {
+	keys := make([]string, 0, len(s.order))
+	seen := map[string]bool{}
+	for _, t := range s.order {
+		if !seen[t.key] {
+			seen[t.key] = true
+			keys = append(keys, t.key)
+		}
+	}
+	sort.Strings(keys)
+	return Summary{
+		ID:          s.id,
+		Description: s.description,
+		OpenedBy:    s.openedBy,
+		OpenedAt:    s.openedAt,
+		ExpiresAt:   s.expiresAt,
+		ClosedAt:    s.closedAt,
+		Reverted:    s.reverted,
+		Changes:     append([]Change(nil), s.changes...),
+		Keys:        keys,
+	}
+}
@@ -0,0 +1,59 @@
+package incident
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestSessionRevertRestoresPreviousState(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	fg := resolver.New(resolver.WithOverrideStore(overrides))
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	chain := gate.WithScopeChain(gate.ScopeChain{system})
+	if err := fg.Set(ctx, "checkout", system, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	manager := New(fg, WithReader(overrides))
+	session, err := manager.Open(ctx, "INC-1234", "payments outage", gate.ActorRef{ID: "oncall"}, time.Hour)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := manager.Open(ctx, "INC-1234", "", gate.ActorRef{}, 0); err == nil {
+		t.Fatalf("expected duplicate open to fail")
+	}
+	if err := session.Set(ctx, "checkout", system, false, gate.ActorRef{ID: "oncall"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := session.Set(ctx, "maintenance_banner", system, true, gate.ActorRef{ID: "oncall"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	records, err := fg.ListOverrides(ctx, store.ListFilter{Labels: map[string]string{LabelIncident: "INC-1234"}})
+	if err != nil || len(records) != 2 {
+		t.Fatalf("expected 2 labeled overrides, got %d (%v)", len(records), err)
+	}
+
+	summary, err := session.Revert(ctx, gate.ActorRef{ID: "oncall"})
+	if err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+	if !summary.Reverted || len(summary.Changes) != 2 || len(summary.Keys) != 2 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if value, _ := fg.Enabled(ctx, "checkout", chain); !value {
+		t.Fatalf("expected checkout restored to enabled")
+	}
+	if value, _ := fg.Enabled(ctx, "maintenance_banner", chain); value {
+		t.Fatalf("expected maintenance banner restored to default")
+	}
+	if err := session.Set(ctx, "checkout", system, false, gate.ActorRef{}); err == nil {
+		t.Fatalf("expected closed session to reject changes")
+	}
+}
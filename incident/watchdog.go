@@ -0,0 +1,331 @@
+package incident
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// DefaultWatchInterval is how often a Watchdog consults its trigger.
+const DefaultWatchInterval = 30 * time.Second
+
+// LabelRollback marks rollback events with the key that was reverted.
+const LabelRollback = "rollback"
+
+// RollbackTrigger decides whether a key in active rollout should be reverted, for
+// example when an error budget burns too fast. The reason ends up in the rollback event.
+type RollbackTrigger interface {
+	Evaluate(ctx context.Context, key string) (bool, string)
+}
+
+// TriggerFunc adapts a function to RollbackTrigger.
+type TriggerFunc func(ctx context.Context, key string) (bool, string)
+
+// Evaluate implements RollbackTrigger.
+func (fn TriggerFunc) Evaluate(ctx context.Context, key string) (bool, string) {
+	if fn == nil {
+		return false, ""
+	}
+	return fn(ctx, key)
+}
+
+// Rollout is a change the Watchdog is watching.
+type Rollout struct {
+	Key       string
+	Scope     gate.ScopeRef
+	Enabled   bool
+	Actor     gate.ActorRef
+	StartedAt time.Time
+	Previous  store.Override
+}
+
+// Rollback records a rollout reverted because its trigger fired.
+type Rollback struct {
+	Rollout
+	Reason string
+	At     time.Time
+}
+
+// Watchdog periodically evaluates a RollbackTrigger for keys in active rollout and,
+// when it fires, restores the override captured before the rollout.
+type Watchdog struct {
+	gate     gate.MutableFeatureGate
+	trigger  RollbackTrigger
+	reader   store.Reader
+	interval time.Duration
+	actor    gate.ActorRef
+	hooks    []activity.Hook
+	onError  func(error)
+	now      func() time.Time
+
+	mu       sync.Mutex
+	rollouts map[target]Rollout
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// WatchdogOption customizes a Watchdog.
+type WatchdogOption func(*Watchdog)
+
+// WithWatchdogReader sets the override reader used to capture state before a rollout.
+// Without a reader, rollbacks unset the override.
+func WithWatchdogReader(reader store.Reader) WatchdogOption {
+	return func(w *Watchdog) {
+		if w == nil {
+			return
+		}
+		w.reader = reader
+	}
+}
+
+// WithWatchInterval sets how often the trigger is consulted. Values below one use the default.
+func WithWatchInterval(interval time.Duration) WatchdogOption {
+	return func(w *Watchdog) {
+		if w == nil {
+			return
+		}
+		w.interval = interval
+	}
+}
+
+// WithRollbackActor sets the actor recorded on rollback writes.
+func WithRollbackActor(actor gate.ActorRef) WatchdogOption {
+	return func(w *Watchdog) {
+		if w == nil {
+			return
+		}
+		w.actor = actor
+	}
+}
+
+// WithRollbackHook receives an activity.ActionRollback event for every rollback.
+func WithRollbackHook(hook activity.Hook) WatchdogOption {
+	return func(w *Watchdog) {
+		if w == nil || hook == nil {
+			return
+		}
+		w.hooks = append(w.hooks, hook)
+	}
+}
+
+// WithWatchdogErrorHandler receives errors from background checks.
+func WithWatchdogErrorHandler(fn func(error)) WatchdogOption {
+	return func(w *Watchdog) {
+		if w == nil {
+			return
+		}
+		w.onError = fn
+	}
+}
+
+// WithWatchdogClock sets the clock used for rollout and rollback timestamps.
+func WithWatchdogClock(c clock.Clock) WatchdogOption {
+	return func(w *Watchdog) {
+		if w == nil {
+			return
+		}
+		w.now = clock.NowFunc(c)
+	}
+}
+
+// NewWatchdog constructs a Watchdog that writes through fg and consults trigger.
+func NewWatchdog(fg gate.MutableFeatureGate, trigger RollbackTrigger, opts ...WatchdogOption) *Watchdog {
+	w := &Watchdog{
+		gate:     fg,
+		trigger:  trigger,
+		interval: DefaultWatchInterval,
+		actor:    gate.ActorRef{ID: "featuregate-watchdog", Type: "system"},
+		now:      time.Now,
+		rollouts: map[target]Rollout{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(w)
+		}
+	}
+	if w.interval <= 0 {
+		w.interval = DefaultWatchInterval
+	}
+	if w.now == nil {
+		w.now = time.Now
+	}
+	return w
+}
+
+// Begin captures the current override, applies the rollout value, and starts watching it.
+func (w *Watchdog) Begin(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if w.gate == nil {
+		return ferrors.WrapSentinel(ferrors.ErrGateRequired, "incident: feature gate is required", nil)
+	}
+	t := target{key: gate.NormalizeKey(strings.TrimSpace(key)), scope: scopeRef}
+	prev, err := readPrevious(ctx, w.reader, t)
+	if err != nil {
+		return err
+	}
+	if err := w.gate.Set(ctx, key, scopeRef, enabled, actor, opts...); err != nil {
+		return err
+	}
+	w.Watch(Rollout{Key: t.key, Scope: scopeRef, Enabled: enabled, Actor: actor, Previous: prev})
+	return nil
+}
+
+// Watch tracks a rollout applied elsewhere. Previous is restored on rollback;
+// a zero StartedAt is set to now. Watching the same key and scope again keeps the
+// originally captured Previous.
+func (w *Watchdog) Watch(rollout Rollout) {
+	rollout.Key = gate.NormalizeKey(strings.TrimSpace(rollout.Key))
+	if rollout.StartedAt.IsZero() {
+		rollout.StartedAt = w.now()
+	}
+	t := target{key: rollout.Key, scope: rollout.Scope}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if existing, ok := w.rollouts[t]; ok {
+		rollout.Previous = existing.Previous
+	}
+	w.rollouts[t] = rollout
+}
+
+// Complete stops watching a rollout, leaving its override in place.
+func (w *Watchdog) Complete(key string, scopeRef gate.ScopeRef) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.rollouts, target{key: gate.NormalizeKey(strings.TrimSpace(key)), scope: scopeRef})
+}
+
+// Active lists watched rollouts sorted by key.
+func (w *Watchdog) Active() []Rollout {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]Rollout, 0, len(w.rollouts))
+	for _, rollout := range w.rollouts {
+		out = append(out, rollout)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Key != out[j].Key {
+			return out[i].Key < out[j].Key
+		}
+		return out[i].Scope.ID < out[j].Scope.ID
+	})
+	return out
+}
+
+// Check evaluates the trigger once per watched key and rolls back those that fire.
+// Rolled back rollouts stop being watched; failed rollbacks stay watched for the next check.
+func (w *Watchdog) Check(ctx context.Context) ([]Rollback, error) {
+	if w.trigger == nil {
+		return nil, nil
+	}
+	fired := map[string]string{}
+	var rollbacks []Rollback
+	for _, rollout := range w.Active() {
+		reason, evaluated := fired[rollout.Key]
+		if !evaluated {
+			ok, why := w.trigger.Evaluate(ctx, rollout.Key)
+			if !ok {
+				why = ""
+			} else if why == "" {
+				why = "rollback trigger fired"
+			}
+			fired[rollout.Key] = why
+			reason = why
+		}
+		if reason == "" {
+			continue
+		}
+		rollback, err := w.rollback(ctx, rollout, reason)
+		if err != nil {
+			return rollbacks, err
+		}
+		rollbacks = append(rollbacks, rollback)
+	}
+	return rollbacks, nil
+}
+
+func (w *Watchdog) rollback(ctx context.Context, rollout Rollout, reason string) (Rollback, error) {
+	now := w.now()
+	t := target{key: rollout.Key, scope: rollout.Scope}
+	if err := restore(ctx, w.gate, t, rollout.Previous, w.actor, now); err != nil {
+		return Rollback{}, ferrors.WrapOperation(err, ferrors.TextCodeStoreWriteFailed, "incident: rollback failed", map[string]any{
+			ferrors.MetaFeatureKey: rollout.Key,
+			ferrors.MetaScope:      rollout.Scope,
+			ferrors.MetaOperation:  "rollback",
+		})
+	}
+	w.mu.Lock()
+	delete(w.rollouts, t)
+	w.mu.Unlock()
+
+	event := activity.UpdateEvent{
+		Key:           rollout.Key,
+		NormalizedKey: rollout.Key,
+		Scope:         rollout.Scope,
+		Actor:         w.actor,
+		Action:        activity.ActionRollback,
+		Metadata: gate.OverrideMetadata{
+			Reason: reason,
+			Labels: map[string]string{LabelRollback: rollout.Key},
+		},
+	}
+	if prev := rollout.Previous; prev.HasValue() && !prev.Expired(now) {
+		value := prev.Value
+		event.Value = &value
+		event.ExpiresAt = prev.ExpiresAt
+	}
+	for _, hook := range w.hooks {
+		hook.OnUpdate(ctx, event)
+	}
+	return Rollback{Rollout: rollout, Reason: reason, At: now}, nil
+}
+
+// Start runs Check every interval until Stop.
+func (w *Watchdog) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		return
+	}
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	go w.loop(w.stop, w.done)
+}
+
+// Stop ends background checks and waits for the current one to finish.
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (w *Watchdog) loop(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+			_, err := w.Check(ctx)
+			cancel()
+			if err != nil && w.onError != nil {
+				w.onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package incident
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestWatchdogRollsBackWhenTriggerFires(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	fg := resolver.New(resolver.WithOverrideStore(overrides))
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme"}
+	actor := gate.ActorRef{ID: "alice"}
+
+	if err := fg.Set(ctx, "billing.v2", tenant, false, actor, gate.WithReason("baseline")); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	burning := map[string]bool{}
+	var events []activity.UpdateEvent
+	watchdog := NewWatchdog(fg, TriggerFunc(func(_ context.Context, key string) (bool, string) {
+		return burning[key], "error budget burn 14x"
+	}), WithWatchdogReader(overrides), WithRollbackHook(activity.HookFunc(func(_ context.Context, event activity.UpdateEvent) {
+		events = append(events, event)
+	})))
+
+	if err := watchdog.Begin(ctx, "billing.v2", tenant, true, actor); err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := watchdog.Begin(ctx, "search.v3", tenant, true, actor); err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+
+	rollbacks, err := watchdog.Check(ctx)
+	if err != nil || len(rollbacks) != 0 {
+		t.Fatalf("expected no rollbacks, got %+v (%v)", rollbacks, err)
+	}
+
+	burning["billing.v2"] = true
+	rollbacks, err = watchdog.Check(ctx)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(rollbacks) != 1 || rollbacks[0].Key != "billing.v2" || rollbacks[0].Reason != "error budget burn 14x" {
+		t.Fatalf("unexpected rollbacks %+v", rollbacks)
+	}
+	enabled, err := fg.Enabled(ctx, "billing.v2", gate.WithScopeChain(gate.ScopeChain{tenant}))
+	if err != nil || enabled {
+		t.Fatalf("expected previous disabled override restored, got %v (%v)", enabled, err)
+	}
+	if len(events) != 1 || events[0].Action != activity.ActionRollback || events[0].Value == nil || *events[0].Value {
+		t.Fatalf("unexpected rollback events %+v", events)
+	}
+	if active := watchdog.Active(); len(active) != 1 || active[0].Key != "search.v3" {
+		t.Fatalf("expected only search.v3 watched, got %+v", active)
+	}
+
+	burning["search.v3"] = true
+	if _, err := watchdog.Check(ctx); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	matches, err := overrides.GetAll(ctx, "search.v3", gate.ScopeChain{tenant})
+	if err != nil || len(matches) != 1 || matches[0].Override.HasValue() {
+		t.Fatalf("expected rollout without previous state to be unset, got %+v (%v)", matches, err)
+	}
+}
@@ -0,0 +1,9 @@
+// Package integration runs the store conformance suite against real backends started
+// in Docker with dockertest. It is a separate module so the root module stays free of
+// drivers and Docker tooling, and its tests sit behind the integration build tag:
+//
+//	cd integration && go test -tags integration ./...
+//
+// Set FEATUREGATE_POSTGRES_DSN to run against an existing Postgres instead of a
+// container.
+package integration
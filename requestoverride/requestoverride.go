@@ -0,0 +1,204 @@
+package requestoverride
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const (
+	// DefaultHeader is the request header carrying a signed override token.
+	DefaultHeader = "X-Feature-Override"
+	// DefaultCookie is the cookie carrying a signed override token.
+	DefaultCookie = "feature_override"
+
+	TextCodeTokenInvalid = "REQUEST_OVERRIDE_TOKEN_INVALID"
+	TextCodeTokenExpired = "REQUEST_OVERRIDE_TOKEN_EXPIRED"
+)
+
+// Authorizer reports whether the request may apply overrides (for example QA or staff users).
+type Authorizer func(r *http.Request) bool
+
+// Option customizes the request override middleware.
+type Option func(*config)
+
+type config struct {
+	header     string
+	cookie     string
+	authorizer Authorizer
+	now        func() time.Time
+	onReject   func(r *http.Request, err error)
+}
+
+// WithHeader overrides the header name. An empty name disables header lookup.
+func WithHeader(name string) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.header = strings.TrimSpace(name)
+	}
+}
+
+// WithCookie overrides the cookie name. An empty name disables cookie lookup.
+func WithCookie(name string) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.cookie = strings.TrimSpace(name)
+	}
+}
+
+// WithAuthorizer sets the check that limits overrides to QA/staff users.
+// Without an authorizer, all override tokens are ignored.
+func WithAuthorizer(authorizer Authorizer) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.authorizer = authorizer
+	}
+}
+
+// WithClock sets the clock used for token expiry.
+func WithClock(c clock.Clock) Option {
+	return func(cfg *config) {
+		if cfg == nil {
+			return
+		}
+		cfg.now = clock.NowFunc(c)
+	}
+}
+
+// WithNowFunc overrides the clock used for token expiry.
+func WithNowFunc(now func() time.Time) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.now = now
+	}
+}
+
+// WithRejectHandler observes tokens that fail verification.
+func WithRejectHandler(fn func(r *http.Request, err error)) Option {
+	return func(c *config) {
+		if c == nil {
+			return
+		}
+		c.onReject = fn
+	}
+}
+
+// Middleware pins feature values from a signed header or cookie into the request context.
+// Requests that are not authorized or carry an invalid token pass through unchanged.
+func Middleware(secret []byte, opts ...Option) func(http.Handler) http.Handler {
+	cfg := config{
+		header: DefaultHeader,
+		cookie: DefaultCookie,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.now == nil {
+		cfg.now = time.Now
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := cfg.token(r)
+			if token == "" || len(secret) == 0 || cfg.authorizer == nil || !cfg.authorizer(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			values, err := Verify(secret, token, cfg.now())
+			if err != nil {
+				if cfg.onReject != nil {
+					cfg.onReject(r, err)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(gate.WithRequestOverrides(r.Context(), values)))
+		})
+	}
+}
+
+func (c config) token(r *http.Request) string {
+	if c.header != "" {
+		if value := strings.TrimSpace(r.Header.Get(c.header)); value != "" {
+			return value
+		}
+	}
+	if c.cookie != "" {
+		if cookie, err := r.Cookie(c.cookie); err == nil {
+			return strings.TrimSpace(cookie.Value)
+		}
+	}
+	return ""
+}
+
+type payload struct {
+	Values    map[string]bool `json:"v"`
+	ExpiresAt int64           `json:"exp,omitempty"`
+}
+
+// Sign encodes pinned values into a token for the header or cookie.
+// A zero expiresAt produces a token that never expires.
+func Sign(secret []byte, values map[string]bool, expiresAt time.Time) (string, error) {
+	body := payload{Values: map[string]bool{}}
+	for key, value := range values {
+		if normalized := gate.NormalizeKey(key); normalized != "" {
+			body.Values[normalized] = value
+		}
+	}
+	if !expiresAt.IsZero() {
+		body.ExpiresAt = expiresAt.Unix()
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return "", ferrors.WrapInternal(err, TextCodeTokenInvalid, "requestoverride: encode failed", nil)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	return encoded + "." + signature(secret, encoded), nil
+}
+
+// Verify checks a token signature and expiry and returns the pinned values.
+func Verify(secret []byte, token string, now time.Time) (map[string]bool, error) {
+	encoded, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || encoded == "" || sig == "" {
+		return nil, ferrors.NewBadInput(TextCodeTokenInvalid, "requestoverride: malformed token", nil)
+	}
+	if !hmac.Equal([]byte(sig), []byte(signature(secret, encoded))) {
+		return nil, ferrors.NewBadInput(TextCodeTokenInvalid, "requestoverride: invalid signature", nil)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ferrors.WrapBadInput(err, TextCodeTokenInvalid, "requestoverride: malformed payload", nil)
+	}
+	body := payload{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, ferrors.WrapBadInput(err, TextCodeTokenInvalid, "requestoverride: malformed payload", nil)
+	}
+	if body.ExpiresAt > 0 && !now.Before(time.Unix(body.ExpiresAt, 0)) {
+		return nil, ferrors.NewBadInput(TextCodeTokenExpired, "requestoverride: token expired", nil)
+	}
+	return body.Values, nil
+}
+
+func signature(secret []byte, encoded string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,72 @@
+package requestoverride
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+)
+
+var testSecret = []byte("qa-secret")
+
+func resolveHandler(t *testing.T, fg gate.TraceableFeatureGate, got *gate.ResolveTrace) http.Handler {
+	return http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, trace, err := fg.ResolveWithTrace(r.Context(), "users.signup")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		*got = trace
+	})
+}
+
+func TestMiddlewarePinsValuesForAuthorizedRequests(t *testing.T) {
+	fg := resolver.New(resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{
+		"users.signup": false,
+	})))
+	token, err := Sign(testSecret, map[string]bool{"users.signup": true}, time.Time{})
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	var trace gate.ResolveTrace
+	handler := Middleware(testSecret, WithAuthorizer(func(r *http.Request) bool {
+		return r.Header.Get("X-Staff") == "1"
+	}))(resolveHandler(t, fg, &trace))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(DefaultHeader, token)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if trace.Source != gate.ResolveSourceDefault {
+		t.Fatalf("expected unauthorized request to ignore token, got %s", trace.Source)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Staff", "1")
+	req.AddCookie(&http.Cookie{Name: DefaultCookie, Value: token})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !trace.Value || trace.Source != gate.ResolveSourceRequestOverride {
+		t.Fatalf("expected request override, got %v (%s)", trace.Value, trace.Source)
+	}
+}
+
+func TestVerifyRejectsTamperedAndExpiredTokens(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	token, err := Sign(testSecret, map[string]bool{"dashboard": true}, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if _, err := Verify([]byte("other"), token, now); err == nil {
+		t.Fatalf("expected signature error")
+	}
+	if _, err := Verify(testSecret, token, now.Add(time.Hour)); err == nil {
+		t.Fatalf("expected expiry error")
+	}
+	values, err := Verify(testSecret, token, now)
+	if err != nil || !values["dashboard"] {
+		t.Fatalf("expected valid token, got %v (%v)", values, err)
+	}
+}
@@ -0,0 +1,63 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func BenchmarkEnabledSystemScope(b *testing.B) {
+	ctx := scope.WithSystem(context.Background(), true)
+	overrides := store.NewMemoryStore()
+	_ = overrides.Set(ctx, "checkout", gate.ScopeRef{Kind: gate.ScopeSystem}, true, gate.ActorRef{})
+	g := New(WithOverrideStore(overrides), WithDefaults(staticDefaults{"search": {Set: true, Value: true}}))
+
+	b.Run("override", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = g.Enabled(ctx, "checkout")
+		}
+	})
+	b.Run("default", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = g.Enabled(ctx, "search")
+		}
+	})
+}
+
+func BenchmarkEnabledTenantScope(b *testing.B) {
+	ctx := scope.WithTenantID(context.Background(), "acme")
+	overrides := store.NewMemoryStore()
+	_ = overrides.Set(ctx, "checkout", gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}, true, gate.ActorRef{})
+	g := New(WithOverrideStore(overrides))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.Enabled(ctx, "checkout")
+	}
+}
+
+func BenchmarkEnabledWithChainCache(b *testing.B) {
+	ctx := scope.WithChainCache(scope.WithTenantID(context.Background(), "acme"))
+	overrides := store.NewMemoryStore()
+	_ = overrides.Set(ctx, "checkout", gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}, true, gate.ActorRef{})
+	g := New(WithOverrideStore(overrides))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.Enabled(ctx, "checkout")
+	}
+}
+
+func BenchmarkResolveWithTrace(b *testing.B) {
+	ctx := scope.WithTenantID(context.Background(), "acme")
+	overrides := store.NewMemoryStore()
+	_ = overrides.Set(ctx, "checkout", gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}, true, gate.ActorRef{})
+	g := New(WithOverrideStore(overrides))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = g.ResolveWithTrace(ctx, "checkout")
+	}
+}
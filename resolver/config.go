@@ -0,0 +1,82 @@
+package resolver
+
+import (
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scopechain"
+)
+
+const (
+	// StrategyDefault names the built-in scope-group strategy.
+	StrategyDefault = "default"
+	// StrategyCustom names a strategy set with WithResolveStrategy.
+	StrategyCustom = "custom"
+)
+
+// Config is a serializable snapshot of the behavioral configuration of a Gate.
+// Operators can compare it against the documented behavior matrix at runtime.
+type Config struct {
+	ScopeOrder                  []string                     `json:"scope_order"`
+	Strategy                    string                       `json:"strategy"`
+	StrategyNames               map[string]string            `json:"strategy_names,omitempty"`
+	FailureMode                 ClaimsFailureMode            `json:"failure_mode"`
+	FailurePolicies             map[string]ClaimsFailureMode `json:"failure_policies,omitempty"`
+	StrictStore                 bool                         `json:"strict_store"`
+	AppendSystemOnFailure       bool                         `json:"append_system_on_failure"`
+	AppendSystemOnProvidedChain bool                         `json:"append_system_on_provided_chain"`
+	PreserveRolePermOrder       bool                         `json:"preserve_role_perm_order"`
+	ResolveTimeout              time.Duration                `json:"resolve_timeout,omitempty"`
+	BundleVersion               string                       `json:"bundle_version,omitempty"`
+	KeyPolicy                   *gate.KeyPolicy              `json:"key_policy,omitempty"`
+	KeyNamespace                string                       `json:"key_namespace,omitempty"`
+	TraceRedaction              bool                         `json:"trace_redaction,omitempty"`
+}
+
+// DefaultConfig returns the configuration of a Gate built without options.
+func DefaultConfig() Config {
+	return Config{
+		ScopeOrder:                  scopeKindNames(scopechain.DefaultScopeOrder()),
+		Strategy:                    StrategyDefault,
+		FailureMode:                 FailOpen,
+		StrictStore:                 false,
+		AppendSystemOnFailure:       true,
+		AppendSystemOnProvidedChain: false,
+		PreserveRolePermOrder:       false,
+	}
+}
+
+// Config reports the behavioral configuration the gate resolves with.
+func (g *Gate) Config() Config {
+	if g == nil {
+		return Config{}
+	}
+	cfg := Config{
+		ScopeOrder:                  scopeKindNames(g.scopeOrder),
+		Strategy:                    g.strategyName,
+		StrategyNames:               g.strategyNames.snapshot(),
+		FailureMode:                 g.failureMode,
+		FailurePolicies:             g.failurePolicies.snapshot(),
+		StrictStore:                 g.strictStore,
+		AppendSystemOnFailure:       g.appendSystemOnFailure,
+		AppendSystemOnProvidedChain: g.appendSystemOnProvidedChain,
+		PreserveRolePermOrder:       g.preserveRolePermOrder,
+		ResolveTimeout:              g.resolveTimeout,
+		BundleVersion:               g.bundleVersion,
+		KeyNamespace:                g.keyNamespace,
+		TraceRedaction:              g.redactor != nil,
+	}
+	if !g.keyPolicy.IsZero() {
+		policy := g.keyPolicy
+		cfg.KeyPolicy = &policy
+	}
+	return cfg
+}
+
+func scopeKindNames(kinds []gate.ScopeKind) []string {
+	out := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		out = append(out, kind.String())
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestNewMatchesDefaultConfig(t *testing.T) {
+	if got := New().Config(); !reflect.DeepEqual(got, DefaultConfig()) {
+		t.Fatalf("default gate drifted from the documented behavior matrix: %+v", got)
+	}
+}
+
+func TestConfigReflectsOptions(t *testing.T) {
+	fg := New(
+		WithScopeOrder(gate.ScopeTenant, gate.ScopeSystem),
+		WithClaimsFailureMode(FailClosed),
+		WithStrictStore(true),
+		WithAppendSystemOnFailure(false),
+		WithAppendSystemOnProvidedChain(true),
+		WithPreserveRolePermOrder(true),
+		WithResolveStrategy(func(_ context.Context, _ string, _ gate.ScopeChain, _ []store.OverrideMatch, _ ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
+			return OverrideDecision{}, gate.ResolveTrace{}, nil
+		}),
+	)
+	want := Config{
+		ScopeOrder:                  []string{"tenant", "system"},
+		Strategy:                    StrategyCustom,
+		FailureMode:                 FailClosed,
+		StrictStore:                 true,
+		AppendSystemOnFailure:       false,
+		AppendSystemOnProvidedChain: true,
+		PreserveRolePermOrder:       true,
+	}
+	if got := fg.Config(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected config: %+v", got)
+	}
+	if got := New(WithNamedResolveStrategy("rbac", defaultResolveStrategy)).Config().Strategy; got != "rbac" {
+		t.Fatalf("expected named strategy, got %q", got)
+	}
+}
+
+type staticClaims gate.ActorClaims
+
+func (c staticClaims) ClaimsFromContext(context.Context) (gate.ActorClaims, error) {
+	return gate.ActorClaims(c), nil
+}
+
+func TestChainBuilderMatchesDerivedChain(t *testing.T) {
+	claims := gate.ActorClaims{SubjectID: "u1", TenantID: "acme", OrgID: "eng", Roles: []string{"Admin"}}
+	fg := New(
+		WithClaimsProvider(staticClaims(claims)),
+		WithEnvironment("prod"),
+		WithScopeOrder(gate.ScopeRole, gate.ScopeUser, gate.ScopeEnv),
+	)
+	_, trace, err := fg.ResolveWithTrace(context.Background(), "reports")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if got := fg.ChainBuilder().Build(claims); !reflect.DeepEqual(got, trace.Chain) {
+		t.Fatalf("expected builder chain %+v to match resolved chain %+v", got, trace.Chain)
+	}
+}
@@ -0,0 +1,166 @@
+package resolver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultHookBufferSize is the async hook queue size used when none is given.
+	DefaultHookBufferSize = 1024
+	// DefaultHookWorkers is the number of async hook workers used when none is given.
+	DefaultHookWorkers = 1
+)
+
+// HookOverflow controls what async hook dispatch does when the queue is full.
+type HookOverflow string
+
+const (
+	// HookOverflowDrop discards the event and counts it in DroppedHookEvents.
+	HookOverflowDrop HookOverflow = "drop"
+	// HookOverflowBlock waits for queue space, trading latency for delivery.
+	HookOverflowBlock HookOverflow = "block"
+	// HookOverflowSync runs the hooks inline on the caller's goroutine.
+	HookOverflowSync HookOverflow = "sync"
+)
+
+type hookDispatchConfig struct {
+	enabled  bool
+	buffer   int
+	workers  int
+	overflow HookOverflow
+}
+
+// WithAsyncHooks runs resolve and update hooks on worker goroutines fed by a queue of
+// bufferSize events, so slow hooks do not add latency to Enabled or Set. Hooks
+// receive a context detached from the caller's cancellation. Call Gate.Close on
+// shutdown to drain the queue; events emitted after Close run inline.
+func WithAsyncHooks(bufferSize, workers int) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.hookDispatch.enabled = true
+		g.hookDispatch.buffer = bufferSize
+		g.hookDispatch.workers = workers
+	}
+}
+
+// WithHookOverflow sets the policy applied when the async hook queue is full
+// (HookOverflowDrop by default).
+func WithHookOverflow(policy HookOverflow) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.hookDispatch.overflow = policy
+	}
+}
+
+// Close drains queued hook events and stops the hook workers. It returns ctx's error
+// if the queue is not drained in time. Gates without async hooks return nil.
+func (g *Gate) Close(ctx context.Context) error {
+	if g == nil || g.dispatcher == nil {
+		return nil
+	}
+	return g.dispatcher.close(ctx)
+}
+
+// DroppedHookEvents reports events discarded because the async hook queue was full.
+func (g *Gate) DroppedHookEvents() uint64 {
+	if g == nil || g.dispatcher == nil {
+		return 0
+	}
+	return g.dispatcher.dropped.Load()
+}
+
+// hookDispatcher runs hook deliveries on a fixed worker pool.
+type hookDispatcher struct {
+	queue    chan func()
+	overflow HookOverflow
+
+	mu      sync.RWMutex
+	closed  bool
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+func newHookDispatcher(cfg hookDispatchConfig) *hookDispatcher {
+	if cfg.buffer < 1 {
+		cfg.buffer = DefaultHookBufferSize
+	}
+	if cfg.workers < 1 {
+		cfg.workers = DefaultHookWorkers
+	}
+	switch cfg.overflow {
+	case HookOverflowBlock, HookOverflowSync:
+	default:
+		cfg.overflow = HookOverflowDrop
+	}
+	d := &hookDispatcher{
+		queue:    make(chan func(), cfg.buffer),
+		overflow: cfg.overflow,
+		done:     make(chan struct{}),
+	}
+	var wg sync.WaitGroup
+	for range cfg.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for deliver := range d.queue {
+				deliver()
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(d.done)
+	}()
+	return d
+}
+
+// dispatch queues deliver, applying the overflow policy when the queue is full.
+func (d *hookDispatcher) dispatch(deliver func()) {
+	d.mu.RLock()
+	if d.closed {
+		d.mu.RUnlock()
+		deliver()
+		return
+	}
+	if d.overflow == HookOverflowBlock {
+		d.queue <- deliver
+		d.mu.RUnlock()
+		return
+	}
+	select {
+	case d.queue <- deliver:
+		d.mu.RUnlock()
+		return
+	default:
+	}
+	d.mu.RUnlock()
+	if d.overflow == HookOverflowSync {
+		deliver()
+		return
+	}
+	d.dropped.Add(1)
+}
+
+func (d *hookDispatcher) close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,85 @@
+package resolver
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestAsyncHooksDoNotBlockResolution(t *testing.T) {
+	release := make(chan struct{})
+	var resolved atomic.Int32
+	hook := gate.ResolveHookFunc(func(ctx context.Context, _ gate.ResolveEvent) {
+		<-release
+		if ctx.Err() != nil {
+			t.Errorf("expected hook context to outlive the caller")
+		}
+		resolved.Add(1)
+	})
+	fg := New(WithResolveHook(hook), WithAsyncHooks(2, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			if _, err := fg.Enabled(ctx, "checkout"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected resolution not to wait for hooks")
+	}
+	cancel()
+
+	// One event is held by the worker and two are queued; the rest overflow.
+	if dropped := fg.DroppedHookEvents(); dropped != 2 && dropped != 3 {
+		t.Fatalf("expected overflow to drop events, got %d", dropped)
+	}
+	close(release)
+	if err := fg.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got := int(resolved.Load()) + int(fg.DroppedHookEvents()); got != 5 {
+		t.Fatalf("expected every event delivered or dropped, got %d", got)
+	}
+
+	if _, err := fg.Enabled(context.Background(), "checkout"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.Load() != int32(5-fg.DroppedHookEvents()+1) {
+		t.Fatalf("expected events after Close to run inline")
+	}
+}
+
+func TestAsyncHooksBlockPolicyDeliversUpdates(t *testing.T) {
+	var updates atomic.Int32
+	fg := New(
+		WithOverrideStore(store.NewMemoryStore()),
+		WithActivityHook(activity.HookFunc(func(context.Context, activity.UpdateEvent) {
+			time.Sleep(time.Millisecond)
+			updates.Add(1)
+		})),
+		WithAsyncHooks(1, 2),
+		WithHookOverflow(HookOverflowBlock),
+	)
+	for i := 0; i < 4; i++ {
+		if err := fg.Set(context.Background(), "checkout", gate.ScopeRef{Kind: gate.ScopeSystem}, i%2 == 0, gate.ActorRef{ID: "ops"}); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+	}
+	if err := fg.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if updates.Load() != 4 || fg.DroppedHookEvents() != 0 {
+		t.Fatalf("expected all updates delivered, got %d (dropped %d)", updates.Load(), fg.DroppedHookEvents())
+	}
+}
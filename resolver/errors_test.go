@@ -0,0 +1,117 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+type failingDefaults struct{}
+
+func (failingDefaults) Default(context.Context, string) (DefaultResult, error) {
+	return DefaultResult{}, errors.New("config unavailable")
+}
+
+// TestErrorSitesRecordMetadata pins the metadata every resolver error site records,
+// so callers can rely on the ferrors accessors.
+func TestErrorSitesRecordMetadata(t *testing.T) {
+	ctx := context.Background()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	down := errors.New("store down")
+	type want struct {
+		key, operation, store string
+		scope, strict         bool
+	}
+	cases := map[string]struct {
+		run  func() error
+		want want
+	}{
+		"resolve invalid key": {
+			run: func() error {
+				_, err := New().Enabled(ctx, "  ")
+				return err
+			},
+			want: want{operation: "resolve"},
+		},
+		"resolve claims": {
+			run: func() error {
+				_, err := New(WithClaimsProvider(failingClaims{}), WithClaimsFailureMode(FailClosed)).Enabled(ctx, "checkout")
+				return err
+			},
+			want: want{key: "checkout", operation: "resolve_claims"},
+		},
+		"resolve store read": {
+			run: func() error {
+				_, err := New(WithOverrideStore(&stubStore{getErr: down}), WithStrictStore(true)).Enabled(ctx, "checkout")
+				return err
+			},
+			want: want{key: "checkout", operation: "get_all", store: "override", strict: true},
+		},
+		"resolve default": {
+			run: func() error {
+				_, err := New(WithDefaults(failingDefaults{})).Enabled(ctx, "checkout")
+				return err
+			},
+			want: want{key: "checkout", operation: "default", strict: true},
+		},
+		"set without store": {
+			run:  func() error { return New().Set(ctx, "checkout", tenant, true, gate.ActorRef{}) },
+			want: want{key: "checkout", operation: "set", store: "override", scope: true},
+		},
+		"set invalid key": {
+			run: func() error {
+				return New(WithOverrideStore(store.NewMemoryStore())).Set(ctx, " ", tenant, true, gate.ActorRef{})
+			},
+			want: want{operation: "set", scope: true},
+		},
+		"set store write": {
+			run: func() error {
+				return New(WithOverrideStore(&stubStore{setErr: down})).Set(ctx, "checkout", tenant, true, gate.ActorRef{})
+			},
+			want: want{key: "checkout", operation: "set", store: "override", scope: true},
+		},
+		"unset store write": {
+			run: func() error {
+				return New(WithOverrideStore(&stubStore{unsetErr: down})).Unset(ctx, "checkout", tenant, gate.ActorRef{})
+			},
+			want: want{key: "checkout", operation: "unset", store: "override", scope: true},
+		},
+		"list without lister": {
+			run: func() error {
+				_, err := New(WithOverrideStore(&stubStore{})).ListOverrides(ctx, store.ListFilter{Key: "checkout"})
+				return err
+			},
+			want: want{key: "checkout", operation: "list", store: "override"},
+		},
+	}
+	for name, tc := range cases {
+		err := tc.run()
+		if err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+		if _, ok := ferrors.As(err); !ok {
+			t.Fatalf("%s: expected a rich error, got %T", name, err)
+		}
+		if key, ok := ferrors.FeatureKey(err); !ok || key != tc.want.key {
+			t.Fatalf("%s: expected feature key %q, got %q (%v)", name, tc.want.key, key, ok)
+		}
+		if op, ok := ferrors.Operation(err); !ok || op != tc.want.operation {
+			t.Fatalf("%s: expected operation %q, got %q (%v)", name, tc.want.operation, op, ok)
+		}
+		if storeName, _ := ferrors.Store(err); storeName != tc.want.store {
+			t.Fatalf("%s: expected store %q, got %q", name, tc.want.store, storeName)
+		}
+		if scope, ok := ferrors.Scope(err); ok != tc.want.scope || (ok && scope.ID != "acme") {
+			t.Fatalf("%s: expected scope %v, got %+v (%v)", name, tc.want.scope, scope, ok)
+		}
+		if tc.want.strict {
+			if _, ok := ferrors.Strict(err); !ok {
+				t.Fatalf("%s: expected strict metadata", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+type whatIfKey struct{}
+
+// Evaluate resolves key for a synthetic actor described by claims instead of the
+// caller, answering "would user U in tenant T see this feature?". The caller's
+// request overrides and evaluation memo are ignored, and resolve hooks are not
+// called, so what-if checks do not count as exposures or value changes. The
+// permission provider, hierarchy, cache, and schedules apply as for a real
+// resolve. Of opts only gate.WithFallback is honored; scope chains and claims
+// passed as options are ignored.
+func (g *Gate) Evaluate(ctx context.Context, key string, claims gate.ActorClaims, opts ...gate.ResolveOption) (bool, gate.ResolveTrace, error) {
+	ctx = context.WithValue(gate.DetachRequest(ctx), whatIfKey{}, true)
+	return g.evaluate(ctx, key, whatIfOptions(claims, opts)...)
+}
+
+func whatIfOptions(claims gate.ActorClaims, opts []gate.ResolveOption) []gate.ResolveOption {
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	resolveOpts := []gate.ResolveOption{gate.WithClaims(claims)}
+	if req.Fallback != nil {
+		resolveOpts = append(resolveOpts, gate.WithFallback(*req.Fallback))
+	}
+	return resolveOpts
+}
+
+func whatIf(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	value, _ := ctx.Value(whatIfKey{}).(bool)
+	return value
+}
+
+// EvaluationMatrix holds what-if results with one row per claim set and one column
+// per key, in the order they were passed to EvaluateMatrix.
+type EvaluationMatrix struct {
+	Keys   []string
+	Claims []gate.ActorClaims
+	Cells  [][]EvaluationCell
+}
+
+// EvaluationCell is the outcome of resolving one key for one claim set.
+type EvaluationCell struct {
+	Value bool
+	Trace gate.ResolveTrace
+	Error error
+}
+
+// Row returns the values claim set i sees, keyed by normalized key. Cells that
+// failed are left out.
+func (m EvaluationMatrix) Row(i int) map[string]bool {
+	if i < 0 || i >= len(m.Cells) {
+		return nil
+	}
+	out := make(map[string]bool, len(m.Cells[i]))
+	for _, cell := range m.Cells[i] {
+		if cell.Error != nil || cell.Trace.NormalizedKey == "" {
+			continue
+		}
+		out[cell.Trace.NormalizedKey] = cell.Value
+	}
+	return out
+}
+
+// EvaluateMatrix runs Evaluate for every key against every claim set. The chain for
+// each claim set is built once, and claim sets that produce the same chain share
+// store reads; cells answered that way carry a Memoized trace. Options are handled
+// as for Evaluate.
+func (g *Gate) EvaluateMatrix(ctx context.Context, keys []string, claims []gate.ActorClaims, opts ...gate.ResolveOption) EvaluationMatrix {
+	ctx = context.WithValue(gate.DetachRequest(ctx), whatIfKey{}, true)
+	ctx = gate.WithEvaluationMemo(ctx)
+	memo := gate.EvaluationMemoFromContext(ctx)
+
+	matrix := EvaluationMatrix{
+		Keys:   append([]string(nil), keys...),
+		Claims: append([]gate.ActorClaims(nil), claims...),
+		Cells:  make([][]EvaluationCell, len(claims)),
+	}
+	for i, actor := range claims {
+		resolveOpts := whatIfOptions(actor, opts)
+		var chainTrace gate.ResolveTrace
+		// A failed chain leaves the claims option in place so each key applies its
+		// own claims failure policy.
+		if chain, _, err := g.buildChain(ctx, &chainTrace, gate.ResolveRequest{Claims: &actor}, FailClosed); err == nil {
+			resolveOpts[0] = gate.WithScopeChain(chain)
+		}
+		row := make([]EvaluationCell, len(keys))
+		for j, key := range keys {
+			value, trace, err := g.evaluate(ctx, key, resolveOpts...)
+			if err == nil && !trace.Memoized && trace.Source != gate.ResolveSourceCallerFallback {
+				memo.Record(g, trace)
+			}
+			row[j] = EvaluationCell{Value: value, Trace: trace, Error: err}
+		}
+		matrix.Cells[i] = row
+	}
+	return matrix
+}
@@ -0,0 +1,86 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestEvaluateResolvesForSyntheticActor(t *testing.T) {
+	overrides := store.NewMemoryStore()
+	hooked := 0
+	fg := New(
+		WithOverrideStore(overrides),
+		WithResolveHook(gate.ResolveHookFunc(func(context.Context, gate.ResolveEvent) { hooked++ })),
+	)
+	caller := scope.WithUserID(scope.WithTenantID(context.Background(), "globex"), "ops")
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	if err := fg.Set(caller, "checkout", tenant, true, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	caller = gate.WithRequestOverrides(gate.FreezeOnFirstRead(caller), map[string]bool{"checkout": false})
+	value, trace, err := fg.Evaluate(caller, "checkout", gate.ActorClaims{SubjectID: "u1", TenantID: "acme"})
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if !value || trace.Source != gate.ResolveSourceOverride {
+		t.Fatalf("expected tenant override for synthetic actor, got %v from %s", value, trace.Source)
+	}
+	if hooked != 0 {
+		t.Fatalf("expected what-if resolves to skip hooks, got %d", hooked)
+	}
+	if evaluations := gate.EvaluationMemoFromContext(caller).Evaluations(); len(evaluations) != 0 {
+		t.Fatalf("expected caller memo untouched, got %+v", evaluations)
+	}
+
+	value, err = fg.Enabled(caller, "checkout")
+	if err != nil || value {
+		t.Fatalf("expected caller to keep its pinned value, got %v (%v)", value, err)
+	}
+	if hooked != 1 {
+		t.Fatalf("expected caller resolve to reach hooks, got %d", hooked)
+	}
+}
+
+type countingReader struct {
+	store.Reader
+	reads int
+}
+
+func (r *countingReader) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]store.OverrideMatch, error) {
+	r.reads++
+	return r.Reader.GetAll(ctx, key, chain)
+}
+
+func TestEvaluateMatrixSharesReadsPerChain(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	if err := overrides.Set(ctx, "checkout", tenant, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	reader := &countingReader{Reader: overrides}
+	fg := New(WithOverrideStore(reader))
+
+	claims := []gate.ActorClaims{{TenantID: "acme"}, {TenantID: "acme"}, {TenantID: "globex"}}
+	matrix := fg.EvaluateMatrix(ctx, []string{"checkout", "search"}, claims)
+	if len(matrix.Cells) != 3 || len(matrix.Cells[0]) != 2 {
+		t.Fatalf("expected a 3x2 matrix, got %+v", matrix.Cells)
+	}
+	if row := matrix.Row(0); !row["checkout"] || row["search"] {
+		t.Fatalf("expected acme to see checkout only, got %+v", row)
+	}
+	if !matrix.Cells[1][0].Value || !matrix.Cells[1][0].Trace.Memoized {
+		t.Fatalf("expected the repeated chain to reuse the first read, got %+v", matrix.Cells[1][0].Trace)
+	}
+	if row := matrix.Row(2); row["checkout"] {
+		t.Fatalf("expected globex not to see checkout, got %+v", row)
+	}
+	if reader.reads != 4 {
+		t.Fatalf("expected one read per key and distinct chain, got %d", reader.reads)
+	}
+}
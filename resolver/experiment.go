@@ -0,0 +1,39 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/goliatone/go-featuregate/experiment"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// WithExperiments enables Gate.Experiment with assigner.
+func WithExperiments(assigner *experiment.Assigner) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.experiments = assigner
+	}
+}
+
+// Experiment assigns the caller to a variant of the experiment key. Claims come from
+// gate.WithClaims or the gate's claims provider, and an experiment's targeting flag
+// is resolved by this gate.
+func (g *Gate) Experiment(ctx context.Context, key string, opts ...gate.ResolveOption) (experiment.Assignment, error) {
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	if req.Claims == nil && g.experiments != nil {
+		var trace gate.ResolveTrace
+		claims, err := g.claims(ctx, &trace, req)
+		if err != nil {
+			return experiment.Assignment{}, err
+		}
+		opts = append(opts, gate.WithClaims(claims))
+	}
+	return g.experiments.Assign(ctx, g, key, opts...)
+}
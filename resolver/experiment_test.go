@@ -0,0 +1,40 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/experiment"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestGateExperimentUsesOverridesForTargeting(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	fg := New(
+		WithOverrideStore(overrides),
+		WithExperiments(experiment.New(experiment.StaticExperiments{
+			"onboarding.flow": {Variants: []experiment.Variant{{Key: "classic"}, {Key: "guided"}}, Flag: "onboarding.experiment"},
+		})),
+	)
+	userCtx := scope.WithUserID(scope.WithTenantID(ctx, "acme"), "u1")
+
+	before, err := fg.Experiment(userCtx, "onboarding.flow")
+	if err != nil || before.InExperiment || before.Reason != experiment.ReasonNotTargeted {
+		t.Fatalf("expected subject outside the experiment, got %+v (%v)", before, err)
+	}
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	if err := overrides.Set(ctx, "onboarding.experiment", tenant, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	after, err := fg.Experiment(userCtx, "onboarding.flow")
+	if err != nil || !after.InExperiment || after.UnitID != "u1" {
+		t.Fatalf("expected tenant override to enroll the subject, got %+v (%v)", after, err)
+	}
+
+	if _, err := New().Experiment(userCtx, "onboarding.flow"); err == nil {
+		t.Fatalf("expected an error without experiments")
+	}
+}
@@ -0,0 +1,96 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// Explain resolves key like ResolveWithTrace and returns the decision path as
+// ordered steps. The chain step names where the chain came from: a caller scope
+// chain, caller claims, or the claims provider.
+func (g *Gate) Explain(ctx context.Context, key string, opts ...gate.ResolveOption) (gate.Explanation, error) {
+	_, trace, err := g.resolve(ctx, key, opts...)
+	explanation := gate.NewExplanation(g.redact(trace))
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	if len(explanation.Steps) > 0 && explanation.Steps[0].Stage == gate.ExplainStageChain {
+		explanation.Steps[0].Detail = g.chainOrigin(ctx, req) + " → " + explanation.Steps[0].Detail
+	}
+	if g.cacheConsulted(ctx, trace) {
+		explanation.Steps = insertBefore(explanation.Steps, gate.ExplainStageOverride, gate.ExplanationStep{
+			Stage:  gate.ExplainStageCache,
+			Detail: "miss",
+		})
+	}
+	return explanation, err
+}
+
+// chainOrigin describes where the scope chain of req comes from. Claims are left
+// out when a trace redactor is set, since they carry the identifiers it hides.
+func (g *Gate) chainOrigin(ctx context.Context, req gate.ResolveRequest) string {
+	if req.ScopeChain != nil {
+		return "caller scope chain"
+	}
+	if g.redactor != nil {
+		if req.Claims != nil {
+			return "caller claims"
+		}
+		return "claims"
+	}
+	if req.Claims != nil {
+		return "caller claims " + claimsLabel(*req.Claims)
+	}
+	claims, err := g.claims(ctx, nil, req)
+	if err != nil {
+		return "claims provider failed (" + err.Error() + ")"
+	}
+	return "claims " + claimsLabel(claims)
+}
+
+// cacheConsulted reports whether the resolve in trace read the cache and missed.
+func (g *Gate) cacheConsulted(ctx context.Context, trace gate.ResolveTrace) bool {
+	if _, noop := g.cache.(cache.NoopCache); noop || trace.CacheHit || trace.Memoized {
+		return false
+	}
+	if trace.Source == gate.ResolveSourceRequestOverride || trace.Chain == nil {
+		return false
+	}
+	return !g.cachePolicies.Lookup(trace.NormalizedKey).Bypass && !whatIf(ctx)
+}
+
+func claimsLabel(claims gate.ActorClaims) string {
+	var parts []string
+	add := func(name, value string) {
+		if value != "" {
+			parts = append(parts, name+"="+value)
+		}
+	}
+	add("subject", claims.SubjectID)
+	add("tenant", claims.TenantID)
+	add("org", claims.OrgID)
+	add("roles", strings.Join(claims.Roles, ","))
+	add("perms", strings.Join(claims.Perms, ","))
+	add("env", claims.Env)
+	if len(parts) == 0 {
+		return "(none)"
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func insertBefore(steps []gate.ExplanationStep, stage string, step gate.ExplanationStep) []gate.ExplanationStep {
+	for i, existing := range steps {
+		if existing.Stage == stage {
+			steps = append(steps[:i+1], steps[i:]...)
+			steps[i] = step
+			return steps
+		}
+	}
+	return append(steps, step)
+}
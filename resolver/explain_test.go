@@ -0,0 +1,58 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestGateExplainsDecisionPath(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	admin := gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"}
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	_ = overrides.Set(ctx, "reports", admin, true, gate.ActorRef{}, gate.WithReason("beta"))
+	_ = overrides.Set(ctx, "reports", tenant, false, gate.ActorRef{})
+	g := New(
+		WithOverrideStore(overrides),
+		WithCache(mapCache{}),
+		WithDefaults(staticDefaults{"search": {Set: true, Value: true}}),
+	)
+	claims := gate.WithClaims(gate.ActorClaims{TenantID: "acme", SubjectID: "u1", Roles: []string{"admin"}})
+
+	explanation, err := g.Explain(ctx, "reports", claims)
+	if err != nil || !explanation.Value || explanation.Source != gate.ResolveSourceOverride {
+		t.Fatalf("expected reports enabled by override, got %+v (%v)", explanation, err)
+	}
+	var stages []string
+	for _, step := range explanation.Steps {
+		stages = append(stages, step.Stage)
+	}
+	if got := strings.Join(stages, ","); got != "chain,cache,override,override,result" {
+		t.Fatalf("unexpected stages %s:\n%s", got, explanation)
+	}
+	rendered := explanation.String()
+	for _, want := range []string{
+		"reports = enabled (source: override)",
+		"1. chain: caller claims (subject=u1 tenant=acme roles=admin) → user:u1 > role:admin > role:admin > tenant:acme > system",
+		"2. cache: miss",
+		`3. override: found at role:admin → enabled, reason: "beta"`,
+		"4. override: disabled at tenant:acme shadowed",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("expected %q in explanation:\n%s", want, rendered)
+		}
+	}
+
+	explanation, _ = g.Explain(ctx, "reports", claims)
+	if explanation.Steps[1].Detail != "hit → enabled" {
+		t.Fatalf("expected a cache hit on the second explain, got:\n%s", explanation)
+	}
+	explanation, _ = g.Explain(ctx, "search", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}}))
+	if rendered := explanation.String(); !strings.Contains(rendered, "caller scope chain") || !strings.Contains(rendered, "default: set → enabled") {
+		t.Fatalf("unexpected default explanation:\n%s", rendered)
+	}
+}
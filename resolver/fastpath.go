@@ -0,0 +1,130 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// fastPathEligible reports whether the gate's configuration lets Enabled skip the
+// trace: nothing observes resolutions (hooks, cache), nothing rewrites them (key
+// policy, schedules, custom strategies), and stages run without deadlines.
+func (g *Gate) fastPathEligible() bool {
+	if len(g.hooks) > 0 || g.schedules != nil || g.resolveTimeout > 0 || !g.keyPolicy.IsZero() {
+		return false
+	}
+	if g.strategyName != StrategyDefault || len(g.strategyNames.exact) > 0 || len(g.strategyNames.prefixes) > 0 {
+		return false
+	}
+	_, noCache := g.cache.(cache.NoopCache)
+	return noCache
+}
+
+// enabledFast resolves key for Enabled without building a trace, match maps, or
+// match traces. ok is false when the request needs the full path: resolve options,
+// an evaluation memo, or any error, which the full path reports with its trace. The
+// derived chain and the store's result still allocate; benchmarks.Budgets bounds them.
+func (g *Gate) enabledFast(ctx context.Context, key string, opts []gate.ResolveOption) (value bool, ok bool) {
+	if !g.fastPath || len(opts) > 0 || gate.EvaluationMemoFromContext(ctx) != nil {
+		return false, false
+	}
+	normalized := gate.NormalizeKey(key)
+	if normalized == "" {
+		return false, false
+	}
+	if value, ok := gate.RequestOverride(ctx, normalized); ok {
+		return value, true
+	}
+	var trace gate.ResolveTrace
+	chain, _, err := g.resolveChain(ctx, normalized, &trace, gate.ResolveRequest{})
+	if err != nil {
+		return false, false
+	}
+	if g.overrides != nil {
+		value, matched, err := g.overrideValue(ctx, normalized, chain)
+		if err != nil {
+			return false, false
+		}
+		if matched {
+			return value, true
+		}
+	}
+	def, err := g.defaults.Default(gate.WithResolvedChain(ctx, chain), normalized)
+	if err != nil {
+		return false, false
+	}
+	return def.Set && def.Value, true
+}
+
+// overrideValue applies the default strategy to the overrides of key and its
+// aliases, matching the full path without recording what it skipped.
+func (g *Gate) overrideValue(ctx context.Context, key string, chain gate.ScopeChain) (bool, bool, error) {
+	matches, err := g.overrides.GetAll(ctx, key, chain)
+	if err != nil {
+		return false, false, err
+	}
+	if value, ok := g.pickOverride(chain, matches); ok {
+		return value, true, nil
+	}
+	for _, alias := range gate.AliasesFor(key) {
+		matches, err := g.overrides.GetAll(ctx, alias, chain)
+		if err != nil {
+			return false, false, err
+		}
+		if value, ok := g.pickOverride(chain, matches); ok {
+			return value, true, nil
+		}
+	}
+	return false, false, nil
+}
+
+// pickOverride walks the scope groups in order and lets selectInGroup decide each
+// one, as the default strategy does, reading overrides straight from matches instead
+// of a match map. Expired overrides are ignored.
+func (g *Gate) pickOverride(chain gate.ScopeChain, matches []store.OverrideMatch) (bool, bool) {
+	if len(matches) == 0 {
+		return false, false
+	}
+	var now time.Time
+	for _, group := range g.groupOrder {
+		i, value := selectInGroup(group, len(chain), func(i int) gate.OverrideState {
+			if !scopeKindInGroup(chain[i].Kind, group) {
+				return gate.OverrideStateMissing
+			}
+			override, ok := g.overrideFor(matches, chain[i], &now)
+			if !ok {
+				return gate.OverrideStateMissing
+			}
+			return override.State
+		})
+		if i >= 0 {
+			return value, true
+		}
+	}
+	return false, false
+}
+
+// overrideFor returns the last unexpired match stored for ref, as the strategy's
+// match map does after expired overrides are dropped. now is read once, on the
+// first override with an expiry.
+func (g *Gate) overrideFor(matches []store.OverrideMatch, ref gate.ScopeRef, now *time.Time) (store.Override, bool) {
+	for i := len(matches) - 1; i >= 0; i-- {
+		override := matches[i].Override
+		if !sameScope(matches[i].Scope, ref) {
+			continue
+		}
+		if !override.ExpiresAt.IsZero() {
+			if now.IsZero() {
+				*now = g.now()
+			}
+			if override.Expired(*now) {
+				continue
+			}
+		}
+		return override, true
+	}
+	return store.Override{}, false
+}
@@ -0,0 +1,87 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestFastPathAgreesWithFullPathAcrossStrategies(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	claims := gate.ActorClaims{SubjectID: "u1", TenantID: "acme", OrgID: "o1", Roles: []string{"admin", "guest"}}
+	user := gate.QualifiedRef(gate.ScopeUser, "u1", claims)
+	admin := gate.QualifiedRef(gate.ScopeRole, "admin", claims)
+	guest := gate.QualifiedRef(gate.ScopeRole, "guest", claims)
+	org := gate.QualifiedRef(gate.ScopeOrg, "o1", claims)
+	tenant := gate.QualifiedRef(gate.ScopeTenant, "acme", claims)
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+
+	type override struct {
+		scope   gate.ScopeRef
+		enabled bool
+		opts    []gate.MutationOption
+	}
+	cases := []struct {
+		name      string
+		overrides []override
+	}{
+		{name: "no overrides"},
+		{name: "user beats tenant", overrides: []override{{scope: user}, {scope: tenant, enabled: true}}},
+		{name: "disabled role wins", overrides: []override{{scope: admin, enabled: true}, {scope: guest}}},
+		{name: "enabled role", overrides: []override{{scope: admin, enabled: true}, {scope: tenant}}},
+		{name: "org beats tenant", overrides: []override{{scope: org, enabled: true}, {scope: tenant}}},
+		{name: "expired user falls through", overrides: []override{{scope: user, enabled: true, opts: []gate.MutationOption{gate.WithExpiresAt(now.Add(-time.Minute))}}, {scope: org}}},
+		{name: "priority against order", overrides: []override{{scope: user, opts: []gate.MutationOption{gate.WithPriority(1)}}, {scope: system, enabled: true, opts: []gate.MutationOption{gate.WithPriority(9)}}}},
+		{name: "system only", overrides: []override{{scope: system, enabled: true}}},
+	}
+	strategies := []struct {
+		name string
+		opts []Option
+		fast bool
+	}{
+		{name: "default", fast: true},
+		{name: "default tenant first", opts: []Option{WithScopeOrder(gate.ScopeTenant, gate.ScopeOrg, gate.ScopeRole, gate.ScopePerm, gate.ScopeUser, gate.ScopeSystem)}, fast: true},
+		{name: "default as custom", opts: []Option{WithResolveStrategy(DefaultResolveStrategy)}},
+		{name: StrategyMostSpecificWins, opts: []Option{WithStrategyName("*", StrategyMostSpecificWins)}},
+		{name: StrategyMostRestrictiveWins, opts: []Option{WithMostRestrictiveWins()}},
+		{name: StrategyPriority, opts: []Option{WithResolveStrategy(PriorityStrategy)}},
+	}
+
+	for _, tc := range cases {
+		overrides := store.NewMemoryStore()
+		for _, o := range tc.overrides {
+			if err := overrides.Set(ctx, "reports", o.scope, o.enabled, gate.ActorRef{}, o.opts...); err != nil {
+				t.Fatalf("%s: set: %v", tc.name, err)
+			}
+		}
+		for _, strategy := range strategies {
+			opts := append([]Option{
+				WithOverrideStore(overrides),
+				WithDefaults(staticDefaults{"reports": {Set: true, Value: true}}),
+				WithClock(clock.NewManual(now)),
+				WithClaimsProvider(&fixedClaims{claims: claims}),
+			}, strategy.opts...)
+			g := New(opts...)
+			if g.fastPath != strategy.fast {
+				t.Fatalf("%s/%s: expected fast path %v, got %v", tc.name, strategy.name, strategy.fast, g.fastPath)
+			}
+			want, _, wantErr := g.ResolveWithTrace(ctx, "reports")
+			got, gotErr := g.Enabled(ctx, "reports")
+			if got != want || (gotErr == nil) != (wantErr == nil) {
+				t.Fatalf("%s/%s: Enabled %v (%v), traced %v (%v)", tc.name, strategy.name, got, gotErr, want, wantErr)
+			}
+			if !strategy.fast {
+				continue
+			}
+			reference := New(append(opts, WithResolveStrategy(DefaultResolveStrategy))...)
+			if slow, err := reference.Enabled(ctx, "reports"); err != nil || slow != got {
+				t.Fatalf("%s/%s: fast path %v, full path %v (%v)", tc.name, strategy.name, got, slow, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// EnableGroup enables every catalog flag in group at scopeRef in one batch. The
+// catalog comes from WithCatalog; mutation options apply to each override.
+func (g *Gate) EnableGroup(ctx context.Context, group string, scopeRef gate.ScopeRef, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	return g.setGroup(ctx, group, scopeRef, true, actor, opts...)
+}
+
+// DisableGroup disables every catalog flag in group at scopeRef in one batch.
+func (g *Gate) DisableGroup(ctx context.Context, group string, scopeRef gate.ScopeRef, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	return g.setGroup(ctx, group, scopeRef, false, actor, opts...)
+}
+
+func (g *Gate) setGroup(ctx context.Context, group string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	group = strings.TrimSpace(group)
+	scopeRef = g.chains.NormalizeRef(scopeRef)
+	meta := map[string]any{
+		ferrors.MetaGroup:     group,
+		ferrors.MetaScope:     scopeRef,
+		ferrors.MetaOperation: "set_group",
+	}
+	if g.writer == nil {
+		return ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "", meta)
+	}
+	if g.catalog == nil {
+		return ferrors.NewOperation(ferrors.TextCodeCatalogRequired, "feature catalog is required to expand groups", meta)
+	}
+	keys := catalog.GroupKeys(g.catalog, group)
+	if len(keys) == 0 {
+		return ferrors.NewBadInput(ferrors.TextCodeGroupUnknown, "feature group has no members", meta)
+	}
+
+	req := gate.ApplyMutationOptions(opts...)
+	expiresAt := req.Expiry(g.now())
+	mutations := make([]store.Mutation, 0, len(keys))
+	for _, key := range keys {
+		normalized := g.keyPolicy.Normalize(key)
+		if err := g.checkKey(normalized, "set_group"); err != nil {
+			return err
+		}
+		mutations = append(mutations, store.Mutation{
+			Key:       normalized,
+			Scope:     scopeRef,
+			Enabled:   boolPtr(enabled),
+			Actor:     actor,
+			ExpiresAt: expiresAt,
+			Metadata:  req.Metadata,
+			Priority:  req.Priority,
+		})
+	}
+	if err := store.ApplyMutations(ctx, g.writer, mutations); err != nil {
+		meta[ferrors.MetaFeatureKeys] = keys
+		meta[ferrors.MetaStore] = "override"
+		return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "override store group write failed", meta)
+	}
+	if g.cache != nil {
+		g.cache.Clear(ctx)
+	}
+	for _, m := range mutations {
+		g.emitUpdate(ctx, activity.UpdateEvent{
+			Key:           m.Key,
+			NormalizedKey: m.Key,
+			Scope:         scopeRef,
+			Actor:         actor,
+			Action:        activity.ActionSet,
+			Value:         boolPtr(enabled),
+			ExpiresAt:     expiresAt,
+			Metadata:      req.Metadata,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestGateTogglesCatalogGroups(t *testing.T) {
+	ctx := context.Background()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	cat := catalog.NewStatic(map[string]catalog.FeatureDefinition{
+		"reports.export": {Groups: []string{"pro"}},
+		"audit.log":      {Groups: []string{"pro", "enterprise"}},
+		"dashboard":      {},
+	})
+	g := New(WithOverrideStore(store.NewMemoryStore()), WithCatalog(cat))
+	claims := gate.WithClaims(gate.ActorClaims{TenantID: "acme"})
+
+	if err := g.EnableGroup(ctx, "pro", tenant, gate.ActorRef{ID: "billing"}); err != nil {
+		t.Fatalf("enable group: %v", err)
+	}
+	for key, want := range map[string]bool{"reports.export": true, "audit.log": true, "dashboard": false} {
+		if value, _ := g.Enabled(ctx, key, claims); value != want {
+			t.Fatalf("expected %s = %v after enabling pro", key, want)
+		}
+	}
+	if err := g.DisableGroup(ctx, "enterprise", tenant, gate.ActorRef{ID: "billing"}); err != nil {
+		t.Fatalf("disable group: %v", err)
+	}
+	if value, _ := g.Enabled(ctx, "audit.log", claims); value {
+		t.Fatalf("expected audit.log disabled with the enterprise group")
+	}
+
+	err := g.EnableGroup(ctx, "unknown", tenant, gate.ActorRef{})
+	if rich, ok := ferrors.As(err); !ok || rich.TextCode != ferrors.TextCodeGroupUnknown {
+		t.Fatalf("expected unknown group error, got %v", err)
+	}
+}
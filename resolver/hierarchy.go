@@ -0,0 +1,96 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// WithTenantHierarchy makes derived chains include the ancestors of the tenant and
+// org scopes, right after each descendant, so overrides on a parent org cascade to
+// child orgs while the child's own overrides still win. Added scopes are listed in
+// ResolveTrace.Inherited. Explicit chains from gate.WithScopeChain are left as given.
+func WithTenantHierarchy(provider gate.TenantHierarchyProvider) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.hierarchy = provider
+	}
+}
+
+// StaticHierarchy serves parent relationships from maps of child ID to parent ID.
+type StaticHierarchy struct {
+	Tenants map[string]string
+	Orgs    map[string]string
+}
+
+// ParentTenants implements gate.TenantHierarchyProvider.
+func (h StaticHierarchy) ParentTenants(_ context.Context, tenantID string) ([]string, error) {
+	return walkParents(h.Tenants, tenantID), nil
+}
+
+// ParentOrgs implements gate.TenantHierarchyProvider.
+func (h StaticHierarchy) ParentOrgs(_ context.Context, _ string, orgID string) ([]string, error) {
+	return walkParents(h.Orgs, orgID), nil
+}
+
+// walkParents follows child-to-parent links, stopping at roots and cycles.
+func walkParents(parents map[string]string, id string) []string {
+	var out []string
+	seen := map[string]bool{id: true}
+	for {
+		parent := strings.TrimSpace(parents[id])
+		if parent == "" || seen[parent] {
+			return out
+		}
+		seen[parent] = true
+		out = append(out, parent)
+		id = parent
+	}
+}
+
+// inherit inserts ancestor tenant and org scopes after their descendants.
+func (g *Gate) inherit(ctx context.Context, chain gate.ScopeChain, trace *gate.ResolveTrace) (gate.ScopeChain, error) {
+	present := make(map[gate.ScopeRef]bool, len(chain))
+	for _, ref := range chain {
+		present[ref] = true
+	}
+	out := make(gate.ScopeChain, 0, len(chain))
+	var inherited []gate.InheritedScope
+	for _, ref := range chain {
+		out = append(out, ref)
+		var parents []string
+		var err error
+		switch ref.Kind {
+		case gate.ScopeOrg:
+			parents, err = g.hierarchy.ParentOrgs(ctx, ref.TenantID, ref.ID)
+		case gate.ScopeTenant:
+			parents, err = g.hierarchy.ParentTenants(ctx, ref.ID)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			parent = strings.TrimSpace(parent)
+			if parent == "" {
+				continue
+			}
+			ancestor := gate.ScopeRef{Kind: ref.Kind, ID: parent, TenantID: parent}
+			if ref.Kind == gate.ScopeOrg {
+				ancestor = gate.ScopeRef{Kind: gate.ScopeOrg, ID: parent, TenantID: ref.TenantID, OrgID: parent}
+			}
+			if present[ancestor] {
+				continue
+			}
+			present[ancestor] = true
+			out = append(out, ancestor)
+			inherited = append(inherited, gate.InheritedScope{Scope: ancestor, From: ref})
+		}
+	}
+	trace.Inherited = inherited
+	return out, nil
+}
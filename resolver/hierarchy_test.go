@@ -0,0 +1,49 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestGateCascadesParentOrgOverrides(t *testing.T) {
+	ctx := context.Background()
+	platform := gate.ScopeRef{Kind: gate.ScopeOrg, ID: "platform", TenantID: "acme", OrgID: "platform"}
+	eng := gate.ScopeRef{Kind: gate.ScopeOrg, ID: "eng", TenantID: "acme", OrgID: "eng"}
+	overrides := store.NewMemoryStore()
+	if err := overrides.Set(ctx, "deploys.canary", eng, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	g := New(
+		WithOverrideStore(overrides),
+		WithTenantHierarchy(StaticHierarchy{Orgs: map[string]string{"platform": "eng", "eng": "root", "root": "platform"}}),
+	)
+	claims := gate.WithClaims(gate.ActorClaims{TenantID: "acme", OrgID: "platform"})
+
+	value, trace, err := g.ResolveWithTrace(ctx, "deploys.canary", claims)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || trace.Override.Match != eng {
+		t.Fatalf("expected parent org override to cascade, got %v from %+v", value, trace.Override.Match)
+	}
+	if from, ok := trace.InheritedFrom(eng); !ok || from != platform {
+		t.Fatalf("expected eng to be inherited through platform, got %+v %v", from, ok)
+	}
+	if len(trace.Inherited) != 2 || trace.Chain[0] != platform || trace.Chain[1] != eng {
+		t.Fatalf("expected ancestors after the child org, got chain %+v inherited %+v", trace.Chain, trace.Inherited)
+	}
+	if explain := gate.Explain(trace); !strings.Contains(explain, "inherited by org:platform") {
+		t.Fatalf("expected explain to mention inheritance, got:\n%s", explain)
+	}
+
+	if err := overrides.Set(ctx, "deploys.canary", platform, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set child: %v", err)
+	}
+	if value, _ := g.Enabled(ctx, "deploys.canary", claims); value {
+		t.Fatalf("expected the child org override to win over its parent")
+	}
+}
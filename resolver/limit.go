@@ -0,0 +1,221 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// LimitDefaultResult captures a limit default lookup.
+type LimitDefaultResult struct {
+	Set   bool
+	Value int64
+	Plan  string
+}
+
+// LimitDefaults resolves default limits. Defaults providers implement it next to
+// Defaults to serve numeric flags; the gate uses the provider set with WithDefaults.
+type LimitDefaults interface {
+	LimitDefault(ctx context.Context, key string) (LimitDefaultResult, error)
+}
+
+// Limit resolves a numeric limit, such as "projects.max", for the scope chain
+// derived from ctx or the options.
+func (g *Gate) Limit(ctx context.Context, key string, opts ...gate.ResolveOption) (int64, error) {
+	value, _, err := g.LimitWithTrace(ctx, key, opts...)
+	return value, err
+}
+
+// LimitWithTrace resolves a numeric limit and returns its provenance. Overrides
+// follow the scope groups boolean flags use: the first group with an override
+// wins, and within the role and permission group the lowest limit wins, as a
+// disabled override does for flags. Without an override the limit default
+// applies, and zero otherwise. Limits are not cached or memoized and do not reach
+// resolve hooks.
+func (g *Gate) LimitWithTrace(ctx context.Context, key string, opts ...gate.ResolveOption) (int64, gate.LimitTrace, error) {
+	trimmed := strings.TrimSpace(key)
+	normalized := g.keyPolicy.Normalize(trimmed)
+	trace := gate.LimitTrace{Key: trimmed, NormalizedKey: normalized, Source: gate.ResolveSourceFallback}
+	if normalized == "" {
+		return 0, trace, ferrors.WrapSentinel(ferrors.ErrInvalidKey, "", map[string]any{
+			ferrors.MetaFeatureKey:           trimmed,
+			ferrors.MetaFeatureKeyNormalized: normalized,
+			ferrors.MetaOperation:            "limit",
+		})
+	}
+	if err := g.checkKey(normalized, "limit"); err != nil {
+		return 0, trace, err
+	}
+
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	var chainTrace gate.ResolveTrace
+	chain, _, err := g.resolveChain(ctx, normalized, &chainTrace, req)
+	trace.Chain = chain
+	if err != nil {
+		return 0, trace, ferrors.WrapExternal(err, ferrors.TextCodeScopeResolveFailed, "claims resolution failed", map[string]any{
+			ferrors.MetaFeatureKey:           trimmed,
+			ferrors.MetaFeatureKeyNormalized: normalized,
+			ferrors.MetaOperation:            "limit_claims",
+		})
+	}
+
+	if reader, ok := g.overrides.(store.LimitReader); ok {
+		storeCtx, cancel := g.stageContext(ctx)
+		matched, storeErr := g.resolveLimitOverrides(storeCtx, reader, normalized, chain, &trace)
+		cancel()
+		if storeErr != nil {
+			storeErr = ferrors.WrapExternal(storeErr, ferrors.TextCodeStoreReadFailed, "limit store read failed", map[string]any{
+				ferrors.MetaFeatureKey:           trimmed,
+				ferrors.MetaFeatureKeyNormalized: normalized,
+				ferrors.MetaStore:                "override",
+				ferrors.MetaOperation:            "get_limits",
+				ferrors.MetaStrict:               g.strictStore,
+			})
+			trace.OverrideError = storeErr
+			if g.strictStore {
+				return 0, trace, storeErr
+			}
+		} else if matched {
+			return trace.Value, trace, nil
+		}
+	}
+
+	if defaults, ok := g.defaults.(LimitDefaults); ok {
+		defaultCtx, cancel := g.stageContext(ctx)
+		def, err := defaults.LimitDefault(gate.WithResolvedChain(defaultCtx, chain), normalized)
+		cancel()
+		if err != nil {
+			err = ferrors.WrapExternal(err, ferrors.TextCodeDefaultLookupFailed, "limit default lookup failed", map[string]any{
+				ferrors.MetaFeatureKey:           trimmed,
+				ferrors.MetaFeatureKeyNormalized: normalized,
+				ferrors.MetaOperation:            "limit_default",
+			})
+			trace.DefaultError = err
+			return 0, trace, err
+		}
+		trace.DefaultSet = def.Set
+		trace.Plan = def.Plan
+		if def.Set {
+			trace.Value = def.Value
+			trace.Source = gate.ResolveSourceDefault
+		}
+	}
+	return trace.Value, trace, nil
+}
+
+// SetLimit stores a numeric limit override when the override writer implements
+// store.LimitWriter.
+func (g *Gate) SetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, value int64, actor gate.ActorRef) error {
+	return g.writeLimit(ctx, key, scopeRef, &value, actor)
+}
+
+// UnsetLimit clears a numeric limit override.
+func (g *Gate) UnsetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	return g.writeLimit(ctx, key, scopeRef, nil, actor)
+}
+
+func (g *Gate) writeLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, value *int64, actor gate.ActorRef) error {
+	trimmed := strings.TrimSpace(key)
+	normalized := g.keyPolicy.Normalize(trimmed)
+	scopeRef = g.chains.NormalizeRef(scopeRef)
+	operation, action := "set_limit", activity.ActionSetLimit
+	if value == nil {
+		operation, action = "unset_limit", activity.ActionUnsetLimit
+	}
+	meta := map[string]any{
+		ferrors.MetaFeatureKey:           trimmed,
+		ferrors.MetaFeatureKeyNormalized: normalized,
+		ferrors.MetaScope:                scopeRef,
+		ferrors.MetaOperation:            operation,
+	}
+	writer, ok := g.writer.(store.LimitWriter)
+	if !ok {
+		meta[ferrors.MetaStore] = "override"
+		return ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "", meta)
+	}
+	if normalized == "" {
+		return ferrors.WrapSentinel(ferrors.ErrInvalidKey, "", meta)
+	}
+	if err := g.checkKey(normalized, operation); err != nil {
+		return err
+	}
+	var err error
+	if value != nil {
+		err = writer.SetLimit(ctx, normalized, scopeRef, *value, actor)
+	} else {
+		err = writer.UnsetLimit(ctx, normalized, scopeRef, actor)
+	}
+	if err != nil {
+		meta[ferrors.MetaStore] = "override"
+		return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "limit store write failed", meta)
+	}
+	g.emitUpdate(ctx, activity.UpdateEvent{
+		Key:           trimmed,
+		NormalizedKey: normalized,
+		Scope:         scopeRef,
+		Actor:         actor,
+		Action:        action,
+		Limit:         value,
+	})
+	return nil
+}
+
+// resolveLimitOverrides reads limit overrides for key and its aliases and records
+// the winner in trace.
+func (g *Gate) resolveLimitOverrides(ctx context.Context, reader store.LimitReader, key string, chain gate.ScopeChain, trace *gate.LimitTrace) (bool, error) {
+	for _, candidate := range append([]string{key}, gate.AliasesFor(key)...) {
+		matches, err := reader.GetLimits(ctx, candidate, chain)
+		if err != nil {
+			return false, err
+		}
+		for _, match := range matches {
+			trace.Matches = append(trace.Matches, gate.LimitMatchTrace{Scope: match.Scope, Value: match.Value})
+		}
+		if winner, ok := g.pickLimit(chain, matches); ok {
+			trace.Value = winner.Value
+			trace.Match = winner.Scope
+			trace.Source = gate.ResolveSourceOverride
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (g *Gate) pickLimit(chain gate.ScopeChain, matches []store.LimitMatch) (store.LimitMatch, bool) {
+	if len(matches) == 0 {
+		return store.LimitMatch{}, false
+	}
+	byScope := make(map[string]store.LimitMatch, len(matches))
+	for _, match := range matches {
+		byScope[scopeKey(match.Scope)] = match
+	}
+	for _, group := range groupOrderFor(g.scopeOrder) {
+		var winner store.LimitMatch
+		found := false
+		for _, ref := range chain {
+			if !scopeKindInGroup(ref.Kind, group) {
+				continue
+			}
+			match, ok := byScope[scopeKey(ref)]
+			if !ok {
+				continue
+			}
+			if !found || (group == groupRolePerm && match.Value < winner.Value) {
+				winner, found = match, true
+			}
+		}
+		if found {
+			return winner, true
+		}
+	}
+	return store.LimitMatch{}, false
+}
@@ -0,0 +1,66 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+type staticLimits map[string]int64
+
+func (d staticLimits) Default(context.Context, string) (DefaultResult, error) {
+	return DefaultResult{}, nil
+}
+
+func (d staticLimits) LimitDefault(_ context.Context, key string) (LimitDefaultResult, error) {
+	value, ok := d[key]
+	return LimitDefaultResult{Set: ok, Value: value}, nil
+}
+
+func TestGateResolvesLimitsThroughScopeChain(t *testing.T) {
+	ctx := context.Background()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1", TenantID: "acme"}
+	var events []activity.UpdateEvent
+	g := New(
+		WithOverrideStore(store.NewMemoryStore()),
+		WithDefaults(staticLimits{"projects.max": 10}),
+		WithActivityHook(activity.HookFunc(func(_ context.Context, event activity.UpdateEvent) {
+			events = append(events, event)
+		})),
+	)
+	claims := gate.WithClaims(gate.ActorClaims{TenantID: "acme", SubjectID: "u1"})
+
+	value, trace, err := g.LimitWithTrace(ctx, "projects.max", claims)
+	if err != nil || value != 10 || trace.Source != gate.ResolveSourceDefault {
+		t.Fatalf("expected default limit 10, got %d %s (%v)", value, trace.Source, err)
+	}
+	if err := g.SetLimit(ctx, "projects.max", tenant, 100, gate.ActorRef{ID: "billing"}); err != nil {
+		t.Fatalf("set limit: %v", err)
+	}
+	if value, _ := g.Limit(ctx, "projects.max", claims); value != 100 {
+		t.Fatalf("expected tenant limit 100, got %d", value)
+	}
+	if err := g.SetLimit(ctx, "projects.max", user, 5, gate.ActorRef{ID: "billing"}); err != nil {
+		t.Fatalf("set limit: %v", err)
+	}
+	value, trace, _ = g.LimitWithTrace(ctx, "projects.max", claims)
+	if value != 5 || trace.Match != user || len(trace.Matches) != 2 {
+		t.Fatalf("expected user limit to win, got %d %+v", value, trace)
+	}
+	if err := g.UnsetLimit(ctx, "projects.max", user, gate.ActorRef{ID: "billing"}); err != nil {
+		t.Fatalf("unset limit: %v", err)
+	}
+	if value, _ := g.Limit(ctx, "projects.max", claims); value != 100 {
+		t.Fatalf("expected tenant limit after unset, got %d", value)
+	}
+	if len(events) != 3 || events[0].Action != activity.ActionSetLimit || events[0].Limit == nil || *events[0].Limit != 100 || events[2].Action != activity.ActionUnsetLimit {
+		t.Fatalf("unexpected limit events: %+v", events)
+	}
+	if value, _ := g.Limit(ctx, "seats.max", claims); value != 0 {
+		t.Fatalf("expected unknown limits to resolve to zero, got %d", value)
+	}
+}
@@ -0,0 +1,71 @@
+package resolver
+
+import (
+	"strings"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// keyPatterns maps key patterns to per-key settings using the pattern rules of
+// cache.Policies: an exact key, a prefix ending in "*", or "*" for every key. Exact
+// keys win over prefixes, and longer prefixes win over shorter ones.
+type keyPatterns[T any] struct {
+	exact    map[string]T
+	prefixes []prefixValue[T]
+}
+
+type prefixValue[T any] struct {
+	prefix string
+	value  T
+}
+
+func (p *keyPatterns[T]) add(pattern string, value T) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		prefix = gate.NormalizeKey(prefix)
+		for i := range p.prefixes {
+			if p.prefixes[i].prefix == prefix {
+				p.prefixes[i].value = value
+				return
+			}
+		}
+		p.prefixes = append(p.prefixes, prefixValue[T]{prefix: prefix, value: value})
+		return
+	}
+	if p.exact == nil {
+		p.exact = map[string]T{}
+	}
+	p.exact[gate.NormalizeKey(pattern)] = value
+}
+
+// lookup returns the value for a normalized key, or fallback when none matches.
+func (p *keyPatterns[T]) lookup(key string, fallback T) T {
+	if value, ok := p.exact[key]; ok {
+		return value
+	}
+	best, found := -1, fallback
+	for _, candidate := range p.prefixes {
+		if len(candidate.prefix) > best && strings.HasPrefix(key, candidate.prefix) {
+			best, found = len(candidate.prefix), candidate.value
+		}
+	}
+	return found
+}
+
+// snapshot lists the registered patterns for Gate.Config.
+func (p *keyPatterns[T]) snapshot() map[string]T {
+	if len(p.exact) == 0 && len(p.prefixes) == 0 {
+		return nil
+	}
+	out := make(map[string]T, len(p.exact)+len(p.prefixes))
+	for key, value := range p.exact {
+		out[key] = value
+	}
+	for _, candidate := range p.prefixes {
+		out[candidate.prefix+"*"] = candidate.value
+	}
+	return out
+}
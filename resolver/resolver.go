@@ -2,14 +2,20 @@ package resolver
 
 import (
 	"context"
-	"sort"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/goliatone/go-featuregate/activity"
 	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/experiment"
 	"github.com/goliatone/go-featuregate/ferrors"
 	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/idgen"
 	"github.com/goliatone/go-featuregate/scope"
+	"github.com/goliatone/go-featuregate/scopechain"
 	"github.com/goliatone/go-featuregate/store"
 )
 
@@ -19,10 +25,12 @@ var ErrInvalidKey = ferrors.ErrInvalidKey
 // ErrStoreUnavailable signals a missing runtime override store.
 var ErrStoreUnavailable = ferrors.ErrStoreUnavailable
 
-// DefaultResult captures a config default lookup.
+// DefaultResult captures a config default lookup. Plan names the subscription plan
+// the default came from, for providers that derive defaults from entitlements.
 type DefaultResult struct {
 	Set   bool
 	Value bool
+	Plan  string
 }
 
 // Defaults resolves config defaults for a feature key.
@@ -38,25 +46,62 @@ func (NoopDefaults) Default(context.Context, string) (DefaultResult, error) {
 	return DefaultResult{}, nil
 }
 
+// Schedules resolves activation windows for feature keys.
+type Schedules interface {
+	Schedule(ctx context.Context, key string) (gate.Schedule, bool, error)
+}
+
+// StaticSchedules serves schedules from a map keyed by feature key.
+type StaticSchedules map[string]gate.Schedule
+
+// Schedule implements Schedules.
+func (s StaticSchedules) Schedule(_ context.Context, key string) (gate.Schedule, bool, error) {
+	schedule, ok := s[gate.NormalizeKey(key)]
+	return schedule, ok, nil
+}
+
 // Gate resolves feature values using overrides, defaults, and fallbacks.
 type Gate struct {
-	defaults                 Defaults
-	overrides                store.Reader
-	writer                   store.Writer
-	claimsProvider           gate.ClaimsProvider
-	permissionProvider       gate.PermissionProvider
-	cache                    cache.Cache
-	hooks                    []gate.ResolveHook
-	updateHooks              []activity.Hook
-	strictStore              bool
-	scopeOrder               []gate.ScopeKind
-	strategy                 ResolveStrategy
-	failureMode              ClaimsFailureMode
-	failureFallbackChain      gate.ScopeChain
-	appendSystemOnFailure     bool
+	defaults                    Defaults
+	schedules                   Schedules
+	overrides                   store.Reader
+	writer                      store.Writer
+	claimsProvider              gate.ClaimsProvider
+	permissionProvider          gate.PermissionProvider
+	hierarchy                   gate.TenantHierarchyProvider
+	cache                       cache.Cache
+	cachePolicies               cache.Policies
+	keyPolicy                   gate.KeyPolicy
+	keyNamespace                string
+	catalog                     catalog.Catalog
+	hooks                       []gate.ResolveHook
+	extractors                  map[string]gate.ContextExtractor
+	updateHooks                 []activity.Hook
+	hookDispatch                hookDispatchConfig
+	dispatcher                  *hookDispatcher
+	experiments                 *experiment.Assigner
+	strictStore                 bool
+	scopeOrder                  []gate.ScopeKind
+	strategy                    ResolveStrategy
+	strategyName                string
+	failureMode                 ClaimsFailureMode
+	failurePolicies             keyPatterns[ClaimsFailureMode]
+	strategyNames               keyPatterns[string]
+	groupOrder                  []groupKind
+	redactor                    gate.TraceRedactor
+	severityPolicy              *ferrors.SeverityPolicy
+	fastPath                    bool
+	failureFallbackChain        gate.ScopeChain
+	appendSystemOnFailure       bool
 	appendSystemOnProvidedChain bool
-	preserveRolePermOrder     bool
-	rolePermNormalizer        IdentifierNormalizer
+	preserveRolePermOrder       bool
+	rolePermNormalizer          IdentifierNormalizer
+	chains                      *scopechain.Builder
+	resolveTimeout              time.Duration
+	environment                 string
+	bundleVersion               string
+	ids                         idgen.Generator
+	now                         func() time.Time
 }
 
 // Option customizes a Gate.
@@ -71,7 +116,7 @@ const (
 )
 
 // IdentifierNormalizer normalizes role/perm identifiers.
-type IdentifierNormalizer func(string) string
+type IdentifierNormalizer = scopechain.IdentifierNormalizer
 
 // ResolveOptions are passed to the strategy for context.
 type ResolveOptions struct {
@@ -79,12 +124,14 @@ type ResolveOptions struct {
 }
 
 // OverrideDecision captures a strategy decision.
+// Source reports the layer that produced a match; empty means gate.ResolveSourceOverride.
 type OverrideDecision struct {
 	Matched  bool
 	Value    bool
 	Match    gate.ScopeRef
 	Matches  []store.OverrideMatch
 	Strategy string
+	Source   gate.ResolveSource
 }
 
 // ResolveStrategy evaluates matches for a chain.
@@ -100,6 +147,17 @@ func WithDefaults(defaults Defaults) Option {
 	}
 }
 
+// WithSchedules sets the activation windows applied to config defaults.
+// Defaults outside their window resolve disabled; overrides are not scheduled.
+func WithSchedules(schedules Schedules) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.schedules = schedules
+	}
+}
+
 // WithOverrideStore sets the runtime override reader.
 func WithOverrideStore(reader store.Reader) Option {
 	return func(g *Gate) {
@@ -160,6 +218,18 @@ func WithResolveStrategy(strategy ResolveStrategy) Option {
 			return
 		}
 		g.strategy = strategy
+		g.strategyName = StrategyCustom
+	}
+}
+
+// WithNamedResolveStrategy overrides the default strategy and reports name in Gate.Config.
+func WithNamedResolveStrategy(name string, strategy ResolveStrategy) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.strategy = strategy
+		g.strategyName = strings.TrimSpace(name)
 	}
 }
 
@@ -173,6 +243,31 @@ func WithClaimsFailureMode(mode ClaimsFailureMode) Option {
 	}
 }
 
+// WithClaimsFailurePolicy sets the claims failure mode for keys matching pattern,
+// e.g. WithClaimsFailurePolicy("billing.*", FailClosed). Patterns follow the
+// cache.Policies rules; keys without a match use WithClaimsFailureMode.
+func WithClaimsFailurePolicy(pattern string, mode ClaimsFailureMode) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.failurePolicies.add(pattern, mode)
+	}
+}
+
+// WithClaimsFailurePolicies registers a claims failure mode per key pattern, so
+// security-sensitive flags can fail closed while cosmetic flags fail open.
+func WithClaimsFailurePolicies(policies map[string]ClaimsFailureMode) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		for pattern, mode := range policies {
+			g.failurePolicies.add(pattern, mode)
+		}
+	}
+}
+
 // WithFailureFallbackChain sets the fallback chain used on claims failure.
 func WithFailureFallbackChain(chain gate.ScopeChain) Option {
 	return func(g *Gate) {
@@ -223,6 +318,70 @@ func WithRolePermNormalizer(normalizer IdentifierNormalizer) Option {
 	}
 }
 
+// WithClock sets the clock used for override expiry and schedules.
+func WithClock(c clock.Clock) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.now = clock.NowFunc(c)
+	}
+}
+
+// WithNowFunc overrides the clock used for override expiry and schedules.
+func WithNowFunc(now func() time.Time) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.now = now
+	}
+}
+
+// WithResolveTimeout bounds each claims, permission, store, defaults, and schedule call
+// with a child context deadline. A timed-out store or defaults call falls back unless
+// strict store mode is on; claims and permission timeouts follow the claims failure mode.
+// Timed-out stages are listed in ResolveTrace.TimedOut. Zero disables the bound.
+func WithResolveTimeout(d time.Duration) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.resolveTimeout = d
+	}
+}
+
+// WithEnvironment sets the deployment environment added to derived chains as a
+// gate.ScopeEnv scope. scope.WithEnv on the request context takes precedence.
+func WithEnvironment(env string) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.environment = strings.TrimSpace(env)
+	}
+}
+
+// WithBundleVersion records the version of the flag bundle the gate serves, reported in Config.
+func WithBundleVersion(version string) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.bundleVersion = strings.TrimSpace(version)
+	}
+}
+
+// WithIDGenerator sets the generator for activity.UpdateEvent IDs. Defaults to UUIDv7.
+func WithIDGenerator(gen idgen.Generator) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.ids = gen
+	}
+}
+
 // WithCache sets the cache implementation.
 func WithCache(c cache.Cache) Option {
 	return func(g *Gate) {
@@ -233,6 +392,17 @@ func WithCache(c cache.Cache) Option {
 	}
 }
 
+// WithCachePolicy applies a cache policy to keys matching pattern, e.g.
+// WithCachePolicy("billing.*", cache.Bypass). See cache.Policies for pattern rules.
+func WithCachePolicy(pattern string, policy cache.Policy) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.cachePolicies.Add(pattern, policy)
+	}
+}
+
 // WithResolveHook registers a resolve hook.
 func WithResolveHook(hook gate.ResolveHook) Option {
 	return func(g *Gate) {
@@ -243,6 +413,74 @@ func WithResolveHook(hook gate.ResolveHook) Option {
 	}
 }
 
+// WithValueChangeHook reports when a key's effective value changes for a scope chain,
+// using a gate.ChangeDetector registered as a resolve hook.
+func WithValueChangeHook(hook gate.ValueChangeHook, opts ...gate.ChangeDetectorOption) Option {
+	return func(g *Gate) {
+		if g == nil || hook == nil {
+			return
+		}
+		g.hooks = append(g.hooks, gate.NewChangeDetector(hook, opts...))
+	}
+}
+
+// WithTraceRedactor rewrites traces before they reach resolve hooks, the resolve
+// cache, and Explain output, for example with gate.HashScopeIDs to keep user IDs
+// out of logs. Traces returned by ResolveWithTrace are not redacted.
+func WithTraceRedactor(redactor gate.TraceRedactor) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.redactor = redactor
+	}
+}
+
+// WithSeverityPolicy sets the severity of the errors resolve hooks receive, for
+// example ferrors.DowngradeNonStrict(ferrors.TextCodeStoreReadFailed,
+// ferrors.SeverityWarning) to stop alerting on tolerated store failures. A policy
+// attached with ferrors.WithSeverityPolicy takes precedence for that request.
+func WithSeverityPolicy(policy *ferrors.SeverityPolicy) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.severityPolicy = policy
+	}
+}
+
+// WithContextExtractor adds request metadata to resolve events under name.
+func WithContextExtractor(name string, extractor gate.ContextExtractor) Option {
+	return func(g *Gate) {
+		if g == nil || extractor == nil {
+			return
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		if g.extractors == nil {
+			g.extractors = map[string]gate.ContextExtractor{}
+		}
+		g.extractors[name] = extractor
+	}
+}
+
+// WithRequestIDExtractor adds the request ID to resolve events.
+func WithRequestIDExtractor(extractor gate.ContextExtractor) Option {
+	return WithContextExtractor(gate.EventMetaRequestID, extractor)
+}
+
+// WithTraceIDExtractor adds the trace ID to resolve events.
+func WithTraceIDExtractor(extractor gate.ContextExtractor) Option {
+	return WithContextExtractor(gate.EventMetaTraceID, extractor)
+}
+
+// WithRouteExtractor adds the request route to resolve events.
+func WithRouteExtractor(extractor gate.ContextExtractor) Option {
+	return WithContextExtractor(gate.EventMetaRoute, extractor)
+}
+
 // WithActivityHook registers an update hook.
 func WithActivityHook(hook activity.Hook) Option {
 	return func(g *Gate) {
@@ -263,17 +501,52 @@ func WithStrictStore(strict bool) Option {
 	}
 }
 
+// WithKeyPolicy validates and normalizes keys at resolve and write time. Keys that
+// break the policy fail with FEATURE_KEY_INVALID, listing each failed rule.
+func WithKeyPolicy(policy gate.KeyPolicy) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.keyPolicy = policy
+	}
+}
+
+// WithKeyNamespace stores override keys under namespace (as "namespace:key") so
+// several services can share one override table. Keys in traces, events, and
+// listings stay unprefixed.
+func WithKeyNamespace(namespace string) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.keyNamespace = strings.TrimSpace(namespace)
+	}
+}
+
+// WithCatalog sets the feature catalog used to expand groups in EnableGroup and DisableGroup.
+func WithCatalog(c catalog.Catalog) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.catalog = c
+	}
+}
+
 // New constructs a Gate with the provided options.
 func New(options ...Option) *Gate {
 	g := &Gate{
-		defaults:             NoopDefaults{},
-		cache:                cache.NoopCache{},
-		scopeOrder:           defaultScopeOrder(),
-		strategy:             defaultResolveStrategy,
-		failureMode:          FailOpen,
-		appendSystemOnFailure: true,
+		defaults:                    NoopDefaults{},
+		cache:                       cache.NoopCache{},
+		scopeOrder:                  scopechain.DefaultScopeOrder(),
+		strategy:                    defaultResolveStrategy,
+		strategyName:                StrategyDefault,
+		failureMode:                 FailOpen,
+		appendSystemOnFailure:       true,
 		appendSystemOnProvidedChain: false,
-		rolePermNormalizer:   defaultRolePermNormalizer,
+		rolePermNormalizer:          scopechain.DefaultRolePermNormalizer,
+		now:                         time.Now,
 	}
 	for _, opt := range options {
 		if opt != nil {
@@ -289,20 +562,59 @@ func New(options ...Option) *Gate {
 	if g.claimsProvider == nil {
 		g.claimsProvider = contextClaimsProvider{}
 	}
+	if notifier, ok := g.writer.(store.FlushNotifier); ok {
+		notifier.OnFlushed(g.invalidateFlushed)
+	}
+	if g.keyNamespace != "" {
+		if g.overrides != nil {
+			g.overrides = store.Namespaced(g.overrides, g.keyNamespace)
+		}
+		if g.writer != nil {
+			g.writer = store.NamespacedWriter(g.writer, g.keyNamespace)
+		}
+	}
 	if g.strategy == nil {
 		g.strategy = defaultResolveStrategy
+		g.strategyName = StrategyDefault
+	}
+	if g.strategyName == "" {
+		g.strategyName = StrategyCustom
 	}
 	if g.scopeOrder == nil {
-		g.scopeOrder = defaultScopeOrder()
+		g.scopeOrder = scopechain.DefaultScopeOrder()
 	}
 	if g.rolePermNormalizer == nil {
-		g.rolePermNormalizer = defaultRolePermNormalizer
-	}
+		g.rolePermNormalizer = scopechain.DefaultRolePermNormalizer
+	}
+	if g.now == nil {
+		g.now = time.Now
+	}
+	g.chains = scopechain.New(
+		scopechain.WithScopeOrder(g.scopeOrder...),
+		scopechain.WithRolePermNormalizer(g.rolePermNormalizer),
+		scopechain.WithPreserveRolePermOrder(g.preserveRolePermOrder),
+		scopechain.WithEnvironment(g.environment),
+	)
+	if g.hookDispatch.enabled {
+		g.dispatcher = newHookDispatcher(g.hookDispatch)
+	}
+	g.groupOrder = groupOrderFor(g.scopeOrder)
+	g.fastPath = g.fastPathEligible()
 	return g
 }
 
-// Enabled resolves a feature value without returning trace data.
+// ChainBuilder returns the builder the gate derives scope chains with, so callers
+// can build the chain a context would resolve with from the same claims.
+func (g *Gate) ChainBuilder() *scopechain.Builder {
+	return g.chains
+}
+
+// Enabled resolves a feature value without returning trace data. Gates without
+// hooks, caches, or other trace consumers skip building the trace entirely.
 func (g *Gate) Enabled(ctx context.Context, key string, opts ...gate.ResolveOption) (bool, error) {
+	if value, ok := g.enabledFast(ctx, key, opts); ok {
+		return value, nil
+	}
 	value, _, err := g.resolve(ctx, key, opts...)
 	return value, err
 }
@@ -313,11 +625,11 @@ func (g *Gate) ResolveWithTrace(ctx context.Context, key string, opts ...gate.Re
 	return value, trace, err
 }
 
-// Set stores a runtime override.
-func (g *Gate) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef) error {
+// Set stores a runtime override. Use gate.WithTTL or gate.WithExpiresAt to expire the override.
+func (g *Gate) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
 	trimmed := strings.TrimSpace(key)
-	normalized := gate.NormalizeKey(trimmed)
-	scopeRef = g.normalizeScopeRef(scopeRef)
+	normalized := g.keyPolicy.Normalize(trimmed)
+	scopeRef = g.chains.NormalizeRef(scopeRef)
 	if g.writer == nil {
 		return ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
@@ -335,7 +647,22 @@ func (g *Gate) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enab
 			ferrors.MetaOperation:            "set",
 		})
 	}
-	if err := g.writer.Set(ctx, normalized, scopeRef, enabled, actor); err != nil {
+	if err := g.checkKey(normalized, "set"); err != nil {
+		return err
+	}
+	req := gate.ApplyMutationOptions(opts...)
+	expiresAt := req.Expiry(g.now())
+	var writeOpts []gate.MutationOption
+	if !expiresAt.IsZero() {
+		writeOpts = append(writeOpts, gate.WithExpiresAt(expiresAt))
+	}
+	if !req.Metadata.IsZero() {
+		writeOpts = append(writeOpts, gate.WithMetadata(req.Metadata))
+	}
+	if req.Priority != 0 {
+		writeOpts = append(writeOpts, gate.WithPriority(req.Priority))
+	}
+	if err := g.writer.Set(ctx, normalized, scopeRef, enabled, actor, writeOpts...); err != nil {
 		return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "override store set failed", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
 			ferrors.MetaFeatureKeyNormalized: normalized,
@@ -354,15 +681,38 @@ func (g *Gate) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enab
 		Actor:         actor,
 		Action:        activity.ActionSet,
 		Value:         boolPtr(enabled),
+		ExpiresAt:     expiresAt,
+		Metadata:      req.Metadata,
 	})
 	return nil
 }
 
+// ListOverrides lists stored overrides when the override store implements store.Lister.
+func (g *Gate) ListOverrides(ctx context.Context, filter store.ListFilter) ([]store.OverrideRecord, error) {
+	lister, ok := g.overrides.(store.Lister)
+	if !ok || lister == nil {
+		return nil, ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(filter.Key),
+			ferrors.MetaStore:      "override",
+			ferrors.MetaOperation:  "list",
+		})
+	}
+	records, err := lister.List(ctx, filter)
+	if err != nil {
+		return nil, ferrors.WrapExternal(err, ferrors.TextCodeStoreReadFailed, "override store list failed", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(filter.Key),
+			ferrors.MetaStore:      "override",
+			ferrors.MetaOperation:  "list",
+		})
+	}
+	return records, nil
+}
+
 // Unset clears a runtime override.
 func (g *Gate) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
 	trimmed := strings.TrimSpace(key)
-	normalized := gate.NormalizeKey(trimmed)
-	scopeRef = g.normalizeScopeRef(scopeRef)
+	normalized := g.keyPolicy.Normalize(trimmed)
+	scopeRef = g.chains.NormalizeRef(scopeRef)
 	if g.writer == nil {
 		return ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
@@ -380,6 +730,9 @@ func (g *Gate) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, ac
 			ferrors.MetaOperation:            "unset",
 		})
 	}
+	if err := g.checkKey(normalized, "unset"); err != nil {
+		return err
+	}
 	if err := g.writer.Unset(ctx, normalized, scopeRef, actor); err != nil {
 		return ferrors.WrapExternal(err, ferrors.TextCodeStoreWriteFailed, "override store unset failed", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
@@ -407,13 +760,26 @@ func (g *Gate) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, ac
 	return nil
 }
 
+// resolve evaluates a key and records successful results in the request's
+// gate.EvaluationMemo, when the context carries one.
 func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOption) (bool, gate.ResolveTrace, error) {
+	value, trace, err := g.evaluate(ctx, key, opts...)
+	if err == nil && !trace.Memoized && trace.Source != gate.ResolveSourceRequestOverride && trace.Source != gate.ResolveSourceCallerFallback {
+		gate.EvaluationMemoFromContext(ctx).Record(g, trace)
+	}
+	return value, trace, err
+}
+
+func (g *Gate) evaluate(ctx context.Context, key string, opts ...gate.ResolveOption) (bool, gate.ResolveTrace, error) {
 	trimmed := strings.TrimSpace(key)
-	normalized := gate.NormalizeKey(trimmed)
+	normalized := g.keyPolicy.Normalize(trimmed)
 	trace := gate.ResolveTrace{
 		Key:           trimmed,
 		NormalizedKey: normalized,
 	}
+	if gate.IsAlias(trimmed) {
+		trace.Alias = trimmed
+	}
 	if normalized == "" {
 		err := ferrors.WrapSentinel(ferrors.ErrInvalidKey, "", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
@@ -424,8 +790,19 @@ func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOpti
 		g.emitResolve(ctx, trace, err)
 		return false, trace, err
 	}
+	if err := g.checkKey(normalized, "resolve"); err != nil {
+		trace.Source = gate.ResolveSourceFallback
+		g.emitResolve(ctx, trace, err)
+		return false, trace, err
+	}
 
-	chain, failureMode, err := g.resolveChain(ctx, opts...)
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	chain, failureMode, err := g.resolveChain(ctx, normalized, &trace, req)
 	if err != nil {
 		err = ferrors.WrapExternal(err, ferrors.TextCodeScopeResolveFailed, "claims resolution failed", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
@@ -441,9 +818,26 @@ func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOpti
 	trace.Chain = chain
 	trace.ClaimsFailureMode = string(failureMode)
 
-	if g.cache != nil {
-		if entry, ok := g.cache.Get(ctx, normalized, chain); ok {
+	if value, ok := gate.RequestOverride(ctx, normalized); ok {
+		trace.Value = value
+		trace.Source = gate.ResolveSourceRequestOverride
+		g.emitResolve(ctx, trace, nil)
+		return value, trace, nil
+	}
+
+	if memoized, ok := gate.EvaluationMemoFromContext(ctx).Lookup(g, normalized, chain); ok {
+		memoized.Alias = callerAlias(memoized, trace.Alias)
+		memoized.Key = trimmed
+		memoized.Memoized = true
+		applyCallerFallback(&memoized, req.Fallback)
+		g.emitResolve(ctx, memoized, nil)
+		return memoized.Value, memoized, nil
+	}
+
+	if policy := g.cachePolicies.Lookup(normalized); g.cache != nil && !policy.Bypass && !store.Uncommitted(ctx) {
+		if entry, ok := g.readCache(ctx, normalized, chain); ok {
 			cached := entry.Trace
+			cached.Alias = callerAlias(cached, trace.Alias)
 			if cached.Key == "" {
 				cached.Key = trimmed
 			}
@@ -453,8 +847,9 @@ func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOpti
 			cached.Chain = chain
 			cached.Value = entry.Value
 			cached.CacheHit = true
+			applyCallerFallback(&cached, req.Fallback)
 			g.emitResolve(ctx, cached, nil)
-			return entry.Value, cached, nil
+			return cached.Value, cached, nil
 		}
 	}
 
@@ -462,7 +857,11 @@ func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOpti
 	var decision OverrideDecision
 	var overrideTrace gate.ResolveTrace
 	if g.overrides != nil {
-		decision, overrideTrace, storeErr = g.resolveOverrides(ctx, normalized, chain)
+		storeCtx, cancel := g.stageContext(ctx)
+		decision, overrideTrace, storeErr = g.resolveOverrides(storeCtx, normalized, chain)
+		g.recordTimeout(storeCtx, storeErr, &trace, gate.StageOverride)
+		cancel()
+		trace.Rule = overrideTrace.Rule
 		if storeErr != nil {
 			storeErr = ferrors.WrapExternal(storeErr, ferrors.TextCodeStoreReadFailed, "override store read failed", map[string]any{
 				ferrors.MetaFeatureKey:           trimmed,
@@ -481,10 +880,18 @@ func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOpti
 		} else {
 			trace.Override = overrideTrace.Override
 			trace.Strategy = overrideTrace.Strategy
+			if overrideTrace.Alias != "" {
+				trace.Alias = overrideTrace.Alias
+			}
 			if decision.Matched {
 				trace.Value = decision.Value
-				trace.Source = gate.ResolveSourceOverride
-				g.writeCache(ctx, normalized, chain, trace, storeErr)
+				trace.Source = decision.Source
+				if trace.Source == "" {
+					trace.Source = gate.ResolveSourceOverride
+				}
+				if trace.Override.ExpiresAt.IsZero() {
+					g.writeCache(ctx, normalized, chain, trace, storeErr)
+				}
 				g.emitResolve(ctx, trace, nil)
 				return decision.Value, trace, nil
 			}
@@ -497,21 +904,30 @@ func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOpti
 	if defaults == nil {
 		defaults = NoopDefaults{}
 	}
-	def, err := defaults.Default(ctx, normalized)
+	defaultCtx, cancel := g.stageContext(ctx)
+	def, err := defaults.Default(gate.WithResolvedChain(defaultCtx, chain), normalized)
+	timedOut := g.recordTimeout(defaultCtx, err, &trace, gate.StageDefault)
+	cancel()
 	if err != nil {
 		err = ferrors.WrapExternal(err, ferrors.TextCodeDefaultLookupFailed, "default lookup failed", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
 			ferrors.MetaFeatureKeyNormalized: normalized,
 			ferrors.MetaChain:                chain,
 			ferrors.MetaOperation:            "default",
+			ferrors.MetaStrict:               g.strictStore,
 		})
 		trace.Default.Error = err
 		trace.Source = gate.ResolveSourceFallback
+		if timedOut && !g.strictStore {
+			g.emitResolve(ctx, trace, nil)
+			return false, trace, nil
+		}
 		g.emitResolve(ctx, trace, err)
 		return false, trace, err
 	}
 	trace.Default.Set = def.Set
 	trace.Default.Value = def.Value
+	trace.Default.Plan = def.Plan
 	if def.Set {
 		trace.Value = def.Value
 		trace.Source = gate.ResolveSourceDefault
@@ -520,71 +936,235 @@ func (g *Gate) resolve(ctx context.Context, key string, opts ...gate.ResolveOpti
 		trace.Source = gate.ResolveSourceFallback
 	}
 
-	g.writeCache(ctx, normalized, chain, trace, storeErr)
+	scheduleCtx, cancel := g.stageContext(ctx)
+	err = g.applySchedule(scheduleCtx, normalized, &trace)
+	timedOut = g.recordTimeout(scheduleCtx, err, &trace, gate.StageSchedule)
+	cancel()
+	if err != nil {
+		err = ferrors.WrapExternal(err, ferrors.TextCodeScheduleLookupFailed, "schedule lookup failed", map[string]any{
+			ferrors.MetaFeatureKey:           trimmed,
+			ferrors.MetaFeatureKeyNormalized: normalized,
+			ferrors.MetaOperation:            "schedule",
+			ferrors.MetaStrict:               g.strictStore,
+		})
+		trace.Schedule.Error = err
+		trace.Value = false
+		trace.Source = gate.ResolveSourceFallback
+		if timedOut && !g.strictStore {
+			g.emitResolve(ctx, trace, nil)
+			return false, trace, nil
+		}
+		g.emitResolve(ctx, trace, err)
+		return false, trace, err
+	}
+
+	if !trace.Schedule.Set {
+		g.writeCache(ctx, normalized, chain, trace, storeErr)
+	}
+	applyCallerFallback(&trace, req.Fallback)
 	g.emitResolve(ctx, trace, nil)
 	return trace.Value, trace, nil
 }
 
-func (g *Gate) resolveChain(ctx context.Context, opts ...gate.ResolveOption) (gate.ScopeChain, ClaimsFailureMode, error) {
-	req := gate.ResolveRequest{}
-	for _, opt := range opts {
-		if opt != nil {
-			opt(&req)
-		}
+// callerAlias replaces the alias an earlier caller passed with the current caller's,
+// keeping aliases recorded because the matching override uses a legacy key.
+func callerAlias(stored gate.ResolveTrace, current string) string {
+	if stored.Alias != "" && stored.Alias != stored.Key {
+		return stored.Alias
+	}
+	return current
+}
+
+// applyCallerFallback swaps in a gate.WithFallback value when the trace found neither
+// an override nor a default. It runs after caching so shared entries keep false.
+func applyCallerFallback(trace *gate.ResolveTrace, fallback *bool) {
+	if fallback == nil || trace.Source != gate.ResolveSourceFallback || trace.Default.Set {
+		return
+	}
+	if trace.Override.Error != nil || trace.Default.Error != nil || trace.Schedule.Error != nil {
+		return
+	}
+	trace.Value = *fallback
+	trace.Source = gate.ResolveSourceCallerFallback
+}
+
+// applySchedule disables an enabled default outside its activation window.
+func (g *Gate) applySchedule(ctx context.Context, key string, trace *gate.ResolveTrace) error {
+	if g.schedules == nil || !trace.Value {
+		return nil
+	}
+	schedule, ok, err := g.schedules.Schedule(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !ok || schedule.IsZero() {
+		return nil
+	}
+	now := g.now()
+	trace.Schedule = gate.ScheduleTrace{
+		Set:      true,
+		Schedule: schedule,
+		Active:   schedule.Active(now),
+		Pending:  schedule.Pending(now),
+	}
+	if !trace.Schedule.Active {
+		trace.Value = false
+	}
+	return nil
+}
+
+// stageContext derives the context for a single resolve stage.
+func (g *Gate) stageContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.resolveTimeout <= 0 {
+		return ctx, func() {}
 	}
+	return context.WithTimeout(ctx, g.resolveTimeout)
+}
+
+// recordTimeout notes stage in the trace when err was caused by the stage deadline.
+func (g *Gate) recordTimeout(stageCtx context.Context, err error, trace *gate.ResolveTrace, stage string) bool {
+	if err == nil || g.resolveTimeout <= 0 || trace == nil {
+		return false
+	}
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(stageCtx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	trace.TimedOut = append(trace.TimedOut, stage)
+	return true
+}
+
+func (g *Gate) resolveChain(ctx context.Context, key string, trace *gate.ResolveTrace, req gate.ResolveRequest) (gate.ScopeChain, ClaimsFailureMode, error) {
+	return g.buildChain(ctx, trace, req, g.failurePolicies.lookup(key, g.failureMode))
+}
+
+// buildChain derives the scope chain for req, falling back to the failure chain
+// unless failureMode is FailClosed.
+func (g *Gate) buildChain(ctx context.Context, trace *gate.ResolveTrace, req gate.ResolveRequest, failureMode ClaimsFailureMode) (gate.ScopeChain, ClaimsFailureMode, error) {
 	if req.ScopeChain != nil {
 		chain := append(gate.ScopeChain(nil), *req.ScopeChain...)
 		if g.appendSystemOnProvidedChain {
-			chain = appendSystemIfMissing(chain)
+			chain = scopechain.AppendSystemIfMissing(chain)
 		}
-		return chain, g.failureMode, nil
+		return chain, failureMode, nil
+	}
+	// Chains derived from the context are memoized per request, by the claims
+	// the provider returns, when a scope.ChainCache is attached; explicit claims
+	// are always rebuilt.
+	chainCache := scope.ChainCacheFromContext(ctx)
+	if req.Claims != nil {
+		chainCache = nil
 	}
-	claims, err := g.claimsProvider.ClaimsFromContext(ctx)
+	claims, err := g.claims(ctx, trace, req)
 	if err != nil {
-		if g.failureMode == FailClosed {
-			return nil, g.failureMode, err
+		if failureMode == FailClosed {
+			return nil, failureMode, err
 		}
 		fallback := append(gate.ScopeChain(nil), g.failureFallbackChain...)
 		if g.appendSystemOnFailure {
-			fallback = appendSystemIfMissing(fallback)
+			fallback = scopechain.AppendSystemIfMissing(fallback)
 		}
-		return fallback, g.failureMode, nil
+		return fallback, failureMode, nil
+	}
+	cacheKey := claims
+	if cacheKey.Env == "" {
+		cacheKey.Env = scope.Env(ctx)
+	}
+	if chain, ok := chainCache.Lookup(g, cacheKey); ok {
+		return chain, failureMode, nil
 	}
 	if g.permissionProvider != nil {
-		perms, permErr := g.permissionProvider.Permissions(ctx, claims)
+		permCtx, cancel := g.stageContext(ctx)
+		perms, permErr := g.permissionProvider.Permissions(permCtx, claims)
+		g.recordTimeout(permCtx, permErr, trace, gate.StagePermissions)
+		cancel()
 		if permErr != nil {
-			if g.failureMode == FailClosed {
-				return nil, g.failureMode, permErr
+			if failureMode == FailClosed {
+				return nil, failureMode, permErr
 			}
 			fallback := append(gate.ScopeChain(nil), g.failureFallbackChain...)
 			if g.appendSystemOnFailure {
-				fallback = appendSystemIfMissing(fallback)
+				fallback = scopechain.AppendSystemIfMissing(fallback)
 			}
-			return fallback, g.failureMode, nil
+			return fallback, failureMode, nil
 		}
 		claims.Perms = mergePerms(claims.Perms, perms)
 	}
-	chain := g.buildChain(claims)
-	return appendSystemIfMissing(chain), g.failureMode, nil
+	if claims.Env == "" {
+		claims.Env = scope.Env(ctx)
+	}
+	chain := g.chains.Build(claims)
+	if g.hierarchy != nil {
+		hierCtx, cancel := g.stageContext(ctx)
+		inherited, hierErr := g.inherit(hierCtx, chain, trace)
+		g.recordTimeout(hierCtx, hierErr, trace, gate.StageHierarchy)
+		cancel()
+		if hierErr != nil {
+			if failureMode == FailClosed {
+				return nil, failureMode, hierErr
+			}
+			fallback := append(gate.ScopeChain(nil), g.failureFallbackChain...)
+			if g.appendSystemOnFailure {
+				fallback = scopechain.AppendSystemIfMissing(fallback)
+			}
+			return fallback, failureMode, nil
+		}
+		chain = inherited
+	}
+	chainCache.Store(g, cacheKey, chain)
+	return chain, failureMode, nil
+}
+
+// claims returns the claims passed with gate.WithClaims, or asks the claims provider.
+func (g *Gate) claims(ctx context.Context, trace *gate.ResolveTrace, req gate.ResolveRequest) (gate.ActorClaims, error) {
+	if req.Claims != nil {
+		return *req.Claims, nil
+	}
+	claimsCtx, cancel := g.stageContext(ctx)
+	defer cancel()
+	claims, err := g.claimsProvider.ClaimsFromContext(claimsCtx)
+	g.recordTimeout(claimsCtx, err, trace, gate.StageClaims)
+	return claims, err
 }
 
 func (g *Gate) writeCache(ctx context.Context, key string, chain gate.ScopeChain, trace gate.ResolveTrace, storeErr error) {
 	if g.cache == nil {
 		return
 	}
-	if storeErr != nil {
+	if storeErr != nil || store.Uncommitted(ctx) {
+		return
+	}
+	policy := g.cachePolicies.Lookup(key)
+	if policy.Bypass || policy.NoStore {
 		return
 	}
-	g.cache.Set(ctx, key, chain, cache.Entry{
+	entry := cache.Entry{
 		Value: trace.Value,
-		Trace: trace,
-	})
+		Trace: g.redact(trace),
+	}
+	if policy.TTL > 0 {
+		entry.ExpiresAt = g.now().Add(policy.TTL)
+	}
+	g.cache.Set(ctx, key, chain, entry)
+}
+
+func (g *Gate) readCache(ctx context.Context, key string, chain gate.ScopeChain) (cache.Entry, bool) {
+	entry, ok := g.cache.Get(ctx, key, chain)
+	if !ok {
+		return cache.Entry{}, false
+	}
+	if entry.Expired(g.now()) {
+		g.cache.Delete(ctx, key, chain)
+		return cache.Entry{}, false
+	}
+	return entry, true
 }
 
 func (g *Gate) emitResolve(ctx context.Context, trace gate.ResolveTrace, err error) {
-	if len(g.hooks) == 0 {
+	if len(g.hooks) == 0 || whatIf(ctx) {
 		return
 	}
+	trace = g.redact(trace)
+	err = g.applySeverity(ctx, &trace, err)
 	event := gate.ResolveEvent{
 		Key:           trace.Key,
 		NormalizedKey: trace.NormalizedKey,
@@ -593,245 +1173,241 @@ func (g *Gate) emitResolve(ctx context.Context, trace gate.ResolveTrace, err err
 		Source:        trace.Source,
 		Error:         err,
 		Trace:         trace,
+		Metadata:      g.eventMetadata(ctx),
 	}
-	for _, hook := range g.hooks {
-		if hook == nil {
-			continue
-		}
-		hook.OnResolve(ctx, event)
-	}
-}
-
-func (g *Gate) emitUpdate(ctx context.Context, event activity.UpdateEvent) {
-	if len(g.updateHooks) == 0 {
-		return
-	}
-	for _, hook := range g.updateHooks {
-		if hook == nil {
-			continue
+	g.deliver(ctx, func(ctx context.Context) {
+		for _, hook := range g.hooks {
+			if hook == nil {
+				continue
+			}
+			hook.OnResolve(ctx, event)
 		}
-		hook.OnUpdate(ctx, event)
-	}
-}
-
-func boolPtr(value bool) *bool {
-	return &value
-}
-
-type contextClaimsProvider struct{}
-
-func (contextClaimsProvider) ClaimsFromContext(ctx context.Context) (gate.ActorClaims, error) {
-	return scope.ClaimsFromContext(ctx), nil
+	})
 }
 
-func defaultScopeOrder() []gate.ScopeKind {
-	return []gate.ScopeKind{
-		gate.ScopeUser,
-		gate.ScopeRole,
-		gate.ScopePerm,
-		gate.ScopeOrg,
-		gate.ScopeTenant,
-		gate.ScopeSystem,
+// redact applies the trace redactor, if any.
+func (g *Gate) redact(trace gate.ResolveTrace) gate.ResolveTrace {
+	if g.redactor == nil {
+		return trace
 	}
+	return g.redactor(trace)
 }
 
-func defaultRolePermNormalizer(value string) string {
-	return strings.ToLower(strings.TrimSpace(value))
-}
-
-func (g *Gate) normalizeScopeRef(ref gate.ScopeRef) gate.ScopeRef {
-	ref.ID = strings.TrimSpace(ref.ID)
-	ref.TenantID = strings.TrimSpace(ref.TenantID)
-	ref.OrgID = strings.TrimSpace(ref.OrgID)
-	if ref.Kind == gate.ScopeRole || ref.Kind == gate.ScopePerm {
-		ref.ID = g.rolePermNormalizer(ref.ID)
+// applySeverity applies the severity policy to err and to the stage errors of
+// trace. Errors shared between them stay identical after the policy is applied.
+func (g *Gate) applySeverity(ctx context.Context, trace *gate.ResolveTrace, err error) error {
+	policy := ferrors.SeverityPolicyFromContext(ctx)
+	if policy == nil {
+		policy = g.severityPolicy
 	}
-	return ref
-}
-
-func (g *Gate) buildChain(claims gate.ActorClaims) gate.ScopeChain {
-	roles := normalizeList(claims.Roles, g.rolePermNormalizer)
-	perms := normalizeList(claims.Perms, g.rolePermNormalizer)
-	if !g.preserveRolePermOrder {
-		roles = sortAndDedupe(roles)
-		perms = sortAndDedupe(perms)
-	} else {
-		roles = dedupeStable(roles)
-		perms = dedupeStable(perms)
+	if policy == nil {
+		return err
 	}
-	chain := make(gate.ScopeChain, 0, len(roles)+len(perms)+4)
-	for _, kind := range g.scopeOrder {
-		switch kind {
-		case gate.ScopeUser:
-			if claims.SubjectID != "" {
-				chain = append(chain, gate.ScopeRef{
-					Kind:     gate.ScopeUser,
-					ID:       claims.SubjectID,
-					TenantID: claims.TenantID,
-					OrgID:    claims.OrgID,
-				})
-			}
-		case gate.ScopeRole:
-			chain = append(chain, buildRolePermRefs(gate.ScopeRole, roles, claims)...)
-		case gate.ScopePerm:
-			chain = append(chain, buildRolePermRefs(gate.ScopePerm, perms, claims)...)
-		case gate.ScopeOrg:
-			if claims.OrgID != "" {
-				chain = append(chain, gate.ScopeRef{
-					Kind:     gate.ScopeOrg,
-					ID:       claims.OrgID,
-					TenantID: claims.TenantID,
-					OrgID:    claims.OrgID,
-				})
-			}
-		case gate.ScopeTenant:
-			if claims.TenantID != "" {
-				chain = append(chain, gate.ScopeRef{
-					Kind:     gate.ScopeTenant,
-					ID:       claims.TenantID,
-					TenantID: claims.TenantID,
-				})
-			}
-		case gate.ScopeSystem:
-			chain = append(chain, gate.ScopeRef{Kind: gate.ScopeSystem})
+	applied := policy.Apply(err)
+	apply := func(stageErr error) error {
+		if stageErr != nil && stageErr == err {
+			return applied
 		}
+		return policy.Apply(stageErr)
 	}
-	return chain
+	trace.Override.Error = apply(trace.Override.Error)
+	trace.Default.Error = apply(trace.Default.Error)
+	trace.Schedule.Error = apply(trace.Schedule.Error)
+	return applied
 }
 
-func buildRolePermRefs(kind gate.ScopeKind, items []string, claims gate.ActorClaims) gate.ScopeChain {
-	if len(items) == 0 {
+func (g *Gate) eventMetadata(ctx context.Context) map[string]string {
+	if len(g.extractors) == 0 || ctx == nil {
 		return nil
 	}
-	refs := make(gate.ScopeChain, 0, len(items)*2)
-	for _, id := range items {
-		if id == "" {
+	var out map[string]string
+	for name, extractor := range g.extractors {
+		value := strings.TrimSpace(extractor(ctx))
+		if value == "" {
 			continue
 		}
-		refs = append(refs, gate.ScopeRef{
-			Kind: kind,
-			ID:   id,
-		})
-		if claims.TenantID != "" || claims.OrgID != "" {
-			refs = append(refs, gate.ScopeRef{
-				Kind:     kind,
-				ID:       id,
-				TenantID: claims.TenantID,
-				OrgID:    claims.OrgID,
-			})
+		if out == nil {
+			out = make(map[string]string, len(g.extractors))
 		}
+		out[name] = value
 	}
-	return refs
+	return out
 }
 
-func appendSystemIfMissing(chain gate.ScopeChain) gate.ScopeChain {
-	for _, ref := range chain {
-		if ref.Kind == gate.ScopeSystem {
-			return chain
-		}
+func (g *Gate) emitUpdate(ctx context.Context, event activity.UpdateEvent) {
+	if len(g.updateHooks) == 0 {
+		return
 	}
-	return append(chain, gate.ScopeRef{Kind: gate.ScopeSystem})
-}
-
-func mergePerms(existing, extra []string) []string {
-	if len(extra) == 0 {
-		return existing
+	if event.ID == "" {
+		event.ID = idgen.Or(g.ids).NewID()
 	}
-	out := make([]string, 0, len(existing)+len(extra))
-	out = append(out, existing...)
-	out = append(out, extra...)
-	return out
+	g.deliver(ctx, func(ctx context.Context) {
+		for _, hook := range g.updateHooks {
+			if hook == nil {
+				continue
+			}
+			hook.OnUpdate(ctx, event)
+		}
+	})
 }
 
-func normalizeList(values []string, normalizer IdentifierNormalizer) []string {
-	if len(values) == 0 {
-		return nil
+// deliver runs fn inline, or on the async hook workers with a context that outlives
+// the caller's cancellation.
+func (g *Gate) deliver(ctx context.Context, fn func(context.Context)) {
+	if g.dispatcher == nil {
+		fn(ctx)
+		return
 	}
-	out := make([]string, 0, len(values))
-	for _, value := range values {
-		trimmed := strings.TrimSpace(value)
-		if trimmed == "" {
-			continue
-		}
-		if normalizer != nil {
-			trimmed = normalizer(trimmed)
-		}
-		if trimmed == "" {
-			continue
-		}
-		out = append(out, trimmed)
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	return out
+	detached := context.WithoutCancel(ctx)
+	g.dispatcher.dispatch(func() { fn(detached) })
 }
 
-func sortAndDedupe(values []string) []string {
-	if len(values) == 0 {
-		return nil
-	}
-	uniq := map[string]struct{}{}
-	for _, value := range values {
-		uniq[value] = struct{}{}
-	}
-	out := make([]string, 0, len(uniq))
-	for value := range uniq {
-		out = append(out, value)
-	}
-	sort.Strings(out)
-	return out
+func boolPtr(value bool) *bool {
+	return &value
 }
 
-func dedupeStable(values []string) []string {
-	if len(values) == 0 {
-		return nil
-	}
-	seen := map[string]struct{}{}
-	out := make([]string, 0, len(values))
-	for _, value := range values {
-		if _, ok := seen[value]; ok {
-			continue
-		}
-		seen[value] = struct{}{}
-		out = append(out, value)
+type contextClaimsProvider struct{}
+
+func (contextClaimsProvider) ClaimsFromContext(ctx context.Context) (gate.ActorClaims, error) {
+	return scope.ClaimsFromContext(ctx), nil
+}
+
+func mergePerms(existing, extra []string) []string {
+	if len(extra) == 0 {
+		return existing
 	}
+	out := make([]string, 0, len(existing)+len(extra))
+	out = append(out, existing...)
+	out = append(out, extra...)
 	return out
 }
 
 func (g *Gate) resolveOverrides(ctx context.Context, key string, chain gate.ScopeChain) (OverrideDecision, gate.ResolveTrace, error) {
 	var trace gate.ResolveTrace
-	trace.Strategy = "default"
+	trace.Strategy = StrategyDefault
+	named, strategy, err := g.strategyFor(key)
+	if named != "" {
+		trace.Strategy = named
+	}
+	if err != nil {
+		return OverrideDecision{}, trace, err
+	}
 	matches, err := g.overrides.GetAll(ctx, key, chain)
 	if err != nil {
 		return OverrideDecision{}, trace, err
 	}
-	matches = normalizeMatches(matches)
-	if decision, trace, err := g.applyStrategy(ctx, key, chain, matches); err != nil {
+	matches, expired := g.dropExpired(normalizeMatches(matches))
+	if decision, trace, err := g.applyStrategy(ctx, key, chain, matches, named, strategy); err != nil {
 		return OverrideDecision{}, trace, err
 	} else if decision.Matched {
+		trace.Override.Expired = expired
+		trace.Override.Scopes = scopeLookups(chain, matches, expired, decision)
 		return decision, trace, nil
 	}
+	scopes := scopeLookups(chain, matches, expired, OverrideDecision{})
 	aliases := gate.AliasesFor(key)
 	for _, alias := range aliases {
 		aliasMatches, aliasErr := g.overrides.GetAll(ctx, alias, chain)
 		if aliasErr != nil {
 			return OverrideDecision{}, trace, aliasErr
 		}
-		aliasMatches = normalizeMatches(aliasMatches)
-		if decision, aliasTrace, err := g.applyStrategy(ctx, alias, chain, aliasMatches); err != nil {
+		aliasMatches, aliasExpired := g.dropExpired(normalizeMatches(aliasMatches))
+		expired = append(expired, aliasExpired...)
+		if decision, aliasTrace, err := g.applyStrategy(ctx, alias, chain, aliasMatches, named, strategy); err != nil {
 			return OverrideDecision{}, aliasTrace, err
 		} else if decision.Matched {
+			aliasTrace.Override.Expired = expired
+			aliasTrace.Override.Scopes = scopeLookups(chain, aliasMatches, aliasExpired, decision)
+			aliasTrace.Alias = alias
 			return decision, aliasTrace, nil
 		}
 	}
+	trace.Override.Expired = expired
+	trace.Override.Scopes = scopes
 	return OverrideDecision{}, trace, nil
 }
 
-func (g *Gate) applyStrategy(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch) (OverrideDecision, gate.ResolveTrace, error) {
-	if g.strategy == nil {
-		g.strategy = defaultResolveStrategy
+// scopeLookups records the lookup outcome for every scope of chain. A scope with
+// a set override other than the decision's match was shadowed; expired overrides
+// only show when nothing active is stored at the scope.
+func scopeLookups(chain gate.ScopeChain, matches []store.OverrideMatch, expired []gate.OverrideMatchTrace, decision OverrideDecision) []gate.ScopeLookupTrace {
+	if len(chain) == 0 {
+		return nil
+	}
+	out := make([]gate.ScopeLookupTrace, len(chain))
+	for i, ref := range chain {
+		lookup := gate.ScopeLookupTrace{Scope: ref, Outcome: gate.ScopeOutcomeNoOverride}
+		if override, ok := findOverride(matches, ref); ok && override.State != gate.OverrideStateMissing {
+			lookup.State = override.State
+			lookup.Outcome = gate.ScopeOutcomeShadowed
+			if decision.Matched && sameScope(decision.Match, ref) {
+				lookup.Outcome = gate.ScopeOutcomeOverridden
+			}
+		} else {
+			for _, match := range expired {
+				if sameScope(match.Scope, ref) {
+					lookup.State = match.State
+					lookup.Outcome = gate.ScopeOutcomeExpired
+				}
+			}
+		}
+		out[i] = lookup
+	}
+	return out
+}
+
+// findOverride returns the last match stored for ref, as the strategy's match map does.
+func findOverride(matches []store.OverrideMatch, ref gate.ScopeRef) (store.Override, bool) {
+	for i := len(matches) - 1; i >= 0; i-- {
+		if sameScope(matches[i].Scope, ref) {
+			return matches[i].Override, true
+		}
 	}
-	decision, trace, err := g.strategy(ctx, key, chain, matches, ResolveOptions{
+	return store.Override{}, false
+}
+
+func sameScope(a, b gate.ScopeRef) bool {
+	return a.Kind == b.Kind && a.ID == b.ID && a.TenantID == b.TenantID && a.OrgID == b.OrgID
+}
+
+// dropExpired removes expired overrides so they resolve as missing, returning them for the trace.
+func (g *Gate) dropExpired(matches []store.OverrideMatch) ([]store.OverrideMatch, []gate.OverrideMatchTrace) {
+	if len(matches) == 0 {
+		return matches, nil
+	}
+	now := g.now()
+	active := matches[:0]
+	var expired []gate.OverrideMatchTrace
+	for _, match := range matches {
+		if match.Override.Expired(now) {
+			expired = append(expired, gate.OverrideMatchTrace{
+				Scope:     match.Scope,
+				State:     match.Override.State,
+				Value:     valueFromOverride(match.Override),
+				ExpiresAt: match.Override.ExpiresAt,
+				Metadata:  match.Override.Metadata,
+				Priority:  match.Override.Priority,
+			})
+			continue
+		}
+		active = append(active, match)
+	}
+	return active, expired
+}
+
+// applyStrategy runs strategy over matches. A non-empty name comes from
+// WithStrategyName and is recorded on the trace and decision.
+func (g *Gate) applyStrategy(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch, name string, strategy ResolveStrategy) (OverrideDecision, gate.ResolveTrace, error) {
+	decision, trace, err := strategy(ctx, key, chain, matches, ResolveOptions{
 		ScopeOrder: g.scopeOrder,
 	})
+	if name != "" {
+		trace.Strategy = name
+		decision.Strategy = name
+	}
 	if err != nil {
 		trace.Override.Error = err
 		return decision, trace, err
@@ -839,15 +1415,21 @@ func (g *Gate) applyStrategy(ctx context.Context, key string, chain gate.ScopeCh
 	return decision, trace, nil
 }
 
+// DefaultResolveStrategy is the built-in scope-group strategy. Custom strategies can
+// delegate to it for explicit overrides before applying their own rules.
+func DefaultResolveStrategy(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch, opts ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
+	return defaultResolveStrategy(ctx, key, chain, matches, opts)
+}
+
 func defaultResolveStrategy(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch, opts ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
 	_ = ctx
 	_ = key
 	trace := gate.ResolveTrace{
-		Strategy: "default",
+		Strategy: StrategyDefault,
 	}
 	if len(matches) == 0 {
 		trace.Override.State = gate.OverrideStateMissing
-		return OverrideDecision{Matched: false, Strategy: "default"}, trace, nil
+		return OverrideDecision{Matched: false, Strategy: StrategyDefault}, trace, nil
 	}
 	matchMap := map[string]store.OverrideMatch{}
 	for _, match := range matches {
@@ -867,7 +1449,7 @@ func defaultResolveStrategy(ctx context.Context, key string, chain gate.ScopeCha
 		return decision, trace, nil
 	}
 	trace.Override.State = gate.OverrideStateMissing
-	return OverrideDecision{Matched: false, Strategy: "default"}, trace, nil
+	return OverrideDecision{Matched: false, Strategy: StrategyDefault}, trace, nil
 }
 
 func groupOrderFor(scopeOrder []gate.ScopeKind) []groupKind {
@@ -884,12 +1466,14 @@ func groupOrderFor(scopeOrder []gate.ScopeKind) []groupKind {
 			order = append(order, groupOrg)
 		case gate.ScopeTenant:
 			order = append(order, groupTenant)
+		case gate.ScopeEnv:
+			order = append(order, groupEnv)
 		case gate.ScopeSystem:
 			order = append(order, groupSystem)
 		}
 	}
 	if len(order) == 0 {
-		return []groupKind{groupUser, groupRolePerm, groupOrg, groupTenant, groupSystem}
+		return []groupKind{groupUser, groupRolePerm, groupOrg, groupTenant, groupEnv, groupSystem}
 	}
 	return order
 }
@@ -897,11 +1481,12 @@ func groupOrderFor(scopeOrder []gate.ScopeKind) []groupKind {
 type groupKind string
 
 const (
-	groupUser    groupKind = "user"
+	groupUser     groupKind = "user"
 	groupRolePerm groupKind = "role_perm"
-	groupOrg     groupKind = "org"
-	groupTenant  groupKind = "tenant"
-	groupSystem  groupKind = "system"
+	groupOrg      groupKind = "org"
+	groupTenant   groupKind = "tenant"
+	groupEnv      groupKind = "env"
+	groupSystem   groupKind = "system"
 )
 
 func containsGroup(groups []groupKind, target groupKind) bool {
@@ -936,6 +1521,8 @@ func scopeKindInGroup(kind gate.ScopeKind, group groupKind) bool {
 		return kind == gate.ScopeOrg
 	case groupTenant:
 		return kind == gate.ScopeTenant
+	case groupEnv:
+		return kind == gate.ScopeEnv
 	case groupSystem:
 		return kind == gate.ScopeSystem
 	default:
@@ -948,65 +1535,49 @@ func evaluateGroup(group groupKind, matches []store.OverrideMatch) (OverrideDeci
 		State:   gate.OverrideStateMissing,
 		Matches: toMatchTraces(matches),
 	}
-	switch group {
-	case groupRolePerm:
-		for _, match := range matches {
-			if match.Override.State == gate.OverrideStateDisabled {
-				trace.State = gate.OverrideStateDisabled
-				trace.Value = boolPtr(false)
-				trace.Match = match.Scope
-				return OverrideDecision{
-					Matched:  true,
-					Value:    false,
-					Match:    match.Scope,
-					Matches:  matches,
-					Strategy: "default",
-				}, trace
-			}
-		}
-		for _, match := range matches {
-			if match.Override.State == gate.OverrideStateEnabled {
-				trace.State = gate.OverrideStateEnabled
-				trace.Value = boolPtr(true)
-				trace.Match = match.Scope
-				return OverrideDecision{
-					Matched:  true,
-					Value:    true,
-					Match:    match.Scope,
-					Matches:  matches,
-					Strategy: "default",
-				}, trace
-			}
-		}
-	default:
-		for _, match := range matches {
-			if match.Override.State == gate.OverrideStateEnabled {
-				trace.State = gate.OverrideStateEnabled
-				trace.Value = boolPtr(true)
-				trace.Match = match.Scope
-				return OverrideDecision{
-					Matched:  true,
-					Value:    true,
-					Match:    match.Scope,
-					Matches:  matches,
-					Strategy: "default",
-				}, trace
+	i, value := selectInGroup(group, len(matches), func(i int) gate.OverrideState {
+		return matches[i].Override.State
+	})
+	if i < 0 {
+		return OverrideDecision{Matched: false, Strategy: StrategyDefault}, trace
+	}
+	match := matches[i]
+	trace.State = match.Override.State
+	trace.Value = boolPtr(value)
+	trace.Match = match.Scope
+	trace.ExpiresAt = match.Override.ExpiresAt
+	trace.Metadata = match.Override.Metadata
+	return OverrideDecision{
+		Matched:  true,
+		Value:    value,
+		Match:    match.Scope,
+		Matches:  matches,
+		Strategy: StrategyDefault,
+	}, trace
+}
+
+// selectInGroup picks the override that decides a scope group. state reports the
+// override state of the group's i-th scope in chain order, for i < n. Within the role
+// and permission group a disabled override wins over enabled ones; elsewhere the
+// first enabled or disabled override does. It returns the winner's index and value,
+// or -1 when no scope in the group is set. The default strategy and the Enabled fast
+// path both decide through it.
+func selectInGroup(group groupKind, n int, state func(i int) gate.OverrideState) (int, bool) {
+	enabled := -1
+	for i := 0; i < n; i++ {
+		switch state(i) {
+		case gate.OverrideStateDisabled:
+			return i, false
+		case gate.OverrideStateEnabled:
+			if group != groupRolePerm {
+				return i, true
 			}
-			if match.Override.State == gate.OverrideStateDisabled {
-				trace.State = gate.OverrideStateDisabled
-				trace.Value = boolPtr(false)
-				trace.Match = match.Scope
-				return OverrideDecision{
-					Matched:  true,
-					Value:    false,
-					Match:    match.Scope,
-					Matches:  matches,
-					Strategy: "default",
-				}, trace
+			if enabled < 0 {
+				enabled = i
 			}
 		}
 	}
-	return OverrideDecision{Matched: false, Strategy: "default"}, trace
+	return enabled, enabled >= 0
 }
 
 func toMatchTraces(matches []store.OverrideMatch) []gate.OverrideMatchTrace {
@@ -1016,9 +1587,12 @@ func toMatchTraces(matches []store.OverrideMatch) []gate.OverrideMatchTrace {
 	out := make([]gate.OverrideMatchTrace, 0, len(matches))
 	for _, match := range matches {
 		out = append(out, gate.OverrideMatchTrace{
-			Scope: match.Scope,
-			State: match.Override.State,
-			Value: valueFromOverride(match.Override),
+			Scope:     match.Scope,
+			State:     match.Override.State,
+			Value:     valueFromOverride(match.Override),
+			ExpiresAt: match.Override.ExpiresAt,
+			Metadata:  match.Override.Metadata,
+			Priority:  match.Override.Priority,
 		})
 	}
 	return out
@@ -1066,11 +1640,27 @@ func scopeKindString(kind gate.ScopeKind) string {
 		return "role"
 	case gate.ScopePerm:
 		return "perm"
+	case gate.ScopeEnv:
+		return "env"
 	default:
 		return "unknown"
 	}
 }
 
+// checkKey validates a normalized key against the key policy.
+func (g *Gate) checkKey(normalized, operation string) error {
+	if g.keyPolicy.IsZero() {
+		return nil
+	}
+	if _, err := g.keyPolicy.Check(normalized); err != nil {
+		if rich, ok := ferrors.As(err); ok {
+			rich.WithMetadata(map[string]any{ferrors.MetaOperation: operation})
+		}
+		return err
+	}
+	return nil
+}
+
 func (g *Gate) invalidateCache(ctx context.Context, key string, scopeRef gate.ScopeRef) {
 	if g.cache == nil {
 		return
@@ -1082,6 +1672,15 @@ func (g *Gate) invalidateCache(ctx context.Context, key string, scopeRef gate.Sc
 	g.cache.Clear(ctx)
 }
 
+// invalidateFlushed drops cached values once a deferred writer, such as a
+// store.WriteQueue, has applied mutations; invalidating on Set alone would let a
+// read between enqueue and flush cache the old value.
+func (g *Gate) invalidateFlushed(ctx context.Context, mutations []store.Mutation) {
+	for _, m := range mutations {
+		g.invalidateCache(ctx, m.Key, m.Scope)
+	}
+}
+
 func (g *Gate) unsetAliases(ctx context.Context, normalized string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
 	if g == nil || g.writer == nil {
 		return nil
@@ -1098,3 +1697,5 @@ func (g *Gate) unsetAliases(ctx context.Context, normalized string, scopeRef gat
 	return nil
 }
 
+var _ gate.TraceableFeatureGate = (*Gate)(nil)
+var _ gate.MutableFeatureGate = (*Gate)(nil)
@@ -3,12 +3,19 @@ package resolver
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	goerrors "github.com/goliatone/go-errors"
 
+	"github.com/goliatone/go-featuregate/activity"
+	"github.com/goliatone/go-featuregate/cache"
+	"github.com/goliatone/go-featuregate/clock"
 	"github.com/goliatone/go-featuregate/ferrors"
 	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/scope"
 	"github.com/goliatone/go-featuregate/store"
 )
 
@@ -48,7 +55,7 @@ func (s *stubStore) GetAll(_ context.Context, key string, chain gate.ScopeChain)
 	return nil, nil
 }
 
-func (s *stubStore) Set(_ context.Context, key string, _ gate.ScopeRef, _ bool, _ gate.ActorRef) error {
+func (s *stubStore) Set(_ context.Context, key string, _ gate.ScopeRef, _ bool, _ gate.ActorRef, _ ...gate.MutationOption) error {
 	s.setCalls = append(s.setCalls, key)
 	if s.setErr != nil {
 		return s.setErr
@@ -185,3 +192,801 @@ func TestGateUnsetDoesNotClearLegacyAliases(t *testing.T) {
 		t.Fatalf("unexpected unset call order: %v", storeStub.unsetCalls)
 	}
 }
+
+func TestGateTreatsExpiredOverrideAsMissing(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	defaults := staticDefaults{
+		"users.signup": {Set: true, Value: false},
+	}
+	g := New(
+		WithDefaults(defaults),
+		WithOverrideStore(store.NewMemoryStore()),
+		WithNowFunc(func() time.Time { return now }),
+	)
+	chain := gate.ScopeChain{{Kind: gate.ScopeSystem}}
+
+	if err := g.Set(ctx, "users.signup", gate.ScopeRef{Kind: gate.ScopeSystem}, true, gate.ActorRef{ID: "actor"}, gate.WithTTL(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	value, trace, err := g.ResolveWithTrace(ctx, "users.signup", gate.WithScopeChain(chain))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || trace.Source != gate.ResolveSourceOverride {
+		t.Fatalf("expected active override, got %v (%s)", value, trace.Source)
+	}
+	if !trace.Override.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected expiry: %v", trace.Override.ExpiresAt)
+	}
+
+	now = now.Add(2 * time.Hour)
+	value, trace, err = g.ResolveWithTrace(ctx, "users.signup", gate.WithScopeChain(chain))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value || trace.Source != gate.ResolveSourceDefault {
+		t.Fatalf("expected expired override to fall back to default, got %v (%s)", value, trace.Source)
+	}
+	if len(trace.Override.Expired) != 1 || trace.Override.Expired[0].Scope.Kind != gate.ScopeSystem {
+		t.Fatalf("expected expired override in trace, got %+v", trace.Override.Expired)
+	}
+}
+
+func TestGateSurfacesOverrideMetadata(t *testing.T) {
+	ctx := context.Background()
+	var events []activity.UpdateEvent
+	g := New(
+		WithOverrideStore(store.NewMemoryStore()),
+		WithActivityHook(activity.HookFunc(func(_ context.Context, event activity.UpdateEvent) {
+			events = append(events, event)
+		})),
+	)
+	scopeRef := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	meta := gate.OverrideMetadata{Reason: "customer demo", TicketURL: "https://tickets.example/OPS-1", Owner: "growth", Labels: map[string]string{"incident": "INC-1234"}}
+
+	if err := g.Set(ctx, "dashboard", scopeRef, true, gate.ActorRef{ID: "admin"}, gate.WithMetadata(meta)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || !reflect.DeepEqual(events[0].Metadata, meta) {
+		t.Fatalf("expected metadata on activity event, got %+v", events)
+	}
+
+	_, trace, err := g.ResolveWithTrace(ctx, "dashboard", gate.WithScopeChain(gate.ScopeChain{scopeRef, {Kind: gate.ScopeSystem}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(trace.Override.Metadata, meta) {
+		t.Fatalf("expected metadata on trace, got %+v", trace.Override.Metadata)
+	}
+}
+
+func TestGateUsesStrategySource(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	if err := overrides.Set(ctx, "dashboard", gate.ScopeRef{Kind: gate.ScopeSystem}, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g := New(
+		WithOverrideStore(overrides),
+		WithResolveStrategy(func(_ context.Context, _ string, _ gate.ScopeChain, matches []store.OverrideMatch, _ ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
+			return OverrideDecision{Matched: len(matches) > 0, Value: true, Strategy: "rules", Source: gate.ResolveSourceRule}, gate.ResolveTrace{Strategy: "rules"}, nil
+		}),
+	)
+
+	_, trace, err := g.ResolveWithTrace(ctx, "dashboard", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trace.Source != gate.ResolveSourceRule || !trace.Source.IsBuiltin() {
+		t.Fatalf("expected rule source, got %s", trace.Source)
+	}
+	if gate.ResolveSource("geo").IsBuiltin() {
+		t.Fatalf("expected custom source to be non-builtin")
+	}
+}
+
+func TestGateAppliesScheduleToDefaults(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewManual(now)
+	g := New(
+		WithDefaults(staticDefaults{"launch": {Set: true, Value: true}}),
+		WithSchedules(StaticSchedules{"launch": {StartsAt: now.Add(time.Hour)}}),
+		WithClock(clk),
+	)
+
+	value, trace, err := g.ResolveWithTrace(ctx, "launch", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value || !trace.Schedule.Pending {
+		t.Fatalf("expected pending schedule to disable default, got %v (%+v)", value, trace.Schedule)
+	}
+	if at, ok := trace.ActivatesAt(); !ok || !at.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected activation time, got %v", at)
+	}
+
+	clk.Advance(2 * time.Hour)
+	if value, err := g.Enabled(ctx, "launch", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})); err != nil || !value {
+		t.Fatalf("expected active schedule to enable default, got %v (%v)", value, err)
+	}
+}
+
+type requestIDKey struct{}
+
+func TestGateEnrichesResolveEventsFromContext(t *testing.T) {
+	var events []gate.ResolveEvent
+	g := New(
+		WithRequestIDExtractor(func(ctx context.Context) string {
+			id, _ := ctx.Value(requestIDKey{}).(string)
+			return id
+		}),
+		WithRouteExtractor(func(context.Context) string { return "" }),
+		WithResolveHook(gate.ResolveHookFunc(func(_ context.Context, event gate.ResolveEvent) {
+			events = append(events, event)
+		})),
+	)
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-42")
+	if _, err := g.Enabled(ctx, "dashboard", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].Metadata[gate.EventMetaRequestID] != "req-42" {
+		t.Fatalf("expected request id on event, got %+v", events)
+	}
+	if _, ok := events[0].Metadata[gate.EventMetaRoute]; ok {
+		t.Fatalf("expected empty route to be skipped")
+	}
+}
+
+type blockingStore struct{}
+
+func (blockingStore) GetAll(ctx context.Context, _ string, _ gate.ScopeChain) ([]store.OverrideMatch, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type blockingDefaults struct{}
+
+func (blockingDefaults) Default(ctx context.Context, _ string) (DefaultResult, error) {
+	<-ctx.Done()
+	return DefaultResult{}, ctx.Err()
+}
+
+func TestGateResolveTimeoutFallsBack(t *testing.T) {
+	fg := New(
+		WithDefaults(staticDefaults{"feature": {Set: true, Value: true}}),
+		WithOverrideStore(blockingStore{}),
+		WithResolveTimeout(10*time.Millisecond),
+	)
+	value, trace, err := fg.ResolveWithTrace(context.Background(), "feature", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}}))
+	if err != nil {
+		t.Fatalf("expected fallback without error, got %v", err)
+	}
+	if !value || trace.Source != gate.ResolveSourceDefault {
+		t.Fatalf("expected default after store timeout, got %v (%s)", value, trace.Source)
+	}
+	if !reflect.DeepEqual(trace.TimedOut, []string{gate.StageOverride}) {
+		t.Fatalf("expected override timeout in trace, got %v", trace.TimedOut)
+	}
+
+	fg = New(WithDefaults(blockingDefaults{}), WithResolveTimeout(10*time.Millisecond))
+	value, trace, err = fg.ResolveWithTrace(context.Background(), "feature", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}}))
+	if err != nil || value || trace.Source != gate.ResolveSourceFallback {
+		t.Fatalf("expected fallback after defaults timeout, got %v (%s, %v)", value, trace.Source, err)
+	}
+
+	fg = New(WithDefaults(blockingDefaults{}), WithResolveTimeout(10*time.Millisecond), WithStrictStore(true))
+	if _, trace, err = fg.ResolveWithTrace(context.Background(), "feature", gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})); err == nil {
+		t.Fatalf("expected strict mode to surface the timeout")
+	}
+	if !reflect.DeepEqual(trace.TimedOut, []string{gate.StageDefault}) {
+		t.Fatalf("expected default timeout in trace, got %v", trace.TimedOut)
+	}
+}
+
+func TestGatesSharingMemoKeepTheirOwnValues(t *testing.T) {
+	ctx := gate.WithEvaluationMemo(context.Background())
+	on := New(WithDefaults(staticDefaults{"reports": {Set: true, Value: true}}))
+	off := New(WithDefaults(staticDefaults{"reports": {Set: true, Value: false}}))
+
+	if value, _ := on.Enabled(ctx, "reports"); !value {
+		t.Fatal("expected the first gate's default")
+	}
+	value, trace, err := off.ResolveWithTrace(ctx, "reports")
+	if err != nil || value || trace.Memoized {
+		t.Fatalf("expected the second gate to resolve its own default, got %v (memoized %v, %v)", value, trace.Memoized, err)
+	}
+	if value, trace, _ := on.ResolveWithTrace(ctx, "reports"); !value || !trace.Memoized {
+		t.Fatalf("expected the first gate to read its own memo entry, got %v (memoized %v)", value, trace.Memoized)
+	}
+}
+
+func TestGateFreezesFirstReadPerChain(t *testing.T) {
+	ctx := gate.FreezeOnFirstRead(context.Background())
+	overrides := store.NewCachedReadWriter(store.NewMemoryStore())
+	g := New(WithOverrideStore(overrides))
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	acme := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	globex := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "globex", TenantID: "globex"}
+	if err := g.Set(ctx, "dashboard", acme, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	acmeChain := gate.WithScopeChain(gate.ScopeChain{acme, system})
+	if value, err := g.Enabled(ctx, "dashboard", acmeChain); err != nil || !value {
+		t.Fatalf("expected first read to be enabled, got %v (%v)", value, err)
+	}
+	if err := g.Set(ctx, "dashboard", acme, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	value, trace, err := g.ResolveWithTrace(ctx, "dashboard", acmeChain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || !trace.Memoized {
+		t.Fatalf("expected frozen value across invalidation, got %v (%+v)", value, trace)
+	}
+
+	value, trace, err = g.ResolveWithTrace(ctx, "dashboard", gate.WithScopeChain(gate.ScopeChain{globex, system}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value || trace.Memoized {
+		t.Fatalf("expected another chain not to be served the pinned value, got %v (%+v)", value, trace)
+	}
+	if value, _ := g.Enabled(context.Background(), "dashboard", acmeChain); value {
+		t.Fatalf("expected unfrozen context to see the new value")
+	}
+}
+
+type mapCache map[string]cache.Entry
+
+func (m mapCache) Get(_ context.Context, key string, _ gate.ScopeChain) (cache.Entry, bool) {
+	entry, ok := m[key]
+	return entry, ok
+}
+
+func (m mapCache) Set(_ context.Context, key string, _ gate.ScopeChain, entry cache.Entry) {
+	m[key] = entry
+}
+
+func (m mapCache) Delete(_ context.Context, key string, _ gate.ScopeChain) {
+	delete(m, key)
+}
+
+func (m mapCache) Clear(context.Context) {
+	clear(m)
+}
+
+func TestGateAppliesCachePolicies(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewManual(now)
+	entries := mapCache{}
+	g := New(
+		WithDefaults(staticDefaults{
+			"billing.invoices": {Set: true, Value: true},
+			"billing.legacy":   {Set: true, Value: true},
+			"search":           {Set: true, Value: true},
+			"reports":          {Set: true, Value: true},
+		}),
+		WithCache(entries),
+		WithClock(clk),
+		WithCachePolicy("billing.*", cache.Bypass),
+		WithCachePolicy("billing.legacy", cache.NoStore),
+		WithCachePolicy("reports", cache.TTL(time.Minute)),
+	)
+	chain := gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})
+	for _, key := range []string{"billing.invoices", "billing.legacy", "search", "reports"} {
+		if _, err := g.Enabled(ctx, key, chain); err != nil {
+			t.Fatalf("resolve %s: %v", key, err)
+		}
+	}
+	if _, ok := entries["billing.invoices"]; ok {
+		t.Fatalf("expected bypassed key to skip the cache")
+	}
+	if _, ok := entries["billing.legacy"]; ok {
+		t.Fatalf("expected no-store key to skip cache writes")
+	}
+	if entry, ok := entries["search"]; !ok || !entry.ExpiresAt.IsZero() {
+		t.Fatalf("expected default policy entry without expiry, got %+v", entry)
+	}
+	if entry := entries["reports"]; !entry.ExpiresAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("expected ttl expiry, got %s", entry.ExpiresAt)
+	}
+
+	if _, trace, _ := g.ResolveWithTrace(ctx, "reports", chain); !trace.CacheHit {
+		t.Fatalf("expected cache hit before ttl")
+	}
+	clk.Advance(2 * time.Minute)
+	if _, trace, _ := g.ResolveWithTrace(ctx, "reports", chain); trace.CacheHit {
+		t.Fatalf("expected expired entry to be re-resolved")
+	}
+}
+
+func TestGateAddsEnvironmentScope(t *testing.T) {
+	ctx := scope.WithTenantID(context.Background(), "acme")
+	overrides := store.NewMemoryStore()
+	staging := gate.ScopeRef{Kind: gate.ScopeEnv, ID: "staging"}
+	if err := overrides.Set(ctx, "checkout", staging, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	g := New(WithOverrideStore(overrides), WithEnvironment("prod"))
+
+	value, trace, err := g.ResolveWithTrace(ctx, "checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value || len(trace.Chain) != 3 || trace.Chain[1] != (gate.ScopeRef{Kind: gate.ScopeEnv, ID: "prod"}) {
+		t.Fatalf("expected prod env scope without the staging override, got %v (%+v)", value, trace.Chain)
+	}
+
+	value, trace, err = g.ResolveWithTrace(scope.WithEnv(ctx, "staging"), "checkout")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || trace.Override.Match != staging {
+		t.Fatalf("expected context env to select the staging override, got %v (%+v)", value, trace.Override)
+	}
+}
+
+func TestGateInvalidatesCacheWhenQueuedWritesFlush(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	queue := store.NewWriteQueue(overrides, store.WithQueueInterval(time.Hour))
+	defer queue.Close(ctx)
+	entries := mapCache{}
+	g := New(
+		WithOverrideStore(overrides),
+		WithOverrideWriter(queue),
+		WithDefaults(staticDefaults{"reports": {Set: true, Value: false}}),
+		WithCache(entries),
+	)
+	chain := gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})
+
+	if err := g.Set(ctx, "reports", gate.ScopeRef{Kind: gate.ScopeSystem}, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if value, _ := g.Enabled(ctx, "reports", chain); value {
+		t.Fatal("expected the queued write to be invisible before the flush")
+	}
+	if err := queue.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	value, trace, err := g.ResolveWithTrace(ctx, "reports", chain)
+	if err != nil || !value || trace.CacheHit {
+		t.Fatalf("expected the flush to invalidate the cached value, got %v (hit %v, %v)", value, trace.CacheHit, err)
+	}
+}
+
+func TestGateAppliesCallerFallback(t *testing.T) {
+	ctx := gate.WithEvaluationMemo(context.Background())
+	entries := mapCache{}
+	g := New(
+		WithDefaults(staticDefaults{"billing.v2": {Set: true, Value: false}}),
+		WithCache(entries),
+	)
+	chain := gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeSystem}})
+
+	value, trace, err := g.ResolveWithTrace(ctx, "reports.beta", chain, gate.WithFallback(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || trace.Source != gate.ResolveSourceCallerFallback {
+		t.Fatalf("expected caller fallback, got %v from %s", value, trace.Source)
+	}
+	if entry := entries["reports.beta"]; entry.Value || entry.Trace.Source != gate.ResolveSourceFallback {
+		t.Fatalf("expected shared cache to keep the false fallback, got %+v", entry)
+	}
+
+	value, trace, _ = g.ResolveWithTrace(ctx, "reports.beta", chain)
+	if value || trace.Source != gate.ResolveSourceFallback {
+		t.Fatalf("expected plain fallback without the option, got %v from %s", value, trace.Source)
+	}
+	if value, trace, _ = g.ResolveWithTrace(ctx, "reports.beta", chain, gate.WithFallback(true)); !value || !trace.CacheHit {
+		t.Fatalf("expected caller fallback on cache hit, got %v (cache hit %v)", value, trace.CacheHit)
+	}
+
+	if value, trace, _ = g.ResolveWithTrace(ctx, "billing.v2", chain, gate.WithFallback(true)); value || trace.Source != gate.ResolveSourceDefault {
+		t.Fatalf("expected default to win over caller fallback, got %v from %s", value, trace.Source)
+	}
+}
+
+type failingClaims struct{}
+
+func (failingClaims) ClaimsFromContext(context.Context) (gate.ActorClaims, error) {
+	return gate.ActorClaims{}, errors.New("claims unavailable")
+}
+
+func TestGateAppliesPerKeyClaimsFailurePolicies(t *testing.T) {
+	ctx := context.Background()
+	g := New(
+		WithDefaults(staticDefaults{
+			"billing.invoices": {Set: true, Value: true},
+			"billing.public":   {Set: true, Value: true},
+			"ui.dark_mode":     {Set: true, Value: true},
+		}),
+		WithClaimsProvider(failingClaims{}),
+		WithClaimsFailurePolicies(map[string]ClaimsFailureMode{
+			"billing.*":      FailClosed,
+			"billing.public": FailOpen,
+		}),
+	)
+
+	if _, trace, err := g.ResolveWithTrace(ctx, "billing.invoices"); err == nil || trace.ClaimsFailureMode != string(FailClosed) {
+		t.Fatalf("expected billing prefix to fail closed, got %v (%s)", err, trace.ClaimsFailureMode)
+	}
+	if value, err := g.Enabled(ctx, "billing.public"); err != nil || !value {
+		t.Fatalf("expected exact policy to fail open, got %v %v", value, err)
+	}
+	if value, err := g.Enabled(ctx, "ui.dark_mode"); err != nil || !value {
+		t.Fatalf("expected unmatched key to use the global mode, got %v %v", value, err)
+	}
+	want := map[string]ClaimsFailureMode{"billing.*": FailClosed, "billing.public": FailOpen}
+	if got := g.Config().FailurePolicies; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected config policies: %+v", got)
+	}
+}
+
+func TestGateDerivesChainFromExplicitClaims(t *testing.T) {
+	ctx := scope.WithTenantID(context.Background(), "other")
+	editor := gate.ScopeRef{Kind: gate.ScopeRole, ID: "editor", TenantID: "acme"}
+	overrides := store.NewMemoryStore()
+	if err := overrides.Set(ctx, "reports.export", editor, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	g := New(WithOverrideStore(overrides), WithClaimsProvider(failingClaims{}), WithClaimsFailureMode(FailClosed))
+
+	claims := gate.ActorClaims{SubjectID: "u1", TenantID: "acme", Roles: []string{"Editor"}}
+	value, trace, err := g.ResolveWithTrace(ctx, "reports.export", gate.WithClaims(claims))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || trace.Override.Match != editor {
+		t.Fatalf("expected tenant-qualified role override from claims, got %v from %+v", value, trace.Override.Match)
+	}
+	if !reflect.DeepEqual(trace.Chain, g.ChainBuilder().Build(claims)) {
+		t.Fatalf("expected chain built from claims, got %+v", trace.Chain)
+	}
+}
+
+func TestGateReportsValueChanges(t *testing.T) {
+	ctx := scope.WithTenantID(context.Background(), "acme")
+	overrides := store.NewMemoryStore()
+	var changes []gate.ValueChangeEvent
+	fg := New(
+		WithOverrideStore(overrides),
+		WithValueChangeHook(gate.ValueChangeHookFunc(func(_ context.Context, event gate.ValueChangeEvent) {
+			changes = append(changes, event)
+		})),
+	)
+	if _, err := fg.Enabled(ctx, "checkout"); err != nil {
+		t.Fatalf("enabled: %v", err)
+	}
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	if err := fg.Set(ctx, "checkout", tenant, true, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if _, err := fg.Enabled(ctx, "checkout"); err != nil {
+		t.Fatalf("enabled: %v", err)
+	}
+	if len(changes) != 1 || changes[0].String() != "checkout flipped from false to true for tenant:acme" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestGateAppliesKeyPolicy(t *testing.T) {
+	ctx := context.Background()
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	g := New(
+		WithOverrideStore(store.NewMemoryStore()),
+		WithKeyPolicy(gate.KeyPolicy{FoldCase: true, AllowedChars: "._"}),
+	)
+	if err := g.Set(ctx, "Billing.V2", system, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if value, trace, err := g.ResolveWithTrace(ctx, "billing.v2"); err != nil || !value || trace.NormalizedKey != "billing.v2" {
+		t.Fatalf("expected folded keys to share the override, got %v %q (%v)", value, trace.NormalizedKey, err)
+	}
+	err := g.Set(ctx, "billing v2", system, true, gate.ActorRef{})
+	if rich, ok := ferrors.As(err); !ok || rich.TextCode != ferrors.TextCodeKeyInvalid {
+		t.Fatalf("expected write with invalid key to fail, got %v", err)
+	}
+	if _, err := g.Enabled(ctx, "billing v2"); err == nil {
+		t.Fatalf("expected resolve with invalid key to fail")
+	}
+	if cfg := g.Config(); cfg.KeyPolicy == nil || !cfg.KeyPolicy.FoldCase {
+		t.Fatalf("expected config to report the key policy, got %+v", cfg.KeyPolicy)
+	}
+}
+
+func TestGateResolvesRegisteredAliases(t *testing.T) {
+	ctx := context.Background()
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	overrides := store.NewMemoryStore()
+	// An override written before the rename stays under the legacy key.
+	if err := overrides.Set(ctx, "billing.beta", system, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	gate.RegisterAliases(map[string]string{"billing.beta": "billing.v2"})
+	t.Cleanup(func() { gate.UnregisterAliases("billing.beta") })
+	g := New(WithOverrideStore(overrides))
+
+	value, trace, err := g.ResolveWithTrace(ctx, "billing.v2")
+	if err != nil || !value || trace.Alias != "billing.beta" {
+		t.Fatalf("expected legacy override to apply through the alias, got %v %q (%v)", value, trace.Alias, err)
+	}
+	value, trace, err = g.ResolveWithTrace(ctx, " billing.beta ")
+	if err != nil || !value || trace.NormalizedKey != "billing.v2" || trace.Alias != "billing.beta" {
+		t.Fatalf("expected legacy key to resolve as canonical, got %v %+v (%v)", value, trace, err)
+	}
+	if explain := gate.Explain(trace); !strings.Contains(explain, "alias: billing.beta") {
+		t.Fatalf("expected explain to mention the alias, got:\n%s", explain)
+	}
+
+	gate.UnregisterAliases("billing.beta")
+	if value, trace, _ := g.ResolveWithTrace(ctx, "billing.v2"); value || trace.Alias != "" {
+		t.Fatalf("expected unregistered alias to stop applying, got %v %q", value, trace.Alias)
+	}
+
+	gate.RegisterAliases(map[string]string{"billing.beta": "billing.v2"})
+	if err := g.Unset(ctx, "billing.v2", system, gate.ActorRef{}); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+	if value, _ := g.Enabled(ctx, "billing.v2"); value {
+		t.Fatalf("expected unset to clear the legacy override too")
+	}
+}
+
+func TestGateNamespacesStoreKeys(t *testing.T) {
+	ctx := context.Background()
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	shared := store.NewMemoryStore()
+	billing := New(WithOverrideStore(shared), WithKeyNamespace("billing-svc"))
+	search := New(WithOverrideStore(shared), WithKeyNamespace("search-svc"))
+	if err := billing.Set(ctx, "checkout", system, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if value, trace, _ := billing.ResolveWithTrace(ctx, "checkout"); !value || trace.NormalizedKey != "checkout" {
+		t.Fatalf("expected billing to read its own override, got %v %q", value, trace.NormalizedKey)
+	}
+	if value, _ := search.Enabled(ctx, "checkout"); value {
+		t.Fatalf("expected search not to see the billing override")
+	}
+	if records, err := billing.ListOverrides(ctx, store.ListFilter{}); err != nil || len(records) != 1 || records[0].Key != "checkout" {
+		t.Fatalf("expected unprefixed listing, got %+v (%v)", records, err)
+	}
+}
+
+type countingClaims struct {
+	calls int
+	roles []string
+}
+
+func (c *countingClaims) ClaimsFromContext(ctx context.Context) (gate.ActorClaims, error) {
+	c.calls++
+	claims := scope.ClaimsFromContext(ctx)
+	claims.Roles = c.roles
+	return claims, nil
+}
+
+type countingPerms struct {
+	calls int
+}
+
+func (p *countingPerms) Permissions(context.Context, gate.ActorClaims) ([]string, error) {
+	p.calls++
+	return nil, nil
+}
+
+func TestGateReusesChainFromContextCache(t *testing.T) {
+	claims := &countingClaims{}
+	perms := &countingPerms{}
+	g := New(WithClaimsProvider(claims), WithPermissionProvider(perms), WithOverrideStore(store.NewMemoryStore()))
+	ctx := scope.WithChainCache(scope.WithTenantID(context.Background(), "acme"))
+	for _, key := range []string{"checkout", "search", "billing.v2"} {
+		if _, trace, err := g.ResolveWithTrace(ctx, key); err != nil || trace.Chain.TenantID() != "acme" {
+			t.Fatalf("resolve %s: %v %+v", key, err, trace.Chain)
+		}
+	}
+	if perms.calls != 1 {
+		t.Fatalf("expected one chain derivation per request, got %d", perms.calls)
+	}
+	if _, trace, _ := g.ResolveWithTrace(scope.WithTenantID(ctx, "globex"), "checkout"); trace.Chain.TenantID() != "globex" || perms.calls != 2 {
+		t.Fatalf("expected a changed tenant to derive its own chain, got %+v after %d derivations", trace.Chain, perms.calls)
+	}
+	if _, err := g.Enabled(ctx, "checkout", gate.WithClaims(gate.ActorClaims{TenantID: "initech"})); err != nil || claims.calls != 4 {
+		t.Fatalf("expected explicit claims to bypass the provider, got %d calls (%v)", claims.calls, err)
+	}
+}
+
+func TestGateChainCacheKeysByClaimsValue(t *testing.T) {
+	overrides := store.NewMemoryStore()
+	claims := &countingClaims{roles: []string{"viewer"}}
+	g := New(WithClaimsProvider(claims), WithOverrideStore(overrides))
+	ctx := scope.WithChainCache(scope.WithTenantID(context.Background(), "acme"))
+	admin := gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"}
+	if err := overrides.Set(ctx, "reports", admin, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	if value, _ := g.Enabled(ctx, "reports"); value {
+		t.Fatal("expected viewers not to match the admin override")
+	}
+	claims.roles = []string{"admin"}
+	if value, trace, _ := g.ResolveWithTrace(ctx, "reports"); !value {
+		t.Fatalf("expected claims with other roles to derive their own chain, got %+v", trace.Chain)
+	}
+	claims.roles = []string{"viewer"}
+	if value, _ := g.Enabled(ctx, "reports"); value {
+		t.Fatal("expected the viewer chain to be served again")
+	}
+}
+
+type fixedClaims struct {
+	claims gate.ActorClaims
+}
+
+func (c *fixedClaims) ClaimsFromContext(context.Context) (gate.ActorClaims, error) {
+	return c.claims, nil
+}
+
+func TestGateFastPathMatchesTracedResolve(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	overrides := store.NewMemoryStore()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	set := func(key string, ref gate.ScopeRef, enabled bool, opts ...gate.MutationOption) {
+		t.Helper()
+		if err := overrides.Set(ctx, key, ref, enabled, gate.ActorRef{}, opts...); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+	}
+	set("reports", gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"}, true)
+	set("reports", gate.ScopeRef{Kind: gate.ScopeRole, ID: "guest", TenantID: "acme"}, false)
+	set("reports", tenant, true)
+	set("search", gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1", TenantID: "acme"}, true, gate.WithExpiresAt(now.Add(-time.Minute)))
+	set("search", tenant, false)
+	set("billing.beta", tenant, true)
+	gate.RegisterAliases(map[string]string{"billing.beta": "billing.v2"})
+	t.Cleanup(func() { gate.UnregisterAliases("billing.beta") })
+
+	claimsProvider := &fixedClaims{}
+	g := New(
+		WithOverrideStore(overrides),
+		WithDefaults(staticDefaults{"search": {Set: true, Value: true}, "dashboard": {Set: true, Value: true}}),
+		WithClock(clock.NewManual(now)),
+		WithClaimsProvider(claimsProvider),
+	)
+	if !g.fastPath {
+		t.Fatalf("expected a gate without trace consumers to take the fast path")
+	}
+	claimSets := []gate.ActorClaims{
+		{TenantID: "acme", SubjectID: "u1", Roles: []string{"admin"}},
+		{TenantID: "acme", SubjectID: "u1", Roles: []string{"admin", "guest"}},
+		{TenantID: "globex", SubjectID: "u2"},
+	}
+	for _, claims := range claimSets {
+		claimsProvider.claims = claims
+		for _, key := range []string{"reports", "search", "billing.v2", "dashboard", "unknown"} {
+			want, _, wantErr := g.ResolveWithTrace(ctx, key, gate.WithClaims(claims))
+			got, gotErr := g.Enabled(ctx, key)
+			if got != want || (gotErr == nil) != (wantErr == nil) {
+				t.Fatalf("%s for %+v: fast path %v (%v), traced %v (%v)", key, claims, got, gotErr, want, wantErr)
+			}
+		}
+	}
+	if New(WithResolveHook(gate.ResolveHookFunc(func(context.Context, gate.ResolveEvent) {}))).fastPath {
+		t.Fatalf("expected resolve hooks to disable the fast path")
+	}
+}
+
+func TestGateTracesEveryScopeOutcome(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	overrides := store.NewMemoryStore()
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1", TenantID: "acme"}
+	admin := gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"}
+	guest := gate.ScopeRef{Kind: gate.ScopeRole, ID: "guest", TenantID: "acme"}
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	_ = overrides.Set(ctx, "reports", user, true, gate.ActorRef{}, gate.WithExpiresAt(now.Add(-time.Minute)))
+	_ = overrides.Set(ctx, "reports", admin, true, gate.ActorRef{})
+	_ = overrides.Set(ctx, "reports", guest, false, gate.ActorRef{})
+	_ = overrides.Set(ctx, "reports", tenant, true, gate.ActorRef{})
+	g := New(WithOverrideStore(overrides), WithClock(clock.NewManual(now)))
+
+	chain := gate.WithScopeChain(gate.ScopeChain{user, admin, guest, tenant, system})
+	value, trace, err := g.ResolveWithTrace(ctx, "reports", chain)
+	if err != nil || value {
+		t.Fatalf("expected the guest role to disable reports, got %v (%v)", value, err)
+	}
+	want := []gate.ScopeLookupTrace{
+		{Scope: user, Outcome: gate.ScopeOutcomeExpired, State: gate.OverrideStateEnabled},
+		{Scope: admin, Outcome: gate.ScopeOutcomeShadowed, State: gate.OverrideStateEnabled},
+		{Scope: guest, Outcome: gate.ScopeOutcomeOverridden, State: gate.OverrideStateDisabled},
+		{Scope: tenant, Outcome: gate.ScopeOutcomeShadowed, State: gate.OverrideStateEnabled},
+		{Scope: system, Outcome: gate.ScopeOutcomeNoOverride},
+	}
+	if !reflect.DeepEqual(trace.Override.Scopes, want) {
+		t.Fatalf("unexpected scope outcomes:\n got %+v\nwant %+v", trace.Override.Scopes, want)
+	}
+	if explain := gate.Explain(trace); !strings.Contains(explain, "shadowed: enabled at tenant:acme") {
+		t.Fatalf("expected explain to list shadowed scopes, got:\n%s", explain)
+	}
+
+	_, trace, _ = g.ResolveWithTrace(ctx, "search", chain)
+	for _, lookup := range trace.Override.Scopes {
+		if lookup.Outcome != gate.ScopeOutcomeNoOverride {
+			t.Fatalf("expected no overrides for search, got %+v", trace.Override.Scopes)
+		}
+	}
+}
+
+func TestGateRedactsTracesLeavingTheGate(t *testing.T) {
+	ctx := context.Background()
+	overrides := store.NewMemoryStore()
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "jane@example.com", TenantID: "acme"}
+	_ = overrides.Set(ctx, "reports", user, true, gate.ActorRef{})
+	var events []gate.ResolveEvent
+	entries := mapCache{}
+	g := New(
+		WithOverrideStore(overrides),
+		WithCache(entries),
+		WithTraceRedactor(gate.DropScopeIDs(gate.ScopeUser)),
+		WithResolveHook(gate.ResolveHookFunc(func(_ context.Context, event gate.ResolveEvent) {
+			events = append(events, event)
+		})),
+	)
+	claims := gate.WithClaims(gate.ActorClaims{TenantID: "acme", SubjectID: "jane@example.com"})
+
+	value, trace, err := g.ResolveWithTrace(ctx, "reports", claims)
+	if err != nil || !value || trace.Override.Match.ID != "jane@example.com" {
+		t.Fatalf("expected the caller's trace unredacted, got %v %+v (%v)", value, trace.Override.Match, err)
+	}
+	if len(events) != 1 || events[0].Trace.Override.Match.ID != "" || events[0].Chain[0].ID != "" {
+		t.Fatalf("expected hook traces redacted, got %+v", events)
+	}
+	if entry, ok := entries["reports"]; !ok || entry.Trace.Override.Match.ID != "" {
+		t.Fatalf("expected cached traces redacted, got %+v", entry.Trace.Override.Match)
+	}
+	explanation, _ := g.Explain(ctx, "reports", claims)
+	if rendered := explanation.String(); strings.Contains(rendered, "jane") {
+		t.Fatalf("expected explain output redacted, got:\n%s", rendered)
+	}
+	if !g.Config().TraceRedaction {
+		t.Fatalf("expected config to report trace redaction")
+	}
+}
+
+func TestGateAppliesSeverityPolicyToHookErrors(t *testing.T) {
+	policy := ferrors.NewSeverityPolicy(ferrors.DowngradeNonStrict(ferrors.TextCodeStoreReadFailed, ferrors.SeverityWarning))
+	var events []gate.ResolveEvent
+	hook := WithResolveHook(gate.ResolveHookFunc(func(_ context.Context, event gate.ResolveEvent) {
+		events = append(events, event)
+	}))
+	overrides := &stubStore{getErr: errors.New("store down")}
+
+	tolerant := New(WithOverrideStore(overrides), WithSeverityPolicy(policy), hook)
+	_, trace, err := tolerant.ResolveWithTrace(context.Background(), "checkout")
+	if err != nil {
+		t.Fatalf("expected the store failure tolerated, got %v", err)
+	}
+	if ferrors.SeverityOf(trace.Override.Error) != ferrors.SeverityError {
+		t.Fatalf("expected the caller's trace to keep the original severity")
+	}
+	if len(events) != 1 || ferrors.SeverityOf(events[0].Trace.Override.Error) != ferrors.SeverityWarning {
+		t.Fatalf("expected the hook to see a downgraded store error, got %+v", events)
+	}
+
+	events = nil
+	strict := New(WithOverrideStore(overrides), WithStrictStore(true), hook)
+	ctx := ferrors.WithSeverityPolicy(context.Background(), policy)
+	if _, _, err := strict.ResolveWithTrace(ctx, "checkout"); err == nil {
+		t.Fatalf("expected strict store failure")
+	}
+	if len(events) != 1 || ferrors.SeverityOf(events[0].Error) != ferrors.SeverityError || events[0].Error != events[0].Trace.Override.Error {
+		t.Fatalf("expected strict failures to keep their severity, got %+v", events)
+	}
+}
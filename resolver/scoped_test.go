@@ -0,0 +1,45 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestScopedGateOverridesMatchClaimsChains(t *testing.T) {
+	ctx := context.Background()
+	fg := New(WithOverrideStore(store.NewMemoryStore()))
+	acme := gate.ScopedTo(fg, "acme")
+	actor := gate.ActorRef{ID: "ops"}
+
+	if err := acme.Set(ctx, "reports", gate.ScopeRef{Kind: gate.ScopeOrg, ID: "o1"}, true, actor); err != nil {
+		t.Fatalf("set org: %v", err)
+	}
+	if err := acme.Set(ctx, "exports", gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1", OrgID: "o1"}, true, actor); err != nil {
+		t.Fatalf("set user: %v", err)
+	}
+	if err := acme.Set(ctx, "audit", gate.ScopeRef{Kind: gate.ScopeRole, ID: "Admin", OrgID: "o1"}, true, actor); err != nil {
+		t.Fatalf("set role: %v", err)
+	}
+
+	claims := gate.WithClaims(gate.ActorClaims{SubjectID: "u1", OrgID: "o1", Roles: []string{"admin"}})
+	for _, key := range []string{"reports", "exports", "audit"} {
+		value, trace, err := acme.ResolveWithTrace(ctx, key, claims)
+		if err != nil || !value || trace.Source != gate.ResolveSourceOverride {
+			t.Fatalf("expected the %s override to apply, got %v from %s (%v)", key, value, trace.Source, err)
+		}
+		if value, _ := fg.Enabled(ctx, key, gate.WithClaims(gate.ActorClaims{SubjectID: "u1", TenantID: "acme", OrgID: "o1", Roles: []string{"admin"}})); !value {
+			t.Fatalf("expected the %s override to apply through the unscoped gate", key)
+		}
+	}
+
+	chain := gate.WithScopeChain(gate.ScopeChain{{Kind: gate.ScopeOrg, ID: "o1"}, {Kind: gate.ScopeTenant, ID: "acme"}})
+	if value, err := acme.Enabled(ctx, "reports", chain); err != nil || !value {
+		t.Fatalf("expected an unqualified org ref to be qualified with the tenant, got %v (%v)", value, err)
+	}
+	if value, _ := gate.ScopedTo(fg, "globex").Enabled(ctx, "reports", gate.WithClaims(gate.ActorClaims{OrgID: "o1"})); value {
+		t.Fatal("expected the acme org override not to reach globex")
+	}
+}
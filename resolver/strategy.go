@@ -0,0 +1,210 @@
+package resolver
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+const (
+	// StrategyMostSpecificWins names the built-in strategy that takes the first override
+	// in chain order, ignoring scope groups, so a user override beats a role deny.
+	StrategyMostSpecificWins = "most_specific_wins"
+	// StrategyMostRestrictiveWins names the built-in strategy where a disabled override
+	// anywhere in the chain wins over every enabled one.
+	StrategyMostRestrictiveWins = "most_restrictive_wins"
+	// StrategyPriority names the built-in strategy where the override with the highest
+	// gate.WithPriority value wins.
+	StrategyPriority = "priority"
+)
+
+var strategyRegistry = struct {
+	mu     sync.RWMutex
+	byName map[string]ResolveStrategy
+}{
+	byName: map[string]ResolveStrategy{
+		StrategyDefault:             defaultResolveStrategy,
+		StrategyMostSpecificWins:    mostSpecificWinsStrategy,
+		StrategyMostRestrictiveWins: MostRestrictiveWinsStrategy,
+		StrategyPriority:            PriorityStrategy,
+	},
+}
+
+// RegisterStrategy makes fn available to WithStrategyName under name. Registering a
+// name again replaces the earlier strategy, including the built-in ones.
+func RegisterStrategy(name string, fn ResolveStrategy) error {
+	name = strings.TrimSpace(name)
+	if name == "" || fn == nil {
+		return ferrors.NewBadInput(ferrors.TextCodeStrategyInvalid, "strategy name and function are required", map[string]any{
+			ferrors.MetaStrategy: name,
+		})
+	}
+	strategyRegistry.mu.Lock()
+	defer strategyRegistry.mu.Unlock()
+	strategyRegistry.byName[name] = fn
+	return nil
+}
+
+// LookupStrategy returns the strategy registered under name.
+func LookupStrategy(name string) (ResolveStrategy, bool) {
+	strategyRegistry.mu.RLock()
+	defer strategyRegistry.mu.RUnlock()
+	fn, ok := strategyRegistry.byName[strings.TrimSpace(name)]
+	return fn, ok
+}
+
+// RegisteredStrategies lists the registered strategy names in sorted order.
+func RegisteredStrategies() []string {
+	strategyRegistry.mu.RLock()
+	defer strategyRegistry.mu.RUnlock()
+	names := make([]string, 0, len(strategyRegistry.byName))
+	for name := range strategyRegistry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithStrategyName resolves keys matching pattern with the registered strategy name,
+// e.g. WithStrategyName("billing.*", StrategyMostSpecificWins). Patterns follow the
+// cache.Policies rules. The name is looked up on every resolve, so strategies may be
+// registered after the gate is built; an unknown name fails the override stage.
+func WithStrategyName(pattern, name string) Option {
+	return func(g *Gate) {
+		if g == nil {
+			return
+		}
+		g.strategyNames.add(pattern, strings.TrimSpace(name))
+	}
+}
+
+// WithMostRestrictiveWins resolves every key with MostRestrictiveWinsStrategy, for
+// compliance deployments that need deny-by-default semantics.
+func WithMostRestrictiveWins() Option {
+	return WithNamedResolveStrategy(StrategyMostRestrictiveWins, MostRestrictiveWinsStrategy)
+}
+
+// strategyFor returns the strategy for a normalized key. The name is empty when the
+// key uses the gate-wide strategy.
+func (g *Gate) strategyFor(key string) (string, ResolveStrategy, error) {
+	name := g.strategyNames.lookup(key, "")
+	if name == "" {
+		if g.strategy == nil {
+			return "", defaultResolveStrategy, nil
+		}
+		return "", g.strategy, nil
+	}
+	fn, ok := LookupStrategy(name)
+	if !ok {
+		return name, nil, ferrors.NewOperation(ferrors.TextCodeStrategyUnknown, "resolve strategy is not registered", map[string]any{
+			ferrors.MetaFeatureKey: key,
+			ferrors.MetaStrategy:   name,
+		})
+	}
+	return name, fn, nil
+}
+
+func mostSpecificWinsStrategy(_ context.Context, _ string, chain gate.ScopeChain, matches []store.OverrideMatch, _ ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
+	trace := gate.ResolveTrace{Strategy: StrategyMostSpecificWins}
+	trace.Override.State = gate.OverrideStateMissing
+	trace.Override.Matches = toMatchTraces(matches)
+	byScope := make(map[string]store.OverrideMatch, len(matches))
+	for _, match := range matches {
+		byScope[scopeKey(match.Scope)] = match
+	}
+	for _, ref := range chain {
+		match, ok := byScope[scopeKey(ref)]
+		if !ok {
+			continue
+		}
+		value := valueFromOverride(match.Override)
+		if value == nil {
+			continue
+		}
+		trace.Override.State = match.Override.State
+		trace.Override.Value = value
+		trace.Override.Match = match.Scope
+		trace.Override.ExpiresAt = match.Override.ExpiresAt
+		trace.Override.Metadata = match.Override.Metadata
+		return OverrideDecision{
+			Matched:  true,
+			Value:    *value,
+			Match:    match.Scope,
+			Matches:  matches,
+			Strategy: StrategyMostSpecificWins,
+		}, trace, nil
+	}
+	return OverrideDecision{Strategy: StrategyMostSpecificWins}, trace, nil
+}
+
+// MostRestrictiveWinsStrategy denies when any override in the chain is disabled,
+// reporting the first disabled scope in chain order. Without a disabled override it
+// falls back to the default scope-group precedence.
+func MostRestrictiveWinsStrategy(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch, opts ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
+	byScope := make(map[string]store.OverrideMatch, len(matches))
+	for _, match := range matches {
+		byScope[scopeKey(match.Scope)] = match
+	}
+	for _, ref := range chain {
+		match, ok := byScope[scopeKey(ref)]
+		if !ok || match.Override.State != gate.OverrideStateDisabled {
+			continue
+		}
+		trace := gate.ResolveTrace{Strategy: StrategyMostRestrictiveWins}
+		trace.Override = gate.OverrideTrace{
+			State:     gate.OverrideStateDisabled,
+			Value:     boolPtr(false),
+			Match:     match.Scope,
+			ExpiresAt: match.Override.ExpiresAt,
+			Metadata:  match.Override.Metadata,
+			Matches:   toMatchTraces(matches),
+		}
+		return OverrideDecision{
+			Matched:  true,
+			Value:    false,
+			Match:    match.Scope,
+			Matches:  matches,
+			Strategy: StrategyMostRestrictiveWins,
+		}, trace, nil
+	}
+	decision, trace, err := defaultResolveStrategy(ctx, key, chain, matches, opts)
+	decision.Strategy = StrategyMostRestrictiveWins
+	trace.Strategy = StrategyMostRestrictiveWins
+	return decision, trace, err
+}
+
+// PriorityStrategy resolves with the overrides carrying the highest priority. Ties,
+// including overrides that never set one, follow the default scope-group precedence.
+func PriorityStrategy(ctx context.Context, key string, chain gate.ScopeChain, matches []store.OverrideMatch, opts ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
+	top := matches
+	best, found := 0, false
+	for _, match := range matches {
+		if !match.Override.HasValue() {
+			continue
+		}
+		if !found || match.Override.Priority > best {
+			best, found = match.Override.Priority, true
+		}
+	}
+	if found {
+		top = make([]store.OverrideMatch, 0, len(matches))
+		for _, match := range matches {
+			if match.Override.HasValue() && match.Override.Priority == best {
+				top = append(top, match)
+			}
+		}
+	}
+	decision, trace, err := defaultResolveStrategy(ctx, key, chain, top, opts)
+	decision.Strategy = StrategyPriority
+	trace.Strategy = StrategyPriority
+	if decision.Matched {
+		decision.Matches = matches
+		trace.Override.Matches = toMatchTraces(matches)
+	}
+	return decision, trace, err
+}
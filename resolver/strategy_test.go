@@ -0,0 +1,128 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestGateUsesNamedStrategyPerKey(t *testing.T) {
+	ctx := context.Background()
+	editor := gate.ScopeRef{Kind: gate.ScopeRole, ID: "editor"}
+	viewer := gate.ScopeRef{Kind: gate.ScopeRole, ID: "viewer"}
+	overrides := store.NewMemoryStore()
+	for _, key := range []string{"reports.export", "billing.export"} {
+		if err := overrides.Set(ctx, key, editor, true, gate.ActorRef{}); err != nil {
+			t.Fatalf("set editor: %v", err)
+		}
+		if err := overrides.Set(ctx, key, viewer, false, gate.ActorRef{}); err != nil {
+			t.Fatalf("set viewer: %v", err)
+		}
+	}
+	g := New(
+		WithOverrideStore(overrides),
+		WithStrategyName("reports.*", StrategyMostSpecificWins),
+	)
+	chain := gate.WithScopeChain(gate.ScopeChain{editor, viewer, {Kind: gate.ScopeSystem}})
+
+	value, trace, err := g.ResolveWithTrace(ctx, "reports.export", chain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || trace.Strategy != StrategyMostSpecificWins || trace.Override.Match != editor {
+		t.Fatalf("expected first role in chain to win, got %v via %s (%+v)", value, trace.Strategy, trace.Override.Match)
+	}
+	value, trace, _ = g.ResolveWithTrace(ctx, "billing.export", chain)
+	if value || trace.Strategy != StrategyDefault {
+		t.Fatalf("expected default strategy deny to win, got %v via %s", value, trace.Strategy)
+	}
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	ctx := context.Background()
+	if err := RegisterStrategy(" ", DefaultResolveStrategy); err == nil {
+		t.Fatalf("expected empty name to be rejected")
+	}
+	always := func(_ context.Context, _ string, _ gate.ScopeChain, _ []store.OverrideMatch, _ ResolveOptions) (OverrideDecision, gate.ResolveTrace, error) {
+		return OverrideDecision{Matched: true, Value: true}, gate.ResolveTrace{}, nil
+	}
+	if err := RegisterStrategy("test_always_on", always); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	g := New(
+		WithOverrideStore(store.NewMemoryStore()),
+		WithStrictStore(true),
+		WithStrategyName("beta", "test_always_on"),
+		WithStrategyName("gamma", "test_missing"),
+	)
+	value, trace, err := g.ResolveWithTrace(ctx, "beta")
+	if err != nil || !value || trace.Strategy != "test_always_on" {
+		t.Fatalf("expected registered strategy, got %v via %s: %v", value, trace.Strategy, err)
+	}
+	if _, _, err := g.ResolveWithTrace(ctx, "gamma"); err == nil {
+		t.Fatalf("expected unknown strategy to fail in strict mode")
+	} else if rich, ok := ferrors.As(err); !ok || rich.TextCode != ferrors.TextCodeStrategyUnknown {
+		t.Fatalf("expected unknown strategy error, got %v", err)
+	}
+}
+
+func TestMostRestrictiveWinsDeniesAcrossGroups(t *testing.T) {
+	ctx := context.Background()
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1"}
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	overrides := store.NewMemoryStore()
+	if err := overrides.Set(ctx, "exports.pii", user, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set user: %v", err)
+	}
+	if err := overrides.Set(ctx, "exports.pii", tenant, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set tenant: %v", err)
+	}
+	chain := gate.WithScopeChain(gate.ScopeChain{user, tenant, {Kind: gate.ScopeSystem}})
+
+	if value, _ := New(WithOverrideStore(overrides)).Enabled(ctx, "exports.pii", chain); !value {
+		t.Fatalf("expected default strategy to let the user override win")
+	}
+	g := New(WithOverrideStore(overrides), WithMostRestrictiveWins())
+	value, trace, err := g.ResolveWithTrace(ctx, "exports.pii", chain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value || trace.Override.Match != tenant || trace.Strategy != StrategyMostRestrictiveWins {
+		t.Fatalf("expected tenant deny to win, got %v from %+v via %s", value, trace.Override.Match, trace.Strategy)
+	}
+	if got := g.Config().Strategy; got != StrategyMostRestrictiveWins {
+		t.Fatalf("expected config to report strategy, got %q", got)
+	}
+}
+
+func TestPriorityStrategyOrdersRoleOverrides(t *testing.T) {
+	ctx := context.Background()
+	editor := gate.ScopeRef{Kind: gate.ScopeRole, ID: "editor"}
+	viewer := gate.ScopeRef{Kind: gate.ScopeRole, ID: "viewer"}
+	overrides := store.NewMemoryStore()
+	g := New(WithOverrideStore(overrides), WithStrategyName("reports.*", StrategyPriority))
+	if err := g.Set(ctx, "reports.export", viewer, false, gate.ActorRef{}, gate.WithPriority(1)); err != nil {
+		t.Fatalf("set viewer: %v", err)
+	}
+	if err := g.Set(ctx, "reports.export", editor, true, gate.ActorRef{}, gate.WithPriority(5)); err != nil {
+		t.Fatalf("set editor: %v", err)
+	}
+	chain := gate.WithScopeChain(gate.ScopeChain{viewer, editor, {Kind: gate.ScopeSystem}})
+
+	value, trace, err := g.ResolveWithTrace(ctx, "reports.export", chain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value || trace.Override.Match != editor || trace.Strategy != StrategyPriority {
+		t.Fatalf("expected higher priority editor override to win, got %v from %+v via %s", value, trace.Override.Match, trace.Strategy)
+	}
+	if len(trace.Override.Matches) != 2 || trace.Override.Matches[1].Priority != 5 {
+		t.Fatalf("expected all matches with priorities in trace, got %+v", trace.Override.Matches)
+	}
+	if value, _ := New(WithOverrideStore(overrides)).Enabled(ctx, "reports.export", chain); value {
+		t.Fatalf("expected default strategy to keep role deny-wins")
+	}
+}
@@ -0,0 +1,111 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// VerifyOption customizes VerifyCache.
+type VerifyOption func(*verifyConfig)
+
+type verifyConfig struct {
+	chains     []gate.ScopeChain
+	invalidate bool
+}
+
+// VerifyChains checks the given scope chains instead of the chain derived from ctx.
+func VerifyChains(chains ...gate.ScopeChain) VerifyOption {
+	return func(cfg *verifyConfig) {
+		cfg.chains = append(cfg.chains, chains...)
+	}
+}
+
+// VerifyInvalidate deletes cache entries that disagree with the stores.
+func VerifyInvalidate() VerifyOption {
+	return func(cfg *verifyConfig) {
+		cfg.invalidate = true
+	}
+}
+
+// CacheCheck describes one cache entry that VerifyCache could not confirm.
+type CacheCheck struct {
+	Key    string
+	Chain  gate.ScopeChain
+	Cached bool
+	Actual bool
+	// Trace is the fresh resolution that bypassed the cache.
+	Trace       gate.ResolveTrace
+	Invalidated bool
+	Error       error
+}
+
+// CacheReport summarizes a VerifyCache run.
+type CacheReport struct {
+	// Checked counts cache entries compared against the stores.
+	Checked     int
+	Divergences []CacheCheck
+	Errors      []CacheCheck
+}
+
+// Consistent reports whether every checked entry matched the stores.
+func (r CacheReport) Consistent() bool {
+	return len(r.Divergences) == 0 && len(r.Errors) == 0
+}
+
+// VerifyCache re-resolves keys bypassing the resolve cache and store caches, and
+// reports cached values that no longer match, for example after a store restore.
+// It checks the chain derived from ctx unless VerifyChains is given; keys without
+// a live cache entry are skipped. Resolve hooks are not called.
+func (g *Gate) VerifyCache(ctx context.Context, keys []string, opts ...VerifyOption) CacheReport {
+	var report CacheReport
+	if g == nil || g.cache == nil {
+		return report
+	}
+	cfg := verifyConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	freshCtx := store.WithUncommitted(context.WithValue(gate.DetachRequest(ctx), whatIfKey{}, true))
+	for _, key := range keys {
+		normalized := g.keyPolicy.Normalize(key)
+		if normalized == "" || g.cachePolicies.Lookup(normalized).Bypass {
+			continue
+		}
+		chains := cfg.chains
+		if len(chains) == 0 {
+			var trace gate.ResolveTrace
+			chain, _, err := g.resolveChain(ctx, normalized, &trace, gate.ResolveRequest{})
+			if err != nil {
+				report.Errors = append(report.Errors, CacheCheck{Key: normalized, Error: err})
+				continue
+			}
+			chains = []gate.ScopeChain{chain}
+		}
+		for _, chain := range chains {
+			entry, ok := g.readCache(ctx, normalized, chain)
+			if !ok {
+				continue
+			}
+			report.Checked++
+			value, trace, err := g.evaluate(freshCtx, normalized, gate.WithScopeChain(chain))
+			check := CacheCheck{Key: normalized, Chain: chain, Cached: entry.Value, Actual: value, Trace: trace, Error: err}
+			if err != nil {
+				report.Errors = append(report.Errors, check)
+				continue
+			}
+			if value == entry.Value {
+				continue
+			}
+			if cfg.invalidate {
+				g.cache.Delete(ctx, normalized, chain)
+				check.Invalidated = true
+			}
+			report.Divergences = append(report.Divergences, check)
+		}
+	}
+	return report
+}
@@ -0,0 +1,49 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestVerifyCacheReportsAndInvalidatesStaleEntries(t *testing.T) {
+	ctx := context.Background()
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	chain := gate.ScopeChain{system}
+	overrides := store.NewMemoryStore()
+	entries := mapCache{}
+	g := New(WithOverrideStore(overrides), WithCache(entries))
+	for _, key := range []string{"checkout", "search"} {
+		if _, err := g.Enabled(ctx, key, gate.WithScopeChain(chain)); err != nil {
+			t.Fatalf("resolve %s: %v", key, err)
+		}
+	}
+	// Writing to the store directly, as a restore would, leaves the cache stale.
+	if err := overrides.Set(ctx, "checkout", system, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	report := g.VerifyCache(ctx, []string{"checkout", "search", "missing"}, VerifyChains(chain))
+	if report.Checked != 2 || len(report.Divergences) != 1 || report.Consistent() {
+		t.Fatalf("expected one divergence out of two entries, got %+v", report)
+	}
+	if got := report.Divergences[0]; got.Key != "checkout" || got.Cached || !got.Actual || got.Invalidated {
+		t.Fatalf("unexpected divergence %+v", got)
+	}
+	if _, ok := entries["checkout"]; !ok {
+		t.Fatalf("expected report-only run to keep the entry")
+	}
+
+	report = g.VerifyCache(ctx, []string{"checkout"}, VerifyChains(chain), VerifyInvalidate())
+	if len(report.Divergences) != 1 || !report.Divergences[0].Invalidated {
+		t.Fatalf("expected the stale entry to be invalidated, got %+v", report)
+	}
+	if _, ok := entries["checkout"]; ok {
+		t.Fatalf("expected the stale entry to be deleted")
+	}
+	if value, _ := g.Enabled(ctx, "checkout", gate.WithScopeChain(chain)); !value {
+		t.Fatalf("expected resolves to see the store value after invalidation")
+	}
+}
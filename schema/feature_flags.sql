@@ -1,11 +1,20 @@
 -- Feature flag overrides (nullable enabled means explicit unset).
--- scope_type values: system, tenant, org, user.
+-- scope_type values: system, tenant, org, user, role, perm.
+-- tenant_id/org_id qualify scopes (for example a role within a tenant).
 CREATE TABLE feature_flags (
     key text NOT NULL,
     scope_type text NOT NULL,
     scope_id text NOT NULL DEFAULT '',
+    tenant_id text NOT NULL DEFAULT '',
+    org_id text NOT NULL DEFAULT '',
     enabled boolean NULL,
     updated_by text,
     updated_at timestamp with time zone NOT NULL DEFAULT now(),
-    PRIMARY KEY (key, scope_type, scope_id)
+    expires_at timestamp with time zone NULL,
+    reason text NULL,
+    ticket_url text NULL,
+    owner text NULL,
+    labels jsonb NULL,
+    priority integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (key, scope_type, scope_id, tenant_id, org_id)
 );
@@ -0,0 +1,88 @@
+package scope
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+type chainCacheKey struct{}
+
+// ChainCache memoizes derived scope chains for one request, so gates normalize
+// roles, add permissions, and walk the tenant hierarchy once however many flags the
+// request checks. Entries are keyed by the gate that derived them and by the full
+// claims value, roles, permissions, and env included, so a context whose claims
+// differ in any field derives its own chain.
+type ChainCache struct {
+	mu      sync.Mutex
+	entries []chainCacheEntry
+}
+
+type chainCacheEntry struct {
+	owner  any
+	claims gate.ActorClaims
+	chain  gate.ScopeChain
+}
+
+// WithChainCache attaches an empty chain cache to ctx unless one is already present.
+func WithChainCache(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ChainCacheFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, chainCacheKey{}, &ChainCache{})
+}
+
+// ChainCacheFromContext returns the chain cache attached to ctx, if any.
+func ChainCacheFromContext(ctx context.Context) *ChainCache {
+	if ctx == nil {
+		return nil
+	}
+	cache, _ := ctx.Value(chainCacheKey{}).(*ChainCache)
+	return cache
+}
+
+// Lookup returns the chain owner derived from claims.
+func (c *ChainCache) Lookup(owner any, claims gate.ActorClaims) (gate.ScopeChain, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range c.entries {
+		if entry.owner == owner && sameClaims(entry.claims, claims) {
+			return entry.chain, true
+		}
+	}
+	return nil, false
+}
+
+// Store records the chain owner derived from claims. The chain is shared by later
+// lookups and must not be modified.
+func (c *ChainCache) Store(owner any, claims gate.ActorClaims, chain gate.ScopeChain) {
+	if c == nil {
+		return
+	}
+	claims.Roles = slices.Clone(claims.Roles)
+	claims.Perms = slices.Clone(claims.Perms)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, entry := range c.entries {
+		if entry.owner == owner && sameClaims(entry.claims, claims) {
+			c.entries[i].chain = chain[:len(chain):len(chain)]
+			return
+		}
+	}
+	c.entries = append(c.entries, chainCacheEntry{owner: owner, claims: claims, chain: chain[:len(chain):len(chain)]})
+}
+
+// sameClaims compares every claims field. Roles and permissions compare in order;
+// the same set in another order only costs a rebuild.
+func sameClaims(a, b gate.ActorClaims) bool {
+	return a.SubjectID == b.SubjectID && a.TenantID == b.TenantID && a.OrgID == b.OrgID && a.Env == b.Env &&
+		slices.Equal(a.Roles, b.Roles) && slices.Equal(a.Perms, b.Perms)
+}
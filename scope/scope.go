@@ -14,12 +14,14 @@ const (
 	tenantIDKey contextKey = "featuregate.tenant_id"
 	orgIDKey    contextKey = "featuregate.org_id"
 	userIDKey   contextKey = "featuregate.user_id"
+	envKey      contextKey = "featuregate.env"
 )
 
 const (
 	MetadataTenantID = "tenant_id"
 	MetadataOrgID    = "org_id"
 	MetadataUserID   = "user_id"
+	MetadataEnv      = "env"
 )
 
 // WithSystem stores a system scope flag in context.
@@ -54,6 +56,15 @@ func WithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, userIDKey, trimmed)
 }
 
+// WithEnv stores a deployment environment (for example "staging") in context.
+func WithEnv(ctx context.Context, env string) context.Context {
+	trimmed := strings.TrimSpace(env)
+	if trimmed == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, envKey, trimmed)
+}
+
 // ClearTenantID clears a tenant identifier from context.
 func ClearTenantID(ctx context.Context) context.Context {
 	return context.WithValue(ctx, tenantIDKey, "")
@@ -89,6 +100,43 @@ func UserID(ctx context.Context) string {
 	return toString(ctx.Value(userIDKey))
 }
 
+// Env extracts the deployment environment from context.
+func Env(ctx context.Context) string {
+	return toString(ctx.Value(envKey))
+}
+
+// HeaderNames lists the transport header or metadata names carrying scope values.
+type HeaderNames struct {
+	TenantID string
+	OrgID    string
+	UserID   string
+}
+
+// DefaultHeaderNames are the header names read by the service framework adapters.
+var DefaultHeaderNames = HeaderNames{
+	TenantID: "X-Tenant-ID",
+	OrgID:    "X-Org-ID",
+	UserID:   "X-User-ID",
+}
+
+// FromHeaders stores the scope values returned by get in ctx. Empty values are skipped,
+// so values set by earlier middleware survive.
+func FromHeaders(ctx context.Context, get func(name string) string, names HeaderNames) context.Context {
+	if get == nil {
+		return ctx
+	}
+	ctx = WithTenantID(ctx, headerValue(get, names.TenantID))
+	ctx = WithOrgID(ctx, headerValue(get, names.OrgID))
+	return WithUserID(ctx, headerValue(get, names.UserID))
+}
+
+func headerValue(get func(string) string, name string) string {
+	if strings.TrimSpace(name) == "" {
+		return ""
+	}
+	return get(name)
+}
+
 // ClaimsFromContext builds ActorClaims from context values.
 func ClaimsFromContext(ctx context.Context) gate.ActorClaims {
 	if ctx == nil {
@@ -98,6 +146,7 @@ func ClaimsFromContext(ctx context.Context) gate.ActorClaims {
 		SubjectID: UserID(ctx),
 		TenantID:  TenantID(ctx),
 		OrgID:     OrgID(ctx),
+		Env:       Env(ctx),
 	}
 }
 
@@ -3,6 +3,8 @@ package scope
 import (
 	"context"
 	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
 )
 
 func TestScopeHelpersNoopAndClear(t *testing.T) {
@@ -55,11 +57,12 @@ func TestClaimsFromContext(t *testing.T) {
 	ctx = WithTenantID(ctx, "acme")
 	ctx = WithOrgID(ctx, "engineering")
 	ctx = WithUserID(ctx, "user-123")
+	ctx = WithEnv(ctx, " staging ")
 	ctx = WithSystem(ctx, true)
 
 	got := ClaimsFromContext(ctx)
-	if got.SubjectID != "user-123" || got.TenantID != "acme" || got.OrgID != "engineering" {
-		t.Fatalf("ClaimsFromContext() = %+v, want subject/tenant/org", got)
+	if got.SubjectID != "user-123" || got.TenantID != "acme" || got.OrgID != "engineering" || got.Env != "staging" {
+		t.Fatalf("ClaimsFromContext() = %+v, want subject/tenant/org/env", got)
 	}
 }
 
@@ -70,3 +73,41 @@ func TestClaimsFromContextNil(t *testing.T) {
 		t.Fatalf("ClaimsFromContext(nil) = %+v, want empty claims", got)
 	}
 }
+
+func TestFromHeadersKeepsExistingValues(t *testing.T) {
+	headers := map[string]string{"X-User-ID": " user-9 ", "X-Org-ID": ""}
+	ctx := WithOrgID(context.Background(), "engineering")
+	ctx = FromHeaders(ctx, func(name string) string { return headers[name] }, DefaultHeaderNames)
+
+	if UserID(ctx) != "user-9" || OrgID(ctx) != "engineering" || TenantID(ctx) != "" {
+		t.Fatalf("FromHeaders() = user %q org %q tenant %q", UserID(ctx), OrgID(ctx), TenantID(ctx))
+	}
+}
+
+func TestChainCacheKeysByOwnerAndClaims(t *testing.T) {
+	cache := ChainCacheFromContext(WithChainCache(context.Background()))
+	perms := []string{"reports:read"}
+	claims := gate.ActorClaims{TenantID: "acme", Roles: []string{"admin"}, Perms: perms}
+	chain := gate.ScopeChain{{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}}
+	cache.Store("gate-a", claims, chain)
+
+	if got, ok := cache.Lookup("gate-a", gate.ActorClaims{TenantID: "acme", Roles: []string{"admin"}, Perms: []string{"reports:read"}}); !ok || len(got) != 1 {
+		t.Fatalf("expected equal claims to hit, got %v %v", got, ok)
+	}
+	for name, other := range map[string]gate.ActorClaims{
+		"roles": {TenantID: "acme", Roles: []string{"viewer"}, Perms: perms},
+		"perms": {TenantID: "acme", Roles: []string{"admin"}},
+		"env":   {TenantID: "acme", Roles: []string{"admin"}, Perms: perms, Env: "prod"},
+	} {
+		if _, ok := cache.Lookup("gate-a", other); ok {
+			t.Fatalf("expected claims differing in %s to miss", name)
+		}
+	}
+	if _, ok := cache.Lookup("gate-b", claims); ok {
+		t.Fatal("expected another owner to miss")
+	}
+	perms[0] = "reports:write"
+	if _, ok := cache.Lookup("gate-a", gate.ActorClaims{TenantID: "acme", Roles: []string{"admin"}, Perms: []string{"reports:read"}}); !ok {
+		t.Fatal("expected stored claims not to alias the caller's slices")
+	}
+}
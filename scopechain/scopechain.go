@@ -0,0 +1,253 @@
+// Package scopechain derives scope chains from actor claims the way resolver.Gate
+// does, so adapters, tests, and snapshot builders can build identical chains
+// without duplicating the rules.
+package scopechain
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// IdentifierNormalizer normalizes role/perm identifiers.
+type IdentifierNormalizer func(string) string
+
+// Builder turns actor claims into a scope chain.
+type Builder struct {
+	order         []gate.ScopeKind
+	normalizer    IdentifierNormalizer
+	preserveOrder bool
+	environment   string
+}
+
+// Option customizes a Builder.
+type Option func(*Builder)
+
+// New constructs a Builder with the resolver's defaults: DefaultScopeOrder, sorted
+// and lower-cased roles and perms, and no fallback environment.
+func New(opts ...Option) *Builder {
+	b := &Builder{
+		order:      DefaultScopeOrder(),
+		normalizer: DefaultRolePermNormalizer,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	if b.order == nil {
+		b.order = DefaultScopeOrder()
+	}
+	if b.normalizer == nil {
+		b.normalizer = DefaultRolePermNormalizer
+	}
+	return b
+}
+
+// WithScopeOrder sets the chain construction order.
+func WithScopeOrder(order ...gate.ScopeKind) Option {
+	return func(b *Builder) {
+		if b == nil {
+			return
+		}
+		b.order = append([]gate.ScopeKind(nil), order...)
+	}
+}
+
+// WithRolePermNormalizer overrides role/perm normalization.
+func WithRolePermNormalizer(normalizer IdentifierNormalizer) Option {
+	return func(b *Builder) {
+		if b == nil {
+			return
+		}
+		b.normalizer = normalizer
+	}
+}
+
+// WithPreserveRolePermOrder keeps roles and perms in claim order instead of sorting them.
+func WithPreserveRolePermOrder(enabled bool) Option {
+	return func(b *Builder) {
+		if b == nil {
+			return
+		}
+		b.preserveOrder = enabled
+	}
+}
+
+// WithEnvironment sets the env scope used when the claims carry none.
+func WithEnvironment(env string) Option {
+	return func(b *Builder) {
+		if b == nil {
+			return
+		}
+		b.environment = strings.TrimSpace(env)
+	}
+}
+
+// DefaultScopeOrder returns the resolver's default chain order.
+func DefaultScopeOrder() []gate.ScopeKind {
+	return []gate.ScopeKind{
+		gate.ScopeUser,
+		gate.ScopeRole,
+		gate.ScopePerm,
+		gate.ScopeOrg,
+		gate.ScopeTenant,
+		gate.ScopeEnv,
+		gate.ScopeSystem,
+	}
+}
+
+// DefaultRolePermNormalizer trims and lower-cases role/perm identifiers.
+func DefaultRolePermNormalizer(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// Order returns the chain construction order.
+func (b *Builder) Order() []gate.ScopeKind {
+	return append([]gate.ScopeKind(nil), b.order...)
+}
+
+// Build returns the chain for claims in the configured order, always ending with the
+// system scope. Role and perm scopes are added unqualified and, when the claims carry
+// a tenant or org, qualified by them as well.
+func (b *Builder) Build(claims gate.ActorClaims) gate.ScopeChain {
+	if claims.Env == "" {
+		claims.Env = b.environment
+	}
+	return AppendSystemIfMissing(b.build(claims))
+}
+
+// NormalizeRef trims a scope ref and normalizes role/perm identifiers.
+func (b *Builder) NormalizeRef(ref gate.ScopeRef) gate.ScopeRef {
+	ref.ID = strings.TrimSpace(ref.ID)
+	ref.TenantID = strings.TrimSpace(ref.TenantID)
+	ref.OrgID = strings.TrimSpace(ref.OrgID)
+	if ref.Kind == gate.ScopeRole || ref.Kind == gate.ScopePerm {
+		ref.ID = b.normalizer(ref.ID)
+	}
+	return ref
+}
+
+func (b *Builder) build(claims gate.ActorClaims) gate.ScopeChain {
+	roles := normalizeList(claims.Roles, b.normalizer)
+	perms := normalizeList(claims.Perms, b.normalizer)
+	if !b.preserveOrder {
+		roles = sortAndDedupe(roles)
+		perms = sortAndDedupe(perms)
+	} else {
+		roles = dedupeStable(roles)
+		perms = dedupeStable(perms)
+	}
+	chain := make(gate.ScopeChain, 0, len(roles)+len(perms)+4)
+	for _, kind := range b.order {
+		switch kind {
+		case gate.ScopeUser:
+			if claims.SubjectID != "" {
+				chain = append(chain, gate.QualifiedRef(gate.ScopeUser, claims.SubjectID, claims))
+			}
+		case gate.ScopeRole:
+			chain = append(chain, rolePermRefs(gate.ScopeRole, roles, claims)...)
+		case gate.ScopePerm:
+			chain = append(chain, rolePermRefs(gate.ScopePerm, perms, claims)...)
+		case gate.ScopeOrg:
+			if claims.OrgID != "" {
+				chain = append(chain, gate.QualifiedRef(gate.ScopeOrg, claims.OrgID, claims))
+			}
+		case gate.ScopeTenant:
+			if claims.TenantID != "" {
+				chain = append(chain, gate.QualifiedRef(gate.ScopeTenant, claims.TenantID, claims))
+			}
+		case gate.ScopeEnv:
+			if env := strings.TrimSpace(claims.Env); env != "" {
+				chain = append(chain, gate.ScopeRef{Kind: gate.ScopeEnv, ID: env})
+			}
+		case gate.ScopeSystem:
+			chain = append(chain, gate.ScopeRef{Kind: gate.ScopeSystem})
+		}
+	}
+	return chain
+}
+
+// AppendSystemIfMissing appends the system scope unless chain already contains it.
+func AppendSystemIfMissing(chain gate.ScopeChain) gate.ScopeChain {
+	for _, ref := range chain {
+		if ref.Kind == gate.ScopeSystem {
+			return chain
+		}
+	}
+	return append(chain, gate.ScopeRef{Kind: gate.ScopeSystem})
+}
+
+func rolePermRefs(kind gate.ScopeKind, items []string, claims gate.ActorClaims) gate.ScopeChain {
+	if len(items) == 0 {
+		return nil
+	}
+	refs := make(gate.ScopeChain, 0, len(items)*2)
+	for _, id := range items {
+		if id == "" {
+			continue
+		}
+		refs = append(refs, gate.ScopeRef{
+			Kind: kind,
+			ID:   id,
+		})
+		if claims.TenantID != "" || claims.OrgID != "" {
+			refs = append(refs, gate.QualifiedRef(kind, id, claims))
+		}
+	}
+	return refs
+}
+
+func normalizeList(values []string, normalizer IdentifierNormalizer) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			continue
+		}
+		if normalizer != nil {
+			trimmed = normalizer(trimmed)
+		}
+		if trimmed == "" {
+			continue
+		}
+		out = append(out, trimmed)
+	}
+	return out
+}
+
+func sortAndDedupe(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	uniq := map[string]struct{}{}
+	for _, value := range values {
+		uniq[value] = struct{}{}
+	}
+	out := make([]string, 0, len(uniq))
+	for value := range uniq {
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func dedupeStable(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package scopechain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func TestBuilderBuildsResolverChain(t *testing.T) {
+	claims := gate.ActorClaims{
+		SubjectID: "u1",
+		TenantID:  "acme",
+		Roles:     []string{" Editor", "admin", "editor"},
+		Perms:     []string{"reports.read"},
+	}
+	got := New(WithEnvironment("prod")).Build(claims)
+	want := gate.ScopeChain{
+		{Kind: gate.ScopeUser, ID: "u1", TenantID: "acme"},
+		{Kind: gate.ScopeRole, ID: "admin"},
+		{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"},
+		{Kind: gate.ScopeRole, ID: "editor"},
+		{Kind: gate.ScopeRole, ID: "editor", TenantID: "acme"},
+		{Kind: gate.ScopePerm, ID: "reports.read"},
+		{Kind: gate.ScopePerm, ID: "reports.read", TenantID: "acme"},
+		{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"},
+		{Kind: gate.ScopeEnv, ID: "prod"},
+		{Kind: gate.ScopeSystem},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected chain:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestBuilderHonorsOrderAndAppendsSystem(t *testing.T) {
+	b := New(
+		WithScopeOrder(gate.ScopeTenant, gate.ScopeRole),
+		WithPreserveRolePermOrder(true),
+	)
+	got := b.Build(gate.ActorClaims{TenantID: "acme", Roles: []string{"viewer", "admin"}, Env: "staging"})
+	want := gate.ScopeChain{
+		{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"},
+		{Kind: gate.ScopeRole, ID: "viewer"},
+		{Kind: gate.ScopeRole, ID: "viewer", TenantID: "acme"},
+		{Kind: gate.ScopeRole, ID: "admin"},
+		{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"},
+		{Kind: gate.ScopeSystem},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected chain:\n got %+v\nwant %+v", got, want)
+	}
+	if ref := b.NormalizeRef(gate.ScopeRef{Kind: gate.ScopeRole, ID: " Admin "}); ref.ID != "admin" {
+		t.Fatalf("expected normalized role id, got %q", ref.ID)
+	}
+}
@@ -0,0 +1,287 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// DefaultCacheTTL is the entry lifetime used by CachedReader.
+const DefaultCacheTTL = 5 * time.Second
+
+// CachedReader decorates a Reader with a short-TTL in-process cache keyed by
+// (key, scope). Scopes without a stored override are cached as misses too, with
+// their own TTL, unless negative caching is disabled.
+type CachedReader struct {
+	inner       Reader
+	ttl         time.Duration
+	missTTL     time.Duration
+	cacheMisses bool
+	now         func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]map[scopeKey]cachedEntry
+}
+
+type cachedEntry struct {
+	override Override
+	found    bool
+	expires  time.Time
+}
+
+// CacheOption customizes a CachedReader.
+type CacheOption func(*CachedReader)
+
+// WithCacheTTL sets the entry lifetime.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *CachedReader) {
+		if c == nil {
+			return
+		}
+		c.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL sets the lifetime of cached misses (scopes without an override).
+// Values below or equal to zero use the entry TTL.
+func WithNegativeCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *CachedReader) {
+		if c == nil {
+			return
+		}
+		c.missTTL = ttl
+	}
+}
+
+// WithoutNegativeCache disables caching of misses, so scopes without an override are
+// read from the inner reader on every lookup.
+func WithoutNegativeCache() CacheOption {
+	return func(c *CachedReader) {
+		if c == nil {
+			return
+		}
+		c.cacheMisses = false
+	}
+}
+
+// WithCacheClock sets the clock used for entry expiry.
+func WithCacheClock(c clock.Clock) CacheOption {
+	return func(cr *CachedReader) {
+		if cr == nil {
+			return
+		}
+		cr.now = clock.NowFunc(c)
+	}
+}
+
+// WithCacheNowFunc overrides the clock used for entry expiry.
+func WithCacheNowFunc(now func() time.Time) CacheOption {
+	return func(c *CachedReader) {
+		if c == nil {
+			return
+		}
+		c.now = now
+	}
+}
+
+// NewCachedReader wraps inner with a read-through cache.
+func NewCachedReader(inner Reader, opts ...CacheOption) *CachedReader {
+	c := &CachedReader{
+		inner:       inner,
+		ttl:         DefaultCacheTTL,
+		cacheMisses: true,
+		now:         time.Now,
+		entries:     map[string]map[scopeKey]cachedEntry{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	if c.ttl <= 0 {
+		c.ttl = DefaultCacheTTL
+	}
+	if c.missTTL <= 0 {
+		c.missTTL = c.ttl
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	return c
+}
+
+// GetAll implements Reader. Only scopes missing from the cache are read from the inner reader;
+// contexts marked with WithUncommitted always read through without touching the cache.
+func (c *CachedReader) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
+	if c == nil || c.inner == nil {
+		return nil, storeRequiredError("cached", key, gate.ScopeRef{}, "get_all")
+	}
+	normalized, err := storedKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if Uncommitted(ctx) {
+		return c.inner.GetAll(ctx, normalized, chain)
+	}
+	now := c.now()
+	cached := make(map[scopeKey]cachedEntry, len(chain))
+	var missing gate.ScopeChain
+	c.mu.RLock()
+	for _, ref := range chain {
+		scope := scopeKeyFromRef(ref)
+		if entry, ok := c.entries[normalized][scope]; ok && now.Before(entry.expires) {
+			cached[scope] = entry
+			continue
+		}
+		missing = append(missing, ref)
+	}
+	c.mu.RUnlock()
+
+	if len(missing) > 0 {
+		matches, err := c.inner.GetAll(ctx, normalized, missing)
+		if err != nil {
+			return nil, err
+		}
+		missExpires := now.Add(c.missTTL)
+		for _, ref := range missing {
+			cached[scopeKeyFromRef(ref)] = cachedEntry{expires: missExpires}
+		}
+		expires := now.Add(c.ttl)
+		for _, match := range matches {
+			cached[scopeKeyFromRef(match.Scope)] = cachedEntry{override: match.Override, found: true, expires: expires}
+		}
+		c.mu.Lock()
+		if c.entries[normalized] == nil {
+			c.entries[normalized] = map[scopeKey]cachedEntry{}
+		}
+		for _, ref := range missing {
+			scope := scopeKeyFromRef(ref)
+			entry := cached[scope]
+			if !entry.found && !c.cacheMisses {
+				delete(c.entries[normalized], scope)
+				continue
+			}
+			c.entries[normalized][scope] = entry
+		}
+		c.mu.Unlock()
+	}
+
+	out := make([]OverrideMatch, 0, len(chain))
+	for _, ref := range chain {
+		if entry := cached[scopeKeyFromRef(ref)]; entry.found {
+			out = append(out, OverrideMatch{Scope: ref, Override: entry.override})
+		}
+	}
+	return out, nil
+}
+
+// Invalidate drops the cached entry for a key and scope.
+func (c *CachedReader) Invalidate(key string, scopeRef gate.ScopeRef) {
+	if c == nil {
+		return
+	}
+	scope := scopeKeyFromRef(scopeRef)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries[gate.NormalizeKey(key)], scope)
+	delete(c.entries[strings.TrimSpace(key)], scope)
+}
+
+// InvalidateKey drops every cached entry for a key.
+func (c *CachedReader) InvalidateKey(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, gate.NormalizeKey(key))
+	delete(c.entries, strings.TrimSpace(key))
+}
+
+// Clear drops all cached entries.
+func (c *CachedReader) Clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]map[scopeKey]cachedEntry{}
+}
+
+// CachedReadWriter is a CachedReader whose writes pass through to the inner
+// writer and invalidate the affected entry.
+type CachedReadWriter struct {
+	*CachedReader
+	writer Writer
+}
+
+// NewCachedReadWriter wraps inner with a read-through cache invalidated on writes.
+func NewCachedReadWriter(inner ReadWriter, opts ...CacheOption) *CachedReadWriter {
+	return &CachedReadWriter{
+		CachedReader: NewCachedReader(inner, opts...),
+		writer:       inner,
+	}
+}
+
+// Set implements Writer.
+func (c *CachedReadWriter) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if c == nil || c.writer == nil {
+		return storeRequiredError("cached", key, scopeRef, "set")
+	}
+	defer c.Invalidate(key, scopeRef)
+	return c.writer.Set(ctx, key, scopeRef, enabled, actor, opts...)
+}
+
+// Unset implements Writer.
+func (c *CachedReadWriter) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	if c == nil || c.writer == nil {
+		return storeRequiredError("cached", key, scopeRef, "unset")
+	}
+	defer c.Invalidate(key, scopeRef)
+	return c.writer.Unset(ctx, key, scopeRef, actor)
+}
+
+// GetLimits implements LimitReader when the inner store does. Limits are not cached.
+func (c *CachedReader) GetLimits(ctx context.Context, key string, chain gate.ScopeChain) ([]LimitMatch, error) {
+	var limits LimitReader
+	if c != nil {
+		limits, _ = c.inner.(LimitReader)
+	}
+	if limits == nil {
+		return nil, storeRequiredError("cached", key, gate.ScopeRef{}, "get_limits")
+	}
+	return limits.GetLimits(ctx, key, chain)
+}
+
+// SetLimit implements LimitWriter when the inner writer does.
+func (c *CachedReadWriter) SetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, value int64, actor gate.ActorRef) error {
+	var limits LimitWriter
+	if c != nil {
+		limits, _ = c.writer.(LimitWriter)
+	}
+	if limits == nil {
+		return storeRequiredError("cached", key, scopeRef, "set_limit")
+	}
+	return limits.SetLimit(ctx, key, scopeRef, value, actor)
+}
+
+// UnsetLimit implements LimitWriter when the inner writer does.
+func (c *CachedReadWriter) UnsetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	var limits LimitWriter
+	if c != nil {
+		limits, _ = c.writer.(LimitWriter)
+	}
+	if limits == nil {
+		return storeRequiredError("cached", key, scopeRef, "unset_limit")
+	}
+	return limits.UnsetLimit(ctx, key, scopeRef, actor)
+}
+
+var (
+	_ Reader     = (*CachedReader)(nil)
+	_ ReadWriter = (*CachedReadWriter)(nil)
+)
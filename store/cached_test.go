@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+type countingReader struct {
+	ReadWriter
+	reads int
+}
+
+func (c *countingReader) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
+	c.reads++
+	return c.ReadWriter.GetAll(ctx, key, chain)
+}
+
+func TestCachedReaderServesFromCacheUntilInvalidated(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingReader{ReadWriter: NewMemoryStore()}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cached := NewCachedReadWriter(inner, WithCacheTTL(time.Minute), WithCacheNowFunc(func() time.Time { return now }))
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	chain := gate.ScopeChain{tenant, {Kind: gate.ScopeSystem}}
+
+	if err := cached.Set(ctx, "dashboard", tenant, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		matches, err := cached.GetAll(ctx, "dashboard", chain)
+		if err != nil || len(matches) != 1 || matches[0].Scope != tenant {
+			t.Fatalf("unexpected matches: %+v (%v)", matches, err)
+		}
+	}
+	if inner.reads != 1 {
+		t.Fatalf("expected a single inner read, got %d", inner.reads)
+	}
+
+	if err := cached.Set(ctx, "dashboard", tenant, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	matches, _ := cached.GetAll(ctx, "dashboard", chain)
+	if inner.reads != 2 || len(matches) != 1 || matches[0].Override.Value {
+		t.Fatalf("expected invalidated read of disabled override, got %+v after %d reads", matches, inner.reads)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := cached.GetAll(ctx, "dashboard", chain); err != nil || inner.reads != 3 {
+		t.Fatalf("expected expired entries to be re-read, got %d reads (%v)", inner.reads, err)
+	}
+}
+
+func TestCachedReaderNegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := WithCacheNowFunc(func() time.Time { return now })
+	chain := gate.ScopeChain{{Kind: gate.ScopeSystem}}
+
+	inner := &countingReader{ReadWriter: NewMemoryStore()}
+	cached := NewCachedReader(inner, WithCacheTTL(time.Minute), WithNegativeCacheTTL(10*time.Second), clock)
+	for i := 0; i < 3; i++ {
+		if matches, err := cached.GetAll(ctx, "missing", chain); err != nil || len(matches) != 0 {
+			t.Fatalf("unexpected matches: %+v (%v)", matches, err)
+		}
+	}
+	if inner.reads != 1 {
+		t.Fatalf("expected misses to be cached, got %d reads", inner.reads)
+	}
+	now = now.Add(20 * time.Second)
+	if _, err := cached.GetAll(ctx, "missing", chain); err != nil || inner.reads != 2 {
+		t.Fatalf("expected miss to expire with its own ttl, got %d reads (%v)", inner.reads, err)
+	}
+
+	inner = &countingReader{ReadWriter: NewMemoryStore()}
+	cached = NewCachedReader(inner, WithoutNegativeCache(), clock)
+	for i := 0; i < 3; i++ {
+		if _, err := cached.GetAll(ctx, "missing", chain); err != nil {
+			t.Fatalf("get_all: %v", err)
+		}
+	}
+	if inner.reads != 3 {
+		t.Fatalf("expected every miss to reach the store, got %d reads", inner.reads)
+	}
+}
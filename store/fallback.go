@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	goerrors "github.com/goliatone/go-errors"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// FallbackStore reads from a chain of stores, trying each in order until one
+// answers, and writes to the primary. A Redis primary with a SQL fallback keeps
+// flags resolving while Redis is down. Only failures fall through: a store that
+// answers without an override ends the lookup.
+type FallbackStore struct {
+	stores []Reader
+}
+
+// Fallback returns a store reading from primary, then from each fallback in order
+// when the stores before it fail. Writes go to primary.
+func Fallback(primary Reader, fallbacks ...Reader) *FallbackStore {
+	stores := make([]Reader, 0, len(fallbacks)+1)
+	for _, reader := range append([]Reader{primary}, fallbacks...) {
+		if reader != nil {
+			stores = append(stores, reader)
+		}
+	}
+	return &FallbackStore{stores: stores}
+}
+
+// GetAll implements Reader.
+func (f *FallbackStore) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
+	if f == nil || len(f.stores) == 0 {
+		return nil, storeRequiredError("fallback", key, gate.ScopeRef{}, "get_all")
+	}
+	var errs []error
+	for _, reader := range f.stores {
+		matches, err := reader.GetAll(ctx, key, chain)
+		if err == nil {
+			return matches, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, fallbackError(key, "get_all", errs)
+}
+
+// GetLimits implements LimitReader, trying the stores that read limits.
+func (f *FallbackStore) GetLimits(ctx context.Context, key string, chain gate.ScopeChain) ([]LimitMatch, error) {
+	var errs []error
+	if f != nil {
+		for _, reader := range f.stores {
+			limits, ok := reader.(LimitReader)
+			if !ok {
+				continue
+			}
+			matches, err := limits.GetLimits(ctx, key, chain)
+			if err == nil {
+				return matches, nil
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil, storeRequiredError("fallback", key, gate.ScopeRef{}, "get_limits")
+	}
+	return nil, fallbackError(key, "get_limits", errs)
+}
+
+// List implements Lister, trying the stores that list overrides.
+func (f *FallbackStore) List(ctx context.Context, filter ListFilter) ([]OverrideRecord, error) {
+	var errs []error
+	if f != nil {
+		for _, reader := range f.stores {
+			lister, ok := reader.(Lister)
+			if !ok {
+				continue
+			}
+			records, err := lister.List(ctx, filter)
+			if err == nil {
+				return records, nil
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil, ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "store: no fallback store can list", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(filter.Key),
+			ferrors.MetaStore:      "fallback",
+			ferrors.MetaOperation:  "list",
+		})
+	}
+	return nil, fallbackError(filter.Key, "list", errs)
+}
+
+// Set implements Writer, writing to the primary.
+func (f *FallbackStore) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	writer, ok := f.primary().(Writer)
+	if !ok {
+		return storeRequiredError("fallback", key, scopeRef, "set")
+	}
+	return writer.Set(ctx, key, scopeRef, enabled, actor, opts...)
+}
+
+// Unset implements Writer, writing to the primary.
+func (f *FallbackStore) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	writer, ok := f.primary().(Writer)
+	if !ok {
+		return storeRequiredError("fallback", key, scopeRef, "unset")
+	}
+	return writer.Unset(ctx, key, scopeRef, actor)
+}
+
+// WriteBatch implements BatchWriter, writing to the primary.
+func (f *FallbackStore) WriteBatch(ctx context.Context, mutations []Mutation) error {
+	writer, ok := f.primary().(Writer)
+	if !ok {
+		return storeRequiredError("fallback", "", gate.ScopeRef{}, "write_batch")
+	}
+	if batch, ok := writer.(BatchWriter); ok {
+		return batch.WriteBatch(ctx, mutations)
+	}
+	return ApplyMutations(ctx, writer, mutations)
+}
+
+// SetLimit implements LimitWriter, writing to the primary.
+func (f *FallbackStore) SetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, value int64, actor gate.ActorRef) error {
+	limits, ok := f.primary().(LimitWriter)
+	if !ok {
+		return storeRequiredError("fallback", key, scopeRef, "set_limit")
+	}
+	return limits.SetLimit(ctx, key, scopeRef, value, actor)
+}
+
+// UnsetLimit implements LimitWriter, writing to the primary.
+func (f *FallbackStore) UnsetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	limits, ok := f.primary().(LimitWriter)
+	if !ok {
+		return storeRequiredError("fallback", key, scopeRef, "unset_limit")
+	}
+	return limits.UnsetLimit(ctx, key, scopeRef, actor)
+}
+
+func (f *FallbackStore) primary() Reader {
+	if f == nil || len(f.stores) == 0 {
+		return nil
+	}
+	return f.stores[0]
+}
+
+// fallbackError reports that every store failed. The per-store messages are
+// recorded in order under ferrors.MetaStoreErrors, and errors.Is matches any of
+// the underlying errors.
+func fallbackError(key, operation string, errs []error) error {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = strconv.Itoa(i) + ": " + err.Error()
+	}
+	return ferrors.Wrap(errors.Join(errs...), goerrors.CategoryExternal, ferrors.TextCodeStoreReadFailed, "store: every fallback store failed", map[string]any{
+		ferrors.MetaFeatureKey:  strings.TrimSpace(key),
+		ferrors.MetaStore:       "fallback",
+		ferrors.MetaOperation:   operation,
+		ferrors.MetaStoreErrors: messages,
+	})
+}
+
+var (
+	_ ReadWriter  = (*FallbackStore)(nil)
+	_ Lister      = (*FallbackStore)(nil)
+	_ BatchWriter = (*FallbackStore)(nil)
+	_ LimitReader = (*FallbackStore)(nil)
+	_ LimitWriter = (*FallbackStore)(nil)
+)
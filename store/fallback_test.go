@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+type downStore struct {
+	ReadWriter
+	err error
+}
+
+func (d downStore) GetAll(context.Context, string, gate.ScopeChain) ([]OverrideMatch, error) {
+	return nil, d.err
+}
+
+func TestFallbackReadsInOrderAndWritesToPrimary(t *testing.T) {
+	ctx := context.Background()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	chain := gate.ScopeChain{tenant}
+	primary := &flakyStore{ReadWriter: NewMemoryStore(), failures: 1, err: syscall.ECONNREFUSED}
+	secondary := NewMemoryStore()
+	if err := secondary.Set(ctx, "checkout", tenant, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	fallback := Fallback(primary, secondary)
+
+	matches, err := fallback.GetAll(ctx, "checkout", chain)
+	if err != nil || len(matches) != 1 || !matches[0].Override.Value {
+		t.Fatalf("expected the secondary to answer while the primary is down, got %+v (%v)", matches, err)
+	}
+	matches, err = fallback.GetAll(ctx, "checkout", chain)
+	if err != nil || len(matches) != 0 {
+		t.Fatalf("expected the recovered primary to answer, even without an override, got %+v (%v)", matches, err)
+	}
+
+	if err := fallback.Set(ctx, "checkout", tenant, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if stored, _ := primary.ReadWriter.GetAll(ctx, "checkout", chain); len(stored) != 1 || stored[0].Override.Value {
+		t.Fatalf("expected the write on the primary, got %+v", stored)
+	}
+	if stored, _ := secondary.GetAll(ctx, "checkout", chain); len(stored) != 1 || !stored[0].Override.Value {
+		t.Fatalf("expected the secondary untouched, got %+v", stored)
+	}
+}
+
+func TestFallbackAggregatesStoreErrors(t *testing.T) {
+	refused := errors.New("redis: connection refused")
+	timeout := errors.New("sql: timeout")
+	fallback := Fallback(downStore{err: refused}, downStore{err: timeout})
+
+	_, err := fallback.GetAll(context.Background(), "checkout", nil)
+	if !errors.Is(err, refused) || !errors.Is(err, timeout) {
+		t.Fatalf("expected both store errors to be wrapped, got %v", err)
+	}
+	messages, ok := ferrors.StoreErrors(err)
+	if !ok || len(messages) != 2 || messages[0] != "0: redis: connection refused" || messages[1] != "1: sql: timeout" {
+		t.Fatalf("unexpected store errors: %v", messages)
+	}
+	if op, _ := ferrors.Operation(err); op != "get_all" {
+		t.Fatalf("expected the operation in metadata, got %q", op)
+	}
+}
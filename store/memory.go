@@ -2,9 +2,12 @@ package store
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/goliatone/go-featuregate/clock"
 	"github.com/goliatone/go-featuregate/ferrors"
 	"github.com/goliatone/go-featuregate/gate"
 )
@@ -15,10 +18,28 @@ var ErrMemoryStoreRequired = ferrors.ErrStoreRequired
 // ErrInvalidKey signals a missing or invalid feature key.
 var ErrInvalidKey = ferrors.ErrInvalidKey
 
-// MemoryStore keeps overrides in memory for tests and examples.
+// MemoryStore keeps overrides in memory for tests and examples. Overrides are keyed
+// by the full scope ref, tenant and org qualifiers included, and GetAll matches the
+// refs of a resolver chain exactly as the SQL stores do, so role and perm overrides
+// behave the same in tests as in production.
 type MemoryStore struct {
 	mu      sync.RWMutex
 	entries map[string]map[scopeKey]Override
+	limits  map[string]map[scopeKey]int64
+	now     func() time.Time
+}
+
+// MemoryOption customizes a MemoryStore.
+type MemoryOption func(*MemoryStore)
+
+// WithMemoryClock sets the clock used to turn TTLs into expiry times.
+func WithMemoryClock(c clock.Clock) MemoryOption {
+	return func(m *MemoryStore) {
+		if m == nil {
+			return
+		}
+		m.now = clock.NowFunc(c)
+	}
 }
 
 type scopeKey struct {
@@ -29,16 +50,28 @@ type scopeKey struct {
 }
 
 // NewMemoryStore constructs an in-memory override store.
-func NewMemoryStore() *MemoryStore {
-	return &MemoryStore{entries: map[string]map[scopeKey]Override{}}
+func NewMemoryStore(opts ...MemoryOption) *MemoryStore {
+	m := &MemoryStore{
+		entries: map[string]map[scopeKey]Override{},
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	if m.now == nil {
+		m.now = time.Now
+	}
+	return m
 }
 
 // GetAll implements Reader.
 func (m *MemoryStore) GetAll(_ context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
 	if m == nil {
-		return nil, storeRequiredError(key, gate.ScopeRef{}, "get_all")
+		return nil, storeRequiredError("memory", key, gate.ScopeRef{}, "get_all")
 	}
-	normalized, err := normalizeKey(key)
+	normalized, err := storedKey(key)
 	if err != nil {
 		return nil, err
 	}
@@ -65,9 +98,9 @@ func (m *MemoryStore) GetAll(_ context.Context, key string, chain gate.ScopeChai
 }
 
 // Set implements Writer.
-func (m *MemoryStore) Set(_ context.Context, key string, scopeRef gate.ScopeRef, enabled bool, _ gate.ActorRef) error {
+func (m *MemoryStore) Set(_ context.Context, key string, scopeRef gate.ScopeRef, enabled bool, _ gate.ActorRef, opts ...gate.MutationOption) error {
 	if m == nil {
-		return storeRequiredError(key, scopeRef, "set")
+		return storeRequiredError("memory", key, scopeRef, "set")
 	}
 	normalized, err := normalizeKey(key)
 	if err != nil {
@@ -77,6 +110,14 @@ func (m *MemoryStore) Set(_ context.Context, key string, scopeRef gate.ScopeRef,
 	if enabled {
 		override = EnabledOverride()
 	}
+	req := gate.ApplyMutationOptions(opts...)
+	now := time.Now
+	if m.now != nil {
+		now = m.now
+	}
+	override.ExpiresAt = req.Expiry(now())
+	override.Metadata = req.Metadata
+	override.Priority = req.Priority
 	scope := scopeKeyFromRef(scopeRef)
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -93,9 +134,9 @@ func (m *MemoryStore) Set(_ context.Context, key string, scopeRef gate.ScopeRef,
 // Unset implements Writer.
 func (m *MemoryStore) Unset(_ context.Context, key string, scopeRef gate.ScopeRef, _ gate.ActorRef) error {
 	if m == nil {
-		return storeRequiredError(key, scopeRef, "unset")
+		return storeRequiredError("memory", key, scopeRef, "unset")
 	}
-	normalized, err := normalizeKey(key)
+	normalized, err := storedKey(key)
 	if err != nil {
 		return err
 	}
@@ -112,6 +153,115 @@ func (m *MemoryStore) Unset(_ context.Context, key string, scopeRef gate.ScopeRe
 	return nil
 }
 
+// GetLimits implements LimitReader.
+func (m *MemoryStore) GetLimits(_ context.Context, key string, chain gate.ScopeChain) ([]LimitMatch, error) {
+	if m == nil {
+		return nil, storeRequiredError("memory", key, gate.ScopeRef{}, "get_limits")
+	}
+	normalized, err := storedKey(key)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	limits := m.limits[normalized]
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	var matches []LimitMatch
+	for _, ref := range chain {
+		if value, ok := limits[scopeKeyFromRef(ref)]; ok {
+			matches = append(matches, LimitMatch{Scope: ref, Value: value})
+		}
+	}
+	return matches, nil
+}
+
+// SetLimit implements LimitWriter.
+func (m *MemoryStore) SetLimit(_ context.Context, key string, scopeRef gate.ScopeRef, value int64, _ gate.ActorRef) error {
+	if m == nil {
+		return storeRequiredError("memory", key, scopeRef, "set_limit")
+	}
+	normalized, err := normalizeKey(key)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.limits == nil {
+		m.limits = map[string]map[scopeKey]int64{}
+	}
+	if m.limits[normalized] == nil {
+		m.limits[normalized] = map[scopeKey]int64{}
+	}
+	m.limits[normalized][scopeKeyFromRef(scopeRef)] = value
+	return nil
+}
+
+// UnsetLimit implements LimitWriter.
+func (m *MemoryStore) UnsetLimit(_ context.Context, key string, scopeRef gate.ScopeRef, _ gate.ActorRef) error {
+	if m == nil {
+		return storeRequiredError("memory", key, scopeRef, "unset_limit")
+	}
+	normalized, err := storedKey(key)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.limits[normalized], scopeKeyFromRef(scopeRef))
+	return nil
+}
+
+// List implements Lister. Records are sorted by key, then scope.
+func (m *MemoryStore) List(_ context.Context, filter ListFilter) ([]OverrideRecord, error) {
+	if m == nil {
+		return nil, storeRequiredError("memory", filter.Key, gate.ScopeRef{}, "list")
+	}
+	key := ""
+	if strings.TrimSpace(filter.Key) != "" {
+		normalized, err := normalizeKey(filter.Key)
+		if err != nil {
+			return nil, err
+		}
+		key = normalized
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	records := make([]OverrideRecord, 0)
+	for entryKey, entries := range m.entries {
+		if key != "" && entryKey != key {
+			continue
+		}
+		for scope, override := range entries {
+			if !override.Metadata.MatchesLabels(filter.Labels) {
+				continue
+			}
+			records = append(records, OverrideRecord{
+				Key: entryKey,
+				Scope: gate.ScopeRef{
+					Kind:     scope.kind,
+					ID:       scope.id,
+					TenantID: scope.tenantID,
+					OrgID:    scope.orgID,
+				},
+				Override: override,
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Scope.Kind != b.Scope.Kind {
+			return a.Scope.Kind < b.Scope.Kind
+		}
+		return a.Scope.ID < b.Scope.ID
+	})
+	return records, nil
+}
+
 // Delete removes a stored override entirely.
 func (m *MemoryStore) Delete(key string, scopeRef gate.ScopeRef) bool {
 	if m == nil {
@@ -146,11 +296,21 @@ func (m *MemoryStore) Clear() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.entries = map[string]map[scopeKey]Override{}
+	m.limits = nil
 }
 
 func normalizeKey(key string) (string, error) {
+	return checkKey(strings.TrimSpace(key), gate.NormalizeKey(key))
+}
+
+// storedKey trims key without resolving aliases. Reads and unsets use it so rows
+// written under a legacy key before gate.RegisterAliases stay addressable.
+func storedKey(key string) (string, error) {
 	trimmed := strings.TrimSpace(key)
-	normalized := gate.NormalizeKey(trimmed)
+	return checkKey(trimmed, trimmed)
+}
+
+func checkKey(trimmed, normalized string) (string, error) {
 	if normalized == "" {
 		return "", ferrors.WrapSentinel(ferrors.ErrInvalidKey, "store: feature key required", map[string]any{
 			ferrors.MetaFeatureKey:           trimmed,
@@ -179,16 +339,21 @@ func scopeKeyFromRef(ref gate.ScopeRef) scopeKey {
 	}
 }
 
-var _ ReadWriter = (*MemoryStore)(nil)
+var (
+	_ ReadWriter  = (*MemoryStore)(nil)
+	_ Lister      = (*MemoryStore)(nil)
+	_ LimitReader = (*MemoryStore)(nil)
+	_ LimitWriter = (*MemoryStore)(nil)
+)
 
-func storeRequiredError(key string, scopeRef gate.ScopeRef, operation string) error {
+func storeRequiredError(name, key string, scopeRef gate.ScopeRef, operation string) error {
 	trimmed := strings.TrimSpace(key)
 	normalized := gate.NormalizeKey(trimmed)
-	return ferrors.WrapSentinel(ferrors.ErrStoreRequired, "store: memory store is required", map[string]any{
+	return ferrors.WrapSentinel(ferrors.ErrStoreRequired, "store: "+name+" store is required", map[string]any{
 		ferrors.MetaFeatureKey:           trimmed,
 		ferrors.MetaFeatureKeyNormalized: normalized,
 		ferrors.MetaScope:                scopeRef,
-		ferrors.MetaStore:                "memory",
+		ferrors.MetaStore:                name,
 		ferrors.MetaOperation:            operation,
 	})
 }
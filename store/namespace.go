@@ -0,0 +1,181 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// NamespaceSeparator joins a namespace and a feature key in stored keys.
+const NamespaceSeparator = ":"
+
+// NamespacedStore prefixes keys with a namespace before they reach the inner
+// store and strips it from listed records, so several services can share one
+// table without their keys colliding.
+type NamespacedStore struct {
+	inner     Reader
+	writer    Writer
+	namespace string
+}
+
+// Namespaced wraps inner so its keys live under namespace. Writes go through inner
+// when it is also a Writer. An empty namespace returns a pass-through wrapper.
+func Namespaced(inner Reader, namespace string) *NamespacedStore {
+	n := &NamespacedStore{inner: inner, namespace: strings.TrimSpace(namespace)}
+	if writer, ok := inner.(Writer); ok {
+		n.writer = writer
+	}
+	return n
+}
+
+// NamespacedWriter wraps a Writer that has no read side, for gates configured
+// with separate override readers and writers.
+func NamespacedWriter(writer Writer, namespace string) *NamespacedStore {
+	n := &NamespacedStore{writer: writer, namespace: strings.TrimSpace(namespace)}
+	if reader, ok := writer.(Reader); ok {
+		n.inner = reader
+	}
+	return n
+}
+
+// Namespace returns the namespace keys are stored under.
+func (n *NamespacedStore) Namespace() string {
+	if n == nil {
+		return ""
+	}
+	return n.namespace
+}
+
+// GetAll implements Reader.
+func (n *NamespacedStore) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
+	if n == nil || n.inner == nil {
+		return nil, storeRequiredError("namespaced", key, gate.ScopeRef{}, "get_all")
+	}
+	return n.inner.GetAll(ctx, n.prefix(key), chain)
+}
+
+// Set implements Writer.
+func (n *NamespacedStore) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if n == nil || n.writer == nil {
+		return storeRequiredError("namespaced", key, scopeRef, "set")
+	}
+	return n.writer.Set(ctx, n.prefix(key), scopeRef, enabled, actor, opts...)
+}
+
+// Unset implements Writer.
+func (n *NamespacedStore) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	if n == nil || n.writer == nil {
+		return storeRequiredError("namespaced", key, scopeRef, "unset")
+	}
+	return n.writer.Unset(ctx, n.prefix(key), scopeRef, actor)
+}
+
+// WriteBatch implements BatchWriter, prefixing every mutation key.
+func (n *NamespacedStore) WriteBatch(ctx context.Context, mutations []Mutation) error {
+	if n == nil || n.writer == nil {
+		return storeRequiredError("namespaced", "", gate.ScopeRef{}, "write_batch")
+	}
+	prefixed := make([]Mutation, len(mutations))
+	for i, m := range mutations {
+		m.Key = n.prefix(m.Key)
+		prefixed[i] = m
+	}
+	return ApplyMutations(ctx, n.writer, prefixed)
+}
+
+// GetLimits implements LimitReader when the inner store does.
+func (n *NamespacedStore) GetLimits(ctx context.Context, key string, chain gate.ScopeChain) ([]LimitMatch, error) {
+	var limits LimitReader
+	if n != nil {
+		limits, _ = n.inner.(LimitReader)
+	}
+	if limits == nil {
+		return nil, storeRequiredError("namespaced", key, gate.ScopeRef{}, "get_limits")
+	}
+	return limits.GetLimits(ctx, n.prefix(key), chain)
+}
+
+// SetLimit implements LimitWriter when the inner store does.
+func (n *NamespacedStore) SetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, value int64, actor gate.ActorRef) error {
+	var limits LimitWriter
+	if n != nil {
+		limits, _ = n.writer.(LimitWriter)
+	}
+	if limits == nil {
+		return storeRequiredError("namespaced", key, scopeRef, "set_limit")
+	}
+	return limits.SetLimit(ctx, n.prefix(key), scopeRef, value, actor)
+}
+
+// UnsetLimit implements LimitWriter when the inner store does.
+func (n *NamespacedStore) UnsetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	var limits LimitWriter
+	if n != nil {
+		limits, _ = n.writer.(LimitWriter)
+	}
+	if limits == nil {
+		return storeRequiredError("namespaced", key, scopeRef, "unset_limit")
+	}
+	return limits.UnsetLimit(ctx, n.prefix(key), scopeRef, actor)
+}
+
+// List implements Lister. Only records inside the namespace are returned, with the
+// namespace stripped from their keys.
+func (n *NamespacedStore) List(ctx context.Context, filter ListFilter) ([]OverrideRecord, error) {
+	lister, ok := n.listSource()
+	if !ok {
+		return nil, ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "store: namespaced inner store cannot list", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(filter.Key),
+			ferrors.MetaStore:      "namespaced",
+			ferrors.MetaOperation:  "list",
+		})
+	}
+	if strings.TrimSpace(filter.Key) != "" {
+		filter.Key = n.prefix(filter.Key)
+	}
+	records, err := lister.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]OverrideRecord, 0, len(records))
+	for _, record := range records {
+		key, ok := n.strip(record.Key)
+		if !ok {
+			continue
+		}
+		record.Key = key
+		out = append(out, record)
+	}
+	return out, nil
+}
+
+func (n *NamespacedStore) listSource() (Lister, bool) {
+	if n == nil || n.inner == nil {
+		return nil, false
+	}
+	lister, ok := n.inner.(Lister)
+	return lister, ok
+}
+
+func (n *NamespacedStore) prefix(key string) string {
+	key = strings.TrimSpace(key)
+	if n.namespace == "" || key == "" {
+		return key
+	}
+	return n.namespace + NamespaceSeparator + key
+}
+
+func (n *NamespacedStore) strip(key string) (string, bool) {
+	if n.namespace == "" {
+		return key, true
+	}
+	return strings.CutPrefix(key, n.namespace+NamespaceSeparator)
+}
+
+var (
+	_ ReadWriter  = (*NamespacedStore)(nil)
+	_ Lister      = (*NamespacedStore)(nil)
+	_ BatchWriter = (*NamespacedStore)(nil)
+)
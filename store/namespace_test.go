@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func TestNamespacedStoresKeepServicesApart(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemoryStore()
+	billing := Namespaced(shared, "billing-svc")
+	search := Namespaced(shared, "search-svc")
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	chain := gate.ScopeChain{system}
+
+	if err := billing.Set(ctx, "checkout", system, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("set billing: %v", err)
+	}
+	if err := search.Set(ctx, "checkout", system, false, gate.ActorRef{}); err != nil {
+		t.Fatalf("set search: %v", err)
+	}
+	if matches, err := billing.GetAll(ctx, "checkout", chain); err != nil || len(matches) != 1 || !matches[0].Override.Value {
+		t.Fatalf("expected billing override, got %+v (%v)", matches, err)
+	}
+	if matches, _ := shared.GetAll(ctx, "billing-svc:checkout", chain); len(matches) != 1 {
+		t.Fatalf("expected the inner store to hold the prefixed key, got %+v", matches)
+	}
+
+	records, err := search.List(ctx, ListFilter{})
+	if err != nil || len(records) != 1 || records[0].Key != "checkout" || records[0].Override.Value {
+		t.Fatalf("expected only the search record with a stripped key, got %+v (%v)", records, err)
+	}
+	if records, _ := billing.List(ctx, ListFilter{Key: "checkout"}); len(records) != 1 || records[0].Key != "checkout" {
+		t.Fatalf("expected key filter to apply inside the namespace, got %+v", records)
+	}
+
+	if err := ApplyMutations(ctx, billing, []Mutation{{Key: "search", Scope: system}}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if matches, _ := shared.GetAll(ctx, "billing-svc:search", chain); len(matches) != 1 || matches[0].Override.State != gate.OverrideStateUnset {
+		t.Fatalf("expected batched unset under the namespace, got %+v", matches)
+	}
+}
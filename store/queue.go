@@ -0,0 +1,587 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/idgen"
+)
+
+const (
+	// DefaultQueueBatchSize caps the mutations written per flush.
+	DefaultQueueBatchSize = 100
+	// DefaultQueueInterval is the delay between background flushes.
+	DefaultQueueInterval = 100 * time.Millisecond
+	// DefaultQueueMaxPending caps the mutations held in memory.
+	DefaultQueueMaxPending = 10000
+
+	TextCodeQueueFull   = "STORE_QUEUE_FULL"
+	TextCodeQueueClosed = "STORE_QUEUE_CLOSED"
+)
+
+// Mutation is a single queued override write. A nil Enabled is an unset.
+type Mutation struct {
+	ID        string                `json:"id,omitempty"`
+	Key       string                `json:"key"`
+	Scope     gate.ScopeRef         `json:"scope"`
+	Enabled   *bool                 `json:"enabled,omitempty"`
+	Actor     gate.ActorRef         `json:"actor"`
+	ExpiresAt time.Time             `json:"expires_at,omitempty"`
+	Metadata  gate.OverrideMetadata `json:"metadata,omitempty"`
+	Priority  int                   `json:"priority,omitempty"`
+}
+
+// BatchWriter applies several mutations in one round trip. Mutations never
+// repeat a key and scope within a batch.
+type BatchWriter interface {
+	WriteBatch(ctx context.Context, mutations []Mutation) error
+}
+
+// ApplyMutations writes mutations in order, using BatchWriter when the writer supports it.
+func ApplyMutations(ctx context.Context, w Writer, mutations []Mutation) error {
+	if len(mutations) == 0 {
+		return nil
+	}
+	if batch, ok := w.(BatchWriter); ok {
+		return batch.WriteBatch(ctx, mutations)
+	}
+	for _, m := range mutations {
+		if err := applyMutation(ctx, w, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyMutation(ctx context.Context, w Writer, m Mutation) error {
+	if m.Enabled == nil {
+		return w.Unset(ctx, m.Key, m.Scope, m.Actor)
+	}
+	var opts []gate.MutationOption
+	if !m.ExpiresAt.IsZero() {
+		opts = append(opts, gate.WithExpiresAt(m.ExpiresAt))
+	}
+	if !m.Metadata.IsZero() {
+		opts = append(opts, gate.WithMetadata(m.Metadata))
+	}
+	if m.Priority != 0 {
+		opts = append(opts, gate.WithPriority(m.Priority))
+	}
+	return w.Set(ctx, m.Key, m.Scope, *m.Enabled, m.Actor, opts...)
+}
+
+// Spill receives mutations the queue could not write: batches that failed,
+// writes rejected because the queue was full, and later writes to a key that
+// already spilled.
+type Spill interface {
+	Spill(ctx context.Context, mutations []Mutation) error
+}
+
+// SpillFunc adapts a function to Spill.
+type SpillFunc func(ctx context.Context, mutations []Mutation) error
+
+// Spill implements Spill.
+func (fn SpillFunc) Spill(ctx context.Context, mutations []Mutation) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, mutations)
+}
+
+// FlushNotifier is implemented by writers that apply mutations after the call
+// returns. The gate registers a handler to invalidate its cache once they land.
+type FlushNotifier interface {
+	OnFlushed(fn func(ctx context.Context, mutations []Mutation))
+}
+
+// WriteQueue buffers writes in front of a Writer and flushes them in batches.
+// Writes to the same key and scope are coalesced (last write wins) and batches are
+// written one at a time, so per-key ordering is preserved. The background loop
+// writes at most one batch per interval, which rate-limits the underlying store.
+//
+// A failed batch goes to the spill when one is configured; otherwise it stays at
+// the head of the queue and is retried on the next flush. Once a key and scope
+// spill, later writes to it follow into the spill until Replay, so a replayed
+// mutation never overwrites a newer one.
+//
+// Set and Unset return once the mutation is queued; reads from the underlying
+// store observe it after the next flush. Handlers registered with OnFlushed run
+// after each batch is written.
+type WriteQueue struct {
+	inner      Writer
+	batchSize  int
+	interval   time.Duration
+	maxPending int
+	spill      Spill
+	onError    func(error, []Mutation)
+	now        func() time.Time
+	ids        idgen.Generator
+
+	mu      sync.Mutex
+	pending []Mutation
+	index   map[mutationKey]int
+	closed  bool
+	held    map[mutationKey]struct{}
+	flushed []func(context.Context, []Mutation)
+
+	flushMu sync.Mutex
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+type mutationKey struct {
+	key   string
+	scope gate.ScopeRef
+}
+
+// QueueOption customizes a WriteQueue.
+type QueueOption func(*WriteQueue)
+
+// WithQueueBatchSize caps the mutations written per flush.
+func WithQueueBatchSize(size int) QueueOption {
+	return func(q *WriteQueue) {
+		if q == nil {
+			return
+		}
+		q.batchSize = size
+	}
+}
+
+// WithQueueInterval sets the delay between background flushes.
+func WithQueueInterval(interval time.Duration) QueueOption {
+	return func(q *WriteQueue) {
+		if q == nil {
+			return
+		}
+		q.interval = interval
+	}
+}
+
+// WithQueueMaxPending caps the mutations held in memory. Further writes go to the
+// spill, or fail with STORE_QUEUE_FULL when no spill is configured.
+func WithQueueMaxPending(limit int) QueueOption {
+	return func(q *WriteQueue) {
+		if q == nil {
+			return
+		}
+		q.maxPending = limit
+	}
+}
+
+// WithQueueSpill sets where undeliverable mutations are kept.
+func WithQueueSpill(spill Spill) QueueOption {
+	return func(q *WriteQueue) {
+		if q == nil {
+			return
+		}
+		q.spill = spill
+	}
+}
+
+// WithQueueErrorHandler observes background flush failures.
+func WithQueueErrorHandler(fn func(err error, mutations []Mutation)) QueueOption {
+	return func(q *WriteQueue) {
+		if q == nil {
+			return
+		}
+		q.onError = fn
+	}
+}
+
+// WithQueueClock sets the clock used to turn TTLs into expiry times.
+func WithQueueClock(c clock.Clock) QueueOption {
+	return func(q *WriteQueue) {
+		if q == nil {
+			return
+		}
+		q.now = clock.NowFunc(c)
+	}
+}
+
+// WithQueueIDGenerator sets the generator for Mutation IDs assigned on enqueue.
+// Defaults to UUIDv7.
+func WithQueueIDGenerator(gen idgen.Generator) QueueOption {
+	return func(q *WriteQueue) {
+		if q == nil {
+			return
+		}
+		q.ids = gen
+	}
+}
+
+// NewWriteQueue starts a queue in front of inner. Call Close to flush and stop it.
+func NewWriteQueue(inner Writer, opts ...QueueOption) *WriteQueue {
+	q := &WriteQueue{
+		inner:      inner,
+		batchSize:  DefaultQueueBatchSize,
+		interval:   DefaultQueueInterval,
+		maxPending: DefaultQueueMaxPending,
+		now:        time.Now,
+		index:      map[mutationKey]int{},
+		held:       map[mutationKey]struct{}{},
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(q)
+		}
+	}
+	if q.batchSize < 1 {
+		q.batchSize = DefaultQueueBatchSize
+	}
+	if q.interval <= 0 {
+		q.interval = DefaultQueueInterval
+	}
+	if q.maxPending < 1 {
+		q.maxPending = DefaultQueueMaxPending
+	}
+	if q.now == nil {
+		q.now = time.Now
+	}
+	go q.loop()
+	return q
+}
+
+// Set implements Writer by queueing the mutation.
+func (q *WriteQueue) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if q == nil {
+		return storeRequiredError("queue", key, scopeRef, "set")
+	}
+	req := gate.ApplyMutationOptions(opts...)
+	return q.Enqueue(ctx, Mutation{
+		Key:       key,
+		Scope:     scopeRef,
+		Enabled:   &enabled,
+		Actor:     actor,
+		ExpiresAt: req.Expiry(q.now()),
+		Metadata:  req.Metadata,
+		Priority:  req.Priority,
+	})
+}
+
+// Unset implements Writer by queueing the mutation.
+func (q *WriteQueue) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	return q.Enqueue(ctx, Mutation{Key: key, Scope: scopeRef, Actor: actor})
+}
+
+// Enqueue queues a mutation. Use Replay for mutations read from a spill.
+func (q *WriteQueue) Enqueue(ctx context.Context, m Mutation) error {
+	return q.enqueue(ctx, m, false)
+}
+
+// Replay queues spilled mutations in ID order (IDs sort by creation time) and
+// releases their keys, so later writes reach the store again. A replayed
+// mutation never replaces a newer pending write to the same key and scope.
+//
+// Replay a spill file at startup, before the queue takes other writes: the
+// queue only knows which keys spilled in this process.
+func (q *WriteQueue) Replay(ctx context.Context, mutations []Mutation) error {
+	if q == nil || q.inner == nil {
+		return storeRequiredError("queue", "", gate.ScopeRef{}, "replay")
+	}
+	ordered := slices.Clone(mutations)
+	slices.SortStableFunc(ordered, func(a, b Mutation) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+	q.mu.Lock()
+	for _, m := range ordered {
+		delete(q.held, mutationKey{key: m.Key, scope: m.Scope})
+	}
+	q.mu.Unlock()
+	var errs []error
+	for _, m := range ordered {
+		if err := q.enqueue(ctx, m, true); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (q *WriteQueue) enqueue(ctx context.Context, m Mutation, replay bool) error {
+	if q == nil || q.inner == nil {
+		return storeRequiredError("queue", m.Key, m.Scope, "enqueue")
+	}
+	normalized, err := normalizeKey(m.Key)
+	if err != nil {
+		return err
+	}
+	m.Key = normalized
+	if m.ID == "" {
+		m.ID = idgen.Or(q.ids).NewID()
+	}
+	id := mutationKey{key: normalized, scope: m.Scope}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ferrors.NewOperation(TextCodeQueueClosed, "store: write queue closed", map[string]any{
+			ferrors.MetaFeatureKey: normalized,
+			ferrors.MetaScope:      m.Scope,
+			ferrors.MetaOperation:  "enqueue",
+		})
+	}
+	if i, ok := q.index[id]; ok {
+		if !replay || q.pending[i].ID <= m.ID {
+			q.pending[i] = m
+		}
+		q.mu.Unlock()
+		return nil
+	}
+	if len(q.pending) >= q.maxPending {
+		q.mu.Unlock()
+		if q.spill != nil {
+			return q.spillAndHold(ctx, []Mutation{m})
+		}
+		return ferrors.NewOperation(TextCodeQueueFull, "store: write queue full", map[string]any{
+			ferrors.MetaFeatureKey: normalized,
+			ferrors.MetaScope:      m.Scope,
+			ferrors.MetaOperation:  "enqueue",
+		})
+	}
+	q.index[id] = len(q.pending)
+	q.pending = append(q.pending, m)
+	q.mu.Unlock()
+	return nil
+}
+
+// OnFlushed registers fn to run after each batch is written to the underlying
+// writer. It implements FlushNotifier.
+func (q *WriteQueue) OnFlushed(fn func(ctx context.Context, mutations []Mutation)) {
+	if q == nil || fn == nil {
+		return
+	}
+	q.mu.Lock()
+	q.flushed = append(q.flushed, fn)
+	q.mu.Unlock()
+}
+
+// Pending reports the number of queued mutations.
+func (q *WriteQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Flush writes every queued mutation, one batch at a time. It stops at the first
+// batch that can be neither written nor spilled; that batch stays queued.
+func (q *WriteQueue) Flush(ctx context.Context) error {
+	for {
+		flushed, err := q.flushBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if flushed == 0 {
+			return nil
+		}
+	}
+}
+
+// Close stops the background loop and flushes the remaining mutations. Mutations
+// that could not be written are reported in the returned error and dropped.
+func (q *WriteQueue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+	close(q.stop)
+	<-q.done
+	return q.Flush(ctx)
+}
+
+func (q *WriteQueue) loop() {
+	defer close(q.done)
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+			_, _ = q.flushBatch(context.Background())
+		}
+	}
+}
+
+// flushBatch writes up to batchSize mutations. Mutations for held keys and failed
+// writes go to the spill; whatever cannot be spilled is requeued, reported to the
+// error handler and returned.
+func (q *WriteQueue) flushBatch(ctx context.Context) (int, error) {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	q.mu.Lock()
+	n := min(len(q.pending), q.batchSize)
+	if n == 0 {
+		q.mu.Unlock()
+		return 0, nil
+	}
+	batch := append([]Mutation(nil), q.pending[:n]...)
+	q.pending = append(q.pending[:0], q.pending[n:]...)
+	q.reindex()
+	var ready, held []Mutation
+	for _, m := range batch {
+		if _, ok := q.held[mutationKey{key: m.Key, scope: m.Scope}]; ok {
+			held = append(held, m)
+			continue
+		}
+		ready = append(ready, m)
+	}
+	handlers := q.flushed
+	q.mu.Unlock()
+
+	var errs []error
+	if len(held) > 0 {
+		if err := q.spill.Spill(ctx, held); err != nil {
+			errs = append(errs, err)
+			q.requeue(held)
+		}
+	}
+	if len(ready) > 0 {
+		err := ApplyMutations(ctx, q.inner, ready)
+		switch {
+		case err == nil:
+			for _, fn := range handlers {
+				fn(ctx, ready)
+			}
+		case q.spill == nil:
+			errs = append(errs, err)
+			q.requeue(ready)
+		default:
+			if spillErr := q.spillAndHold(ctx, ready); spillErr != nil {
+				errs = append(errs, err, spillErr)
+				q.requeue(ready)
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return n, nil
+	}
+	err := ferrors.WrapExternal(errors.Join(errs...), ferrors.TextCodeStoreWriteFailed, "store: queued batch write failed", map[string]any{
+		ferrors.MetaStore:     "queue",
+		ferrors.MetaOperation: "flush",
+	})
+	if q.onError != nil {
+		q.onError(err, batch)
+	}
+	return n, err
+}
+
+// spillAndHold spills mutations and holds their keys, so later writes to them
+// follow into the spill instead of overtaking it.
+func (q *WriteQueue) spillAndHold(ctx context.Context, mutations []Mutation) error {
+	if err := q.spill.Spill(ctx, mutations); err != nil {
+		return err
+	}
+	q.mu.Lock()
+	for _, m := range mutations {
+		q.held[mutationKey{key: m.Key, scope: m.Scope}] = struct{}{}
+	}
+	q.mu.Unlock()
+	return nil
+}
+
+// requeue puts mutations back at the head of the queue, skipping any key and
+// scope that has a newer pending write.
+func (q *WriteQueue) requeue(mutations []Mutation) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	kept := make([]Mutation, 0, len(mutations)+len(q.pending))
+	for _, m := range mutations {
+		if _, ok := q.index[mutationKey{key: m.Key, scope: m.Scope}]; ok {
+			continue
+		}
+		kept = append(kept, m)
+	}
+	q.pending = append(kept, q.pending...)
+	q.reindex()
+}
+
+func (q *WriteQueue) reindex() {
+	q.index = make(map[mutationKey]int, len(q.pending))
+	for i, m := range q.pending {
+		q.index[mutationKey{key: m.Key, scope: m.Scope}] = i
+	}
+}
+
+// FileSpill appends spilled mutations to a JSON lines file and syncs after each write.
+type FileSpill struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSpill returns a spill that appends to path.
+func NewFileSpill(path string) *FileSpill {
+	return &FileSpill{path: path}
+}
+
+// Spill implements Spill.
+func (s *FileSpill) Spill(_ context.Context, mutations []Mutation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, m := range mutations {
+		if err := enc.Encode(m); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadSpillFile loads mutations written by FileSpill, in write order.
+// A missing file yields no mutations.
+func ReadSpillFile(path string) ([]Mutation, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var out []Mutation
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		m := Mutation{}
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			return out, err
+		}
+		out = append(out, m)
+	}
+	return out, scanner.Err()
+}
+
+var (
+	_ Writer        = (*WriteQueue)(nil)
+	_ FlushNotifier = (*WriteQueue)(nil)
+	_ Spill         = (*FileSpill)(nil)
+)
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+type batchRecorder struct {
+	*MemoryStore
+	batches [][]Mutation
+	err     error
+}
+
+func (b *batchRecorder) WriteBatch(ctx context.Context, mutations []Mutation) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.batches = append(b.batches, append([]Mutation(nil), mutations...))
+	for _, m := range mutations {
+		if err := applyMutation(ctx, b.MemoryStore, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestWriteQueueCoalescesAndBatches(t *testing.T) {
+	ctx := context.Background()
+	inner := &batchRecorder{MemoryStore: NewMemoryStore()}
+	q := NewWriteQueue(inner, WithQueueBatchSize(2), WithQueueInterval(time.Hour))
+	defer q.Close(ctx)
+
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme"}
+	for _, enabled := range []bool{true, false, true} {
+		if err := q.Set(ctx, "a", tenant, enabled, gate.ActorRef{}); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+	}
+	_ = q.Set(ctx, "b", tenant, true, gate.ActorRef{})
+	_ = q.Unset(ctx, "c", tenant, gate.ActorRef{})
+	if q.Pending() != 3 {
+		t.Fatalf("expected writes to one key to coalesce, got %d pending", q.Pending())
+	}
+	if err := q.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(inner.batches) != 2 || len(inner.batches[0]) != 2 || inner.batches[0][0].Key != "a" {
+		t.Fatalf("unexpected batches: %+v", inner.batches)
+	}
+	matches, _ := inner.GetAll(ctx, "a", gate.ScopeChain{tenant})
+	if len(matches) != 1 || !matches[0].Override.Value {
+		t.Fatalf("expected last write to win, got %+v", matches)
+	}
+}
+
+func TestWriteQueueSpillsFailedAndOverflowingWrites(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spill.jsonl")
+	inner := &batchRecorder{MemoryStore: NewMemoryStore(), err: errors.New("db down")}
+	q := NewWriteQueue(inner,
+		WithQueueMaxPending(1),
+		WithQueueInterval(time.Hour),
+		WithQueueSpill(NewFileSpill(path)),
+	)
+
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+	if err := q.Set(ctx, "first", system, true, gate.ActorRef{ID: "ops"}, gate.WithReason("rollout")); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := q.Set(ctx, "second", system, false, gate.ActorRef{ID: "ops"}); err != nil {
+		t.Fatalf("expected overflow to spill, got %v", err)
+	}
+	if err := q.Close(ctx); err != nil {
+		t.Fatalf("expected failed batch to spill, got %v", err)
+	}
+
+	spilled, err := ReadSpillFile(path)
+	if err != nil {
+		t.Fatalf("read spill: %v", err)
+	}
+	if len(spilled) != 2 || spilled[0].Key != "second" || spilled[1].Key != "first" || spilled[1].Metadata.Reason != "rollout" {
+		t.Fatalf("unexpected spill contents: %+v", spilled)
+	}
+	if spilled[1].ID >= spilled[0].ID {
+		t.Fatalf("expected IDs to record enqueue order, got %q then %q", spilled[1].ID, spilled[0].ID)
+	}
+
+	inner.err = nil
+	replay := NewWriteQueue(inner, WithQueueInterval(time.Hour))
+	if err := replay.Replay(ctx, spilled); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if err := replay.Close(ctx); err != nil {
+		t.Fatalf("flush replay: %v", err)
+	}
+	if len(inner.batches) != 1 || inner.batches[0][0].Key != "first" {
+		t.Fatalf("expected replay in ID order, got %+v", inner.batches)
+	}
+	matches, _ := inner.GetAll(ctx, "first", gate.ScopeChain{system})
+	if len(matches) != 1 || !matches[0].Override.Value {
+		t.Fatalf("expected replayed write, got %+v", matches)
+	}
+	if err := q.Set(ctx, "third", system, true, gate.ActorRef{}); err == nil {
+		t.Fatalf("expected closed queue to reject writes")
+	}
+}
+
+func TestWriteQueueHoldsSpilledKeysUntilReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spill.jsonl")
+	inner := &batchRecorder{MemoryStore: NewMemoryStore(), err: errors.New("db down")}
+	q := NewWriteQueue(inner, WithQueueInterval(time.Hour), WithQueueSpill(NewFileSpill(path)))
+	defer q.Close(ctx)
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+
+	_ = q.Set(ctx, "a", system, true, gate.ActorRef{})
+	if err := q.Flush(ctx); err != nil {
+		t.Fatalf("expected failed batch to spill, got %v", err)
+	}
+	inner.err = nil
+	_ = q.Set(ctx, "a", system, false, gate.ActorRef{})
+	_ = q.Set(ctx, "b", system, true, gate.ActorRef{})
+	if err := q.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if matches, _ := inner.GetAll(ctx, "a", gate.ScopeChain{system}); len(matches) != 0 {
+		t.Fatalf("expected the held key to stay out of the store, got %+v", matches)
+	}
+	if matches, _ := inner.GetAll(ctx, "b", gate.ScopeChain{system}); len(matches) != 1 {
+		t.Fatalf("expected other keys to be written, got %+v", matches)
+	}
+
+	spilled, err := ReadSpillFile(path)
+	if err != nil || len(spilled) != 2 {
+		t.Fatalf("expected both writes to a in the spill, got %+v (%v)", spilled, err)
+	}
+	slices.Reverse(spilled)
+	if err := q.Replay(ctx, spilled); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if err := q.Flush(ctx); err != nil {
+		t.Fatalf("flush replay: %v", err)
+	}
+	matches, _ := inner.GetAll(ctx, "a", gate.ScopeChain{system})
+	if len(matches) != 1 || matches[0].Override.Value {
+		t.Fatalf("expected the latest spilled write to win, got %+v", matches)
+	}
+}
+
+func TestWriteQueueKeepsFailedBatchesWithoutSpill(t *testing.T) {
+	ctx := context.Background()
+	inner := &batchRecorder{MemoryStore: NewMemoryStore(), err: errors.New("db down")}
+	var reported int
+	q := NewWriteQueue(inner,
+		WithQueueInterval(time.Hour),
+		WithQueueErrorHandler(func(error, []Mutation) { reported++ }),
+	)
+	defer q.Close(ctx)
+	system := gate.ScopeRef{Kind: gate.ScopeSystem}
+
+	_ = q.Set(ctx, "a", system, true, gate.ActorRef{})
+	_ = q.Set(ctx, "b", system, true, gate.ActorRef{})
+	if err := q.Flush(ctx); err == nil {
+		t.Fatal("expected the failed flush to return an error")
+	}
+	if q.Pending() != 2 || reported != 1 {
+		t.Fatalf("expected the failed batch to stay queued and be reported, got %d pending, %d reports", q.Pending(), reported)
+	}
+
+	inner.err = nil
+	_ = q.Set(ctx, "a", system, false, gate.ActorRef{})
+	if err := q.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	matches, _ := inner.GetAll(ctx, "a", gate.ScopeChain{system})
+	if len(matches) != 1 || matches[0].Override.Value {
+		t.Fatalf("expected the newer write to win over the retried one, got %+v", matches)
+	}
+	if matches, _ := inner.GetAll(ctx, "b", gate.ScopeChain{system}); len(matches) != 1 {
+		t.Fatalf("expected the retried write, got %+v", matches)
+	}
+}
@@ -0,0 +1,315 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"syscall"
+	"time"
+
+	goerrors "github.com/goliatone/go-errors"
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+const (
+	// DefaultRetryAttempts is the number of tries per operation, the first included.
+	DefaultRetryAttempts = 3
+	// DefaultRetryBackoff is the delay before the first retry.
+	DefaultRetryBackoff = 50 * time.Millisecond
+	// DefaultRetryMaxBackoff caps the delay between retries.
+	DefaultRetryMaxBackoff = time.Second
+	// DefaultRetryJitter is the fraction of each delay that is randomized.
+	DefaultRetryJitter = 0.2
+)
+
+// RetryPolicy configures RetryingStore. Zero fields use the defaults above.
+type RetryPolicy struct {
+	// MaxAttempts is the number of tries per operation, the first included.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles per attempt up
+	// to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter randomizes each delay by up to this fraction, in [0, 1], so clients
+	// that failed together do not retry together. Negative values disable jitter.
+	Jitter float64
+	// IsTransient reports whether a failure is worth retrying. Defaults to
+	// IsTransient.
+	IsTransient func(error) bool
+}
+
+// DefaultRetryPolicy returns the policy zero fields fall back to.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    DefaultRetryAttempts,
+		InitialBackoff: DefaultRetryBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Jitter:         DefaultRetryJitter,
+		IsTransient:    IsTransient,
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	defaults := DefaultRetryPolicy()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaults.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaults.MaxBackoff
+	}
+	if p.Jitter == 0 {
+		p.Jitter = defaults.Jitter
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	if p.IsTransient == nil {
+		p.IsTransient = defaults.IsTransient
+	}
+	return p
+}
+
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxBackoff)
+	if p.Jitter > 0 {
+		spread := float64(delay) * p.Jitter
+		delay = time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+	}
+	return delay
+}
+
+// IsTransient reports whether err looks like a brief infrastructure failure:
+// network timeouts, refused or reset connections, unexpected EOFs, and errors that
+// report Temporary() or Transient() true. Cancellations and deadlines of the
+// caller's context are never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var transient interface{ Transient() bool }
+	if errors.As(err, &transient) {
+		return transient.Transient()
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// RetryingStore retries transient failures of an inner store with exponential
+// backoff and jitter, so brief network blips do not surface as flag failures.
+// Operations that still fail after a retry report the tries made under
+// ferrors.MetaAttempts; failures that are not transient are returned unchanged.
+type RetryingStore struct {
+	inner  Reader
+	writer Writer
+	policy RetryPolicy
+}
+
+// WithRetry wraps inner with policy. Writes go through inner when it is also a
+// Writer; limit, list, and batch operations go through when inner supports them.
+func WithRetry(inner Reader, policy RetryPolicy) *RetryingStore {
+	r := &RetryingStore{inner: inner, policy: policy.withDefaults()}
+	if writer, ok := inner.(Writer); ok {
+		r.writer = writer
+	}
+	return r
+}
+
+// Policy returns the policy in effect, with defaults applied.
+func (r *RetryingStore) Policy() RetryPolicy {
+	if r == nil {
+		return DefaultRetryPolicy()
+	}
+	return r.policy
+}
+
+// GetAll implements Reader.
+func (r *RetryingStore) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
+	if r == nil || r.inner == nil {
+		return nil, storeRequiredError("retry", key, gate.ScopeRef{}, "get_all")
+	}
+	var matches []OverrideMatch
+	err := r.do(ctx, key, gate.ScopeRef{}, "get_all", func() error {
+		var err error
+		matches, err = r.inner.GetAll(ctx, key, chain)
+		return err
+	})
+	return matches, err
+}
+
+// Set implements Writer.
+func (r *RetryingStore) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if r == nil || r.writer == nil {
+		return storeRequiredError("retry", key, scopeRef, "set")
+	}
+	return r.do(ctx, key, scopeRef, "set", func() error {
+		return r.writer.Set(ctx, key, scopeRef, enabled, actor, opts...)
+	})
+}
+
+// Unset implements Writer.
+func (r *RetryingStore) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	if r == nil || r.writer == nil {
+		return storeRequiredError("retry", key, scopeRef, "unset")
+	}
+	return r.do(ctx, key, scopeRef, "unset", func() error {
+		return r.writer.Unset(ctx, key, scopeRef, actor)
+	})
+}
+
+// WriteBatch implements BatchWriter. Batches are retried whole when the inner
+// store writes batches; otherwise each mutation is retried on its own.
+func (r *RetryingStore) WriteBatch(ctx context.Context, mutations []Mutation) error {
+	if r == nil || r.writer == nil {
+		return storeRequiredError("retry", "", gate.ScopeRef{}, "write_batch")
+	}
+	batch, ok := r.writer.(BatchWriter)
+	if !ok {
+		return ApplyMutations(ctx, r, mutations)
+	}
+	return r.do(ctx, "", gate.ScopeRef{}, "write_batch", func() error {
+		return batch.WriteBatch(ctx, mutations)
+	})
+}
+
+// GetLimits implements LimitReader when the inner store does.
+func (r *RetryingStore) GetLimits(ctx context.Context, key string, chain gate.ScopeChain) ([]LimitMatch, error) {
+	var limits LimitReader
+	if r != nil {
+		limits, _ = r.inner.(LimitReader)
+	}
+	if limits == nil {
+		return nil, storeRequiredError("retry", key, gate.ScopeRef{}, "get_limits")
+	}
+	var matches []LimitMatch
+	err := r.do(ctx, key, gate.ScopeRef{}, "get_limits", func() error {
+		var err error
+		matches, err = limits.GetLimits(ctx, key, chain)
+		return err
+	})
+	return matches, err
+}
+
+// SetLimit implements LimitWriter when the inner store does.
+func (r *RetryingStore) SetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, value int64, actor gate.ActorRef) error {
+	var limits LimitWriter
+	if r != nil {
+		limits, _ = r.writer.(LimitWriter)
+	}
+	if limits == nil {
+		return storeRequiredError("retry", key, scopeRef, "set_limit")
+	}
+	return r.do(ctx, key, scopeRef, "set_limit", func() error {
+		return limits.SetLimit(ctx, key, scopeRef, value, actor)
+	})
+}
+
+// UnsetLimit implements LimitWriter when the inner store does.
+func (r *RetryingStore) UnsetLimit(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	var limits LimitWriter
+	if r != nil {
+		limits, _ = r.writer.(LimitWriter)
+	}
+	if limits == nil {
+		return storeRequiredError("retry", key, scopeRef, "unset_limit")
+	}
+	return r.do(ctx, key, scopeRef, "unset_limit", func() error {
+		return limits.UnsetLimit(ctx, key, scopeRef, actor)
+	})
+}
+
+// List implements Lister when the inner store does.
+func (r *RetryingStore) List(ctx context.Context, filter ListFilter) ([]OverrideRecord, error) {
+	var lister Lister
+	if r != nil {
+		lister, _ = r.inner.(Lister)
+	}
+	if lister == nil {
+		return nil, ferrors.WrapSentinel(ferrors.ErrStoreUnavailable, "store: retry inner store cannot list", map[string]any{
+			ferrors.MetaFeatureKey: strings.TrimSpace(filter.Key),
+			ferrors.MetaStore:      "retry",
+			ferrors.MetaOperation:  "list",
+		})
+	}
+	var records []OverrideRecord
+	err := r.do(ctx, filter.Key, gate.ScopeRef{}, "list", func() error {
+		var err error
+		records, err = lister.List(ctx, filter)
+		return err
+	})
+	return records, err
+}
+
+// do runs op until it succeeds, fails with an error that is not transient, the
+// attempts run out, or ctx is done.
+func (r *RetryingStore) do(ctx context.Context, key string, scopeRef gate.ScopeRef, operation string, op func() error) error {
+	var err error
+	attempts := 0
+	for attempts < r.policy.MaxAttempts {
+		attempts++
+		if err = op(); err == nil || !r.policy.IsTransient(err) {
+			break
+		}
+		if attempts == r.policy.MaxAttempts || !sleepContext(ctx, r.policy.backoff(attempts)) {
+			break
+		}
+	}
+	if err == nil || attempts == 1 {
+		return err
+	}
+	textCode := ferrors.TextCodeStoreWriteFailed
+	if operation == "get_all" || operation == "get_limits" || operation == "list" {
+		textCode = ferrors.TextCodeStoreReadFailed
+	}
+	meta := map[string]any{
+		ferrors.MetaFeatureKey: strings.TrimSpace(key),
+		ferrors.MetaStore:      "retry",
+		ferrors.MetaOperation:  operation,
+		ferrors.MetaAttempts:   attempts,
+	}
+	if scopeRef != (gate.ScopeRef{}) {
+		meta[ferrors.MetaScope] = scopeRef
+	}
+	return ferrors.Wrap(err, goerrors.CategoryExternal, textCode, "store: "+operation+" failed after retries", meta)
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+var (
+	_ ReadWriter  = (*RetryingStore)(nil)
+	_ Lister      = (*RetryingStore)(nil)
+	_ BatchWriter = (*RetryingStore)(nil)
+	_ LimitReader = (*RetryingStore)(nil)
+	_ LimitWriter = (*RetryingStore)(nil)
+)
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+type flakyStore struct {
+	ReadWriter
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakyStore) fail() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func (f *flakyStore) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return f.ReadWriter.GetAll(ctx, key, chain)
+}
+
+func (f *flakyStore) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if err := f.fail(); err != nil {
+		return err
+	}
+	return f.ReadWriter.Set(ctx, key, scopeRef, enabled, actor, opts...)
+}
+
+func TestRetryingStoreRetriesTransientFailures(t *testing.T) {
+	ctx := context.Background()
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	inner := &flakyStore{ReadWriter: NewMemoryStore(), failures: 2, err: syscall.ECONNRESET}
+	retrying := WithRetry(inner, policy)
+	if err := retrying.Set(ctx, "checkout", tenant, true, gate.ActorRef{}); err != nil {
+		t.Fatalf("expected the blip to be retried away, got %v", err)
+	}
+	if inner.calls != 3 {
+		t.Fatalf("expected three tries, got %d", inner.calls)
+	}
+
+	inner.calls, inner.failures = 0, 5
+	_, err := retrying.GetAll(ctx, "checkout", gate.ScopeChain{tenant})
+	if !errors.Is(err, syscall.ECONNRESET) || inner.calls != 3 {
+		t.Fatalf("expected the failure after three tries, got %v after %d", err, inner.calls)
+	}
+	if attempts, ok := ferrors.Attempts(err); !ok || attempts != 3 {
+		t.Fatalf("expected attempts in metadata, got %d (%v)", attempts, ok)
+	}
+	if op, _ := ferrors.Operation(err); op != "get_all" {
+		t.Fatalf("expected the operation in metadata, got %q", op)
+	}
+
+	permanent := errors.New("permission denied")
+	inner.calls, inner.err = 0, permanent
+	if _, err := retrying.GetAll(ctx, "checkout", gate.ScopeChain{tenant}); err != permanent || inner.calls != 1 {
+		t.Fatalf("expected permanent failures returned unchanged after one try, got %v after %d", err, inner.calls)
+	}
+
+	inner.calls, inner.err = 0, syscall.ECONNREFUSED
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := retrying.GetAll(cancelled, "checkout", gate.ScopeChain{tenant}); err == nil || inner.calls != 1 {
+		t.Fatalf("expected a cancelled context to stop retries, got %v after %d", err, inner.calls)
+	}
+
+	classified := WithRetry(inner, RetryPolicy{InitialBackoff: time.Millisecond, IsTransient: func(err error) bool { return err == permanent }})
+	inner.calls, inner.err, inner.failures = 0, permanent, 1
+	if _, err := classified.GetAll(ctx, "checkout", gate.ScopeChain{tenant}); err != nil || inner.calls != 2 {
+		t.Fatalf("expected the custom classifier to retry, got %v after %d", err, inner.calls)
+	}
+}
+
+func TestRetryPolicyBackoffGrowsWithinBounds(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 25 * time.Millisecond, Jitter: -1}.withDefaults()
+	for retry, want := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 25 * time.Millisecond} {
+		if got := policy.backoff(retry); got != want {
+			t.Fatalf("retry %d: expected %v, got %v", retry, want, got)
+		}
+	}
+	jittered := RetryPolicy{InitialBackoff: 10 * time.Millisecond, Jitter: 0.5}.withDefaults()
+	for i := 0; i < 20; i++ {
+		if got := jittered.backoff(1); got < 5*time.Millisecond || got > 15*time.Millisecond {
+			t.Fatalf("expected jitter within 50%%, got %v", got)
+		}
+	}
+}
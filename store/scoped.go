@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+
+	goerrors "github.com/goliatone/go-errors"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// ScopeReader looks up the override stored for a single scope. It is the shape of
+// stores keyed by one scope per lookup, such as key-value stores, and of stores
+// written before reads took the whole chain.
+type ScopeReader interface {
+	Get(ctx context.Context, key string, scope gate.ScopeRef) (Override, error)
+}
+
+// ScopeReaderFunc wraps a function as a ScopeReader.
+type ScopeReaderFunc func(ctx context.Context, key string, scope gate.ScopeRef) (Override, error)
+
+// Get implements ScopeReader.
+func (fn ScopeReaderFunc) Get(ctx context.Context, key string, scope gate.ScopeRef) (Override, error) {
+	if fn == nil {
+		return MissingOverride(), nil
+	}
+	return fn(ctx, key, scope)
+}
+
+// PerScopeStore adapts a ScopeReader to Reader by looking up every scope of the
+// chain in order.
+type PerScopeStore struct {
+	inner  ScopeReader
+	writer Writer
+}
+
+// PerScope wraps inner so it can serve chain reads. Scopes without a stored value
+// are left out of the matches. Writes go through inner when it is also a Writer.
+func PerScope(inner ScopeReader) *PerScopeStore {
+	p := &PerScopeStore{inner: inner}
+	if writer, ok := inner.(Writer); ok {
+		p.writer = writer
+	}
+	return p
+}
+
+// GetAll implements Reader. The first failed lookup stops the read.
+func (p *PerScopeStore) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]OverrideMatch, error) {
+	if p == nil || p.inner == nil {
+		return nil, storeRequiredError("per_scope", key, gate.ScopeRef{}, "get_all")
+	}
+	var matches []OverrideMatch
+	for _, ref := range chain {
+		override, err := p.inner.Get(ctx, key, ref)
+		if err != nil {
+			return nil, ferrors.Wrap(err, goerrors.CategoryExternal, ferrors.TextCodeStoreReadFailed, "store: per-scope lookup failed", map[string]any{
+				ferrors.MetaFeatureKey: key,
+				ferrors.MetaScope:      ref,
+				ferrors.MetaStore:      "per_scope",
+				ferrors.MetaOperation:  "get",
+			})
+		}
+		if override.State == "" || override.State == gate.OverrideStateMissing {
+			continue
+		}
+		matches = append(matches, OverrideMatch{Scope: ref, Override: override})
+	}
+	return matches, nil
+}
+
+// Set implements Writer.
+func (p *PerScopeStore) Set(ctx context.Context, key string, scopeRef gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if p == nil || p.writer == nil {
+		return storeRequiredError("per_scope", key, scopeRef, "set")
+	}
+	return p.writer.Set(ctx, key, scopeRef, enabled, actor, opts...)
+}
+
+// Unset implements Writer.
+func (p *PerScopeStore) Unset(ctx context.Context, key string, scopeRef gate.ScopeRef, actor gate.ActorRef) error {
+	if p == nil || p.writer == nil {
+		return storeRequiredError("per_scope", key, scopeRef, "unset")
+	}
+	return p.writer.Unset(ctx, key, scopeRef, actor)
+}
+
+var (
+	_ ScopeReader = ScopeReaderFunc(nil)
+	_ ReadWriter  = (*PerScopeStore)(nil)
+)
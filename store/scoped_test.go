@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func TestPerScopeMatchesStoredScopesInChainOrder(t *testing.T) {
+	tenant := gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1", TenantID: "acme"}
+	var looked []gate.ScopeRef
+	reader := PerScope(ScopeReaderFunc(func(_ context.Context, key string, scope gate.ScopeRef) (Override, error) {
+		looked = append(looked, scope)
+		switch scope {
+		case tenant:
+			return EnabledOverride(), nil
+		case user:
+			return UnsetOverride(), nil
+		}
+		return MissingOverride(), nil
+	}))
+
+	matches, err := reader.GetAll(context.Background(), "checkout", gate.ScopeChain{user, tenant, {Kind: gate.ScopeSystem}})
+	if err != nil {
+		t.Fatalf("get all: %v", err)
+	}
+	if len(looked) != 3 {
+		t.Fatalf("expected every scope looked up, got %+v", looked)
+	}
+	if len(matches) != 2 || matches[0].Scope != user || matches[0].Override.State != gate.OverrideStateUnset || matches[1].Scope != tenant || !matches[1].Override.Value {
+		t.Fatalf("expected the user unset then the tenant override, got %+v", matches)
+	}
+	if err := reader.Set(context.Background(), "checkout", tenant, true, gate.ActorRef{}); !errors.Is(err, ferrors.ErrStoreRequired) {
+		t.Fatalf("expected writes to need a writer, got %v", err)
+	}
+}
+
+func TestPerScopeReportsTheFailedScope(t *testing.T) {
+	refused := errors.New("redis: connection refused")
+	user := gate.ScopeRef{Kind: gate.ScopeUser, ID: "u1"}
+	reader := PerScope(ScopeReaderFunc(func(context.Context, string, gate.ScopeRef) (Override, error) {
+		return Override{}, refused
+	}))
+
+	_, err := reader.GetAll(context.Background(), "checkout", gate.ScopeChain{user})
+	if !errors.Is(err, refused) {
+		t.Fatalf("expected the lookup error wrapped, got %v", err)
+	}
+	if scope, ok := ferrors.Scope(err); !ok || scope.ID != "u1" {
+		t.Fatalf("expected the failed scope in metadata, got %+v", scope)
+	}
+}
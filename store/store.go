@@ -2,14 +2,18 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/goliatone/go-featuregate/gate"
 )
 
 // Override captures the runtime override state.
 type Override struct {
-	State gate.OverrideState
-	Value bool
+	State     gate.OverrideState
+	Value     bool
+	ExpiresAt time.Time
+	Metadata  gate.OverrideMetadata
+	Priority  int
 }
 
 // MissingOverride builds a placeholder override for absent values.
@@ -37,6 +41,11 @@ func (o Override) HasValue() bool {
 	return o.State == gate.OverrideStateEnabled || o.State == gate.OverrideStateDisabled
 }
 
+// Expired reports whether the override has an expiry at or before now.
+func (o Override) Expired(now time.Time) bool {
+	return !o.ExpiresAt.IsZero() && !now.Before(o.ExpiresAt)
+}
+
 // OverrideMatch captures an override match for a scope reference.
 type OverrideMatch struct {
 	Scope    gate.ScopeRef
@@ -50,10 +59,46 @@ type Reader interface {
 
 // Writer stores runtime overrides.
 type Writer interface {
-	Set(ctx context.Context, key string, scope gate.ScopeRef, enabled bool, actor gate.ActorRef) error
+	Set(ctx context.Context, key string, scope gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error
 	Unset(ctx context.Context, key string, scope gate.ScopeRef, actor gate.ActorRef) error
 }
 
+// LimitMatch captures a numeric limit override for a scope reference.
+type LimitMatch struct {
+	Scope gate.ScopeRef
+	Value int64
+}
+
+// LimitReader resolves numeric limit overrides, kept apart from boolean overrides
+// under the same keys and scopes.
+type LimitReader interface {
+	GetLimits(ctx context.Context, key string, chain gate.ScopeChain) ([]LimitMatch, error)
+}
+
+// LimitWriter stores numeric limit overrides.
+type LimitWriter interface {
+	SetLimit(ctx context.Context, key string, scope gate.ScopeRef, value int64, actor gate.ActorRef) error
+	UnsetLimit(ctx context.Context, key string, scope gate.ScopeRef, actor gate.ActorRef) error
+}
+
+// ListFilter narrows List results. Empty fields match everything.
+type ListFilter struct {
+	Key    string
+	Labels map[string]string
+}
+
+// OverrideRecord is a stored override returned by List.
+type OverrideRecord struct {
+	Key      string
+	Scope    gate.ScopeRef
+	Override Override
+}
+
+// Lister enumerates stored overrides, for example to find every flag touched during an incident.
+type Lister interface {
+	List(ctx context.Context, filter ListFilter) ([]OverrideRecord, error)
+}
+
 // ReadWriter is a combined reader/writer.
 type ReadWriter interface {
 	Reader
@@ -0,0 +1,299 @@
+// Package storetest provides a conformance suite for store.ReadWriter implementations.
+//
+// Adapter packages call Run from their own tests, typically behind a build tag, against
+// a real backend (for example a Postgres container started with dockertest):
+//
+//	storetest.Run(t, func(t *testing.T) store.ReadWriter {
+//		return bunadapter.NewStore(freshDB(t))
+//	})
+//
+// The factory must return an empty store on every call.
+package storetest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/adapters/configadapter"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// Factory builds an empty store for a single subtest.
+type Factory func(t *testing.T) store.ReadWriter
+
+// TimeTolerance bounds the drift accepted when comparing persisted timestamps.
+const TimeTolerance = time.Second
+
+var (
+	actor  = gate.ActorRef{ID: "storetest", Type: "test"}
+	system = gate.ScopeRef{Kind: gate.ScopeSystem}
+	tenant = gate.ScopeRef{Kind: gate.ScopeTenant, ID: "acme", TenantID: "acme"}
+	user   = gate.ScopeRef{Kind: gate.ScopeUser, ID: "u-1", TenantID: "acme"}
+)
+
+// Run executes the store conformance suite and the resolver scenarios.
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+	t.Run("store", func(t *testing.T) { RunStore(t, factory) })
+	t.Run("resolver", func(t *testing.T) { RunResolver(t, factory) })
+}
+
+// RunStore checks the store.ReadWriter contract, and store.Lister when implemented.
+func RunStore(t *testing.T, factory Factory) {
+	t.Helper()
+	ctx := context.Background()
+
+	t.Run("missing key returns no matches", func(t *testing.T) {
+		s := factory(t)
+		matches, err := s.GetAll(ctx, "storetest.missing", gate.ScopeChain{user, tenant, system})
+		if err != nil {
+			t.Fatalf("get_all: %v", err)
+		}
+		if len(matches) != 0 {
+			t.Fatalf("expected no matches, got %+v", matches)
+		}
+	})
+
+	t.Run("set round trips state, metadata, and priority", func(t *testing.T) {
+		s := factory(t)
+		meta := gate.OverrideMetadata{
+			Reason:    "rollout",
+			TicketURL: "https://tickets.example/1",
+			Owner:     "growth",
+			Labels:    map[string]string{"team": "growth"},
+		}
+		if err := s.Set(ctx, "storetest.set", tenant, true, actor, gate.WithMetadata(meta), gate.WithPriority(7)); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		override := single(t, s, "storetest.set", tenant)
+		if override.State != gate.OverrideStateEnabled || !override.Value {
+			t.Fatalf("expected enabled override, got %+v", override)
+		}
+		if !reflect.DeepEqual(override.Metadata, meta) {
+			t.Fatalf("expected metadata %+v, got %+v", meta, override.Metadata)
+		}
+		if override.Priority != 7 {
+			t.Fatalf("expected priority 7, got %d", override.Priority)
+		}
+
+		if err := s.Set(ctx, "storetest.set", tenant, false, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if override := single(t, s, "storetest.set", tenant); override.State != gate.OverrideStateDisabled || override.Value {
+			t.Fatalf("expected disabled override after rewrite, got %+v", override)
+		}
+	})
+
+	t.Run("unset stores an explicit unset", func(t *testing.T) {
+		s := factory(t)
+		if err := s.Set(ctx, "storetest.unset", system, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if err := s.Unset(ctx, "storetest.unset", system, actor); err != nil {
+			t.Fatalf("unset: %v", err)
+		}
+		if override := single(t, s, "storetest.unset", system); override.State != gate.OverrideStateUnset {
+			t.Fatalf("expected unset override, got %+v", override)
+		}
+	})
+
+	t.Run("keys are trimmed", func(t *testing.T) {
+		s := factory(t)
+		if err := s.Set(ctx, "  storetest.normalized ", system, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if override := single(t, s, "storetest.normalized", system); !override.Value {
+			t.Fatalf("expected trimmed key to match, got %+v", override)
+		}
+	})
+
+	t.Run("matches follow chain order", func(t *testing.T) {
+		s := factory(t)
+		for _, ref := range []gate.ScopeRef{system, tenant, user} {
+			if err := s.Set(ctx, "storetest.order", ref, true, actor); err != nil {
+				t.Fatalf("set %v: %v", ref.Kind, err)
+			}
+		}
+		chain := gate.ScopeChain{user, tenant, system}
+		matches, err := s.GetAll(ctx, "storetest.order", chain)
+		if err != nil {
+			t.Fatalf("get_all: %v", err)
+		}
+		if len(matches) != len(chain) {
+			t.Fatalf("expected %d matches, got %+v", len(chain), matches)
+		}
+		for i, match := range matches {
+			if match.Scope != chain[i] {
+				t.Fatalf("match %d: expected scope %+v, got %+v", i, chain[i], match.Scope)
+			}
+		}
+	})
+
+	t.Run("scopes are isolated by qualifiers", func(t *testing.T) {
+		s := factory(t)
+		acmeAdmin := gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin", TenantID: "acme"}
+		otherAdmin := gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin", TenantID: "other"}
+		if err := s.Set(ctx, "storetest.isolated", acmeAdmin, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		matches, err := s.GetAll(ctx, "storetest.isolated", gate.ScopeChain{otherAdmin, system})
+		if err != nil {
+			t.Fatalf("get_all: %v", err)
+		}
+		if len(matches) != 0 {
+			t.Fatalf("expected role override to stay in its tenant, got %+v", matches)
+		}
+	})
+
+	t.Run("expiry is persisted", func(t *testing.T) {
+		s := factory(t)
+		expiresAt := time.Now().Add(time.Hour).UTC()
+		if err := s.Set(ctx, "storetest.expiry", system, true, actor, gate.WithExpiresAt(expiresAt)); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		override := single(t, s, "storetest.expiry", system)
+		if diff := override.ExpiresAt.Sub(expiresAt).Abs(); diff > TimeTolerance {
+			t.Fatalf("expected expiry %s, got %s", expiresAt, override.ExpiresAt)
+		}
+	})
+
+	t.Run("list filters by key and labels", func(t *testing.T) {
+		s := factory(t)
+		lister, ok := s.(store.Lister)
+		if !ok {
+			t.Skip("store does not implement store.Lister")
+		}
+		if err := s.Set(ctx, "storetest.listed", tenant, true, actor, gate.WithLabel("incident", "INC-1")); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if err := s.Set(ctx, "storetest.other", tenant, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		records, err := lister.List(ctx, store.ListFilter{Labels: map[string]string{"incident": "INC-1"}})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if len(records) != 1 || records[0].Key != "storetest.listed" || records[0].Scope != tenant {
+			t.Fatalf("expected one labelled record, got %+v", records)
+		}
+		records, err = lister.List(ctx, store.ListFilter{Key: "storetest.other"})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if len(records) != 1 || records[0].Key != "storetest.other" {
+			t.Fatalf("expected one record for key, got %+v", records)
+		}
+	})
+}
+
+// RunResolver runs end-to-end resolution scenarios with the store behind a resolver.Gate.
+func RunResolver(t *testing.T, factory Factory) {
+	t.Helper()
+	ctx := context.Background()
+	newGate := func(t *testing.T) *resolver.Gate {
+		return resolver.New(
+			resolver.WithDefaults(configadapter.NewDefaultsFromBools(map[string]bool{
+				"storetest.e2e": false,
+			})),
+			resolver.WithOverrideStore(factory(t)),
+		)
+	}
+	chain := gate.WithScopeChain(gate.ScopeChain{user, tenant, system})
+
+	t.Run("override wins over default", func(t *testing.T) {
+		fg := newGate(t)
+		if err := fg.Set(ctx, "storetest.e2e", tenant, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		value, trace, err := fg.ResolveWithTrace(ctx, "storetest.e2e", chain)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if !value || trace.Source != gate.ResolveSourceOverride || trace.Override.Match.Kind != gate.ScopeTenant {
+			t.Fatalf("expected tenant override, got %v (%+v)", value, trace)
+		}
+	})
+
+	t.Run("narrower scope wins", func(t *testing.T) {
+		fg := newGate(t)
+		if err := fg.Set(ctx, "storetest.e2e", tenant, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if err := fg.Set(ctx, "storetest.e2e", user, false, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		value, err := fg.Enabled(ctx, "storetest.e2e", chain)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if value {
+			t.Fatalf("expected user override to disable the feature")
+		}
+	})
+
+	t.Run("role and perm overrides match claims-derived chains", func(t *testing.T) {
+		fg := newGate(t)
+		claims := gate.WithClaims(gate.ActorClaims{
+			SubjectID: "u-1",
+			TenantID:  "acme",
+			OrgID:     "eng",
+			Roles:     []string{"Admin"},
+			Perms:     []string{"billing.read"},
+		})
+		if err := fg.Set(ctx, "storetest.e2e", gate.ScopeRef{Kind: gate.ScopeRole, ID: "ADMIN"}, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		value, trace, err := fg.ResolveWithTrace(ctx, "storetest.e2e", claims)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if !value || trace.Override.Match != (gate.ScopeRef{Kind: gate.ScopeRole, ID: "admin"}) {
+			t.Fatalf("expected the global role override, got %v (%+v)", value, trace.Override.Match)
+		}
+
+		perm := gate.ScopeRef{Kind: gate.ScopePerm, ID: "billing.read", TenantID: "acme", OrgID: "eng"}
+		if err := fg.Set(ctx, "storetest.e2e", perm, false, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		value, trace, err = fg.ResolveWithTrace(ctx, "storetest.e2e", claims)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if value || trace.Override.Match != perm {
+			t.Fatalf("expected the tenant-qualified perm override to disable the feature, got %v (%+v)", value, trace.Override.Match)
+		}
+	})
+
+	t.Run("unset falls back to default", func(t *testing.T) {
+		fg := newGate(t)
+		if err := fg.Set(ctx, "storetest.e2e", system, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if err := fg.Unset(ctx, "storetest.e2e", system, actor); err != nil {
+			t.Fatalf("unset: %v", err)
+		}
+		value, trace, err := fg.ResolveWithTrace(ctx, "storetest.e2e", chain)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if value || trace.Source != gate.ResolveSourceDefault {
+			t.Fatalf("expected default after unset, got %v (%s)", value, trace.Source)
+		}
+	})
+}
+
+func single(t *testing.T, s store.Reader, key string, ref gate.ScopeRef) store.Override {
+	t.Helper()
+	matches, err := s.GetAll(context.Background(), key, gate.ScopeChain{ref})
+	if err != nil {
+		t.Fatalf("get_all: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected one match for %s, got %+v", key, matches)
+	}
+	return matches[0].Override
+}
@@ -0,0 +1,92 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+func TestMemoryStoreConformance(t *testing.T) {
+	Run(t, func(*testing.T) store.ReadWriter {
+		return store.NewMemoryStore()
+	})
+}
+
+func TestCachedReadWriterConformance(t *testing.T) {
+	Run(t, func(*testing.T) store.ReadWriter {
+		return store.NewCachedReadWriter(store.NewMemoryStore())
+	})
+}
+
+func TestRunTransactionsWithOverlayStore(t *testing.T) {
+	RunTransactions(t, func(*testing.T) (store.ReadWriter, Begin) {
+		committed := store.NewMemoryStore()
+		return &overlayStore{committed: committed}, func(ctx context.Context) (context.Context, Tx, error) {
+			tx := &overlayTx{pending: store.NewMemoryStore(), committed: committed}
+			return store.WithUncommitted(context.WithValue(ctx, overlayKey{}, tx)), tx, nil
+		}
+	})
+}
+
+type overlayKey struct{}
+
+// overlayStore stands in for a transactional backend: writes made with a transaction
+// context land in the transaction's pending store until commit.
+type overlayStore struct {
+	committed *store.MemoryStore
+}
+
+type overlayTx struct {
+	pending   *store.MemoryStore
+	committed *store.MemoryStore
+}
+
+func (s *overlayStore) tx(ctx context.Context) *overlayTx {
+	tx, _ := ctx.Value(overlayKey{}).(*overlayTx)
+	return tx
+}
+
+func (s *overlayStore) GetAll(ctx context.Context, key string, chain gate.ScopeChain) ([]store.OverrideMatch, error) {
+	if tx := s.tx(ctx); tx != nil {
+		if matches, err := tx.pending.GetAll(ctx, key, chain); err != nil || len(matches) > 0 {
+			return matches, err
+		}
+	}
+	return s.committed.GetAll(ctx, key, chain)
+}
+
+func (s *overlayStore) Set(ctx context.Context, key string, ref gate.ScopeRef, enabled bool, actor gate.ActorRef, opts ...gate.MutationOption) error {
+	if tx := s.tx(ctx); tx != nil {
+		return tx.pending.Set(ctx, key, ref, enabled, actor, opts...)
+	}
+	return s.committed.Set(ctx, key, ref, enabled, actor, opts...)
+}
+
+func (s *overlayStore) Unset(ctx context.Context, key string, ref gate.ScopeRef, actor gate.ActorRef) error {
+	if tx := s.tx(ctx); tx != nil {
+		return tx.pending.Unset(ctx, key, ref, actor)
+	}
+	return s.committed.Unset(ctx, key, ref, actor)
+}
+
+func (tx *overlayTx) Commit() error {
+	ctx := context.Background()
+	records, err := tx.pending.List(ctx, store.ListFilter{})
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := tx.committed.Set(ctx, record.Key, record.Scope, record.Override.Value, actor); err != nil {
+			return err
+		}
+	}
+	tx.pending = store.NewMemoryStore()
+	return nil
+}
+
+func (tx *overlayTx) Rollback() error {
+	tx.pending = store.NewMemoryStore()
+	return nil
+}
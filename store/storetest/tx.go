@@ -0,0 +1,99 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/resolver"
+	"github.com/goliatone/go-featuregate/store"
+)
+
+// Tx is a transaction handle; bun.Tx and *sql.Tx satisfy it.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// Begin starts a transaction and returns a context that routes store calls through it
+// (for example bunadapter.ContextWithTx).
+type Begin func(ctx context.Context) (context.Context, Tx, error)
+
+// TxFactory builds an empty store and the Begin func for transactions against it.
+type TxFactory func(t *testing.T) (store.ReadWriter, Begin)
+
+// RunTransactions checks transaction-scoped visibility: writes made inside a transaction
+// are visible to reads through the same context only, vanish on rollback, and are
+// published on commit. Reads go through a cached resolver.Gate so leaks via caches fail too.
+func RunTransactions(t *testing.T, factory TxFactory) {
+	t.Helper()
+	ctx := context.Background()
+	chain := gate.WithScopeChain(gate.ScopeChain{system})
+	setup := func(t *testing.T) (*resolver.Gate, Begin) {
+		s, begin := factory(t)
+		return resolver.New(resolver.WithOverrideStore(store.NewCachedReadWriter(s))), begin
+	}
+	enabled := func(t *testing.T, fg *resolver.Gate, ctx context.Context) bool {
+		t.Helper()
+		value, err := fg.Enabled(ctx, "storetest.tx", chain)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		return value
+	}
+
+	t.Run("pending writes are visible inside the transaction only", func(t *testing.T) {
+		fg, begin := setup(t)
+		txCtx, tx, err := begin(ctx)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		defer tx.Rollback()
+		if err := fg.Set(txCtx, "storetest.tx", system, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if !enabled(t, fg, txCtx) {
+			t.Fatalf("expected pending override inside the transaction")
+		}
+		if enabled(t, fg, ctx) {
+			t.Fatalf("expected pending override to stay hidden outside the transaction")
+		}
+	})
+
+	t.Run("rollback discards writes", func(t *testing.T) {
+		fg, begin := setup(t)
+		txCtx, tx, err := begin(ctx)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		if err := fg.Set(txCtx, "storetest.tx", system, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if !enabled(t, fg, txCtx) {
+			t.Fatalf("expected pending override inside the transaction")
+		}
+		if err := tx.Rollback(); err != nil {
+			t.Fatalf("rollback: %v", err)
+		}
+		if enabled(t, fg, ctx) {
+			t.Fatalf("expected rolled back override to be discarded")
+		}
+	})
+
+	t.Run("commit publishes writes", func(t *testing.T) {
+		fg, begin := setup(t)
+		txCtx, tx, err := begin(ctx)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		if err := fg.Set(txCtx, "storetest.tx", system, true, actor); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+		if !enabled(t, fg, ctx) {
+			t.Fatalf("expected committed override to be visible")
+		}
+	})
+}
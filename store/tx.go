@@ -0,0 +1,24 @@
+package store
+
+import "context"
+
+type uncommittedKey struct{}
+
+// WithUncommitted marks ctx as reading transaction-local state that other requests
+// must not see. Caching layers skip reads and writes for such contexts so pending
+// changes never leak out of the transaction.
+func WithUncommitted(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, uncommittedKey{}, true)
+}
+
+// Uncommitted reports whether ctx was marked with WithUncommitted.
+func Uncommitted(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	marked, _ := ctx.Value(uncommittedKey{}).(bool)
+	return marked
+}
@@ -0,0 +1,238 @@
+package templates
+
+import (
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/logger"
+)
+
+// Evaluator implements the template helper rules against an explicit RequestState
+// so engines other than pongo2 can share them.
+type Evaluator struct {
+	gate  gate.FeatureGate
+	trace gate.TraceableFeatureGate
+	cfg   HelperConfig
+}
+
+// NewEvaluator builds an Evaluator using the same options as TemplateHelpers.
+func NewEvaluator(featureGate gate.FeatureGate, opts ...HelperOption) *Evaluator {
+	cfg := DefaultHelperConfig()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.EnableErrorLogging && cfg.Logger == nil {
+		cfg.Logger = logger.Default()
+	}
+	return &Evaluator{
+		gate:  featureGate,
+		trace: traceGate(featureGate),
+		cfg:   cfg,
+	}
+}
+
+// Traceable reports whether the gate supports ResolveWithTrace.
+func (e *Evaluator) Traceable() bool {
+	return e != nil && e.trace != nil
+}
+
+// Feature reports whether key is enabled; errors resolve to false.
+func (e *Evaluator) Feature(state *RequestState, key any) bool {
+	normalized, ok := parseKey(key)
+	if !ok {
+		return false
+	}
+	value, err := e.valueFor(state, normalized)
+	if err != nil {
+		return false
+	}
+	return value
+}
+
+// FeatureAny reports whether any of keys is enabled.
+func (e *Evaluator) FeatureAny(state *RequestState, keys ...any) bool {
+	parsed := parseKeys(keys...)
+	if len(parsed) == 0 {
+		return false
+	}
+	for _, key := range parsed {
+		value, err := e.valueFor(state, key)
+		if err == nil && value {
+			return true
+		}
+	}
+	return false
+}
+
+// FeatureAll reports whether every key is enabled.
+func (e *Evaluator) FeatureAll(state *RequestState, keys ...any) bool {
+	parsed := parseKeys(keys...)
+	if len(parsed) == 0 {
+		return false
+	}
+	for _, key := range parsed {
+		value, err := e.valueFor(state, key)
+		if err != nil || !value {
+			return false
+		}
+	}
+	return true
+}
+
+// FeatureNone reports whether none of keys is enabled.
+func (e *Evaluator) FeatureNone(state *RequestState, keys ...any) bool {
+	parsed := parseKeys(keys...)
+	if len(parsed) == 0 {
+		return false
+	}
+	for _, key := range parsed {
+		value, err := e.valueFor(state, key)
+		if err == nil && value {
+			return false
+		}
+	}
+	return true
+}
+
+// FeatureIf returns whenTrue when key is enabled, otherwise the optional whenFalse value.
+func (e *Evaluator) FeatureIf(state *RequestState, key any, whenTrue any, whenFalse ...any) any {
+	var fallback any = ""
+	if len(whenFalse) > 0 {
+		fallback = whenFalse[0]
+	}
+	normalized, ok := parseKey(key)
+	if !ok {
+		return e.errorOrFallback("feature_if", ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
+			ferrors.MetaFeatureKey: key,
+		}), fallback)
+	}
+	value, err := e.valueFor(state, normalized)
+	if err != nil {
+		return e.errorOrFallback("feature_if", err, fallback)
+	}
+	if value {
+		return whenTrue
+	}
+	return fallback
+}
+
+// FeatureClass returns on when key is enabled, otherwise the optional off value.
+func (e *Evaluator) FeatureClass(state *RequestState, key any, on any, off ...any) any {
+	var fallback any = ""
+	if len(off) > 0 {
+		fallback = off[0]
+	}
+	normalized, ok := parseKey(key)
+	if !ok {
+		return e.errorOrFallback("feature_class", ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
+			ferrors.MetaFeatureKey: key,
+		}), fallback)
+	}
+	value, err := e.valueFor(state, normalized)
+	if err != nil {
+		return e.errorOrFallback("feature_class", err, fallback)
+	}
+	if value {
+		return on
+	}
+	return fallback
+}
+
+// FeatureTrace returns the resolve trace for key, or nil when none is available.
+func (e *Evaluator) FeatureTrace(state *RequestState, key any) any {
+	trace, ok, err := e.traceFor(state, key)
+	if err != nil {
+		return e.errorOrFallback("feature_trace", err, nil)
+	}
+	if !ok {
+		return nil
+	}
+	return trace
+}
+
+// FeatureVariant returns the variant key for key, or the optional fallback on error.
+func (e *Evaluator) FeatureVariant(state *RequestState, key any, fallback ...any) any {
+	var def any = ""
+	if len(fallback) > 0 {
+		def = fallback[0]
+	}
+	variant, err := e.variantFor(state, key)
+	if err != nil {
+		return e.errorOrFallback("feature_variant", err, def)
+	}
+	return variant.Key
+}
+
+// FeatureValue returns the variant value for key, or the optional fallback on error.
+func (e *Evaluator) FeatureValue(state *RequestState, key any, fallback ...any) any {
+	var def any
+	if len(fallback) > 0 {
+		def = fallback[0]
+	}
+	variant, err := e.variantFor(state, key)
+	if err != nil {
+		return e.errorOrFallback("feature_value", err, def)
+	}
+	return variant.Value
+}
+
+// valueFor resolves key from the snapshot, then the gate.
+func (e *Evaluator) valueFor(state *RequestState, key string) (bool, error) {
+	if key == "" {
+		return false, ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
+			ferrors.MetaFeatureKey: key,
+		})
+	}
+	if state != nil && state.Snapshot != nil {
+		if value, ok := snapshotValue(state.Snapshot, key); ok {
+			return value, nil
+		}
+	}
+	if e.gate == nil {
+		return false, ferrors.WrapSentinel(ferrors.ErrGateRequired, "feature gate is required", nil)
+	}
+	return e.gate.Enabled(state.context(), key, state.resolveOptions()...)
+}
+
+// traceFor returns the snapshot or gate trace; ok is false when neither can supply one.
+func (e *Evaluator) traceFor(state *RequestState, key any) (gate.ResolveTrace, bool, error) {
+	normalized, ok := parseKey(key)
+	if !ok {
+		return gate.ResolveTrace{}, false, ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
+			ferrors.MetaFeatureKey: key,
+		})
+	}
+	if state != nil && state.Snapshot != nil {
+		if trace, ok := snapshotTrace(state.Snapshot, normalized); ok {
+			return trace, true, nil
+		}
+	}
+	if e.trace == nil {
+		return gate.ResolveTrace{}, false, nil
+	}
+	_, trace, err := e.trace.ResolveWithTrace(state.context(), normalized, state.resolveOptions()...)
+	if err != nil {
+		return gate.ResolveTrace{}, false, err
+	}
+	return trace, true, nil
+}
+
+// variantFor resolves the variant for key from the snapshot, then the gate.
+func (e *Evaluator) variantFor(state *RequestState, key any) (gate.Variant, error) {
+	normalized, ok := parseKey(key)
+	if !ok {
+		return gate.Variant{}, ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
+			ferrors.MetaFeatureKey: key,
+		})
+	}
+	if state != nil && state.Snapshot != nil {
+		if variant, ok := snapshotVariant(state.Snapshot, normalized); ok {
+			return variant, nil
+		}
+	}
+	if e.gate == nil {
+		return gate.Variant{}, ferrors.WrapSentinel(ferrors.ErrGateRequired, "feature gate is required", nil)
+	}
+	return gate.ResolveVariant(state.context(), e.gate, normalized, state.resolveOptions()...)
+}
@@ -103,243 +103,160 @@ func WithLogger(lgr logger.Logger) HelperOption {
 	}
 }
 
-// TemplateHelpers returns a helper set suitable for WithTemplateFunc.
-func TemplateHelpers(featureGate gate.FeatureGate, opts ...HelperOption) map[string]any {
-	cfg := DefaultHelperConfig()
-	for _, opt := range opts {
-		if opt != nil {
-			opt(&cfg)
-		}
+// requestStateKey holds the bound *RequestState in the execution or template data.
+const requestStateKey = "_featuregate_request"
+
+// RequestState is the request data the helpers resolve with during one render.
+type RequestState struct {
+	Context  context.Context
+	Scope    *gate.ScopeChain
+	Snapshot any
+}
+
+// NewRequestState builds request state. A nil chain lets the gate derive scope from ctx.
+func NewRequestState(ctx context.Context, chain gate.ScopeChain, snapshot any) *RequestState {
+	state := &RequestState{Context: ctx, Snapshot: snapshot}
+	if chain != nil {
+		scoped := append(gate.ScopeChain(nil), chain...)
+		state.Scope = &scoped
+	}
+	return state
+}
+
+// RegisterRequest binds request state to a pongo2 execution context once per render,
+// so helpers skip the context, scope, and snapshot key lookups on every call.
+func RegisterRequest(execCtx *pongo2.ExecutionContext, ctx context.Context, chain gate.ScopeChain, snapshot any) {
+	if execCtx == nil {
+		return
 	}
-	if cfg.EnableErrorLogging && cfg.Logger == nil {
-		cfg.Logger = logger.Default()
+	if execCtx.Private == nil {
+		execCtx.Private = pongo2.Context{}
 	}
-	helpers := &helperSet{
-		gate:  featureGate,
-		trace: traceGate(featureGate),
-		cfg:   cfg,
+	execCtx.Private[requestStateKey] = NewRequestState(ctx, chain, snapshot)
+}
+
+// BindRequest adds request state to template data before rendering, for middleware
+// that prepares the data map. It returns data (allocated when nil).
+func BindRequest(data pongo2.Context, ctx context.Context, chain gate.ScopeChain, snapshot any) pongo2.Context {
+	if data == nil {
+		data = pongo2.Context{}
 	}
+	data[requestStateKey] = NewRequestState(ctx, chain, snapshot)
+	return data
+}
+
+func (s *RequestState) context() context.Context {
+	if s == nil || s.Context == nil {
+		return context.Background()
+	}
+	return s.Context
+}
+
+func (s *RequestState) resolveOptions() []gate.ResolveOption {
+	if s == nil || s.Scope == nil {
+		return nil
+	}
+	return []gate.ResolveOption{gate.WithScopeChain(*s.Scope)}
+}
+
+// TemplateHelpers returns a helper set suitable for WithTemplateFunc.
+func TemplateHelpers(featureGate gate.FeatureGate, opts ...HelperOption) map[string]any {
+	helpers := newHelperSet(featureGate, opts...)
 
 	funcs := map[string]any{
-		"feature":       helpers.feature,
-		"feature_any":   helpers.featureAny,
-		"feature_all":   helpers.featureAll,
-		"feature_none":  helpers.featureNone,
-		"feature_if":    helpers.featureIf,
-		"feature_class": helpers.featureClass,
-	}
-	if helpers.trace != nil {
+		"feature":         helpers.feature,
+		"feature_any":     helpers.featureAny,
+		"feature_all":     helpers.featureAll,
+		"feature_none":    helpers.featureNone,
+		"feature_if":      helpers.featureIf,
+		"feature_class":   helpers.featureClass,
+		"feature_variant": helpers.featureVariant,
+		"feature_value":   helpers.featureValue,
+	}
+	if helpers.Traceable() {
 		funcs["feature_trace"] = helpers.featureTrace
 	}
 	return funcs
 }
 
 type helperSet struct {
-	gate  gate.FeatureGate
-	trace gate.TraceableFeatureGate
-	cfg   HelperConfig
+	*Evaluator
+}
+
+func newHelperSet(featureGate gate.FeatureGate, opts ...HelperOption) *helperSet {
+	return &helperSet{Evaluator: NewEvaluator(featureGate, opts...)}
 }
 
 func (h *helperSet) feature(execCtx *pongo2.ExecutionContext, key any) bool {
-	normalized, ok := parseKey(key)
-	if !ok {
-		return false
-	}
-	value, err := h.resolveValue(execCtx, normalized)
-	if err != nil {
-		return false
-	}
-	return value
+	return h.Feature(h.request(execCtx), key)
 }
 
 func (h *helperSet) featureAny(execCtx *pongo2.ExecutionContext, keys ...any) bool {
-	parsed := parseKeys(keys...)
-	if len(parsed) == 0 {
-		return false
-	}
-	for _, key := range parsed {
-		value, err := h.resolveValue(execCtx, key)
-		if err == nil && value {
-			return true
-		}
-	}
-	return false
+	return h.FeatureAny(h.request(execCtx), keys...)
 }
 
 func (h *helperSet) featureAll(execCtx *pongo2.ExecutionContext, keys ...any) bool {
-	parsed := parseKeys(keys...)
-	if len(parsed) == 0 {
-		return false
-	}
-	for _, key := range parsed {
-		value, err := h.resolveValue(execCtx, key)
-		if err != nil || !value {
-			return false
-		}
-	}
-	return true
+	return h.FeatureAll(h.request(execCtx), keys...)
 }
 
 func (h *helperSet) featureNone(execCtx *pongo2.ExecutionContext, keys ...any) bool {
-	parsed := parseKeys(keys...)
-	if len(parsed) == 0 {
-		return false
-	}
-	for _, key := range parsed {
-		value, err := h.resolveValue(execCtx, key)
-		if err == nil && value {
-			return false
-		}
-	}
-	return true
+	return h.FeatureNone(h.request(execCtx), keys...)
 }
 
 func (h *helperSet) featureIf(execCtx *pongo2.ExecutionContext, key any, whenTrue any, whenFalse ...any) any {
-	var fallback any = ""
-	if len(whenFalse) > 0 {
-		fallback = whenFalse[0]
-	}
-	normalized, ok := parseKey(key)
-	if !ok {
-		return h.errorOrFallback("feature_if", ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
-			ferrors.MetaFeatureKey: key,
-		}), fallback)
-	}
-	value, err := h.resolveValue(execCtx, normalized)
-	if err != nil {
-		return h.errorOrFallback("feature_if", err, fallback)
-	}
-	if value {
-		return whenTrue
-	}
-	return fallback
+	return h.FeatureIf(h.request(execCtx), key, whenTrue, whenFalse...)
 }
 
 func (h *helperSet) featureClass(execCtx *pongo2.ExecutionContext, key any, on any, off ...any) any {
-	var fallback any = ""
-	if len(off) > 0 {
-		fallback = off[0]
-	}
-	normalized, ok := parseKey(key)
-	if !ok {
-		return h.errorOrFallback("feature_class", ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
-			ferrors.MetaFeatureKey: key,
-		}), fallback)
-	}
-	value, err := h.resolveValue(execCtx, normalized)
-	if err != nil {
-		return h.errorOrFallback("feature_class", err, fallback)
-	}
-	if value {
-		return on
-	}
-	return fallback
+	return h.FeatureClass(h.request(execCtx), key, on, off...)
 }
 
 func (h *helperSet) featureTrace(execCtx *pongo2.ExecutionContext, key any) any {
-	normalized, ok := parseKey(key)
-	if !ok {
-		return h.errorOrFallback("feature_trace", ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
-			ferrors.MetaFeatureKey: key,
-		}), nil)
-	}
-	if snapshot := h.snapshot(execCtx); snapshot != nil {
-		if trace, ok := snapshotTrace(snapshot, normalized); ok {
-			return trace
-		}
-	}
-	if h.trace == nil {
-		return nil
-	}
-
-	ctx := h.context(execCtx)
-	opts := h.resolveOptions(execCtx)
-	_, trace, err := h.trace.ResolveWithTrace(ctx, normalized, opts...)
-	if err != nil {
-		return h.errorOrFallback("feature_trace", err, nil)
-	}
-	return trace
+	return h.FeatureTrace(h.request(execCtx), key)
 }
 
-func (h *helperSet) resolveValue(execCtx *pongo2.ExecutionContext, key string) (bool, error) {
-	if key == "" {
-		return false, ferrors.WrapSentinel(ferrors.ErrInvalidKey, "feature key is required", map[string]any{
-			ferrors.MetaFeatureKey: key,
-		})
-	}
-	if snapshot := h.snapshot(execCtx); snapshot != nil {
-		if value, ok := snapshotValue(snapshot, key); ok {
-			return value, nil
-		}
-	}
-	if h.gate == nil {
-		return false, ferrors.WrapSentinel(ferrors.ErrGateRequired, "feature gate is required", nil)
-	}
-	ctx := h.context(execCtx)
-	opts := h.resolveOptions(execCtx)
-	return h.gate.Enabled(ctx, key, opts...)
+func (h *helperSet) featureVariant(execCtx *pongo2.ExecutionContext, key any, fallback ...any) any {
+	return h.FeatureVariant(h.request(execCtx), key, fallback...)
 }
 
-func (h *helperSet) resolveOptions(execCtx *pongo2.ExecutionContext) []gate.ResolveOption {
-	if chain := h.scope(execCtx); chain != nil {
-		return []gate.ResolveOption{gate.WithScopeChain(*chain)}
-	}
-	return nil
+func (h *helperSet) featureValue(execCtx *pongo2.ExecutionContext, key any, fallback ...any) any {
+	return h.FeatureValue(h.request(execCtx), key, fallback...)
 }
 
-func (h *helperSet) context(execCtx *pongo2.ExecutionContext) context.Context {
-	data := templateData(execCtx)
-	if data == nil {
-		return context.Background()
+// request returns the state bound with RegisterRequest or BindRequest, falling back
+// to the configured context, scope, and snapshot keys in the template data.
+func (h *helperSet) request(execCtx *pongo2.ExecutionContext) *RequestState {
+	if execCtx == nil {
+		return &RequestState{}
 	}
-	key := h.cfg.ContextKey
-	if key == "" {
-		key = TemplateContextKey
+	if state, ok := execCtx.Private[requestStateKey].(*RequestState); ok && state != nil {
+		return state
 	}
-	raw, ok := data[key]
-	if !ok || raw == nil {
-		return context.Background()
+	if state, ok := execCtx.Public[requestStateKey].(*RequestState); ok && state != nil {
+		return state
 	}
-	return contextFromValue(raw)
-}
-
-func (h *helperSet) scope(execCtx *pongo2.ExecutionContext) *gate.ScopeChain {
-	data := templateData(execCtx)
-	if data == nil {
-		return nil
-	}
-	key := h.cfg.ScopeKey
-	if key == "" {
-		key = TemplateScopeKey
-	}
-	raw, ok := data[key]
-	if !ok || raw == nil {
-		return nil
+	data := execCtx.Public
+	state := &RequestState{}
+	if raw := data[keyOrDefault(h.cfg.ContextKey, TemplateContextKey)]; raw != nil {
+		state.Context = contextFromValue(raw)
 	}
-	chain, ok := scopeFromValue(raw)
-	if !ok {
-		return nil
+	if raw := data[keyOrDefault(h.cfg.ScopeKey, TemplateScopeKey)]; raw != nil {
+		if chain, ok := scopeFromValue(raw); ok {
+			state.Scope = &chain
+		}
 	}
-	return &chain
+	state.Snapshot = data[keyOrDefault(h.cfg.SnapshotKey, TemplateSnapshotKey)]
+	return state
 }
 
-func (h *helperSet) snapshot(execCtx *pongo2.ExecutionContext) any {
-	data := templateData(execCtx)
-	if data == nil {
-		return nil
-	}
-	key := h.cfg.SnapshotKey
+func keyOrDefault(key, fallback string) string {
 	if key == "" {
-		key = TemplateSnapshotKey
+		return fallback
 	}
-	raw, ok := data[key]
-	if !ok {
-		return nil
-	}
-	return raw
+	return key
 }
 
-func (h *helperSet) errorOrFallback(helper string, err error, fallback any) any {
+func (h *Evaluator) errorOrFallback(helper string, err error, fallback any) any {
 	if h.cfg.EnableStructuredErrors {
 		if h.cfg.EnableErrorLogging {
 			h.logHelperError(helper, err)
@@ -399,10 +316,16 @@ type TraceSnapshotReader interface {
 	Trace(key string) (gate.ResolveTrace, bool)
 }
 
-// Snapshot holds optional precomputed values and traces.
+// VariantSnapshotReader exposes variants for feature keys.
+type VariantSnapshotReader interface {
+	Variant(key string) (gate.Variant, bool)
+}
+
+// Snapshot holds optional precomputed values, traces, and variants.
 type Snapshot struct {
-	Values map[string]bool
-	Traces map[string]gate.ResolveTrace
+	Values   map[string]bool
+	Traces   map[string]gate.ResolveTrace
+	Variants map[string]gate.Variant
 }
 
 // Enabled implements SnapshotReader.
@@ -427,6 +350,60 @@ func (s Snapshot) Trace(key string) (gate.ResolveTrace, bool) {
 	return trace, ok
 }
 
+// Variant implements VariantSnapshotReader, deriving on/off variants from Values.
+func (s Snapshot) Variant(key string) (gate.Variant, bool) {
+	key = gate.NormalizeKey(strings.TrimSpace(key))
+	if key == "" {
+		return gate.Variant{}, false
+	}
+	if variant, ok := s.Variants[key]; ok {
+		return variant, true
+	}
+	if value, ok := s.Values[key]; ok {
+		return gate.BoolVariant(value), true
+	}
+	return gate.Variant{}, false
+}
+
+func snapshotVariant(snapshot any, key string) (gate.Variant, bool) {
+	if reader, ok := snapshot.(VariantSnapshotReader); ok {
+		return reader.Variant(key)
+	}
+	switch typed := snapshot.(type) {
+	case map[string]gate.Variant:
+		variant, ok := typed[key]
+		return variant, ok
+	case map[string]string:
+		value, ok := typed[key]
+		return gate.Variant{Key: value, Value: value}, ok
+	case map[string]any:
+		value, ok := typed[key]
+		if !ok {
+			value, ok = lookupNestedValue(typed, key)
+		}
+		if ok {
+			return variantFromValue(value)
+		}
+	}
+	if value, ok := snapshotValue(snapshot, key); ok {
+		return gate.BoolVariant(value), true
+	}
+	return gate.Variant{}, false
+}
+
+func variantFromValue(value any) (gate.Variant, bool) {
+	switch typed := value.(type) {
+	case gate.Variant:
+		return typed, true
+	case string:
+		return gate.Variant{Key: typed, Value: typed}, true
+	}
+	if enabled, ok := boolFromValue(value); ok {
+		return gate.BoolVariant(enabled), true
+	}
+	return gate.Variant{}, false
+}
+
 func snapshotValue(snapshot any, key string) (bool, bool) {
 	if reader, ok := snapshot.(SnapshotReader); ok {
 		return reader.Enabled(key)
@@ -635,17 +612,6 @@ func scopeFromMap(data map[string]any) (gate.ScopeChain, bool) {
 	return chain, true
 }
 
-func templateData(execCtx *pongo2.ExecutionContext) map[string]any {
-	if execCtx == nil || execCtx.Public == nil {
-		return nil
-	}
-	data := make(map[string]any, len(execCtx.Public))
-	for key, value := range execCtx.Public {
-		data[key] = value
-	}
-	return data
-}
-
 func splitPath(path string) []string {
 	trimmed := strings.TrimSpace(path)
 	if trimmed == "" {
@@ -663,7 +629,7 @@ func splitPath(path string) []string {
 	return out
 }
 
-func (h *helperSet) logHelperError(helper string, err error) {
+func (h *Evaluator) logHelperError(helper string, err error) {
 	if h == nil || h.cfg.Logger == nil {
 		return
 	}
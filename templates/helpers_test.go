@@ -189,3 +189,72 @@ func hasArgPair(args []any, key string, value any) bool {
 	}
 	return false
 }
+
+func TestTemplateHelpersUseRegisteredRequest(t *testing.T) {
+	gateStub := &captureGate{value: true}
+	helpers := TemplateHelpers(gateStub)
+	fn, ok := helpers["feature"].(func(*pongo2.ExecutionContext, any) bool)
+	if !ok {
+		t.Fatalf("feature helper not found")
+	}
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	chain := gate.ScopeChain{{Kind: gate.ScopeTenant, ID: "tenant-1", TenantID: "tenant-1"}}
+
+	execCtx := &pongo2.ExecutionContext{
+		Public: pongo2.Context{
+			TemplateScopeKey: map[string]any{"user_id": "ignored"},
+		},
+	}
+	RegisterRequest(execCtx, ctx, chain, map[string]bool{"cached": false})
+	if !fn(execCtx, "users.signup") {
+		t.Fatalf("expected feature helper to return true")
+	}
+	if gateStub.lastCtx.Value(ctxKey{}) != "request" {
+		t.Fatalf("expected registered context to reach the gate")
+	}
+	if gateStub.lastChain == nil || (*gateStub.lastChain)[0].ID != "tenant-1" {
+		t.Fatalf("expected registered scope to win over template data, got %+v", gateStub.lastChain)
+	}
+	if fn(execCtx, "cached") || gateStub.calls != 1 {
+		t.Fatalf("expected registered snapshot to answer without the gate")
+	}
+
+	data := BindRequest(nil, ctx, nil, nil)
+	if !fn(&pongo2.ExecutionContext{Public: data}, "users.signup") || gateStub.lastChain != nil {
+		t.Fatalf("expected bound request without scope to let the gate derive it")
+	}
+}
+
+type variantGate struct {
+	captureGate
+	variant gate.Variant
+}
+
+func (g *variantGate) Variant(context.Context, string, ...gate.ResolveOption) (gate.Variant, error) {
+	return g.variant, nil
+}
+
+func TestTemplateHelpersVariants(t *testing.T) {
+	execCtx := &pongo2.ExecutionContext{Public: pongo2.Context{
+		TemplateSnapshotKey: map[string]any{"checkout": map[string]any{"layout": "compact"}},
+	}}
+
+	helpers := TemplateHelpers(&variantGate{variant: gate.Variant{Key: "treatment", Value: 3}})
+	variantFn := helpers["feature_variant"].(func(*pongo2.ExecutionContext, any, ...any) any)
+	valueFn := helpers["feature_value"].(func(*pongo2.ExecutionContext, any, ...any) any)
+	if got := variantFn(execCtx, "pricing.tiers"); got != "treatment" {
+		t.Fatalf("expected gate variant, got %v", got)
+	}
+	if got := valueFn(execCtx, "pricing.tiers"); got != 3 {
+		t.Fatalf("expected gate variant value, got %v", got)
+	}
+	if got := variantFn(execCtx, "checkout.layout"); got != "compact" {
+		t.Fatalf("expected snapshot variant, got %v", got)
+	}
+
+	boolHelpers := TemplateHelpers(&captureGate{value: true})
+	if got := boolHelpers["feature_variant"].(func(*pongo2.ExecutionContext, any, ...any) any)(execCtx, "users.signup"); got != gate.VariantOn {
+		t.Fatalf("expected on variant from boolean gate, got %v", got)
+	}
+}
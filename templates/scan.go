@@ -0,0 +1,199 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goliatone/go-featuregate/ferrors"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+var (
+	templateBlock = regexp.MustCompile(`(?s)\{\{(.*?)\}\}|\{%-?(.*?)-?%\}`)
+	helperCall    = regexp.MustCompile(`\b(feature(?:_any|_all|_none|_if|_class|_trace|_variant|_value)?)\s*\(`)
+)
+
+// multiKeyHelpers take every argument as a key; the rest take the key first.
+var multiKeyHelpers = map[string]bool{
+	"feature_any":  true,
+	"feature_all":  true,
+	"feature_none": true,
+}
+
+// ScanKeys returns the sorted, normalized feature keys that a pongo2 template source
+// references through the feature helpers or the {% feature %} tag. Only string literal
+// arguments are collected; keys computed at render time cannot be found statically.
+func ScanKeys(source string) []string {
+	keys, _ := scanSource(source)
+	return keys
+}
+
+// ScanTemplate scans the named template in fsys plus every template it pulls in with
+// a literal {% include %}, {% extends %}, or {% import %}, so a page's snapshot can be
+// warmed with exactly the keys it renders.
+func ScanTemplate(fsys fs.FS, name string) ([]string, error) {
+	seen := map[string]bool{}
+	keys := map[string]struct{}{}
+	pending := []string{path.Clean(name)}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		data, err := fs.ReadFile(fsys, current)
+		if err != nil {
+			return nil, ferrors.WrapBadInput(err, ferrors.TextCodePathInvalid, "templates: read template failed", map[string]any{
+				ferrors.MetaPath: current,
+			})
+		}
+		found, refs := scanSource(string(data))
+		for _, key := range found {
+			keys[key] = struct{}{}
+		}
+		for _, ref := range refs {
+			pending = append(pending, path.Clean(ref))
+		}
+	}
+	return sortedKeys(keys), nil
+}
+
+// SnapshotBuilder resolves a fixed key set into a Snapshot before rendering.
+type SnapshotBuilder struct {
+	gate gate.FeatureGate
+	keys []string
+}
+
+// NewSnapshotBuilder constructs a builder for keys, typically from ScanTemplate.
+func NewSnapshotBuilder(featureGate gate.FeatureGate, keys ...string) *SnapshotBuilder {
+	unique := map[string]struct{}{}
+	for _, key := range keys {
+		if key = gate.NormalizeKey(strings.TrimSpace(key)); key != "" {
+			unique[key] = struct{}{}
+		}
+	}
+	return &SnapshotBuilder{gate: featureGate, keys: sortedKeys(unique)}
+}
+
+// Keys returns the keys the builder resolves.
+func (b *SnapshotBuilder) Keys() []string {
+	return append([]string(nil), b.keys...)
+}
+
+// Build resolves every key, recording traces when the gate is traceable. Keys that
+// fail to resolve are left out, so helpers fall back to the gate, and their errors
+// are joined into the returned error.
+func (b *SnapshotBuilder) Build(ctx context.Context, opts ...gate.ResolveOption) (Snapshot, error) {
+	if b.gate == nil {
+		return Snapshot{}, ferrors.WrapSentinel(ferrors.ErrGateRequired, "feature gate is required", nil)
+	}
+	snapshot := Snapshot{Values: make(map[string]bool, len(b.keys))}
+	traceable := traceGate(b.gate)
+	if traceable != nil {
+		snapshot.Traces = make(map[string]gate.ResolveTrace, len(b.keys))
+	}
+	var errs []error
+	for _, key := range b.keys {
+		if traceable != nil {
+			value, trace, err := traceable.ResolveWithTrace(ctx, key, opts...)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			snapshot.Values[key] = value
+			snapshot.Traces[key] = trace
+			continue
+		}
+		value, err := b.gate.Enabled(ctx, key, opts...)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		snapshot.Values[key] = value
+	}
+	return snapshot, errors.Join(errs...)
+}
+
+// scanSource returns the keys in source and the literal template references it pulls in.
+func scanSource(source string) ([]string, []string) {
+	keys := map[string]struct{}{}
+	var refs []string
+	add := func(key string) {
+		if key = gate.NormalizeKey(strings.TrimSpace(key)); key != "" {
+			keys[key] = struct{}{}
+		}
+	}
+	for _, match := range templateBlock.FindAllStringSubmatch(source, -1) {
+		body := match[1]
+		if body == "" {
+			body = match[2]
+			name, args, _ := strings.Cut(strings.TrimSpace(body), " ")
+			switch name {
+			case FeatureTagName:
+				if key, _, ok := readStringLiteral(strings.TrimSpace(args)); ok {
+					add(key)
+				}
+			case "include", "extends", "import":
+				if ref, _, ok := readStringLiteral(strings.TrimSpace(args)); ok {
+					refs = append(refs, ref)
+				}
+			}
+		}
+		for _, loc := range helperCall.FindAllStringSubmatchIndex(body, -1) {
+			helper := body[loc[2]:loc[3]]
+			rest := body[loc[1]:]
+			for {
+				key, tail, ok := readStringLiteral(strings.TrimSpace(rest))
+				if !ok {
+					break
+				}
+				add(key)
+				if !multiKeyHelpers[helper] {
+					break
+				}
+				tail = strings.TrimSpace(tail)
+				if !strings.HasPrefix(tail, ",") {
+					break
+				}
+				rest = tail[1:]
+			}
+		}
+	}
+	return sortedKeys(keys), refs
+}
+
+// readStringLiteral reads a leading single- or double-quoted literal.
+func readStringLiteral(input string) (string, string, bool) {
+	if input == "" || (input[0] != '"' && input[0] != '\'') {
+		return "", input, false
+	}
+	quote := input[0]
+	var out strings.Builder
+	for i := 1; i < len(input); i++ {
+		switch ch := input[i]; {
+		case ch == '\\' && i+1 < len(input):
+			i++
+			out.WriteByte(input[i])
+		case ch == quote:
+			return out.String(), input[i+1:], true
+		default:
+			out.WriteByte(ch)
+		}
+	}
+	return "", input, false
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for key := range set {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
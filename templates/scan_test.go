@@ -0,0 +1,53 @@
+package templates
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestScanKeysFindsHelperAndTagReferences(t *testing.T) {
+	source := `
+{% extends "base.html" %}
+{% if feature("users.signup") %}x{% endif %}
+{{ feature_any("beta", 'search.v3', dynamic_key) }}
+{{ feature_class( "dark_mode" , "dark", "light") }}
+{% feature "billing.v2" %}on{% else %}off{% endfeature %}
+{{ feature_variant(key_var, "control") }}
+{{ "feature(\"not.a.call\")" }}
+`
+	got := ScanKeys(source)
+	want := []string{"beta", "billing.v2", "dark_mode", "search.v3", "users.signup"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestScanTemplateFollowsIncludesAndBuildsSnapshot(t *testing.T) {
+	fsys := fstest.MapFS{
+		"pages/home.html":   {Data: []byte(`{% extends "layout.html" %}{% include "partials/nav.html" %}{{ feature("home.hero") }}`)},
+		"layout.html":       {Data: []byte(`{% if feature("layout.banner") %}banner{% endif %}`)},
+		"partials/nav.html": {Data: []byte(`{% include "layout.html" %}{{ feature_all("nav.v2", "nav.search") }}`)},
+	}
+	keys, err := ScanTemplate(fsys, "pages/home.html")
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	want := []string{"home.hero", "layout.banner", "nav.search", "nav.v2"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("expected %v, got %v", want, keys)
+	}
+	if _, err := ScanTemplate(fsys, "missing.html"); err == nil {
+		t.Fatalf("expected error for missing template")
+	}
+
+	gateStub := &captureGate{value: true}
+	snapshot, err := NewSnapshotBuilder(gateStub, keys...).Build(context.Background())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(snapshot.Values) != len(keys) || !snapshot.Values["nav.v2"] || gateStub.calls != len(keys) {
+		t.Fatalf("expected every scanned key warmed, got %+v (calls=%d)", snapshot.Values, gateStub.calls)
+	}
+}
@@ -0,0 +1,75 @@
+// Package stdtemplate exposes the featuregate template helpers as a FuncMap for
+// html/template and text/template.
+package stdtemplate
+
+import (
+	"context"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/templates"
+)
+
+// Helpers builds helper maps for the standard library template packages.
+type Helpers struct {
+	eval *templates.Evaluator
+}
+
+// New builds helpers using the same options as templates.TemplateHelpers.
+func New(featureGate gate.FeatureGate, opts ...templates.HelperOption) *Helpers {
+	return &Helpers{eval: templates.NewEvaluator(featureGate, opts...)}
+}
+
+// FuncMap returns helpers that resolve with a background context and let the gate
+// derive scope. Register it before Parse; use Bind per render for request data.
+// The map is assignable to both html/template.FuncMap and text/template.FuncMap.
+func (h *Helpers) FuncMap() map[string]any {
+	return h.funcs(&templates.RequestState{})
+}
+
+// Bind returns helpers bound to one request. A nil chain lets the gate derive scope
+// from ctx; snapshot values take precedence over the gate. Apply it to a clone of the
+// parsed template: tmpl.Clone() followed by Funcs(h.Bind(...)).
+func (h *Helpers) Bind(ctx context.Context, chain gate.ScopeChain, snapshot any) map[string]any {
+	return h.funcs(templates.NewRequestState(ctx, chain, snapshot))
+}
+
+// FuncMap returns the unbound helper map for featureGate.
+func FuncMap(featureGate gate.FeatureGate, opts ...templates.HelperOption) map[string]any {
+	return New(featureGate, opts...).FuncMap()
+}
+
+func (h *Helpers) funcs(state *templates.RequestState) map[string]any {
+	eval := h.eval
+	funcs := map[string]any{
+		"feature": func(key any) bool {
+			return eval.Feature(state, key)
+		},
+		"feature_any": func(keys ...any) bool {
+			return eval.FeatureAny(state, keys...)
+		},
+		"feature_all": func(keys ...any) bool {
+			return eval.FeatureAll(state, keys...)
+		},
+		"feature_none": func(keys ...any) bool {
+			return eval.FeatureNone(state, keys...)
+		},
+		"feature_if": func(key any, whenTrue any, whenFalse ...any) any {
+			return eval.FeatureIf(state, key, whenTrue, whenFalse...)
+		},
+		"feature_class": func(key any, on any, off ...any) any {
+			return eval.FeatureClass(state, key, on, off...)
+		},
+		"feature_variant": func(key any, fallback ...any) any {
+			return eval.FeatureVariant(state, key, fallback...)
+		},
+		"feature_value": func(key any, fallback ...any) any {
+			return eval.FeatureValue(state, key, fallback...)
+		},
+	}
+	if eval.Traceable() {
+		funcs["feature_trace"] = func(key any) any {
+			return eval.FeatureTrace(state, key)
+		}
+	}
+	return funcs
+}
@@ -0,0 +1,83 @@
+package stdtemplate
+
+import (
+	"context"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/goliatone/go-featuregate/gate"
+	"github.com/goliatone/go-featuregate/templates"
+)
+
+type scopeGate struct {
+	values    map[string]bool
+	lastChain *gate.ScopeChain
+}
+
+func (g *scopeGate) Enabled(ctx context.Context, key string, opts ...gate.ResolveOption) (bool, error) {
+	req := gate.ResolveRequest{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&req)
+		}
+	}
+	g.lastChain = req.ScopeChain
+	return g.values[key], nil
+}
+
+func TestHTMLTemplateBindUsesSnapshotAndScope(t *testing.T) {
+	fg := &scopeGate{values: map[string]bool{"users.signup": true}}
+	helpers := New(fg)
+
+	tmpl, err := htmltemplate.New("page").Funcs(helpers.FuncMap()).Parse(
+		`{{if feature "users.signup"}}signup{{end}}|{{feature_class "beta" "on" "off"}}|{{if feature_any "x" "users.signup"}}any{{end}}`,
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	chain := gate.ScopeChain{{Kind: gate.ScopeUser, ID: "user-1"}}
+	bound, err := tmpl.Clone()
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	snapshot := templates.Snapshot{Values: map[string]bool{"beta": true}}
+	bound.Funcs(helpers.Bind(context.Background(), chain, snapshot))
+
+	var out strings.Builder
+	if err := bound.Execute(&out, nil); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if got := out.String(); got != "signup|on|any" {
+		t.Fatalf("unexpected output %q", got)
+	}
+	if fg.lastChain == nil || len(*fg.lastChain) != 1 || (*fg.lastChain)[0].ID != "user-1" {
+		t.Fatalf("expected bound scope chain, got %+v", fg.lastChain)
+	}
+}
+
+func TestTextTemplateFuncMapUnbound(t *testing.T) {
+	fg := &scopeGate{values: map[string]bool{"dashboard": true}}
+
+	tmpl, err := texttemplate.New("page").Funcs(FuncMap(fg)).Parse(
+		`{{feature_if "dashboard" "yes" "no"}} {{feature_if "missing" "yes" "no"}} {{feature_variant "dashboard"}} {{feature_none "missing"}}`,
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, nil); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if got := out.String(); got != "yes no on true" {
+		t.Fatalf("unexpected output %q", got)
+	}
+	if fg.lastChain != nil {
+		t.Fatalf("expected no explicit scope chain, got %+v", fg.lastChain)
+	}
+	if _, ok := FuncMap(fg)["feature_trace"]; ok {
+		t.Fatalf("feature_trace should require a traceable gate")
+	}
+}
@@ -0,0 +1,79 @@
+package templates
+
+import (
+	"github.com/flosch/pongo2/v6"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// FeatureTagName is the pongo2 block tag registered by RegisterFeatureTag.
+const FeatureTagName = "feature"
+
+// RegisterFeatureTag registers the {% feature key %}...{% else %}...{% endfeature %}
+// block tag. The key is any pongo2 expression; the block resolves it like the feature
+// helper, so snapshots, bound request state, and scope keys apply. pongo2 tags are
+// global: call this during setup, before parsing templates. Calling it again replaces
+// the gate for templates parsed afterwards.
+func RegisterFeatureTag(featureGate gate.FeatureGate, opts ...HelperOption) error {
+	helpers := newHelperSet(featureGate, opts...)
+	parser := func(doc *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+		return parseFeatureTag(helpers, doc, arguments)
+	}
+	if err := pongo2.RegisterTag(FeatureTagName, parser); err != nil {
+		return pongo2.ReplaceTag(FeatureTagName, parser)
+	}
+	return nil
+}
+
+type featureTagNode struct {
+	helpers  *helperSet
+	key      pongo2.IEvaluator
+	enabled  *pongo2.NodeWrapper
+	disabled *pongo2.NodeWrapper
+}
+
+func parseFeatureTag(helpers *helperSet, doc *pongo2.Parser, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	key, err := arguments.ParseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if arguments.Remaining() > 0 {
+		return nil, arguments.Error("feature tag takes exactly one key.", nil)
+	}
+	node := &featureTagNode{helpers: helpers, key: key}
+
+	wrapper, tagArgs, err := doc.WrapUntilTag("else", "endfeature")
+	if err != nil {
+		return nil, err
+	}
+	node.enabled = wrapper
+	if tagArgs.Count() > 0 {
+		return nil, tagArgs.Error("Arguments not allowed here.", nil)
+	}
+	if wrapper.Endtag == "else" {
+		wrapper, tagArgs, err = doc.WrapUntilTag("endfeature")
+		if err != nil {
+			return nil, err
+		}
+		node.disabled = wrapper
+		if tagArgs.Count() > 0 {
+			return nil, tagArgs.Error("Arguments not allowed here.", nil)
+		}
+	}
+	return node, nil
+}
+
+// Execute implements pongo2.INodeTag.
+func (node *featureTagNode) Execute(execCtx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
+	key, err := node.key.Evaluate(execCtx)
+	if err != nil {
+		return err
+	}
+	if node.helpers.feature(execCtx, key.Interface()) {
+		return node.enabled.Execute(execCtx, writer)
+	}
+	if node.disabled != nil {
+		return node.disabled.Execute(execCtx, writer)
+	}
+	return nil
+}
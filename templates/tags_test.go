@@ -0,0 +1,43 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+func TestFeatureTagRendersBranches(t *testing.T) {
+	gateStub := &captureGate{value: true}
+	if err := RegisterFeatureTag(gateStub); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := RegisterFeatureTag(gateStub); err != nil {
+		t.Fatalf("re-register should replace the tag: %v", err)
+	}
+
+	tpl, err := pongo2.FromString(`{% feature "users.signup" %}on{% else %}off{% endfeature %}|{% feature name %}yes{% endfeature %}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	out, err := tpl.Execute(pongo2.Context{
+		"name":              "beta",
+		TemplateSnapshotKey: map[string]bool{"users.signup": false},
+		TemplateScopeKey:    map[string]any{"tenant_id": "tenant-1"},
+	})
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if out != "off|yes" {
+		t.Fatalf("unexpected output %q", out)
+	}
+	if gateStub.calls != 1 || gateStub.lastKey != "beta" {
+		t.Fatalf("expected snapshot to answer users.signup and gate to answer beta, calls=%d key=%q", gateStub.calls, gateStub.lastKey)
+	}
+	if gateStub.lastChain == nil || (*gateStub.lastChain)[0].TenantID != "tenant-1" {
+		t.Fatalf("expected template scope to reach the gate, got %+v", gateStub.lastChain)
+	}
+
+	if _, err := pongo2.FromString(`{% feature "a" "b" %}x{% endfeature %}`); err == nil {
+		t.Fatalf("expected parse error for extra arguments")
+	}
+}
@@ -0,0 +1,188 @@
+// Package usage counts which feature keys a process resolves.
+//
+// A Counter is a gate.ResolveHook backed by per-key atomic counters. Register it with
+// resolver.WithResolveHook and expose it through httpapi.WithUsage; keys that stay at
+// zero across the fleet are candidates for removal.
+package usage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goliatone/go-featuregate/catalog"
+	"github.com/goliatone/go-featuregate/clock"
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+// Counter records resolution counts per normalized key since start or the last Reset.
+type Counter struct {
+	now     func() time.Time
+	catalog catalog.Catalog
+	since   atomic.Int64
+	keys    sync.Map // string -> *entry
+}
+
+type entry struct {
+	count atomic.Uint64
+	last  atomic.Int64
+}
+
+// Option customizes a Counter.
+type Option func(*Counter)
+
+// WithCatalog lists catalog keys in snapshots even when they were never resolved.
+func WithCatalog(c catalog.Catalog) Option {
+	return func(u *Counter) {
+		if u == nil {
+			return
+		}
+		u.catalog = c
+	}
+}
+
+// WithClock sets the clock used for timestamps.
+func WithClock(c clock.Clock) Option {
+	return func(u *Counter) {
+		if u == nil {
+			return
+		}
+		u.now = clock.NowFunc(c)
+	}
+}
+
+// WithNowFunc overrides the clock used for timestamps.
+func WithNowFunc(now func() time.Time) Option {
+	return func(u *Counter) {
+		if u == nil || now == nil {
+			return
+		}
+		u.now = now
+	}
+}
+
+// New constructs a Counter.
+func New(opts ...Option) *Counter {
+	u := &Counter{now: time.Now}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(u)
+		}
+	}
+	u.since.Store(u.now().UnixNano())
+	return u
+}
+
+// KeyCount is the usage of a single key.
+type KeyCount struct {
+	Key          string     `json:"key"`
+	Count        uint64     `json:"count"`
+	LastResolved *time.Time `json:"last_resolved,omitempty"`
+	InCatalog    bool       `json:"in_catalog,omitempty"`
+}
+
+// Report is a point-in-time view of a Counter.
+type Report struct {
+	Since time.Time  `json:"since"`
+	At    time.Time  `json:"at"`
+	Keys  []KeyCount `json:"keys"`
+}
+
+// Unused lists keys with a zero count.
+func (r Report) Unused() []string {
+	out := make([]string, 0)
+	for _, key := range r.Keys {
+		if key.Count == 0 {
+			out = append(out, key.Key)
+		}
+	}
+	return out
+}
+
+// OnResolve implements gate.ResolveHook.
+func (u *Counter) OnResolve(_ context.Context, event gate.ResolveEvent) {
+	key := event.NormalizedKey
+	if key == "" {
+		key = gate.NormalizeKey(event.Key)
+	}
+	u.Record(key)
+}
+
+// Record counts one resolution of key.
+func (u *Counter) Record(key string) {
+	if u == nil || key == "" {
+		return
+	}
+	value, ok := u.keys.Load(key)
+	if !ok {
+		value, _ = u.keys.LoadOrStore(key, &entry{})
+	}
+	e := value.(*entry)
+	e.count.Add(1)
+	e.last.Store(u.now().UnixNano())
+}
+
+// Count returns the number of resolutions of key.
+func (u *Counter) Count(key string) uint64 {
+	if u == nil {
+		return 0
+	}
+	value, ok := u.keys.Load(gate.NormalizeKey(key))
+	if !ok {
+		return 0
+	}
+	return value.(*entry).count.Load()
+}
+
+// Snapshot returns counts sorted by key, including zero counts for catalog keys.
+func (u *Counter) Snapshot() Report {
+	if u == nil {
+		return Report{Keys: []KeyCount{}}
+	}
+	report := Report{Since: time.Unix(0, u.since.Load()), At: u.now()}
+	byKey := map[string]*KeyCount{}
+	u.keys.Range(func(k, v any) bool {
+		e := v.(*entry)
+		item := &KeyCount{Key: k.(string), Count: e.count.Load()}
+		if last := e.last.Load(); last != 0 {
+			at := time.Unix(0, last)
+			item.LastResolved = &at
+		}
+		byKey[item.Key] = item
+		return true
+	})
+	if u.catalog != nil {
+		for _, def := range u.catalog.List() {
+			key := gate.NormalizeKey(def.Key)
+			if key == "" {
+				continue
+			}
+			item, ok := byKey[key]
+			if !ok {
+				item = &KeyCount{Key: key}
+				byKey[key] = item
+			}
+			item.InCatalog = true
+		}
+	}
+	report.Keys = make([]KeyCount, 0, len(byKey))
+	for _, item := range byKey {
+		report.Keys = append(report.Keys, *item)
+	}
+	sort.Slice(report.Keys, func(i, j int) bool { return report.Keys[i].Key < report.Keys[j].Key })
+	return report
+}
+
+// Reset clears all counters and restarts the reporting window.
+func (u *Counter) Reset() {
+	if u == nil {
+		return
+	}
+	u.keys.Range(func(k, _ any) bool {
+		u.keys.Delete(k)
+		return true
+	})
+	u.since.Store(u.now().UnixNano())
+}
@@ -0,0 +1,40 @@
+package usage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-featuregate/gate"
+)
+
+func TestCounterCountsConcurrentResolvesAndResets(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	counter := New(WithNowFunc(func() time.Time { return now }))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter.OnResolve(context.Background(), gate.ResolveEvent{Key: " users.signup ", NormalizedKey: "users.signup"})
+		}()
+	}
+	wg.Wait()
+
+	if got := counter.Count("users.signup"); got != 50 {
+		t.Fatalf("expected 50 resolves, got %d", got)
+	}
+	report := counter.Snapshot()
+	if len(report.Keys) != 1 || report.Keys[0].LastResolved == nil || !report.Keys[0].LastResolved.Equal(now) {
+		t.Fatalf("unexpected report: %+v", report.Keys)
+	}
+
+	now = now.Add(time.Hour)
+	counter.Reset()
+	report = counter.Snapshot()
+	if len(report.Keys) != 0 || !report.Since.Equal(now) {
+		t.Fatalf("expected empty report since reset, got %+v", report)
+	}
+}